@@ -54,6 +54,11 @@ func (o *OriginData) BlockBytes() []byte {
 	return o.bb
 }
 
+// State returns the downloaded BeaconState value.
+func (o *OriginData) State() state.BeaconState {
+	return o.st
+}
+
 func fname(prefix string, vu *detect.VersionedUnmarshaler, slot types.Slot, root [32]byte) string {
 	return fmt.Sprintf("%s_%s_%s_%d-%#x.ssz", prefix, vu.Config.ConfigName, version.String(vu.Fork), slot, root)
 }
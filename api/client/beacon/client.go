@@ -35,6 +35,10 @@ const (
 	getForkSchedulePath     = "/eth/v1/config/fork_schedule"
 	getStatePath            = "/eth/v2/debug/beacon/states"
 	getNodeVersionPath      = "/eth/v1/node/version"
+	getDepositSnapshotPath  = "/eth/v1/beacon/deposit_snapshot"
+	getGenesisPath          = "/eth/v1/beacon/genesis"
+
+	postBLSToExecutionChangesPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -171,6 +175,37 @@ func (c *Client) get(ctx context.Context, path string, opts ...reqOption) ([]byt
 	return b, nil
 }
 
+// post is a generic, opinionated POST function to reduce boilerplate amongst the submitters in this package.
+// The request body is encoded as json.
+func (c *Client) post(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	u := c.baseURL.ResolveReference(&url.URL{Path: path})
+	log.Printf("posting to %s", u.String())
+	enc, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error encoding request body")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(enc))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	r, err := c.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = r.Body.Close()
+	}()
+	if r.StatusCode != http.StatusOK {
+		return nil, non200Err(r)
+	}
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading http response body from %s", path)
+	}
+	return b, nil
+}
+
 func renderGetBlockPath(id StateOrBlockId) string {
 	return path.Join(getSignedBlockPath, string(id))
 }
@@ -343,6 +378,81 @@ func (c *Client) GetWeakSubjectivity(ctx context.Context) (*WeakSubjectivityData
 	}, nil
 }
 
+// GetGenesis retrieves the genesis time, genesis validators root and genesis fork version of the chain.
+func (c *Client) GetGenesis(ctx context.Context) (*apimiddleware.GenesisResponse_GenesisJson, error) {
+	body, err := c.get(ctx, getGenesisPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting genesis info")
+	}
+	v := &apimiddleware.GenesisResponseJson{}
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling response body: %s", string(body))
+	}
+	if v.Data == nil {
+		return nil, errors.New("genesis info is missing from the response")
+	}
+	return v.Data, nil
+}
+
+// SubmitChangeBLStoExecution submits signed BLS to execution changes to the operation pool of the beacon node,
+// which broadcasts them to the network.
+func (c *Client) SubmitChangeBLStoExecution(ctx context.Context, request []*apimiddleware.SignedBLSToExecutionChangeJson) error {
+	if _, err := c.post(ctx, postBLSToExecutionChangesPath, request); err != nil {
+		return errors.Wrap(err, "error submitting BLS to execution changes")
+	}
+	return nil
+}
+
+// GetDepositSnapshot retrieves the EIP-4881 snapshot of the finalized deposit tree of the beacon node.
+func (c *Client) GetDepositSnapshot(ctx context.Context) (*ethpb.DepositSnapshot, error) {
+	b, err := c.get(ctx, getDepositSnapshotPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting deposit snapshot")
+	}
+	d := struct {
+		Data struct {
+			Finalized            []string `json:"finalized"`
+			DepositRoot          string   `json:"deposit_root"`
+			DepositCount         string   `json:"deposit_count"`
+			ExecutionBlockHash   string   `json:"execution_block_hash"`
+			ExecutionBlockHeight string   `json:"execution_block_height"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling response body: %s", string(b))
+	}
+	finalized := make([][]byte, len(d.Data.Finalized))
+	for i, f := range d.Data.Finalized {
+		finalized[i], err = hexutil.Decode(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid finalized hash at index %d", i)
+		}
+	}
+	depositRoot, err := hexutil.Decode(d.Data.DepositRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid deposit root")
+	}
+	depositCount, err := strconv.ParseUint(d.Data.DepositCount, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid deposit count")
+	}
+	executionHash, err := hexutil.Decode(d.Data.ExecutionBlockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid execution block hash")
+	}
+	executionHeight, err := strconv.ParseUint(d.Data.ExecutionBlockHeight, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid execution block height")
+	}
+	return &ethpb.DepositSnapshot{
+		Finalized:      finalized,
+		DepositRoot:    depositRoot,
+		DepositCount:   depositCount,
+		ExecutionHash:  executionHash,
+		ExecutionDepth: executionHeight,
+	}, nil
+}
+
 func non200Err(response *http.Response) error {
 	bodyBytes, err := io.ReadAll(response.Body)
 	var body string
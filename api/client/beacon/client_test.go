@@ -1,9 +1,15 @@
 package beacon
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"net/url"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 )
 
@@ -136,3 +142,58 @@ func TestValidHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDepositSnapshot(t *testing.T) {
+	body := `{"data":{"finalized":["0x0101010101010101010101010101010101010101010101010101010101010101"],` +
+		`"deposit_root":"0x0202020202020202020202020202020202020202020202020202020202020202","deposit_count":"4",` +
+		`"execution_block_hash":"0x0303030303030303030303030303030303030303030303030303030303030303","execution_block_height":"100"}}`
+	c, err := NewClient("http://localhost:3500")
+	require.NoError(t, err)
+	c.hc.Transport = &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, getDepositSnapshotPath, req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+			Request:    req,
+		}, nil
+	}}
+	snapshot, err := c.GetDepositSnapshot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(snapshot.Finalized))
+	require.DeepEqual(t, bytes.Repeat([]byte{1}, 32), snapshot.Finalized[0])
+	require.DeepEqual(t, bytes.Repeat([]byte{2}, 32), snapshot.DepositRoot)
+	require.Equal(t, uint64(4), snapshot.DepositCount)
+	require.DeepEqual(t, bytes.Repeat([]byte{3}, 32), snapshot.ExecutionHash)
+	require.Equal(t, uint64(100), snapshot.ExecutionDepth)
+}
+
+func TestSubmitChangeBLStoExecution(t *testing.T) {
+	c, err := NewClient("http://localhost:3500")
+	require.NoError(t, err)
+	c.hc.Transport = &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodPost, req.Method)
+		require.Equal(t, postBLSToExecutionChangesPath, req.URL.Path)
+		var body []*apimiddleware.SignedBLSToExecutionChangeJson
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, 1, len(body))
+		require.Equal(t, "1", body[0].Message.ValidatorIndex)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+			Request:    req,
+		}, nil
+	}}
+	require.NoError(t, c.SubmitChangeBLStoExecution(context.Background(), []*apimiddleware.SignedBLSToExecutionChangeJson{
+		{Message: &apimiddleware.BLSToExecutionChangeJson{ValidatorIndex: "1"}},
+	}))
+
+	c.hc.Transport = &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"code":400,"message":"invalid"}`)),
+			Request:    req,
+		}, nil
+	}}
+	err = c.SubmitChangeBLStoExecution(context.Background(), nil)
+	require.ErrorIs(t, err, ErrNotOK)
+}
@@ -0,0 +1,145 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/node"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	trustedPeersPath = "/prysm/node/trusted_peers"
+	bansPath         = "/prysm/node/bans"
+	bannedPeersPath  = "/prysm/node/bans/peers"
+	bannedRangesPath = "/prysm/node/bans/ip_ranges"
+	peerScoresPath   = "/prysm/node/peers/scores"
+)
+
+func withQuery(query url.Values) reqOption {
+	return func(req *http.Request) {
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// del is a generic, opinionated DELETE function to reduce boilerplate amongst the peer management methods.
+func (c *Client) del(ctx context.Context, path string, opts ...reqOption) error {
+	u := c.baseURL.ResolveReference(&url.URL{Path: path})
+	log.Printf("deleting %s", u.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	for _, o := range opts {
+		o(req)
+	}
+	r, err := c.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = r.Body.Close()
+	}()
+	if r.StatusCode != http.StatusOK {
+		return non200Err(r)
+	}
+	_, err = io.Copy(io.Discard, r.Body)
+	return err
+}
+
+// GetTrustedPeers retrieves the trusted peers of the beacon node.
+func (c *Client) GetTrustedPeers(ctx context.Context) ([]*node.TrustedPeer, error) {
+	b, err := c.get(ctx, trustedPeersPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting trusted peers")
+	}
+	resp := &node.TrustedPeersResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling response body: %s", string(b))
+	}
+	return resp.Data, nil
+}
+
+// AddTrustedPeer adds the peer with the given multiaddress, including its peer ID, to the trusted peers of the beacon node.
+func (c *Client) AddTrustedPeer(ctx context.Context, addr string) error {
+	if _, err := c.post(ctx, trustedPeersPath, &node.AddTrustedPeerRequest{Addr: addr}); err != nil {
+		return errors.Wrap(err, "error adding trusted peer")
+	}
+	return nil
+}
+
+// RemoveTrustedPeer removes the given peer from the trusted peers of the beacon node.
+func (c *Client) RemoveTrustedPeer(ctx context.Context, peerId string) error {
+	if err := c.del(ctx, path.Join(trustedPeersPath, peerId)); err != nil {
+		return errors.Wrap(err, "error removing trusted peer")
+	}
+	return nil
+}
+
+// GetBans retrieves the peers and ip ranges banned by the beacon node.
+func (c *Client) GetBans(ctx context.Context) (*node.Bans, error) {
+	b, err := c.get(ctx, bansPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting bans")
+	}
+	resp := &node.BansResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling response body: %s", string(b))
+	}
+	return resp.Data, nil
+}
+
+// BanPeer bans the given peer for the given duration, in Go duration format. An empty duration bans the peer indefinitely.
+func (c *Client) BanPeer(ctx context.Context, peerId, duration string) error {
+	if _, err := c.post(ctx, bannedPeersPath, &node.BanPeerRequest{PeerId: peerId, Duration: duration}); err != nil {
+		return errors.Wrap(err, "error banning peer")
+	}
+	return nil
+}
+
+// UnbanPeer lifts the ban of the given peer.
+func (c *Client) UnbanPeer(ctx context.Context, peerId string) error {
+	if err := c.del(ctx, path.Join(bannedPeersPath, peerId)); err != nil {
+		return errors.Wrap(err, "error unbanning peer")
+	}
+	return nil
+}
+
+// BanIPRange bans the given ip range, in CIDR notation.
+func (c *Client) BanIPRange(ctx context.Context, cidr string) error {
+	if _, err := c.post(ctx, bannedRangesPath, &node.BanIPRangeRequest{Cidr: cidr}); err != nil {
+		return errors.Wrap(err, "error banning ip range")
+	}
+	return nil
+}
+
+// UnbanIPRange lifts the ban of the given ip range, in CIDR notation.
+func (c *Client) UnbanIPRange(ctx context.Context, cidr string) error {
+	if err := c.del(ctx, bannedRangesPath, withQuery(url.Values{"cidr": {cidr}})); err != nil {
+		return errors.Wrap(err, "error unbanning ip range")
+	}
+	return nil
+}
+
+// GetPeerScores retrieves the score breakdown of the known peers of the beacon node, or of a single peer
+// if a peer ID is given.
+func (c *Client) GetPeerScores(ctx context.Context, peerId string) ([]*node.PeerScore, error) {
+	var opts []reqOption
+	if peerId != "" {
+		opts = append(opts, withQuery(url.Values{"peer_id": {peerId}}))
+	}
+	b, err := c.get(ctx, peerScoresPath, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting peer scores")
+	}
+	resp := &node.PeerScoresResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling response body: %s", string(b))
+	}
+	return resp.Data, nil
+}
@@ -0,0 +1,58 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestUnbanIPRange(t *testing.T) {
+	c, err := NewClient("http://localhost:3500")
+	require.NoError(t, err)
+	c.hc.Transport = &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodDelete, req.Method)
+		require.Equal(t, bannedRangesPath, req.URL.Path)
+		require.Equal(t, "10.0.0.0/8", req.URL.Query().Get("cidr"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+			Request:    req,
+		}, nil
+	}}
+	require.NoError(t, c.UnbanIPRange(context.Background(), "10.0.0.0/8"))
+
+	c.hc.Transport = &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"code":404,"message":"Ip range is not banned"}`)),
+			Request:    req,
+		}, nil
+	}}
+	err = c.UnbanIPRange(context.Background(), "10.0.0.0/8")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetPeerScores(t *testing.T) {
+	body := `{"data":[{"peer_id":"foo","state":"connected","overall_score":-0.5,"bad_responses":{"count":"1","score":-0.5}}]}`
+	c, err := NewClient("http://localhost:3500")
+	require.NoError(t, err)
+	c.hc.Transport = &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, peerScoresPath, req.URL.Path)
+		require.Equal(t, "foo", req.URL.Query().Get("peer_id"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+			Request:    req,
+		}, nil
+	}}
+	scores, err := c.GetPeerScores(context.Background(), "foo")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(scores))
+	require.Equal(t, "foo", scores[0].PeerId)
+	require.Equal(t, -0.5, scores[0].OverallScore)
+	require.Equal(t, "1", scores[0].BadResponses.Count)
+}
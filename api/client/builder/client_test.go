@@ -11,12 +11,16 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	v1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
 	eth "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 )
 
@@ -178,14 +182,75 @@ func TestClient_GetHeader(t *testing.T) {
 	}
 	h, err := c.GetHeader(ctx, slot, bytesutil.ToBytes32(parentHash), bytesutil.ToBytes48(pubkey))
 	require.NoError(t, err)
+	require.Equal(t, version.Bellatrix, h.Version())
 	expectedSig := ezDecode(t, "0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505")
-	require.Equal(t, true, bytes.Equal(expectedSig, h.Signature))
+	require.Equal(t, true, bytes.Equal(expectedSig, h.Signature()))
+	bid, err := h.Message()
+	require.NoError(t, err)
+	header, err := bid.Header()
+	require.NoError(t, err)
 	expectedTxRoot := ezDecode(t, "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2")
-	require.Equal(t, true, bytes.Equal(expectedTxRoot, h.Message.Header.TransactionsRoot))
-	require.Equal(t, uint64(1), h.Message.Header.GasUsed)
+	txRoot, err := header.TransactionsRoot()
+	require.NoError(t, err)
+	require.Equal(t, true, bytes.Equal(expectedTxRoot, txRoot))
+	require.Equal(t, uint64(1), header.GasUsed())
 	value, err := stringToUint256("652312848583266388373324160190187140051835877600158453279131187530910662656")
 	require.NoError(t, err)
-	require.Equal(t, fmt.Sprintf("%#x", value.SSZBytes()), fmt.Sprintf("%#x", h.Message.Value))
+	require.Equal(t, fmt.Sprintf("%#x", value.SSZBytes()), fmt.Sprintf("%#x", bid.Value()))
+}
+
+func TestClient_GetHeaderCapella(t *testing.T) {
+	ctx := context.Background()
+	expectedPath := "/eth/v1/builder/header/23/0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2/0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"
+	hc := &http.Client{
+		Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+			require.Equal(t, expectedPath, r.URL.Path)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(testExampleHeaderResponseCapella)),
+				Request:    r.Clone(ctx),
+			}, nil
+		}),
+	}
+	c := &Client{
+		hc:      hc,
+		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
+	}
+	var slot types.Slot = 23
+	parentHash := ezDecode(t, "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2")
+	pubkey := ezDecode(t, "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a")
+	h, err := c.GetHeader(ctx, slot, bytesutil.ToBytes32(parentHash), bytesutil.ToBytes48(pubkey))
+	require.NoError(t, err)
+	require.Equal(t, version.Capella, h.Version())
+	bid, err := h.Message()
+	require.NoError(t, err)
+	require.Equal(t, version.Capella, bid.Version())
+	header, err := bid.Header()
+	require.NoError(t, err)
+	withdrawalsRoot, err := header.WithdrawalsRoot()
+	require.NoError(t, err)
+	expectedWithdrawalsRoot := ezDecode(t, "0xb15ed76298ff84a586b1d875df08b6676c98dfe9c7cd73fab88450348d8e70c8")
+	require.Equal(t, true, bytes.Equal(expectedWithdrawalsRoot, withdrawalsRoot))
+	require.Equal(t, uint64(1), header.GasUsed())
+}
+
+func TestClient_GetHeader_UnsupportedVersion(t *testing.T) {
+	ctx := context.Background()
+	hc := &http.Client{
+		Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"version": "phase0", "data": {}}`)),
+				Request:    r.Clone(ctx),
+			}, nil
+		}),
+	}
+	c := &Client{
+		hc:      hc,
+		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
+	}
+	_, err := c.GetHeader(ctx, 1, [32]byte{}, [48]byte{})
+	require.ErrorContains(t, "unsupported header version phase0", err)
 }
 
 func TestSubmitBlindedBlock(t *testing.T) {
@@ -204,14 +269,76 @@ func TestSubmitBlindedBlock(t *testing.T) {
 		hc:      hc,
 		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
 	}
-	sbbb := testSignedBlindedBeaconBlockBellatrix(t)
+	sbbb, err := blocks.NewSignedBeaconBlock(testSignedBlindedBeaconBlockBellatrix(t))
+	require.NoError(t, err)
 	ep, err := c.SubmitBlindedBlock(ctx, sbbb)
 	require.NoError(t, err)
-	require.Equal(t, true, bytes.Equal(ezDecode(t, "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"), ep.ParentHash))
+	require.Equal(t, true, bytes.Equal(ezDecode(t, "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"), ep.ParentHash()))
 	bfpg, err := stringToUint256("452312848583266388373324160190187140051835877600158453279131187530910662656")
 	require.NoError(t, err)
-	require.Equal(t, fmt.Sprintf("%#x", bfpg.SSZBytes()), fmt.Sprintf("%#x", ep.BaseFeePerGas))
-	require.Equal(t, uint64(1), ep.GasLimit)
+	require.Equal(t, fmt.Sprintf("%#x", bfpg.SSZBytes()), fmt.Sprintf("%#x", ep.BaseFeePerGas()))
+	require.Equal(t, uint64(1), ep.GasLimit())
+}
+
+func TestSubmitBlindedBlockCapella(t *testing.T) {
+	ctx := context.Background()
+	hc := &http.Client{
+		Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+			require.Equal(t, postBlindedBeaconBlockPath, r.URL.Path)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(testExampleExecutionPayloadCapella)),
+				Request:    r.Clone(ctx),
+			}, nil
+		}),
+	}
+	c := &Client{
+		hc:      hc,
+		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
+	}
+	sbb, err := blocks.NewSignedBeaconBlock(testSignedBlindedBeaconBlockCapella(t))
+	require.NoError(t, err)
+	ep, err := c.SubmitBlindedBlock(ctx, sbb)
+	require.NoError(t, err)
+	require.Equal(t, true, bytes.Equal(ezDecode(t, "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"), ep.ParentHash()))
+	withdrawals, err := ep.Withdrawals()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(withdrawals))
+	assert.Equal(t, uint64(1), withdrawals[0].Index)
+	assert.Equal(t, types.ValidatorIndex(1), withdrawals[0].ValidatorIndex)
+	assert.Equal(t, "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2", hexutil.Encode(withdrawals[0].Address))
+	assert.Equal(t, uint64(1), withdrawals[0].Amount)
+}
+
+func TestSubmitBlindedBlock_VersionMismatch(t *testing.T) {
+	ctx := context.Background()
+	hc := &http.Client{
+		Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(testExampleExecutionPayload)),
+				Request:    r.Clone(ctx),
+			}, nil
+		}),
+	}
+	c := &Client{
+		hc:      hc,
+		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
+	}
+	sbb, err := blocks.NewSignedBeaconBlock(testSignedBlindedBeaconBlockCapella(t))
+	require.NoError(t, err)
+	_, err = c.SubmitBlindedBlock(ctx, sbb)
+	require.ErrorContains(t, "not a capella payload", err)
+}
+
+func TestSubmitBlindedBlock_NotBlinded(t *testing.T) {
+	sb, err := blocks.NewSignedBeaconBlock(&eth.SignedBeaconBlockBellatrix{
+		Block: &eth.BeaconBlockBellatrix{Body: &eth.BeaconBlockBodyBellatrix{ExecutionPayload: &v1.ExecutionPayload{}}},
+	})
+	require.NoError(t, err)
+	c := &Client{baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"}}
+	_, err = c.SubmitBlindedBlock(context.Background(), sb)
+	require.ErrorIs(t, err, errNotBlinded)
 }
 
 func testSignedBlindedBeaconBlockBellatrix(t *testing.T) *eth.SignedBlindedBeaconBlockBellatrix {
@@ -376,3 +503,55 @@ func TestRequestLogger(t *testing.T) {
 	err = c.Status(ctx)
 	require.NoError(t, err)
 }
+
+func testSignedBlindedBeaconBlockCapella(t *testing.T) *eth.SignedBlindedBeaconBlockCapella {
+	b := testSignedBlindedBeaconBlockBellatrix(t)
+	h := b.Block.Body.ExecutionPayloadHeader
+	return &eth.SignedBlindedBeaconBlockCapella{
+		Block: &eth.BlindedBeaconBlockCapella{
+			Slot:          b.Block.Slot,
+			ProposerIndex: b.Block.ProposerIndex,
+			ParentRoot:    b.Block.ParentRoot,
+			StateRoot:     b.Block.StateRoot,
+			Body: &eth.BlindedBeaconBlockBodyCapella{
+				RandaoReveal:      b.Block.Body.RandaoReveal,
+				Eth1Data:          b.Block.Body.Eth1Data,
+				Graffiti:          b.Block.Body.Graffiti,
+				ProposerSlashings: b.Block.Body.ProposerSlashings,
+				AttesterSlashings: b.Block.Body.AttesterSlashings,
+				Attestations:      b.Block.Body.Attestations,
+				Deposits:          b.Block.Body.Deposits,
+				VoluntaryExits:    b.Block.Body.VoluntaryExits,
+				SyncAggregate:     b.Block.Body.SyncAggregate,
+				ExecutionPayloadHeader: &v1.ExecutionPayloadHeaderCapella{
+					ParentHash:       h.ParentHash,
+					FeeRecipient:     h.FeeRecipient,
+					StateRoot:        h.StateRoot,
+					ReceiptsRoot:     h.ReceiptsRoot,
+					LogsBloom:        h.LogsBloom,
+					PrevRandao:       h.PrevRandao,
+					BlockNumber:      h.BlockNumber,
+					GasLimit:         h.GasLimit,
+					GasUsed:          h.GasUsed,
+					Timestamp:        h.Timestamp,
+					ExtraData:        h.ExtraData,
+					BaseFeePerGas:    h.BaseFeePerGas,
+					BlockHash:        h.BlockHash,
+					TransactionsRoot: h.TransactionsRoot,
+					WithdrawalsRoot:  ezDecode(t, "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"),
+				},
+				BlsToExecutionChanges: []*eth.SignedBLSToExecutionChange{
+					{
+						Message: &eth.BLSToExecutionChange{
+							ValidatorIndex:     1,
+							FromBlsPubkey:      ezDecode(t, "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"),
+							ToExecutionAddress: ezDecode(t, "0xabcf8e0d4e9587369b2301d0790347320302cc09"),
+						},
+						Signature: ezDecode(t, "0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"),
+					},
+				},
+			},
+		},
+		Signature: b.Signature,
+	}
+}
@@ -93,6 +93,12 @@ type OptimisticModeFetcher interface {
 	IsOptimisticForRoot(ctx context.Context, root [32]byte) (bool, error)
 }
 
+// LightClientUpdateFetcher retrieves the latest light client updates computed by the node.
+type LightClientUpdateFetcher interface {
+	LatestLightClientFinalityUpdate() *ethpb.LightClientFinalityUpdate
+	LatestLightClientOptimisticUpdate() *ethpb.LightClientOptimisticUpdate
+}
+
 // FinalizedCheckpt returns the latest finalized checkpoint from chain store.
 func (s *Service) FinalizedCheckpt() *ethpb.Checkpoint {
 	cp := s.ForkChoicer().FinalizedCheckpoint()
@@ -6,12 +6,15 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	consensusblocks "github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
@@ -19,6 +22,7 @@ import (
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
 	"github.com/prysmaticlabs/prysm/v3/runtime/version"
 	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	"github.com/sirupsen/logrus"
@@ -70,6 +74,9 @@ func (s *Service) notifyForkchoiceUpdate(ctx context.Context, arg *notifyForkcho
 
 	nextSlot := s.CurrentSlot() + 1 // Cache payload ID for next slot proposer.
 	hasAttr, attr, proposerId := s.getPayloadAttribute(ctx, arg.headState, nextSlot)
+	if hasAttr {
+		s.notifyPayloadAttributesEvent(nextSlot, proposerId, arg.headRoot, headPayload, attr)
+	}
 
 	payloadID, lastValidHash, err := s.cfg.ExecutionEngineCaller.ForkchoiceUpdated(ctx, fcs, attr)
 	if err != nil {
@@ -335,6 +342,78 @@ func (s *Service) getPayloadAttribute(ctx context.Context, st state.BeaconState,
 	return true, attr, proposerID
 }
 
+// shouldOverrideFCU returns whether the new head is a late and weak block that the proposer of the next slot,
+// served by this node, may orphan. In that case the execution engine should keep building on the head's parent.
+func (s *Service) shouldOverrideFCU(newHeadRoot [32]byte) bool {
+	if !features.Get().EnableReorgLateBlocks {
+		return false
+	}
+	if _, _, has := s.cfg.ProposerSlotIndexCache.GetProposerPayloadIDs(s.CurrentSlot()+1, [32]byte{} /* head root */); !has {
+		return false
+	}
+	if s.cfg.ForkChoiceStore.CachedHeadRoot() != newHeadRoot {
+		return false
+	}
+	return s.cfg.ForkChoiceStore.ShouldOverrideFCU()
+}
+
+// parentForkchoiceUpdateArg returns the forkchoice update arguments for the parent of the given head block.
+func (s *Service) parentForkchoiceUpdateArg(ctx context.Context, headBlock interfaces.SignedBeaconBlock) (*notifyForkchoiceUpdateArg, error) {
+	parentRoot := headBlock.Block().ParentRoot()
+	parentBlock, err := s.getBlock(ctx, parentRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent block")
+	}
+	parentState, err := s.cfg.StateGen.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent state")
+	}
+	return &notifyForkchoiceUpdateArg{
+		headState: parentState,
+		headRoot:  parentRoot,
+		headBlock: parentBlock.Block(),
+	}, nil
+}
+
+// notifyPayloadAttributesEvent notifies the state feed of the payload attributes sent to the execution engine along
+// with a forkchoice update, so that external block builders can start building a payload for the proposal.
+func (s *Service) notifyPayloadAttributesEvent(
+	slot types.Slot,
+	proposerIndex types.ValidatorIndex,
+	headRoot [32]byte,
+	headPayload interfaces.ExecutionData,
+	attr payloadattribute.Attributer,
+) {
+	pbAttr := &enginev1.PayloadAttributesV2{
+		Timestamp:             attr.Timestamps(),
+		PrevRandao:            attr.PrevRandao(),
+		SuggestedFeeRecipient: attr.SuggestedFeeRecipient(),
+		Withdrawals:           []*enginev1.Withdrawal{},
+	}
+	if attr.Version() >= version.Capella {
+		withdrawals, err := attr.Withdrawals()
+		if err != nil {
+			log.WithError(err).Error("Could not get withdrawals to notify payload attributes")
+			return
+		}
+		pbAttr.Withdrawals = withdrawals
+	}
+	s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.PayloadAttributes,
+		Data: &ethpbv1.EventPayloadAttribute{
+			Version: version.String(attr.Version()),
+			Data: &ethpbv1.EventPayloadAttribute_BasePayloadAttribute{
+				ProposerIndex:     proposerIndex,
+				ProposalSlot:      slot,
+				ParentBlockNumber: headPayload.BlockNumber(),
+				ParentBlockRoot:   bytesutil.SafeCopyBytes(headRoot[:]),
+				ParentBlockHash:   bytesutil.SafeCopyBytes(headPayload.BlockHash()),
+				PayloadAttributes: pbAttr,
+			},
+		},
+	})
+}
+
 // removeInvalidBlockAndState removes the invalid block and its corresponding state from the cache and DB.
 func (s *Service) removeInvalidBlockAndState(ctx context.Context, blkRoots [][32]byte) error {
 	for _, root := range blkRoots {
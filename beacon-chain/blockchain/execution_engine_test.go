@@ -7,8 +7,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	testDB "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
 	mockExecution "github.com/prysmaticlabs/prysm/v3/beacon-chain/execution/testing"
@@ -17,6 +20,7 @@ import (
 	bstate "github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	state_native "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	consensusblocks "github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
@@ -24,6 +28,7 @@ import (
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	v1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
@@ -862,6 +867,83 @@ func Test_GetPayloadAttributeV2(t *testing.T) {
 	require.Equal(t, 0, len(a))
 }
 
+func Test_NotifyPayloadAttributesEvent(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	opts := []Option{
+		WithDatabase(beaconDB),
+		WithStateGen(stategen.New(beaconDB, doublylinkedtree.New())),
+		WithProposerIdsCache(cache.NewProposerPayloadIDsCache()),
+		WithStateNotifier(notifier),
+	}
+	service, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+
+	st, _ := util.DeterministicGenesisStateCapella(t, 1)
+	suggestedVid := types.ValidatorIndex(1)
+	slot := types.Slot(1)
+	service.cfg.ProposerSlotIndexCache.SetProposerAndPayloadIDs(slot, suggestedVid, [8]byte{}, [32]byte{})
+	hasPayload, attr, vId := service.getPayloadAttribute(ctx, st, slot)
+	require.Equal(t, true, hasPayload)
+
+	headPayload, err := consensusblocks.WrappedExecutionPayloadCapella(&v1.ExecutionPayloadCapella{
+		BlockNumber: 10,
+		BlockHash:   bytesutil.PadTo([]byte("hash"), fieldparams.RootLength),
+	})
+	require.NoError(t, err)
+	headRoot := [32]byte{'a'}
+	service.notifyPayloadAttributesEvent(slot, vId, headRoot, headPayload, attr)
+
+	events := notifier.ReceivedEvents()
+	require.Equal(t, 1, len(events))
+	require.Equal(t, feed.EventType(statefeed.PayloadAttributes), events[0].Type)
+	data, ok := events[0].Data.(*ethpbv1.EventPayloadAttribute)
+	require.Equal(t, true, ok)
+	require.Equal(t, "capella", data.Version)
+	require.Equal(t, suggestedVid, data.Data.ProposerIndex)
+	require.Equal(t, slot, data.Data.ProposalSlot)
+	require.Equal(t, uint64(10), data.Data.ParentBlockNumber)
+	require.DeepEqual(t, headRoot[:], data.Data.ParentBlockRoot)
+	require.DeepEqual(t, headPayload.BlockHash(), data.Data.ParentBlockHash)
+	require.Equal(t, attr.Timestamps(), data.Data.PayloadAttributes.Timestamp)
+	require.DeepEqual(t, attr.PrevRandao(), data.Data.PayloadAttributes.PrevRandao)
+	require.DeepEqual(t, attr.SuggestedFeeRecipient(), data.Data.PayloadAttributes.SuggestedFeeRecipient)
+	require.Equal(t, 0, len(data.Data.PayloadAttributes.Withdrawals))
+}
+
+func Test_ShouldOverrideFCU(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	fcs := doublylinkedtree.New()
+	opts := []Option{
+		WithDatabase(beaconDB),
+		WithStateGen(stategen.New(beaconDB, fcs)),
+		WithForkChoiceStore(fcs),
+		WithProposerIdsCache(cache.NewProposerPayloadIDsCache()),
+	}
+	service, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+	st, root, err := prepareForkchoiceState(ctx, 0, [32]byte{'a'}, [32]byte{}, [32]byte{'A'}, &ethpb.Checkpoint{}, &ethpb.Checkpoint{})
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+	service.SetGenesisTime(time.Now())
+
+	// Feature disabled.
+	service.cfg.ProposerSlotIndexCache.SetProposerAndPayloadIDs(service.CurrentSlot()+1, 1, [8]byte{}, [32]byte{})
+	require.Equal(t, false, service.shouldOverrideFCU(root))
+
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableReorgLateBlocks: true,
+	})
+	defer resetCfg()
+
+	// Not the forkchoice head.
+	require.Equal(t, false, service.shouldOverrideFCU([32]byte{'b'}))
+	// Head is not a late block from the current slot.
+	require.Equal(t, false, service.shouldOverrideFCU(root))
+}
+
 func Test_UpdateLastValidatedCheckpoint(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	params.OverrideBeaconConfig(params.MainnetConfig())
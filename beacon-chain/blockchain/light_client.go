@@ -0,0 +1,146 @@
+package blockchain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	lightclient "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// LatestLightClientFinalityUpdate returns the latest light client finality update, or nil if there is none yet.
+func (s *Service) LatestLightClientFinalityUpdate() *ethpb.LightClientFinalityUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientFinalityUpdate
+}
+
+// LatestLightClientOptimisticUpdate returns the latest light client optimistic update, or nil if there is none yet.
+func (s *Service) LatestLightClientOptimisticUpdate() *ethpb.LightClientOptimisticUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientOptimisticUpdate
+}
+
+// processLightClientUpdates creates the light client update signed by the sync aggregate of the given block.
+// The update replaces the stored best update of its sync committee period if it is better, and it becomes
+// the latest finality and optimistic update when it is newer than the current ones.
+func (s *Service) processLightClientUpdates(ctx context.Context, signed interfaces.SignedBeaconBlock, blockRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.processLightClientUpdates")
+	defer span.End()
+
+	if signed.Version() == version.Phase0 {
+		return nil
+	}
+	// Updates of concurrently imported blocks are compared against the same stored best update.
+	s.lightClientProcessLock.Lock()
+	defer s.lightClientProcessLock.Unlock()
+
+	postState, err := s.cfg.StateGen.StateByRoot(ctx, blockRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get post state")
+	}
+	attestedState, err := s.cfg.StateGen.StateByRoot(ctx, signed.Block().ParentRoot())
+	if err != nil {
+		return errors.Wrap(err, "could not get attested state")
+	}
+	if attestedState.Version() == version.Phase0 {
+		return nil
+	}
+	var finalizedBlock interfaces.SignedBeaconBlock
+	finalizedRoot := bytesutil.ToBytes32(attestedState.FinalizedCheckpoint().Root)
+	if finalizedRoot == params.BeaconConfig().ZeroHash {
+		finalizedBlock, err = s.cfg.BeaconDB.GenesisBlock(ctx)
+	} else {
+		finalizedBlock, err = s.cfg.BeaconDB.Block(ctx, finalizedRoot)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized block")
+	}
+
+	update, err := lightclient.NewLightClientUpdateFromBeaconState(ctx, postState, signed, attestedState, finalizedBlock)
+	if errors.Is(err, lightclient.ErrInsufficientParticipation) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not create light client update")
+	}
+
+	period := lightclient.SyncCommitteePeriodAtSlot(update.AttestedHeader.Beacon.Slot)
+	best, err := s.cfg.BeaconDB.LightClientUpdate(ctx, period)
+	if err != nil {
+		return errors.Wrap(err, "could not get best light client update")
+	}
+	if best == nil || lightclient.IsBetterUpdate(update, best) {
+		if err := s.cfg.BeaconDB.SaveLightClientUpdate(ctx, period, update); err != nil {
+			return errors.Wrap(err, "could not save light client update")
+		}
+	}
+
+	s.updateLatestLightClientUpdates(update)
+	return nil
+}
+
+// updateLatestLightClientUpdates keeps the finality and optimistic updates derived from the given update
+// if they are newer than the current ones, and broadcasts them to the network.
+func (s *Service) updateLatestLightClientUpdates(update *ethpb.LightClientUpdate) {
+	finalityUpdate := lightclient.NewLightClientFinalityUpdateFromUpdate(update)
+	optimisticUpdate := lightclient.NewLightClientOptimisticUpdateFromUpdate(update)
+
+	s.lightClientLock.Lock()
+	newFinality := lightclient.IsFinalityUpdate(update) && isNewerFinalityUpdate(finalityUpdate, s.lightClientFinalityUpdate)
+	if newFinality {
+		s.lightClientFinalityUpdate = finalityUpdate
+	}
+	newOptimistic := s.lightClientOptimisticUpdate == nil ||
+		optimisticUpdate.AttestedHeader.Beacon.Slot > s.lightClientOptimisticUpdate.AttestedHeader.Beacon.Slot
+	if newOptimistic {
+		s.lightClientOptimisticUpdate = optimisticUpdate
+	}
+	s.lightClientLock.Unlock()
+
+	if newFinality {
+		go s.broadcastLightClientUpdate(update.SignatureSlot, finalityUpdate)
+	}
+	if newOptimistic {
+		go s.broadcastLightClientUpdate(update.SignatureSlot, optimisticUpdate)
+	}
+}
+
+// broadcastLightClientUpdate broadcasts a light client update once a third of its signature slot has passed,
+// since peers ignore updates received before the block of the signature slot had time to propagate.
+func (s *Service) broadcastLightClientUpdate(signatureSlot types.Slot, msg proto.Message) {
+	wait := time.Until(slots.StartTime(uint64(s.genesisTime.Unix()), signatureSlot).
+		Add(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 3))
+	if wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+	if err := s.cfg.P2p.Broadcast(s.ctx, msg); err != nil {
+		log.WithError(err).Debug("Could not broadcast light client update")
+	}
+}
+
+// isNewerFinalityUpdate returns true if the finality update has a newer finalized header than the current one,
+// or the same finalized header with a sync committee supermajority the current one lacks.
+func isNewerFinalityUpdate(update, current *ethpb.LightClientFinalityUpdate) bool {
+	if current == nil {
+		return true
+	}
+	if update.FinalizedHeader.Beacon.Slot != current.FinalizedHeader.Beacon.Slot {
+		return update.FinalizedHeader.Beacon.Slot > current.FinalizedHeader.Beacon.Slot
+	}
+	return lightclient.HasSupermajority(update.SyncAggregate) && !lightclient.HasSupermajority(current.SyncAggregate)
+}
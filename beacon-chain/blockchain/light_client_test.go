@@ -0,0 +1,72 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
+	p2pTesting "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestService_processLightClientUpdates(t *testing.T) {
+	ctx := context.Background()
+	opts := append(testServiceOptsWithDB(t), WithP2PBroadcaster(&p2pTesting.MockBroadcaster{}))
+	s, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	c, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(c))
+	require.NoError(t, genesis.SetNextSyncCommittee(c))
+	conf := util.DefaultBlockGenConfig()
+	conf.FullSyncAggregate = true
+	b, err := util.GenerateFullBlockAltair(genesis, keys, conf, 1)
+	require.NoError(t, err)
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	post, err := transition.ExecuteStateTransition(ctx, genesis.Copy(), blk)
+	require.NoError(t, err)
+	require.NoError(t, s.cfg.BeaconDB.SaveState(ctx, genesis, blk.Block().ParentRoot()))
+	require.NoError(t, s.cfg.BeaconDB.SaveState(ctx, post, root))
+
+	require.NoError(t, s.processLightClientUpdates(ctx, blk, root))
+
+	update, err := s.cfg.BeaconDB.LightClientUpdate(ctx, 0)
+	require.NoError(t, err)
+	require.NotNil(t, update)
+	assert.Equal(t, types.Slot(1), update.SignatureSlot)
+	optimistic := s.LatestLightClientOptimisticUpdate()
+	require.NotNil(t, optimistic)
+	assert.Equal(t, types.Slot(1), optimistic.SignatureSlot)
+	// The genesis block is not in the database, so the update carries no finality.
+	assert.Equal(t, (*ethpb.LightClientFinalityUpdate)(nil), s.LatestLightClientFinalityUpdate())
+}
+
+func TestIsNewerFinalityUpdate(t *testing.T) {
+	update := func(finalizedSlot types.Slot, participants uint64) *ethpb.LightClientFinalityUpdate {
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		return &ethpb.LightClientFinalityUpdate{
+			FinalizedHeader: &ethpb.LightClientHeader{Beacon: &ethpb.BeaconBlockHeader{Slot: finalizedSlot}},
+			SyncAggregate:   &ethpb.SyncAggregate{SyncCommitteeBits: bits},
+		}
+	}
+	assert.Equal(t, true, isNewerFinalityUpdate(update(32, 100), nil))
+	assert.Equal(t, true, isNewerFinalityUpdate(update(64, 100), update(32, 512)))
+	assert.Equal(t, false, isNewerFinalityUpdate(update(32, 512), update(64, 100)))
+	assert.Equal(t, true, isNewerFinalityUpdate(update(32, 512), update(32, 100)))
+	assert.Equal(t, false, isNewerFinalityUpdate(update(32, 512), update(32, 400)))
+}
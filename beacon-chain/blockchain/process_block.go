@@ -37,6 +37,9 @@ const slotDeadline = 5 * time.Second
 // A custom deadline for deposit trie insertion.
 const depositDeadline = 20 * time.Second
 
+// A custom deadline for computing and storing light client updates.
+const lightClientDeadline = 10 * time.Second
+
 // This defines size of the upper bound for initial sync block cache.
 var initialSyncBlockCacheSize = uint64(2 * params.BeaconConfig().SlotsPerEpoch)
 
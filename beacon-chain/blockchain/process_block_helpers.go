@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/doubly-linked-tree"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/types"
@@ -308,7 +309,22 @@ func (s *Service) insertFinalizedDeposits(ctx context.Context, fRoot [32]byte) e
 	// to be included(rather than the last one to be processed). This was most likely
 	// done as the state cannot represent signed integers.
 	eth1DepositIndex -= 1
-	s.cfg.DepositCache.InsertFinalizedDeposits(ctx, int64(eth1DepositIndex))
+	// The finalized deposit tree is snapshotted together with the execution block that
+	// contains its last deposit, as defined by EIP-4881. The eth1 data of the state only
+	// identifies that block once all of its deposits have been processed.
+	eth1Data := finalizedState.Eth1Data()
+	if eth1Data != nil && finalizedState.Eth1DepositIndex() == eth1Data.DepositCount {
+		executionHash := common.BytesToHash(eth1Data.BlockHash)
+		// Skip this finalization rather than snapshotting the tree at an unknown block height,
+		// the deposits are finalized along with the next finalized checkpoint.
+		executionNumber, err := s.executionBlockNumber(ctx, executionHash)
+		if err != nil {
+			return errors.Wrap(err, "could not get execution block of finalized deposits")
+		}
+		if err = s.cfg.DepositCache.InsertFinalizedDeposits(ctx, int64(eth1DepositIndex), executionHash, executionNumber); err != nil {
+			return errors.Wrap(err, "could not insert finalized deposits")
+		}
+	}
 	// Deposit proofs are only used during state transition and can be safely removed to save space.
 	if err = s.cfg.DepositCache.PruneProofs(ctx, int64(eth1DepositIndex)); err != nil {
 		return errors.Wrap(err, "could not prune deposit proofs")
@@ -316,6 +332,21 @@ func (s *Service) insertFinalizedDeposits(ctx context.Context, fRoot [32]byte) e
 	return nil
 }
 
+// executionBlockNumber returns the number of the execution block with the given hash.
+func (s *Service) executionBlockNumber(ctx context.Context, hash common.Hash) (uint64, error) {
+	if s.cfg.ExecutionEngineCaller == nil {
+		return 0, errors.New("no execution engine caller")
+	}
+	blk, err := s.cfg.ExecutionEngineCaller.ExecutionBlockByHash(ctx, hash, false /* no txs */)
+	if err != nil {
+		return 0, err
+	}
+	if blk == nil || blk.Number == nil {
+		return 0, errors.Errorf("execution block %#x not found", hash)
+	}
+	return blk.Number.Uint64(), nil
+}
+
 // This ensures that the input root defaults to using genesis root instead of zero hashes. This is needed for handling
 // fork choice justification routine.
 func (s *Service) ensureRootNotZeros(root [32]byte) [32]byte {
@@ -198,6 +198,19 @@ func (s *Service) notifyEngineIfChangedHead(ctx context.Context, newHeadRoot [32
 		headRoot:  newHeadRoot,
 		headBlock: newHeadBlock.Block(),
 	}
+	if s.shouldOverrideFCU(newHeadRoot) {
+		parentArg, err := s.parentForkchoiceUpdateArg(ctx, newHeadBlock)
+		if err != nil {
+			log.WithError(err).Error("Could not get parent of late head block, notifying the engine of the head")
+		} else {
+			log.WithFields(logrus.Fields{
+				"slot":       newHeadBlock.Block().Slot(),
+				"headRoot":   fmt.Sprintf("%#x", bytesutil.Trunc(newHeadRoot[:])),
+				"parentRoot": fmt.Sprintf("%#x", bytesutil.Trunc(parentArg.headRoot[:])),
+			}).Info("Late and weak head block, notifying the engine of its parent instead")
+			arg = parentArg
+		}
+	}
 	_, err = s.notifyForkchoiceUpdate(s.ctx, arg)
 	if err != nil {
 		return err
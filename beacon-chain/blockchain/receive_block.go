@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
@@ -61,6 +62,19 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.SignedBeaco
 		return err
 	}
 
+	// Compute and store the light client update signed by the block's sync aggregate.
+	// This happens in the background so that serving light clients never delays block import.
+	if features.Get().EnableLightClient {
+		go func() {
+			// Use a custom deadline here, since this method runs asynchronously.
+			lcCtx, cancel := context.WithTimeout(context.Background(), lightClientDeadline)
+			defer cancel()
+			if err := s.processLightClientUpdates(lcCtx, blockCopy, blockRoot); err != nil {
+				log.WithError(err).Error("Unable to process light client updates")
+			}
+		}()
+	}
+
 	// Reports on block and fork choice metrics.
 	finalized := s.FinalizedCheckpt()
 	reportSlotMetrics(blockCopy.Block().Slot(), s.HeadSlot(), s.CurrentSlot(), finalized)
@@ -59,6 +59,11 @@ type Service struct {
 	justifiedBalances       *stateBalanceCache
 	wsVerifier              *WeakSubjectivityVerifier
 	processAttestationsLock sync.Mutex
+	// Latest light client updates, kept when the light client server is enabled.
+	lightClientLock             sync.RWMutex
+	lightClientFinalityUpdate   *ethpb.LightClientFinalityUpdate
+	lightClientOptimisticUpdate *ethpb.LightClientOptimisticUpdate
+	lightClientProcessLock      sync.Mutex
 }
 
 // config options for the service.
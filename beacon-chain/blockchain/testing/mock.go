@@ -64,6 +64,8 @@ type ChainService struct {
 	ReceiveBlockMockErr         error
 	OptimisticCheckRootReceived [32]byte
 	FinalizedRoots              map[[32]byte]bool
+	LightClientFinalityUpdate   *ethpb.LightClientFinalityUpdate
+	LightClientOptimisticUpdate *ethpb.LightClientOptimisticUpdate
 }
 
 // ForkChoicer mocks the same method in the chain service
@@ -470,3 +472,13 @@ func (s *ChainService) ReceiveAttesterSlashing(context.Context, *ethpb.AttesterS
 func (s *ChainService) IsFinalized(_ context.Context, blockRoot [32]byte) bool {
 	return s.FinalizedRoots[blockRoot]
 }
+
+// LatestLightClientFinalityUpdate mocks the same method in the chain service.
+func (s *ChainService) LatestLightClientFinalityUpdate() *ethpb.LightClientFinalityUpdate {
+	return s.LightClientFinalityUpdate
+}
+
+// LatestLightClientOptimisticUpdate mocks the same method in the chain service.
+func (s *ChainService) LatestLightClientOptimisticUpdate() *ethpb.LightClientOptimisticUpdate {
+	return s.LightClientOptimisticUpdate
+}
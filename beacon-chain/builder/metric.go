@@ -27,4 +27,47 @@ var (
 			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
 		},
 	)
+	relayGetHeaderLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "relay_get_header_latency_milliseconds",
+			Help:    "Captures RPC latency for get header in milliseconds, per relay",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+		[]string{"relay"},
+	)
+	relayGetHeaderErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_get_header_errors_total",
+			Help: "The number of failed or invalid get header requests, per relay",
+		},
+		[]string{"relay"},
+	)
+	relayBidsReceived = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_bids_received_total",
+			Help: "The number of bids received, per relay",
+		},
+		[]string{"relay"},
+	)
+	relayBidsWon = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_bids_won_total",
+			Help: "The number of bids that were used to propose a block, per relay",
+		},
+		[]string{"relay"},
+	)
+	relaySubmitBlindedBlockErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_submit_blinded_block_errors_total",
+			Help: "The number of failed blinded block submissions, per relay",
+		},
+		[]string{"relay"},
+	)
+	relayRegisterValidatorErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_register_validator_errors_total",
+			Help: "The number of failed validator registrations, per relay",
+		},
+		[]string{"relay"},
+	)
 )
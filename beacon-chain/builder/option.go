@@ -1,10 +1,14 @@
 package builder
 
 import (
+	"time"
+
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v3/container/slice"
 	"github.com/urfave/cli/v2"
 )
 
@@ -12,25 +16,39 @@ type Option func(s *Service) error
 
 // FlagOptions for builder service flag configurations.
 func FlagOptions(c *cli.Context) ([]Option, error) {
-	endpoint := c.String(flags.MevRelayEndpoint.Name)
-	var client *builder.Client
-	if endpoint != "" {
-		var err error
-		client, err = builder.NewClient(endpoint)
+	var opts []Option
+	if c.IsSet(flags.BuilderRelayTimeout.Name) {
+		opts = append(opts, WithRelayTimeout(c.Duration(flags.BuilderRelayTimeout.Name)))
+	}
+	for _, endpoint := range slice.SplitCommaSeparated(c.StringSlice(flags.MevRelayEndpoint.Name)) {
+		if endpoint == "" {
+			continue
+		}
+		client, err := builder.NewClient(endpoint)
 		if err != nil {
 			return nil, err
 		}
-	}
-	opts := []Option{
-		WithBuilderClient(client),
+		opts = append(opts, WithBuilderClient(client))
 	}
 	return opts, nil
 }
 
-// WithBuilderClient sets the builder client for the beacon chain builder service.
+// WithBuilderClient adds a builder client for the beacon chain builder service.
+// It can be used several times to configure multiple relays.
 func WithBuilderClient(client builder.BuilderClient) Option {
 	return func(s *Service) error {
-		s.cfg.builderClient = client
+		s.cfg.builderClients = append(s.cfg.builderClients, client)
+		return nil
+	}
+}
+
+// WithRelayTimeout sets the maximum amount of time each relay is given to respond to a header request.
+func WithRelayTimeout(timeout time.Duration) Option {
+	return func(s *Service) error {
+		if timeout <= 0 {
+			return errors.New("relay timeout must be positive")
+		}
+		s.cfg.relayTimeout = timeout
 		return nil
 	}
 }
@@ -1,17 +1,21 @@
 package builder
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"math/big"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
-	v1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -20,54 +24,68 @@ import (
 // ErrNoBuilder is used when builder endpoint is not configured.
 var ErrNoBuilder = errors.New("builder endpoint not configured")
 
+// defaultRelayTimeout is the maximum amount of time allowed for a relay to respond to a header request
+// when no timeout is configured. This value is known as `BUILDER_PROPOSAL_DELAY_TOLERANCE` in builder spec.
+const defaultRelayTimeout = time.Second
+
 // BlockBuilder defines the interface for interacting with the block builder
 type BlockBuilder interface {
-	SubmitBlindedBlock(ctx context.Context, block *ethpb.SignedBlindedBeaconBlockBellatrix) (*v1.ExecutionPayload, error)
-	GetHeader(ctx context.Context, slot types.Slot, parentHash [32]byte, pubKey [48]byte) (*ethpb.SignedBuilderBid, error)
+	SubmitBlindedBlock(ctx context.Context, block interfaces.SignedBeaconBlock) (interfaces.ExecutionData, error)
+	GetHeaders(ctx context.Context, slot types.Slot, parentHash [32]byte, pubKey [48]byte) ([]builder.SignedBid, error)
 	RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error
 	Configured() bool
 }
 
 // config defines a config struct for dependencies into the service.
 type config struct {
-	builderClient builder.BuilderClient
-	beaconDB      db.HeadAccessDatabase
-	headFetcher   blockchain.HeadFetcher
+	builderClients []builder.BuilderClient
+	relayTimeout   time.Duration
+	beaconDB       db.HeadAccessDatabase
+	headFetcher    blockchain.HeadFetcher
 }
 
 // Service defines a service that provides a client for interacting with the beacon chain and MEV relay network.
 type Service struct {
-	cfg    *config
-	c      builder.BuilderClient
-	ctx    context.Context
-	cancel context.CancelFunc
+	cfg        *config
+	clients    []builder.BuilderClient
+	bidsLock   sync.Mutex
+	bidsSlot   types.Slot
+	bidSources map[[32]byte][]builder.BuilderClient
+	ctx        context.Context
+	cancel     context.CancelFunc
 }
 
 // NewService instantiates a new service.
 func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	s := &Service{
-		ctx:    ctx,
-		cancel: cancel,
-		cfg:    &config{},
+		ctx:        ctx,
+		cancel:     cancel,
+		cfg:        &config{relayTimeout: defaultRelayTimeout},
+		bidSources: make(map[[32]byte][]builder.BuilderClient),
 	}
 	for _, opt := range opts {
 		if err := opt(s); err != nil {
 			return nil, err
 		}
 	}
-	if s.cfg.builderClient != nil && !reflect.ValueOf(s.cfg.builderClient).IsNil() {
-		s.c = s.cfg.builderClient
+	for _, c := range s.cfg.builderClients {
+		if c == nil || reflect.ValueOf(c).IsNil() {
+			continue
+		}
+		s.clients = append(s.clients, c)
 
 		// Is the builder up?
-		if err := s.c.Status(ctx); err != nil {
-			log.WithError(err).Error("Failed to check builder status")
+		if err := c.Status(ctx); err != nil {
+			log.WithError(err).WithField("endpoint", c.NodeURL()).Error("Failed to check builder status")
 		} else {
-			log.WithField("endpoint", s.c.NodeURL()).Info("Builder has been configured")
-			log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
-				"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+			log.WithField("endpoint", c.NodeURL()).Info("Builder has been configured")
 		}
 	}
+	if len(s.clients) > 0 {
+		log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
+			"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+	}
 	return s, nil
 }
 
@@ -82,7 +100,11 @@ func (*Service) Stop() error {
 }
 
 // SubmitBlindedBlock submits a blinded block to the builder relay network.
-func (s *Service) SubmitBlindedBlock(ctx context.Context, b *ethpb.SignedBlindedBeaconBlockBellatrix) (*v1.ExecutionPayload, error) {
+// The block is only submitted to the relays that offered its execution header, or to all
+// relays if the header is not known. The first payload returned by a relay that matches the block hash
+// of the signed header is used.
+// The returned execution payload is of the same fork as the block.
+func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.SignedBeaconBlock) (interfaces.ExecutionData, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.SubmitBlindedBlock")
 	defer span.End()
 	start := time.Now()
@@ -90,33 +112,190 @@ func (s *Service) SubmitBlindedBlock(ctx context.Context, b *ethpb.SignedBlinded
 		submitBlindedBlockLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
-	return s.c.SubmitBlindedBlock(ctx, b)
+	if len(s.clients) == 0 {
+		return nil, ErrNoBuilder
+	}
+	h, err := b.Block().Body().Execution()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution header")
+	}
+	relays := s.bidRelays(bytesutil.ToBytes32(h.BlockHash()))
+	if len(relays) == 0 {
+		relays = s.clients
+	} else {
+		for _, c := range relays {
+			relayBidsWon.WithLabelValues(c.NodeURL()).Inc()
+		}
+	}
+
+	type result struct {
+		payload interfaces.ExecutionData
+		relay   string
+		err     error
+	}
+	results := make(chan result, len(relays))
+	for _, c := range relays {
+		go func(c builder.BuilderClient) {
+			payload, err := c.SubmitBlindedBlock(ctx, b)
+			if err == nil && (payload == nil || payload.IsNil()) {
+				err = errors.New("relay returned nil payload")
+			}
+			results <- result{payload: payload, relay: c.NodeURL(), err: err}
+		}(c)
+	}
+	var lastErr error
+	for range relays {
+		r := <-results
+		if r.err != nil {
+			relaySubmitBlindedBlockErrors.WithLabelValues(r.relay).Inc()
+			log.WithError(r.err).WithField("relay", r.relay).Warn("Failed to submit blinded block to relay")
+			lastErr = r.err
+			continue
+		}
+		if !bytes.Equal(r.payload.BlockHash(), h.BlockHash()) {
+			relaySubmitBlindedBlockErrors.WithLabelValues(r.relay).Inc()
+			lastErr = errors.Errorf("relay returned payload with block hash %#x, expected %#x", r.payload.BlockHash(), h.BlockHash())
+			log.WithError(lastErr).WithField("relay", r.relay).Warn("Failed to submit blinded block to relay")
+			continue
+		}
+		log.WithFields(log.Fields{
+			"relay":     r.relay,
+			"blockHash": fmt.Sprintf("%#x", h.BlockHash()),
+		}).Info("Received payload from relay")
+		return r.payload, nil
+	}
+	return nil, lastErr
 }
 
-// GetHeader retrieves the header for a given slot and parent hash from the builder relay network.
-func (s *Service) GetHeader(ctx context.Context, slot types.Slot, parentHash [32]byte, pubKey [48]byte) (*ethpb.SignedBuilderBid, error) {
-	ctx, span := trace.StartSpan(ctx, "builder.GetHeader")
+// GetHeaders retrieves the headers for a given slot and parent hash from all the configured relays.
+// Relays are queried in parallel and each of them is given the configured relay timeout to respond.
+// The bids are returned unverified, an error is only returned if no relay returned a bid.
+func (s *Service) GetHeaders(ctx context.Context, slot types.Slot, parentHash [32]byte, pubKey [48]byte) ([]builder.SignedBid, error) {
+	ctx, span := trace.StartSpan(ctx, "builder.GetHeaders")
 	defer span.End()
 	start := time.Now()
 	defer func() {
 		getHeaderLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
-	return s.c.GetHeader(ctx, slot, parentHash, pubKey)
+	if len(s.clients) == 0 {
+		return nil, ErrNoBuilder
+	}
+	bids := make([]builder.SignedBid, len(s.clients))
+	errs := make([]error, len(s.clients))
+	var wg sync.WaitGroup
+	for i, c := range s.clients {
+		wg.Add(1)
+		go func(i int, c builder.BuilderClient) {
+			defer wg.Done()
+			bids[i], errs[i] = s.getRelayHeader(ctx, c, slot, parentHash, pubKey)
+		}(i, c)
+	}
+	wg.Wait()
+
+	received := make([]builder.SignedBid, 0, len(bids))
+	sources := make(map[[32]byte][]builder.BuilderClient)
+	var lastErr error
+	for i, c := range s.clients {
+		if errs[i] != nil {
+			relayGetHeaderErrors.WithLabelValues(c.NodeURL()).Inc()
+			log.WithError(errs[i]).WithField("relay", c.NodeURL()).Warn("Failed to get header from relay")
+			lastErr = errs[i]
+			continue
+		}
+		blockHash, value, err := bidBlockHashAndValue(bids[i])
+		if err != nil {
+			relayGetHeaderErrors.WithLabelValues(c.NodeURL()).Inc()
+			log.WithError(err).WithField("relay", c.NodeURL()).Warn("Received invalid header from relay")
+			lastErr = err
+			continue
+		}
+		relayBidsReceived.WithLabelValues(c.NodeURL()).Inc()
+		log.WithFields(log.Fields{
+			"relay":     c.NodeURL(),
+			"slot":      slot,
+			"value":     value.String(),
+			"blockHash": fmt.Sprintf("%#x", blockHash),
+		}).Debug("Received header from relay")
+		sources[blockHash] = append(sources[blockHash], c)
+		received = append(received, bids[i])
+	}
+	s.saveBidRelays(slot, sources)
+	if len(received) == 0 {
+		return nil, errors.Wrap(lastErr, "no relay returned a bid")
+	}
+	return received, nil
+}
+
+// getRelayHeader retrieves a header from a single relay, within the configured relay timeout.
+func (s *Service) getRelayHeader(ctx context.Context, c builder.BuilderClient, slot types.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.relayTimeout)
+	defer cancel()
+	start := time.Now()
+	defer func() {
+		relayGetHeaderLatency.WithLabelValues(c.NodeURL()).Observe(float64(time.Since(start).Milliseconds()))
+	}()
+
+	bid, err := c.GetHeader(ctx, slot, parentHash, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if bid == nil || bid.IsNil() {
+		return nil, errors.New("relay returned nil bid")
+	}
+	return bid, nil
+}
+
+// bidBlockHashAndValue returns the block hash of the execution header committed to by the bid,
+// along with the value of the bid.
+func bidBlockHashAndValue(signedBid builder.SignedBid) ([32]byte, *big.Int, error) {
+	bid, err := signedBid.Message()
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	if bid == nil || bid.IsNil() {
+		return [32]byte{}, nil, errors.New("relay returned nil bid")
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	return bytesutil.ToBytes32(header.BlockHash()), bytesutil.LittleEndianBytesToBigInt(bid.Value()), nil
+}
+
+// saveBidRelays records which relays offered each execution block hash for the given slot,
+// replacing the records of older slots.
+func (s *Service) saveBidRelays(slot types.Slot, sources map[[32]byte][]builder.BuilderClient) {
+	s.bidsLock.Lock()
+	defer s.bidsLock.Unlock()
+	if slot > s.bidsSlot {
+		s.bidsSlot = slot
+		s.bidSources = make(map[[32]byte][]builder.BuilderClient)
+	}
+	for h, relays := range sources {
+		s.bidSources[h] = append(s.bidSources[h], relays...)
+	}
+}
+
+// bidRelays returns the relays that offered the given execution block hash.
+func (s *Service) bidRelays(blockHash [32]byte) []builder.BuilderClient {
+	s.bidsLock.Lock()
+	defer s.bidsLock.Unlock()
+	return s.bidSources[blockHash]
 }
 
 // Status retrieves the status of the builder relay network.
 func (s *Service) Status() error {
 	// Return early if builder isn't initialized in service.
-	if s.c == nil {
+	if len(s.clients) == 0 {
 		return nil
 	}
 
 	return nil
 }
 
-// RegisterValidator registers a validator with the builder relay network.
-// It also saves the registration object to the DB.
+// RegisterValidator registers a validator with all the configured relays in parallel.
+// It also saves the registration object to the DB, unless every relay failed.
 func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
 	ctx, span := trace.StartSpan(ctx, "builder.RegisterValidator")
 	defer span.End()
@@ -125,6 +304,9 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 		registerValidatorLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
+	if len(s.clients) == 0 {
+		return ErrNoBuilder
+	}
 	idxs := make([]types.ValidatorIndex, 0)
 	msgs := make([]*ethpb.ValidatorRegistrationV1, 0)
 	valid := make([]*ethpb.SignedValidatorRegistrationV1, 0)
@@ -141,16 +323,38 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 		msgs = append(msgs, r.Message)
 		valid = append(valid, r)
 	}
-	if err := s.c.RegisterValidator(ctx, valid); err != nil {
-		return errors.Wrap(err, "could not register validator(s)")
+
+	errs := make([]error, len(s.clients))
+	var wg sync.WaitGroup
+	for i, c := range s.clients {
+		wg.Add(1)
+		go func(i int, c builder.BuilderClient) {
+			defer wg.Done()
+			errs[i] = c.RegisterValidator(ctx, valid)
+		}(i, c)
+	}
+	wg.Wait()
+	registered := 0
+	var lastErr error
+	for i, c := range s.clients {
+		if errs[i] != nil {
+			relayRegisterValidatorErrors.WithLabelValues(c.NodeURL()).Inc()
+			log.WithError(errs[i]).WithField("relay", c.NodeURL()).Warn("Failed to register validator(s) with relay")
+			lastErr = errs[i]
+			continue
+		}
+		registered++
+	}
+	if registered == 0 {
+		return errors.Wrap(lastErr, "could not register validator(s)")
 	}
 
 	return s.cfg.beaconDB.SaveRegistrationsByValidatorIDs(ctx, idxs, msgs)
 }
 
-// Configured returns true if the user has configured a builder client.
+// Configured returns true if the user has configured at least one builder client.
 func (s *Service) Configured() bool {
-	return s.c != nil && !reflect.ValueOf(s.c).IsNil()
+	return len(s.clients) > 0
 }
 
 func (s *Service) pollRelayerStatus(ctx context.Context) {
@@ -159,9 +363,9 @@ func (s *Service) pollRelayerStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			if s.c != nil {
-				if err := s.c.Status(ctx); err != nil {
-					log.WithError(err).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
+			for _, c := range s.clients {
+				if err := c.Status(ctx); err != nil {
+					log.WithError(err).WithField("relay", c.NodeURL()).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
 				}
 			}
 		case <-ctx.Done():
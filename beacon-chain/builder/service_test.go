@@ -2,15 +2,24 @@ package builder
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/prysmaticlabs/prysm/v3/api/client/builder"
 	buildertesting "github.com/prysmaticlabs/prysm/v3/api/client/builder/testing"
 	blockchainTesting "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
 	dbtesting "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
 	eth "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
 )
 
 func Test_NewServiceWithBuilder(t *testing.T) {
@@ -37,3 +46,173 @@ func Test_RegisterValidator(t *testing.T) {
 	require.NoError(t, s.RegisterValidator(ctx, []*eth.SignedValidatorRegistrationV1{{Message: &eth.ValidatorRegistrationV1{Pubkey: pubkey[:], FeeRecipient: feeRecipient[:]}}}))
 	assert.Equal(t, true, builder.RegisteredVals[pubkey])
 }
+
+// relayClient is a builder client with a configurable bid, latency and failures.
+type relayClient struct {
+	buildertesting.MockClient
+	url         string
+	blockHash   [32]byte
+	value       byte
+	delay       time.Duration
+	err         error
+	lock        sync.Mutex
+	submissions int
+}
+
+func (r *relayClient) NodeURL() string {
+	return r.url
+}
+
+func (r *relayClient) GetHeader(ctx context.Context, _ types.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+	select {
+	case <-time.After(r.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return builder.WrappedSignedBuilderBid(&eth.SignedBuilderBid{
+		Message: &eth.BuilderBid{
+			Header: &v1.ExecutionPayloadHeader{BlockHash: r.blockHash[:]},
+			Value:  bytesutil.PadTo([]byte{r.value}, 32),
+		},
+	})
+}
+
+func (r *relayClient) RegisterValidator(ctx context.Context, reg []*eth.SignedValidatorRegistrationV1) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.MockClient.RegisterValidator(ctx, reg)
+}
+
+func (r *relayClient) SubmitBlindedBlock(_ context.Context, _ interfaces.SignedBeaconBlock) (interfaces.ExecutionData, error) {
+	r.lock.Lock()
+	r.submissions++
+	r.lock.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	return blocks.WrappedExecutionPayload(&v1.ExecutionPayload{BlockHash: r.blockHash[:]})
+}
+
+func Test_NewServiceWithMultipleBuilders(t *testing.T) {
+	var nilClient *builder.Client
+	s, err := NewService(context.Background(),
+		WithBuilderClient(&buildertesting.MockClient{}),
+		WithBuilderClient(nilClient),
+		WithBuilderClient(&buildertesting.MockClient{}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, true, s.Configured())
+	assert.Equal(t, 2, len(s.clients))
+
+	_, err = NewService(context.Background(), WithRelayTimeout(0))
+	require.ErrorContains(t, "relay timeout must be positive", err)
+}
+
+func Test_GetHeaders(t *testing.T) {
+	ctx := context.Background()
+	good := &relayClient{url: "good", blockHash: [32]byte{'a'}, value: 1}
+	better := &relayClient{url: "better", blockHash: [32]byte{'b'}, value: 2}
+	failing := &relayClient{url: "failing", err: errors.New("relay is down")}
+	slow := &relayClient{url: "slow", blockHash: [32]byte{'c'}, value: 3, delay: time.Second}
+	s, err := NewService(ctx,
+		WithRelayTimeout(50*time.Millisecond),
+		WithBuilderClient(good),
+		WithBuilderClient(better),
+		WithBuilderClient(failing),
+		WithBuilderClient(slow),
+	)
+	require.NoError(t, err)
+
+	bids, err := s.GetHeaders(ctx, 1, [32]byte{}, [48]byte{})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(bids))
+	assert.DeepEqual(t, []builder.BuilderClient{good}, s.bidRelays([32]byte{'a'}))
+	assert.DeepEqual(t, []builder.BuilderClient{better}, s.bidRelays([32]byte{'b'}))
+	assert.Equal(t, 0, len(s.bidRelays([32]byte{'c'})))
+
+	s, err = NewService(ctx, WithRelayTimeout(50*time.Millisecond), WithBuilderClient(failing), WithBuilderClient(slow))
+	require.NoError(t, err)
+	_, err = s.GetHeaders(ctx, 1, [32]byte{}, [48]byte{})
+	require.ErrorContains(t, "no relay returned a bid", err)
+
+	s, err = NewService(ctx)
+	require.NoError(t, err)
+	_, err = s.GetHeaders(ctx, 1, [32]byte{}, [48]byte{})
+	require.ErrorIs(t, err, ErrNoBuilder)
+}
+
+func Test_SubmitBlindedBlock(t *testing.T) {
+	ctx := context.Background()
+	good := &relayClient{url: "good", blockHash: [32]byte{'a'}, value: 1}
+	better := &relayClient{url: "better", blockHash: [32]byte{'b'}, value: 2}
+	s, err := NewService(ctx, WithBuilderClient(good), WithBuilderClient(better))
+	require.NoError(t, err)
+	_, err = s.GetHeaders(ctx, 1, [32]byte{}, [48]byte{})
+	require.NoError(t, err)
+
+	b := util.NewBlindedBeaconBlockBellatrix()
+	b.Block.Slot = 1
+	b.Block.Body.ExecutionPayloadHeader.BlockHash = bytesutil.PadTo([]byte{'b'}, 32)
+	sb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	payload, err := s.SubmitBlindedBlock(ctx, sb)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{'b'}, 32), payload.BlockHash())
+	assert.Equal(t, 0, good.submissions)
+	assert.Equal(t, 1, better.submissions)
+
+	// Blocks with an unknown header are submitted to all relays.
+	b.Block.Body.ExecutionPayloadHeader.BlockHash = bytesutil.PadTo([]byte{'a'}, 32)
+	sb, err = blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	s.bidSources = nil
+	payload, err = s.SubmitBlindedBlock(ctx, sb)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{'a'}, 32), payload.BlockHash())
+	require.NoError(t, waitForSubmissions(good, 1))
+	require.NoError(t, waitForSubmissions(better, 2))
+
+	// Payloads that do not match the block hash of the signed header are rejected.
+	b.Block.Body.ExecutionPayloadHeader.BlockHash = bytesutil.PadTo([]byte{'c'}, 32)
+	sb, err = blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	_, err = s.SubmitBlindedBlock(ctx, sb)
+	require.ErrorContains(t, "relay returned payload with block hash", err)
+}
+
+func waitForSubmissions(r *relayClient, want int) error {
+	for i := 0; i < 100; i++ {
+		r.lock.Lock()
+		got := r.submissions
+		r.lock.Unlock()
+		if got >= want {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.New("timed out waiting for submissions")
+}
+
+func Test_RegisterValidatorMultipleRelays(t *testing.T) {
+	ctx := context.Background()
+	db := dbtesting.SetupDB(t)
+	headFetcher := &blockchainTesting.ChainService{}
+	up := &relayClient{MockClient: buildertesting.NewClient(), url: "up"}
+	down := &relayClient{MockClient: buildertesting.NewClient(), url: "down", err: errors.New("relay is down")}
+	s, err := NewService(ctx, WithDatabase(db), WithHeadFetcher(headFetcher), WithBuilderClient(up), WithBuilderClient(down))
+	require.NoError(t, err)
+	pubkey := bytesutil.ToBytes48([]byte("pubkey"))
+	var feeRecipient [20]byte
+	reg := []*eth.SignedValidatorRegistrationV1{{Message: &eth.ValidatorRegistrationV1{Pubkey: pubkey[:], FeeRecipient: feeRecipient[:]}}}
+	require.NoError(t, s.RegisterValidator(ctx, reg))
+	assert.Equal(t, true, up.RegisteredVals[pubkey])
+	assert.Equal(t, false, down.RegisteredVals[pubkey])
+
+	s, err = NewService(ctx, WithDatabase(db), WithHeadFetcher(headFetcher), WithBuilderClient(down))
+	require.NoError(t, err)
+	require.ErrorContains(t, "could not register validator(s)", s.RegisterValidator(ctx, reg))
+}
@@ -3,6 +3,9 @@ package testing
 import (
 	"context"
 
+	"github.com/prysmaticlabs/prysm/v3/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	v1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
@@ -12,8 +15,11 @@ import (
 type MockBuilderService struct {
 	HasConfigured         bool
 	Payload               *v1.ExecutionPayload
+	PayloadCapella        *v1.ExecutionPayloadCapella
 	ErrSubmitBlindedBlock error
 	Bid                   *ethpb.SignedBuilderBid
+	BidCapella            *ethpb.SignedBuilderBidCapella
+	ExtraBids             []builder.SignedBid
 	ErrGetHeader          error
 	ErrRegisterValidator  error
 }
@@ -24,13 +30,37 @@ func (s *MockBuilderService) Configured() bool {
 }
 
 // SubmitBlindedBlock for mocking.
-func (s *MockBuilderService) SubmitBlindedBlock(context.Context, *ethpb.SignedBlindedBeaconBlockBellatrix) (*v1.ExecutionPayload, error) {
-	return s.Payload, s.ErrSubmitBlindedBlock
+func (s *MockBuilderService) SubmitBlindedBlock(context.Context, interfaces.SignedBeaconBlock) (interfaces.ExecutionData, error) {
+	if s.ErrSubmitBlindedBlock != nil {
+		return nil, s.ErrSubmitBlindedBlock
+	}
+	if s.PayloadCapella != nil {
+		return blocks.WrappedExecutionPayloadCapella(s.PayloadCapella)
+	}
+	return blocks.WrappedExecutionPayload(s.Payload)
 }
 
-// GetHeader for mocking.
-func (s *MockBuilderService) GetHeader(context.Context, types.Slot, [32]byte, [48]byte) (*ethpb.SignedBuilderBid, error) {
-	return s.Bid, s.ErrGetHeader
+// GetHeaders for mocking. It returns Bid or BidCapella, followed by ExtraBids.
+func (s *MockBuilderService) GetHeaders(context.Context, types.Slot, [32]byte, [48]byte) ([]builder.SignedBid, error) {
+	if s.ErrGetHeader != nil {
+		return nil, s.ErrGetHeader
+	}
+	bids := make([]builder.SignedBid, 0, len(s.ExtraBids)+1)
+	switch {
+	case s.BidCapella != nil:
+		b, err := builder.WrappedSignedBuilderBidCapella(s.BidCapella)
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, b)
+	case s.Bid != nil || len(s.ExtraBids) == 0:
+		b, err := builder.WrappedSignedBuilderBid(s.Bid)
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, b)
+	}
+	return append(bids, s.ExtraBids...), nil
 }
 
 // RegisterValidator for mocking.
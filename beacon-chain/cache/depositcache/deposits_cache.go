@@ -11,12 +11,12 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositsnapshot"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
-	"github.com/prysmaticlabs/prysm/v3/config/params"
-	"github.com/prysmaticlabs/prysm/v3/container/trie"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/sirupsen/logrus"
@@ -28,6 +28,10 @@ var (
 		Name: "beacondb_all_deposits",
 		Help: "The number of total deposits in the beaconDB in-memory database",
 	})
+	prunedDepositsCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacondb_pruned_deposits",
+		Help: "The number of finalized deposits pruned from the beaconDB in-memory database",
+	})
 )
 
 // DepositFetcher defines a struct which can retrieve deposit information from a store.
@@ -39,15 +43,18 @@ type DepositFetcher interface {
 	NonFinalizedDeposits(ctx context.Context, lastFinalizedIndex int64, untilBlk *big.Int) []*ethpb.Deposit
 }
 
-// FinalizedDeposits stores the trie of deposits that have been included
+// FinalizedDeposits stores the EIP-4881 deposit tree of deposits that have been included
 // in the beacon state up to the latest finalized checkpoint.
 type FinalizedDeposits struct {
-	Deposits        *trie.SparseMerkleTrie
+	Deposits        *depositsnapshot.DepositTree
 	MerkleTrieIndex int64
 }
 
 // DepositCache stores all in-memory deposit objects. This
 // stores all the deposit related data that is required by the beacon-node.
+// Deposits are removed from the cache once they are finalized, at which point
+// they are only represented by the finalized deposit tree and, without their
+// proofs, by the public key index.
 type DepositCache struct {
 	// Beacon chain deposits in memory.
 	pendingDeposits   []*ethpb.DepositContainer
@@ -59,18 +66,13 @@ type DepositCache struct {
 
 // New instantiates a new deposit cache
 func New() (*DepositCache, error) {
-	finalizedDepositsTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-	if err != nil {
-		return nil, err
-	}
-
 	// finalizedDeposits.MerkleTrieIndex is initialized to -1 because it represents the index of the last trie item.
 	// Inserting the first item into the trie will set the value of the index to 0.
 	return &DepositCache{
 		pendingDeposits:   []*ethpb.DepositContainer{},
 		deposits:          []*ethpb.DepositContainer{},
 		depositsByKey:     map[[fieldparams.BLSPubkeyLength]byte][]*ethpb.DepositContainer{},
-		finalizedDeposits: &FinalizedDeposits{Deposits: finalizedDepositsTrie, MerkleTrieIndex: -1},
+		finalizedDeposits: &FinalizedDeposits{Deposits: depositsnapshot.NewDepositTree(), MerkleTrieIndex: -1},
 	}, nil
 }
 
@@ -91,8 +93,8 @@ func (dc *DepositCache) InsertDeposit(ctx context.Context, d *ethpb.Deposit, blo
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	if int(index) != len(dc.deposits) {
-		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", len(dc.deposits), index)
+	if wanted := dc.nextDepositIndex(); index != wanted {
+		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", wanted, index)
 	}
 	// Keep the slice sorted on insertion in order to avoid costly sorting on retrieval.
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Index >= index })
@@ -128,30 +130,31 @@ func (dc *DepositCache) InsertDepositContainers(ctx context.Context, ctrs []*eth
 	historicalDepositsCount.Add(float64(len(ctrs)))
 }
 
-// InsertFinalizedDeposits inserts deposits up to eth1DepositIndex (inclusive) into the finalized deposits cache.
-func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1DepositIndex int64) {
+// InsertFinalizedDeposits inserts deposits up to eth1DepositIndex (inclusive) into the finalized deposits tree,
+// finalizes the tree at the given execution block and prunes the finalized deposits from the cache.
+func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1DepositIndex int64, executionHash common.Hash, executionNumber uint64) error {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.InsertFinalizedDeposits")
 	defer span.End()
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	depositTrie := dc.finalizedDeposits.Deposits
+	depositTrie := dc.finalizedDeposits.Deposits.Copy()
 	insertIndex := int(dc.finalizedDeposits.MerkleTrieIndex + 1)
 
 	// Don't insert into finalized trie if there is no deposit to
 	// insert.
 	if len(dc.deposits) == 0 {
-		return
+		return nil
 	}
 	// In the event we have less deposits than we need to
 	// finalize we finalize till the index on which we do have it.
-	if len(dc.deposits) <= int(eth1DepositIndex) {
-		eth1DepositIndex = int64(len(dc.deposits)) - 1
+	if lastIndex := dc.deposits[len(dc.deposits)-1].Index; lastIndex < eth1DepositIndex {
+		eth1DepositIndex = lastIndex
 	}
 	// If we finalize to some lower deposit index, we
 	// ignore it.
 	if int(eth1DepositIndex) < insertIndex {
-		return
+		return nil
 	}
 	for _, d := range dc.deposits {
 		if d.Index <= dc.finalizedDeposits.MerkleTrieIndex {
@@ -162,23 +165,97 @@ func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1Deposit
 		}
 		depHash, err := d.Deposit.Data.HashTreeRoot()
 		if err != nil {
-			log.WithError(err).Error("Could not hash deposit data. Finalized deposit cache not updated.")
-			return
+			return errors.Wrap(err, "could not hash deposit data")
 		}
 		if err = depositTrie.Insert(depHash[:], insertIndex); err != nil {
-			log.WithError(err).Error("Could not insert deposit hash")
-			return
+			return errors.Wrap(err, "could not insert deposit hash")
 		}
 		insertIndex++
 	}
+	if err := depositTrie.Finalize(eth1DepositIndex, executionHash, executionNumber); err != nil {
+		return errors.Wrap(err, "could not finalize deposit tree")
+	}
 
 	dc.finalizedDeposits = &FinalizedDeposits{
 		Deposits:        depositTrie,
 		MerkleTrieIndex: eth1DepositIndex,
 	}
+	dc.pruneFinalizedDeposits(eth1DepositIndex)
+	return nil
+}
+
+// InsertDepositSnapshot initializes the finalized deposits of the cache from an EIP-4881 deposit
+// snapshot. Deposits which are part of the snapshot are pruned from the cache.
+func (dc *DepositCache) InsertDepositSnapshot(ctx context.Context, snapshot *ethpb.DepositSnapshot) error {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.InsertDepositSnapshot")
+	defer span.End()
+
+	depositTrie, err := depositsnapshot.DepositTreeFromProto(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "could not create deposit tree from snapshot")
+	}
+
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+
+	lastFinalizedIndex := int64(depositTrie.NumOfItems()) - 1
+	if lastFinalizedIndex < dc.finalizedDeposits.MerkleTrieIndex {
+		return errors.Errorf("snapshot with %d deposits is older than the finalized deposits of the cache", depositTrie.NumOfItems())
+	}
+	dc.finalizedDeposits = &FinalizedDeposits{
+		Deposits:        depositTrie,
+		MerkleTrieIndex: lastFinalizedIndex,
+	}
+	dc.pruneFinalizedDeposits(lastFinalizedIndex)
+	return nil
 }
 
-// AllDepositContainers returns all historical deposit containers.
+// pruneFinalizedDeposits removes all deposits with an index lower or equal to
+// lastFinalizedIndex from the cache. Pruned deposits stay in the public key index
+// without their proof and deposit root, as validator statuses rely on the deposit
+// data and block height of deposits which are not yet active.
+// The caller must hold the deposits lock.
+func (dc *DepositCache) pruneFinalizedDeposits(lastFinalizedIndex int64) {
+	pruneIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Index > lastFinalizedIndex })
+	if pruneIdx == 0 {
+		return
+	}
+	for _, d := range dc.deposits[:pruneIdx] {
+		pubkey := bytesutil.ToBytes48(d.Deposit.Data.PublicKey)
+		ctrs := dc.depositsByKey[pubkey]
+		for i, c := range ctrs {
+			if c.Index == d.Index {
+				ctrs[i] = finalizedDepositContainer(c)
+			}
+		}
+	}
+	// Copy the remaining deposits so the pruned ones can be garbage collected.
+	deposits := make([]*ethpb.DepositContainer, len(dc.deposits)-pruneIdx)
+	copy(deposits, dc.deposits[pruneIdx:])
+	dc.deposits = deposits
+	prunedDepositsCount.Add(float64(pruneIdx))
+}
+
+// finalizedDepositContainer returns a copy of the deposit container without the data
+// which is only needed to include the deposit in a block.
+func finalizedDepositContainer(c *ethpb.DepositContainer) *ethpb.DepositContainer {
+	return &ethpb.DepositContainer{
+		Deposit:         &ethpb.Deposit{Data: c.Deposit.Data},
+		Eth1BlockHeight: c.Eth1BlockHeight,
+		Index:           c.Index,
+	}
+}
+
+// nextDepositIndex returns the index of the next deposit expected by the cache.
+// The caller must hold the deposits lock.
+func (dc *DepositCache) nextDepositIndex() int64 {
+	if len(dc.deposits) > 0 {
+		return dc.deposits[len(dc.deposits)-1].Index + 1
+	}
+	return dc.finalizedDeposits.MerkleTrieIndex + 1
+}
+
+// AllDepositContainers returns all historical deposit containers which have not been finalized yet.
 func (dc *DepositCache) AllDepositContainers(ctx context.Context) []*ethpb.DepositContainer {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.AllDepositContainers")
 	defer span.End()
@@ -202,8 +279,29 @@ func (dc *DepositCache) AllDepositContainers(ctx context.Context) []*ethpb.Depos
 	return deposits
 }
 
+// FinalizedDepositContainers returns the deposit containers, without proofs, of all deposits
+// which have been finalized and pruned from the cache, sorted by index.
+func (dc *DepositCache) FinalizedDepositContainers(ctx context.Context) []*ethpb.DepositContainer {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.FinalizedDepositContainers")
+	defer span.End()
+	dc.depositsLock.RLock()
+	defer dc.depositsLock.RUnlock()
+
+	var deposits []*ethpb.DepositContainer
+	for _, ctrs := range dc.depositsByKey {
+		for _, c := range ctrs {
+			if c.Index <= dc.finalizedDeposits.MerkleTrieIndex {
+				deposits = append(deposits, c)
+			}
+		}
+	}
+	sort.Slice(deposits, func(i, j int) bool { return deposits[i].Index < deposits[j].Index })
+	return deposits
+}
+
 // AllDeposits returns a list of historical deposits until the given block number
 // (inclusive). If no block is specified then this method returns all historical deposits.
+// Deposits which have been finalized and pruned from the cache are not returned.
 func (dc *DepositCache) AllDeposits(ctx context.Context, untilBlk *big.Int) []*ethpb.Deposit {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.AllDeposits")
 	defer span.End()
@@ -231,12 +329,22 @@ func (dc *DepositCache) DepositsNumberAndRootAtHeight(ctx context.Context, block
 	dc.depositsLock.RLock()
 	defer dc.depositsLock.RUnlock()
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Eth1BlockHeight > blockHeight.Uint64() })
-	// send the deposit root of the empty trie, if eth1follow distance is greater than the time of the earliest
-	// deposit.
 	if heightIdx == 0 {
+		// All deposits up to the height have been finalized and pruned from the cache,
+		// so the finalized deposit tree holds the requested number and root.
+		if dc.finalizedDeposits.MerkleTrieIndex >= 0 {
+			root, err := dc.finalizedDeposits.Deposits.HashTreeRoot()
+			if err != nil {
+				log.WithError(err).Error("Could not compute root of finalized deposits")
+				return 0, [32]byte{}
+			}
+			return uint64(dc.finalizedDeposits.MerkleTrieIndex + 1), root
+		}
+		// send the deposit root of the empty trie, if eth1follow distance is greater than the time of the earliest
+		// deposit.
 		return 0, [32]byte{}
 	}
-	return uint64(heightIdx), bytesutil.ToBytes32(dc.deposits[heightIdx-1].DepositRoot)
+	return uint64(dc.deposits[heightIdx-1].Index + 1), bytesutil.ToBytes32(dc.deposits[heightIdx-1].DepositRoot)
 }
 
 // DepositByPubkey looks through historical deposits and finds one which contains
@@ -261,7 +369,7 @@ func (dc *DepositCache) DepositByPubkey(ctx context.Context, pubKey []byte) (*et
 	return deposit, blockNum
 }
 
-// FinalizedDeposits returns the finalized deposits trie.
+// FinalizedDeposits returns a copy of the finalized deposits tree.
 func (dc *DepositCache) FinalizedDeposits(ctx context.Context) *FinalizedDeposits {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.FinalizedDeposits")
 	defer span.End()
@@ -303,11 +411,17 @@ func (dc *DepositCache) PruneProofs(ctx context.Context, untilDepositIndex int64
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	if untilDepositIndex >= int64(len(dc.deposits)) {
-		untilDepositIndex = int64(len(dc.deposits) - 1)
+	if len(dc.deposits) == 0 {
+		return nil
+	}
+	// Finalized deposits are pruned from the cache, so deposit indices are
+	// offset by the index of the first deposit in the cache.
+	firstIndex := dc.deposits[0].Index
+	if untilDepositIndex >= firstIndex+int64(len(dc.deposits)) {
+		untilDepositIndex = firstIndex + int64(len(dc.deposits)) - 1
 	}
 
-	for i := untilDepositIndex; i >= 0; i-- {
+	for i := untilDepositIndex - firstIndex; i >= 0; i-- {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
@@ -416,7 +416,7 @@ func TestFinalizedDeposits_DepositsCachedCorrectly(t *testing.T) {
 		Index: 3,
 	})
 
-	dc.InsertFinalizedDeposits(context.Background(), 2)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
 
 	cachedDeposits := dc.FinalizedDeposits(context.Background())
 	require.NotNil(t, cachedDeposits, "Deposits not cached")
@@ -474,9 +474,9 @@ func TestFinalizedDeposits_UtilizesPreviouslyCachedDeposits(t *testing.T) {
 		Index: 2,
 	}
 	dc.deposits = oldFinalizedDeposits
-	dc.InsertFinalizedDeposits(context.Background(), 1)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 1, [32]byte{}, 0))
 
-	dc.InsertFinalizedDeposits(context.Background(), 2)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
 
 	dc.deposits = append(dc.deposits, []*ethpb.DepositContainer{newFinalizedDeposit}...)
 
@@ -503,7 +503,7 @@ func TestFinalizedDeposits_HandleZeroDeposits(t *testing.T) {
 	dc, err := New()
 	require.NoError(t, err)
 
-	dc.InsertFinalizedDeposits(context.Background(), 2)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
 
 	cachedDeposits := dc.FinalizedDeposits(context.Background())
 	require.NotNil(t, cachedDeposits, "Deposits not cached")
@@ -548,7 +548,7 @@ func TestFinalizedDeposits_HandleSmallerThanExpectedDeposits(t *testing.T) {
 	}
 	dc.deposits = finalizedDeposits
 
-	dc.InsertFinalizedDeposits(context.Background(), 5)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 5, [32]byte{}, 0))
 
 	cachedDeposits := dc.FinalizedDeposits(context.Background())
 	require.NotNil(t, cachedDeposits, "Deposits not cached")
@@ -623,10 +623,10 @@ func TestFinalizedDeposits_HandleLowerEth1DepositIndex(t *testing.T) {
 	}
 	dc.deposits = finalizedDeposits
 
-	dc.InsertFinalizedDeposits(context.Background(), 5)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 5, [32]byte{}, 0))
 
 	// Reinsert finalized deposits with a lower index.
-	dc.InsertFinalizedDeposits(context.Background(), 2)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
 
 	cachedDeposits := dc.FinalizedDeposits(context.Background())
 	require.NotNil(t, cachedDeposits, "Deposits not cached")
@@ -694,7 +694,7 @@ func TestNonFinalizedDeposits_ReturnsAllNonFinalizedDeposits(t *testing.T) {
 			},
 			Index: 3,
 		})
-	dc.InsertFinalizedDeposits(context.Background(), 1)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 1, [32]byte{}, 0))
 
 	deps := dc.NonFinalizedDeposits(context.Background(), 1, nil)
 	assert.Equal(t, 2, len(deps))
@@ -751,7 +751,7 @@ func TestNonFinalizedDeposits_ReturnsNonFinalizedDepositsUpToBlockNumber(t *test
 			},
 			Index: 3,
 		})
-	dc.InsertFinalizedDeposits(context.Background(), 1)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 1, [32]byte{}, 0))
 
 	deps := dc.NonFinalizedDeposits(context.Background(), 1, big.NewInt(10))
 	assert.Equal(t, 1, len(deps))
@@ -799,10 +799,10 @@ func TestFinalizedDeposits_ReturnsTrieCorrectly(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Perform this in a non-sensical ordering
-	dc.InsertFinalizedDeposits(context.Background(), 10)
-	dc.InsertFinalizedDeposits(context.Background(), 2)
-	dc.InsertFinalizedDeposits(context.Background(), 3)
-	dc.InsertFinalizedDeposits(context.Background(), 4)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 10, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 3, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 4, [32]byte{}, 0))
 
 	// Mimick finalized deposit trie fetch.
 	fd := dc.FinalizedDeposits(context.Background())
@@ -817,9 +817,9 @@ func TestFinalizedDeposits_ReturnsTrieCorrectly(t *testing.T) {
 		}
 		insertIndex++
 	}
-	dc.InsertFinalizedDeposits(context.Background(), 15)
-	dc.InsertFinalizedDeposits(context.Background(), 15)
-	dc.InsertFinalizedDeposits(context.Background(), 14)
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 15, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 15, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 14, [32]byte{}, 0))
 
 	fd = dc.FinalizedDeposits(context.Background())
 	deps = dc.NonFinalizedDeposits(context.Background(), fd.MerkleTrieIndex, big.NewInt(30))
@@ -1049,6 +1049,138 @@ func TestDepositMap_WorksCorrectly(t *testing.T) {
 	assert.DeepEqual(t, nilDep, dep)
 }
 
+func TestFinalizedDeposits_PrunesFinalizedDeposits(t *testing.T) {
+	ctx := context.Background()
+	dc, err := New()
+	require.NoError(t, err)
+
+	depositTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	roots := make([][32]byte, 0)
+	for i := 0; i < 6; i++ {
+		// Validator 0 deposits twice, once finalized and once not.
+		pubkey := byte(i)
+		if i == 4 {
+			pubkey = 0
+		}
+		d := &ethpb.Deposit{Proof: makeDepositProof(), Data: &ethpb.Deposit_Data{
+			PublicKey:             bytesutil.PadTo([]byte{pubkey}, 48),
+			WithdrawalCredentials: make([]byte, 32),
+			Signature:             make([]byte, 96),
+		}}
+		depHash, err := d.Data.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, depositTrie.Insert(depHash[:], i))
+		root, err := depositTrie.HashTreeRoot()
+		require.NoError(t, err)
+		roots = append(roots, root)
+		require.NoError(t, dc.InsertDeposit(ctx, d, uint64(10+i), int64(i), root))
+	}
+
+	executionHash := [32]byte{'a'}
+	require.NoError(t, dc.InsertFinalizedDeposits(ctx, 2, executionHash, 12))
+
+	ctrs := dc.AllDepositContainers(ctx)
+	require.Equal(t, 3, len(ctrs))
+	assert.Equal(t, int64(3), ctrs[0].Index)
+	// Pruned deposits are still indexed by public key, without their proof.
+	dep, blk := dc.DepositByPubkey(ctx, bytesutil.PadTo([]byte{1}, 48))
+	require.NotNil(t, dep)
+	assert.Equal(t, uint64(11), blk.Uint64())
+	assert.DeepEqual(t, [][]byte(nil), dep.Proof)
+	dep, blk = dc.DepositByPubkey(ctx, bytesutil.PadTo([]byte{0}, 48))
+	require.NotNil(t, dep)
+	assert.Equal(t, uint64(10), blk.Uint64())
+	finalizedCtrs := dc.FinalizedDepositContainers(ctx)
+	require.Equal(t, 3, len(finalizedCtrs))
+	for i, c := range finalizedCtrs {
+		assert.Equal(t, int64(i), c.Index)
+	}
+
+	fd := dc.FinalizedDeposits(ctx)
+	assert.Equal(t, int64(2), fd.MerkleTrieIndex)
+	snapshot, err := fd.Deposits.GetSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), snapshot.DepositCount())
+	assert.Equal(t, roots[2], snapshot.DepositRoot())
+	assert.Equal(t, executionHash, snapshot.ExecutionBlockHash())
+	assert.Equal(t, uint64(12), snapshot.ExecutionBlockHeight())
+
+	// Heights of pruned deposits are served from the finalized tree.
+	n, root := dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(11))
+	assert.Equal(t, uint64(3), n)
+	assert.Equal(t, roots[2], root)
+	n, root = dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(14))
+	assert.Equal(t, uint64(5), n)
+	assert.Equal(t, roots[4], root)
+
+	// Proofs are pruned relative to the first deposit remaining in the cache.
+	require.NoError(t, dc.PruneProofs(ctx, 3))
+	assert.DeepEqual(t, [][]byte(nil), dc.deposits[0].Deposit.Proof)
+	assert.NotNil(t, dc.deposits[1].Deposit.Proof)
+
+	// New deposits keep being indexed after the pruned ones.
+	d := &ethpb.Deposit{Data: &ethpb.Deposit_Data{
+		PublicKey:             bytesutil.PadTo([]byte{6}, 48),
+		WithdrawalCredentials: make([]byte, 32),
+		Signature:             make([]byte, 96),
+	}}
+	assert.ErrorContains(t, "wanted deposit with index 6", dc.InsertDeposit(ctx, d, 16, 3, [32]byte{}))
+	require.NoError(t, dc.InsertDeposit(ctx, d, 16, 6, [32]byte{}))
+
+	require.NoError(t, dc.InsertFinalizedDeposits(ctx, 6, [32]byte{'b'}, 16))
+	assert.Equal(t, 0, len(dc.AllDepositContainers(ctx)))
+	assert.Equal(t, 7, len(dc.FinalizedDepositContainers(ctx)))
+	dep, blk = dc.DepositByPubkey(ctx, bytesutil.PadTo([]byte{6}, 48))
+	require.NotNil(t, dep)
+	assert.Equal(t, uint64(16), blk.Uint64())
+	n, _ = dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(20))
+	assert.Equal(t, uint64(7), n)
+	require.NoError(t, dc.InsertDeposit(ctx, d, 17, 7, [32]byte{}))
+}
+
+func TestInsertDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	source, err := New()
+	require.NoError(t, err)
+	ctrs := make([]*ethpb.DepositContainer, 0)
+	for i := 0; i < 5; i++ {
+		ctr := &ethpb.DepositContainer{
+			Index:           int64(i),
+			Eth1BlockHeight: uint64(10 + i),
+			Deposit: &ethpb.Deposit{Data: &ethpb.Deposit_Data{
+				PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				Signature:             make([]byte, 96),
+			}},
+		}
+		ctrs = append(ctrs, ctr)
+		require.NoError(t, source.InsertDeposit(ctx, ctr.Deposit, ctr.Eth1BlockHeight, ctr.Index, [32]byte{}))
+	}
+	require.NoError(t, source.InsertFinalizedDeposits(ctx, 2, [32]byte{'a'}, 12))
+	snapshot, err := source.FinalizedDeposits(ctx).Deposits.ToProto()
+	require.NoError(t, err)
+
+	dc, err := New()
+	require.NoError(t, err)
+	dc.InsertDepositContainers(ctx, ctrs[1:])
+	require.NoError(t, dc.InsertDepositSnapshot(ctx, snapshot))
+
+	fd := dc.FinalizedDeposits(ctx)
+	assert.Equal(t, int64(2), fd.MerkleTrieIndex)
+	wantRoot, err := source.FinalizedDeposits(ctx).Deposits.HashTreeRoot()
+	require.NoError(t, err)
+	gotRoot, err := fd.Deposits.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+	remaining := dc.AllDepositContainers(ctx)
+	require.Equal(t, 2, len(remaining))
+	assert.Equal(t, int64(3), remaining[0].Index)
+
+	snapshot.DepositRoot = make([]byte, 32)
+	require.ErrorContains(t, "could not create deposit tree from snapshot", dc.InsertDepositSnapshot(ctx, snapshot))
+}
+
 func makeDepositProof() [][]byte {
 	proof := make([][]byte, int(params.BeaconConfig().DepositContractTreeDepth)+1)
 	for i := range proof {
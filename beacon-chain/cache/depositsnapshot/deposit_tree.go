@@ -0,0 +1,207 @@
+// Package depositsnapshot implements the EIP-4881 standard for minimal sparse Merkle tree.
+// The format proposed by the EIP allows for the pruning of deposits that are no longer needed to participate fully in consensus.
+// Full EIP-4881 specification can be found here: https://eips.ethereum.org/EIPS/eip-4881
+package depositsnapshot
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+var (
+	// ErrInvalidIndex occurs when a deposit is inserted at an index other than the next one in the tree.
+	ErrInvalidIndex = errors.New("index should be equal to the number of deposits in the tree")
+	// ErrTooManyDeposits occurs when the number of deposits to finalize exceeds the number of deposits in the tree.
+	ErrTooManyDeposits = errors.New("number of deposits to finalize exceeds the number of deposits in the tree")
+	// ErrInvalidItemLength occurs when a leaf is not 32 bytes long.
+	ErrInvalidItemLength = errors.New("leaf should be 32 bytes long")
+)
+
+// executionBlock identifies the execution block at which the deposit tree was last finalized.
+type executionBlock struct {
+	Hash  [32]byte
+	Depth uint64
+}
+
+// DepositTree is the Merkle tree representation of deposits as defined by EIP-4881.
+// The tree is immutable: every insertion or finalization replaces the nodes along the
+// affected path, so copies of the tree can be handed out without deep copying it.
+type DepositTree struct {
+	tree                    MerkleTreeNode
+	depositCount            uint64 // number of deposits in the tree, reference implementation calls this mix_in_length.
+	finalizedExecutionBlock executionBlock
+}
+
+// NewDepositTree creates an empty deposit tree.
+func NewDepositTree() *DepositTree {
+	return &DepositTree{
+		tree:                    &ZeroNode{depth: DepositContractDepth},
+		depositCount:            0,
+		finalizedExecutionBlock: executionBlock{},
+	}
+}
+
+// DepositTreeFromSnapshot rebuilds the finalized part of a deposit tree from a snapshot.
+func DepositTreeFromSnapshot(snapshot DepositTreeSnapshot) (*DepositTree, error) {
+	root, err := snapshot.CalculateRoot()
+	if err != nil {
+		return nil, err
+	}
+	if root != snapshot.depositRoot {
+		return nil, ErrInvalidSnapshotRoot
+	}
+	tree, err := fromSnapshotParts(snapshot.finalized, snapshot.depositCount, DepositContractDepth)
+	if err != nil {
+		return nil, err
+	}
+	return &DepositTree{
+		tree:                    tree,
+		depositCount:            snapshot.depositCount,
+		finalizedExecutionBlock: snapshot.executionBlock,
+	}, nil
+}
+
+// DepositTreeFromProto rebuilds the finalized part of a deposit tree from the protobuf representation of a snapshot.
+func DepositTreeFromProto(snapshot *ethpb.DepositSnapshot) (*DepositTree, error) {
+	ds, err := SnapshotFromProto(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return DepositTreeFromSnapshot(ds)
+}
+
+// GetSnapshot returns a snapshot of the finalized part of the deposit tree.
+func (d *DepositTree) GetSnapshot() (DepositTreeSnapshot, error) {
+	var finalized [][32]byte
+	depositCount, finalized := d.tree.GetFinalized(finalized)
+	return fromTreeParts(finalized, depositCount, d.finalizedExecutionBlock)
+}
+
+// Finalize marks all deposits up to and including eth1DepositIndex as finalized, recording the
+// execution block at which they were finalized. Finalizing to an index which is already
+// finalized is a no-op.
+func (d *DepositTree) Finalize(eth1DepositIndex int64, executionHash common.Hash, executionNumber uint64) error {
+	if eth1DepositIndex < 0 {
+		return nil
+	}
+	depositsToFinalize := uint64(eth1DepositIndex) + 1
+	if depositsToFinalize > d.depositCount {
+		return ErrTooManyDeposits
+	}
+	if depositsToFinalize <= d.FinalizedCount() {
+		return nil
+	}
+	tree, err := d.tree.Finalize(depositsToFinalize, DepositContractDepth)
+	if err != nil {
+		return err
+	}
+	d.tree = tree
+	d.finalizedExecutionBlock = executionBlock{
+		Hash:  executionHash,
+		Depth: executionNumber,
+	}
+	return nil
+}
+
+// FinalizedCount returns the number of finalized deposits in the tree.
+func (d *DepositTree) FinalizedCount() uint64 {
+	count, _ := d.tree.GetFinalized(nil)
+	return count
+}
+
+// getProof returns the deposit at the given index along with its merkle branch, where
+// the last element of the branch is the deposit count mixed into the root.
+func (d *DepositTree) getProof(index uint64) ([32]byte, [][32]byte, error) {
+	if index >= d.depositCount {
+		return [32]byte{}, nil, errors.Errorf("merkle index out of range in tree, max range: %d, received: %d", d.depositCount, index)
+	}
+	if index < d.FinalizedCount() {
+		return [32]byte{}, nil, errors.Errorf("merkle index %d is finalized and can no longer be proven", index)
+	}
+	leaf, proof, err := generateProof(d.tree, index, DepositContractDepth)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	var enc [32]byte
+	binary.LittleEndian.PutUint64(enc[:], d.depositCount)
+	return leaf, append(proof, enc), nil
+}
+
+// getRoot returns the root of the deposit tree with the deposit count mixed in.
+func (d *DepositTree) getRoot() [32]byte {
+	return mixInLength(d.tree.GetRoot(), d.depositCount)
+}
+
+// pushLeaf adds a new leaf to the tree.
+func (d *DepositTree) pushLeaf(leaf [32]byte) error {
+	tree, err := d.tree.PushLeaf(leaf, DepositContractDepth)
+	if err != nil {
+		return err
+	}
+	d.tree = tree
+	d.depositCount++
+	return nil
+}
+
+// Insert adds a new leaf to the tree. Deposits can only be appended, so index
+// must be equal to the number of deposits already in the tree.
+func (d *DepositTree) Insert(item []byte, index int) error {
+	if len(item) != 32 {
+		return ErrInvalidItemLength
+	}
+	if index < 0 || uint64(index) != d.depositCount {
+		return errors.Wrapf(ErrInvalidIndex, "wanted %d but got %d", d.depositCount, index)
+	}
+	return d.pushLeaf(bytesutil.ToBytes32(item))
+}
+
+// HashTreeRoot returns the hash tree root of the deposit tree, which is the deposit
+// root as computed by the deposit contract.
+func (d *DepositTree) HashTreeRoot() ([32]byte, error) {
+	return d.getRoot(), nil
+}
+
+// NumOfItems returns the number of deposits in the tree, including finalized ones.
+func (d *DepositTree) NumOfItems() int {
+	return int(d.depositCount)
+}
+
+// MerkleProof returns the merkle proof of the deposit at the given index in the same
+// format as trie.SparseMerkleTrie, with the deposit count as the last element.
+func (d *DepositTree) MerkleProof(index int) ([][]byte, error) {
+	if index < 0 {
+		return nil, errors.Errorf("merkle index is negative: %d", index)
+	}
+	_, proof, err := d.getProof(uint64(index))
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]byte, len(proof))
+	for i := range proof {
+		result[i] = bytesutil.SafeCopyBytes(proof[i][:])
+	}
+	return result, nil
+}
+
+// Copy returns a copy of the deposit tree. As the nodes of the tree are never
+// modified in place, the copy shares them with the original tree.
+func (d *DepositTree) Copy() *DepositTree {
+	return &DepositTree{
+		tree:                    d.tree,
+		depositCount:            d.depositCount,
+		finalizedExecutionBlock: d.finalizedExecutionBlock,
+	}
+}
+
+// ToProto returns the protobuf representation of the snapshot of the deposit tree.
+func (d *DepositTree) ToProto() (*ethpb.DepositSnapshot, error) {
+	snapshot, err := d.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.ToProto(), nil
+}
@@ -0,0 +1,149 @@
+package depositsnapshot
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+var (
+	// ErrInvalidSnapshotRoot occurs when the snapshot root does not match the calculated root.
+	ErrInvalidSnapshotRoot = errors.New("snapshot root is invalid")
+)
+
+// DepositTreeSnapshot represents the data used to create a deposit tree given a snapshot.
+type DepositTreeSnapshot struct {
+	finalized      [][32]byte
+	depositRoot    [32]byte
+	depositCount   uint64
+	executionBlock executionBlock
+}
+
+// CalculateRoot returns the root of a deposit tree snapshot.
+func (ds *DepositTreeSnapshot) CalculateRoot() ([32]byte, error) {
+	size := ds.depositCount
+	index := len(ds.finalized)
+	root := trie.ZeroHashes[0]
+	for i := 0; i < DepositContractDepth; i++ {
+		if (size & 1) == 1 {
+			if index == 0 {
+				return [32]byte{}, errors.New("snapshot has fewer finalized hashes than its deposit count requires")
+			}
+			index--
+			root = hash.Hash(append(ds.finalized[index][:], root[:]...))
+		} else {
+			root = hash.Hash(append(root[:], trie.ZeroHashes[i][:]...))
+		}
+		size >>= 1
+	}
+	if index != 0 {
+		return [32]byte{}, errors.New("snapshot has more finalized hashes than its deposit count requires")
+	}
+	return mixInLength(root, ds.depositCount), nil
+}
+
+// DepositRoot returns the deposit root, with the deposit count mixed in, stored in the snapshot.
+func (ds *DepositTreeSnapshot) DepositRoot() [32]byte {
+	return ds.depositRoot
+}
+
+// DepositCount returns the number of deposits finalized in the snapshot.
+func (ds *DepositTreeSnapshot) DepositCount() uint64 {
+	return ds.depositCount
+}
+
+// ExecutionBlockHash returns the hash of the execution block at which the deposits were finalized.
+func (ds *DepositTreeSnapshot) ExecutionBlockHash() [32]byte {
+	return ds.executionBlock.Hash
+}
+
+// ExecutionBlockHeight returns the height of the execution block at which the deposits were finalized.
+func (ds *DepositTreeSnapshot) ExecutionBlockHeight() uint64 {
+	return ds.executionBlock.Depth
+}
+
+// Finalized returns the hashes of the finalized subtrees of the snapshot.
+func (ds *DepositTreeSnapshot) Finalized() [][32]byte {
+	finalized := make([][32]byte, len(ds.finalized))
+	copy(finalized, ds.finalized)
+	return finalized
+}
+
+// ToProto converts the snapshot to its protobuf representation.
+func (ds *DepositTreeSnapshot) ToProto() *ethpb.DepositSnapshot {
+	finalized := make([][]byte, len(ds.finalized))
+	for i := range ds.finalized {
+		finalized[i] = bytesutil.SafeCopyBytes(ds.finalized[i][:])
+	}
+	return &ethpb.DepositSnapshot{
+		Finalized:      finalized,
+		DepositRoot:    bytesutil.SafeCopyBytes(ds.depositRoot[:]),
+		DepositCount:   ds.depositCount,
+		ExecutionHash:  bytesutil.SafeCopyBytes(ds.executionBlock.Hash[:]),
+		ExecutionDepth: ds.executionBlock.Depth,
+	}
+}
+
+// SnapshotFromProto creates a deposit tree snapshot from its protobuf representation
+// and verifies that the provided deposit root matches the finalized hashes.
+func SnapshotFromProto(snapshot *ethpb.DepositSnapshot) (DepositTreeSnapshot, error) {
+	if snapshot == nil {
+		return DepositTreeSnapshot{}, errors.New("nil deposit snapshot")
+	}
+	finalized := make([][32]byte, len(snapshot.Finalized))
+	for i, f := range snapshot.Finalized {
+		if len(f) != 32 {
+			return DepositTreeSnapshot{}, errors.Errorf("finalized hash at index %d has length %d, wanted 32", i, len(f))
+		}
+		finalized[i] = bytesutil.ToBytes32(f)
+	}
+	if len(snapshot.DepositRoot) != 32 {
+		return DepositTreeSnapshot{}, errors.Errorf("deposit root has length %d, wanted 32", len(snapshot.DepositRoot))
+	}
+	if len(snapshot.ExecutionHash) != 32 {
+		return DepositTreeSnapshot{}, errors.Errorf("execution hash has length %d, wanted 32", len(snapshot.ExecutionHash))
+	}
+	ds := DepositTreeSnapshot{
+		finalized:    finalized,
+		depositRoot:  bytesutil.ToBytes32(snapshot.DepositRoot),
+		depositCount: snapshot.DepositCount,
+		executionBlock: executionBlock{
+			Hash:  bytesutil.ToBytes32(snapshot.ExecutionHash),
+			Depth: snapshot.ExecutionDepth,
+		},
+	}
+	root, err := ds.CalculateRoot()
+	if err != nil {
+		return DepositTreeSnapshot{}, err
+	}
+	if root != ds.depositRoot {
+		return DepositTreeSnapshot{}, ErrInvalidSnapshotRoot
+	}
+	return ds, nil
+}
+
+// fromTreeParts constructs the deposit tree from pre-existing data.
+func fromTreeParts(finalised [][32]byte, depositCount uint64, executionBlock executionBlock) (DepositTreeSnapshot, error) {
+	snapshot := DepositTreeSnapshot{
+		finalized:      finalised,
+		depositRoot:    trie.ZeroHashes[0],
+		depositCount:   depositCount,
+		executionBlock: executionBlock,
+	}
+	root, err := snapshot.CalculateRoot()
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.depositRoot = root
+	return snapshot, nil
+}
+
+func mixInLength(root [32]byte, length uint64) [32]byte {
+	var enc [32]byte
+	binary.LittleEndian.PutUint64(enc[:], length)
+	return hash.Hash(append(root[:], enc[:]...))
+}
@@ -0,0 +1,202 @@
+package depositsnapshot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func leaves(n int) [][]byte {
+	items := make([][]byte, n)
+	for i := range items {
+		h := hash.Hash([]byte(fmt.Sprintf("deposit %d", i)))
+		items[i] = h[:]
+	}
+	return items
+}
+
+func TestDepositTree_MatchesSparseMerkleTrie(t *testing.T) {
+	items := leaves(37)
+	dt := NewDepositTree()
+	smt, err := trie.NewTrie(DepositContractDepth)
+	require.NoError(t, err)
+
+	emptyRoot, err := smt.HashTreeRoot()
+	require.NoError(t, err)
+	root, err := dt.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, emptyRoot, root)
+
+	for i, item := range items {
+		require.NoError(t, dt.Insert(item, i))
+		require.NoError(t, smt.Insert(item, i))
+		want, err := smt.HashTreeRoot()
+		require.NoError(t, err)
+		got, err := dt.HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, want, got, "root mismatch after inserting deposit %d", i)
+	}
+	assert.Equal(t, smt.NumOfItems(), dt.NumOfItems())
+
+	root, err = dt.HashTreeRoot()
+	require.NoError(t, err)
+	for i := range items {
+		want, err := smt.MerkleProof(i)
+		require.NoError(t, err)
+		got, err := dt.MerkleProof(i)
+		require.NoError(t, err)
+		require.DeepEqual(t, want, got)
+		assert.Equal(t, true, trie.VerifyMerkleProof(root[:], items[i], uint64(i), got))
+	}
+}
+
+func TestDepositTree_Insert(t *testing.T) {
+	dt := NewDepositTree()
+	items := leaves(2)
+	require.NoError(t, dt.Insert(items[0], 0))
+	require.ErrorIs(t, dt.Insert(items[1], 0), ErrInvalidIndex)
+	require.ErrorIs(t, dt.Insert(items[1], 2), ErrInvalidIndex)
+	require.ErrorIs(t, dt.Insert([]byte{1, 2, 3}, 1), ErrInvalidItemLength)
+	require.NoError(t, dt.Insert(items[1], 1))
+	assert.Equal(t, 2, dt.NumOfItems())
+}
+
+func TestDepositTree_Finalize(t *testing.T) {
+	items := leaves(20)
+	dt := NewDepositTree()
+	for i, item := range items {
+		require.NoError(t, dt.Insert(item, i))
+	}
+	rootBefore, err := dt.HashTreeRoot()
+	require.NoError(t, err)
+	proofBefore, err := dt.MerkleProof(15)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, dt.Finalize(20, common.Hash{}, 0), ErrTooManyDeposits)
+
+	executionHash := common.HexToHash("0xabcd")
+	require.NoError(t, dt.Finalize(12, executionHash, 100))
+	assert.Equal(t, uint64(13), dt.FinalizedCount())
+
+	rootAfter, err := dt.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, rootBefore, rootAfter)
+
+	// Finalized deposits can no longer be proven, the remaining ones are unaffected.
+	_, err = dt.MerkleProof(12)
+	require.ErrorContains(t, "is finalized", err)
+	proofAfter, err := dt.MerkleProof(15)
+	require.NoError(t, err)
+	assert.DeepEqual(t, proofBefore, proofAfter)
+
+	// Finalizing to a lower index does not change the tree.
+	require.NoError(t, dt.Finalize(5, common.Hash{}, 50))
+	assert.Equal(t, uint64(13), dt.FinalizedCount())
+	snapshot, err := dt.GetSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, [32]byte(executionHash), snapshot.ExecutionBlockHash())
+	assert.Equal(t, uint64(100), snapshot.ExecutionBlockHeight())
+
+	// The tree still accepts new deposits after finalization.
+	smt, err := trie.GenerateTrieFromItems(items, DepositContractDepth)
+	require.NoError(t, err)
+	extra := leaves(25)[20:]
+	for i, item := range extra {
+		require.NoError(t, dt.Insert(item, 20+i))
+		require.NoError(t, smt.Insert(item, 20+i))
+	}
+	want, err := smt.HashTreeRoot()
+	require.NoError(t, err)
+	got, err := dt.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestDepositTree_Copy(t *testing.T) {
+	items := leaves(4)
+	dt := NewDepositTree()
+	for i, item := range items[:3] {
+		require.NoError(t, dt.Insert(item, i))
+	}
+	cp := dt.Copy()
+	require.NoError(t, cp.Insert(items[3], 3))
+	require.NoError(t, cp.Finalize(3, common.Hash{}, 0))
+
+	assert.Equal(t, 3, dt.NumOfItems())
+	assert.Equal(t, uint64(0), dt.FinalizedCount())
+	assert.Equal(t, 4, cp.NumOfItems())
+	assert.Equal(t, uint64(4), cp.FinalizedCount())
+}
+
+func TestDepositTree_SnapshotRoundTrip(t *testing.T) {
+	for _, count := range []int{0, 1, 2, 3, 7, 8, 31, 100} {
+		t.Run(fmt.Sprintf("%d deposits", count), func(t *testing.T) {
+			items := leaves(count + 5)
+			dt := NewDepositTree()
+			for i, item := range items {
+				require.NoError(t, dt.Insert(item, i))
+			}
+			require.NoError(t, dt.Finalize(int64(count)-1, common.HexToHash("0x01"), uint64(count)))
+
+			snapshot, err := dt.GetSnapshot()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(count), snapshot.DepositCount())
+			smt, err := trie.NewTrie(DepositContractDepth)
+			require.NoError(t, err)
+			for i, item := range items[:count] {
+				require.NoError(t, smt.Insert(item, i))
+			}
+			wantRoot, err := smt.HashTreeRoot()
+			require.NoError(t, err)
+			assert.Equal(t, wantRoot, snapshot.DepositRoot())
+
+			restored, err := DepositTreeFromProto(snapshot.ToProto())
+			require.NoError(t, err)
+			assert.Equal(t, count, restored.NumOfItems())
+			for i, item := range items[count:] {
+				require.NoError(t, restored.Insert(item, count+i))
+			}
+			want, err := dt.HashTreeRoot()
+			require.NoError(t, err)
+			got, err := restored.HashTreeRoot()
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+			for i := count; i < len(items); i++ {
+				wantProof, err := dt.MerkleProof(i)
+				require.NoError(t, err)
+				gotProof, err := restored.MerkleProof(i)
+				require.NoError(t, err)
+				assert.DeepEqual(t, wantProof, gotProof)
+			}
+		})
+	}
+}
+
+func TestSnapshotFromProto_InvalidRoot(t *testing.T) {
+	items := leaves(5)
+	dt := NewDepositTree()
+	for i, item := range items {
+		require.NoError(t, dt.Insert(item, i))
+	}
+	require.NoError(t, dt.Finalize(4, common.Hash{}, 0))
+	pb, err := dt.ToProto()
+	require.NoError(t, err)
+
+	pb.DepositRoot = make([]byte, 32)
+	_, err = SnapshotFromProto(pb)
+	require.ErrorIs(t, err, ErrInvalidSnapshotRoot)
+
+	pb, err = dt.ToProto()
+	require.NoError(t, err)
+	pb.Finalized = pb.Finalized[1:]
+	_, err = SnapshotFromProto(pb)
+	require.ErrorContains(t, "fewer finalized hashes", err)
+
+	_, err = SnapshotFromProto(nil)
+	require.ErrorContains(t, "nil deposit snapshot", err)
+}
@@ -1,19 +1,280 @@
 package depositsnapshot
 
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v3/math"
+)
+
 const (
 	DepositContractDepth = 32 // Maximum tree depth as defined by EIP-4881.
 )
 
-// MerkleTreeNode is the interface for a Merkle tree.
+var (
+	// ErrFinalizedNodeCannotPushLeaf may occur when attempting to push a leaf to a finalized node. When a node is finalized, it cannot be modified or changed.
+	ErrFinalizedNodeCannotPushLeaf = errors.New("can't push a leaf to a finalized node")
+	// ErrLeafNodeCannotPushLeaf may occur when attempting to push a leaf to a leaf node.
+	ErrLeafNodeCannotPushLeaf = errors.New("can't push a leaf to a leaf node")
+	// ErrZeroLevel occurs when the value of level is 0.
+	ErrZeroLevel = errors.New("level should be greater than 0")
+	// ErrZeroDepth occurs when the value of depth is 0.
+	ErrZeroDepth = errors.New("depth should be greater than 0")
+)
+
+// MerkleTreeNode is the interface for a Merkle tree. Nodes are never modified
+// in place, every mutating method returns the node which replaces the receiver.
 type MerkleTreeNode interface {
 	// GetRoot returns the root of the Merkle tree.
 	GetRoot() [32]byte
 	// IsFull returns whether there is space left for deposits.
 	IsFull() bool
 	// Finalize marks deposits of the Merkle tree as finalized.
-	Finalize(deposits uint, depth uint) MerkleTreeNode
-	// GetFinalized returns a list of hashes of all the finalized nodes and the number of deposits.
-	GetFinalized(result [][32]byte) ([][32]byte, uint)
+	Finalize(depositsToFinalize uint64, depth uint64) (MerkleTreeNode, error)
+	// GetFinalized returns the number of deposits and a list of hashes of all the finalized nodes.
+	GetFinalized(result [][32]byte) (uint64, [][32]byte)
 	// PushLeaf adds a new leaf node at the next available Zero node.
-	PushLeaf(leaf [32]byte, deposits uint, depth uint) MerkleTreeNode
+	PushLeaf(leaf [32]byte, depth uint64) (MerkleTreeNode, error)
+}
+
+// create builds a new merkle tree of the given depth containing the given leaves.
+func create(leaves [][32]byte, depth uint64) MerkleTreeNode {
+	length := uint64(len(leaves))
+	if length == 0 {
+		return &ZeroNode{depth: depth}
+	}
+	if depth == 0 {
+		return &LeafNode{hash: leaves[0]}
+	}
+	split := math.Min(uint64(1)<<(depth-1), length)
+	left := create(leaves[:split], depth-1)
+	right := create(leaves[split:], depth-1)
+	return newInnerNode(left, right)
+}
+
+// fromSnapshotParts rebuilds the finalized part of a merkle tree from the finalized hashes of a snapshot.
+func fromSnapshotParts(finalized [][32]byte, deposits uint64, level uint64) (MerkleTreeNode, error) {
+	if len(finalized) < 1 || deposits == 0 {
+		return &ZeroNode{depth: level}, nil
+	}
+	if deposits == uint64(1)<<level {
+		return &FinalizedNode{depositCount: deposits, hash: finalized[0]}, nil
+	}
+	if level == 0 {
+		return &ZeroNode{}, ErrZeroLevel
+	}
+	leftSubtree := uint64(1) << (level - 1)
+	if deposits <= leftSubtree {
+		left, err := fromSnapshotParts(finalized, deposits, level-1)
+		if err != nil {
+			return nil, err
+		}
+		return newInnerNode(left, &ZeroNode{depth: level - 1}), nil
+	}
+	left := &FinalizedNode{depositCount: leftSubtree, hash: finalized[0]}
+	right, err := fromSnapshotParts(finalized[1:], deposits-leftSubtree, level-1)
+	if err != nil {
+		return nil, err
+	}
+	return newInnerNode(left, right), nil
+}
+
+// generateProof returns the leaf at the given index along with its merkle branch, ordered from the leaf upwards.
+func generateProof(tree MerkleTreeNode, index uint64, depth uint64) ([32]byte, [][32]byte, error) {
+	var proof [][32]byte
+	node := tree
+	for depth > 0 {
+		innerNode, ok := node.(*InnerNode)
+		if !ok {
+			return [32]byte{}, nil, errors.New("could not generate proof, the requested leaf was finalized or does not exist")
+		}
+		ithBit := (index >> (depth - 1)) & 0x1
+		if ithBit == 1 {
+			proof = append(proof, innerNode.left.GetRoot())
+			node = innerNode.right
+		} else {
+			proof = append(proof, innerNode.right.GetRoot())
+			node = innerNode.left
+		}
+		depth--
+	}
+	for i, j := 0, len(proof)-1; i < j; i, j = i+1, j-1 {
+		proof[i], proof[j] = proof[j], proof[i]
+	}
+	return node.GetRoot(), proof, nil
+}
+
+// FinalizedNode represents a finalized node and satisfies the MerkleTreeNode interface.
+type FinalizedNode struct {
+	depositCount uint64
+	hash         [32]byte
+}
+
+// GetRoot returns the root of the Merkle tree.
+func (f *FinalizedNode) GetRoot() [32]byte {
+	return f.hash
+}
+
+// IsFull returns whether there is space left for deposits.
+// A FinalizedNode will always return true as by definition it
+// is full and deposits can't be added to it.
+func (_ *FinalizedNode) IsFull() bool {
+	return true
+}
+
+// Finalize marks deposits of the Merkle tree as finalized.
+func (f *FinalizedNode) Finalize(_, _ uint64) (MerkleTreeNode, error) {
+	return f, nil
+}
+
+// GetFinalized returns a list of hashes of all the finalized nodes and the number of deposits.
+func (f *FinalizedNode) GetFinalized(result [][32]byte) (uint64, [][32]byte) {
+	return f.depositCount, append(result, f.hash)
+}
+
+// PushLeaf adds a new leaf node at the next available zero node.
+func (*FinalizedNode) PushLeaf(_ [32]byte, _ uint64) (MerkleTreeNode, error) {
+	return nil, ErrFinalizedNodeCannotPushLeaf
+}
+
+// LeafNode represents a leaf node holding a deposit and satisfies the MerkleTreeNode interface.
+type LeafNode struct {
+	hash [32]byte
+}
+
+// GetRoot returns the root of the Merkle tree.
+func (l *LeafNode) GetRoot() [32]byte {
+	return l.hash
+}
+
+// IsFull returns whether there is space left for deposits.
+// A LeafNode will always return true as it is the last node
+// in the tree and therefore can't have any deposits added to it.
+func (_ *LeafNode) IsFull() bool {
+	return true
+}
+
+// Finalize marks deposits of the Merkle tree as finalized.
+func (l *LeafNode) Finalize(_, _ uint64) (MerkleTreeNode, error) {
+	return &FinalizedNode{depositCount: 1, hash: l.hash}, nil
+}
+
+// GetFinalized returns a list of hashes of all the finalized nodes and the number of deposits.
+func (_ *LeafNode) GetFinalized(result [][32]byte) (uint64, [][32]byte) {
+	return 0, result
+}
+
+// PushLeaf adds a new leaf node at the next available zero node.
+func (*LeafNode) PushLeaf(_ [32]byte, _ uint64) (MerkleTreeNode, error) {
+	return nil, ErrLeafNodeCannotPushLeaf
+}
+
+// InnerNode represents an inner node with two children and satisfies the MerkleTreeNode interface.
+type InnerNode struct {
+	left, right MerkleTreeNode
+	root        [32]byte
+}
+
+// newInnerNode creates an inner node from its children. As nodes are immutable,
+// the root is computed once here instead of on every call to GetRoot.
+func newInnerNode(left, right MerkleTreeNode) *InnerNode {
+	leftRoot := left.GetRoot()
+	rightRoot := right.GetRoot()
+	return &InnerNode{
+		left:  left,
+		right: right,
+		root:  hash.Hash(append(leftRoot[:], rightRoot[:]...)),
+	}
+}
+
+// GetRoot returns the root of the Merkle tree.
+func (n *InnerNode) GetRoot() [32]byte {
+	return n.root
+}
+
+// IsFull returns whether there is space left for deposits.
+func (n *InnerNode) IsFull() bool {
+	return n.right.IsFull()
+}
+
+// Finalize marks deposits of the Merkle tree as finalized.
+func (n *InnerNode) Finalize(depositsToFinalize uint64, depth uint64) (MerkleTreeNode, error) {
+	if depth == 0 {
+		return nil, ErrZeroDepth
+	}
+	deposits := uint64(1) << depth
+	if deposits <= depositsToFinalize {
+		return &FinalizedNode{depositCount: deposits, hash: n.root}, nil
+	}
+	left, err := n.left.Finalize(depositsToFinalize, depth-1)
+	if err != nil {
+		return nil, err
+	}
+	right := n.right
+	if depositsToFinalize > deposits/2 {
+		remaining := depositsToFinalize - deposits/2
+		right, err = n.right.Finalize(remaining, depth-1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newInnerNode(left, right), nil
+}
+
+// GetFinalized returns a list of hashes of all the finalized nodes and the number of deposits.
+func (n *InnerNode) GetFinalized(result [][32]byte) (uint64, [][32]byte) {
+	leftDeposits, result := n.left.GetFinalized(result)
+	rightDeposits, result := n.right.GetFinalized(result)
+	return leftDeposits + rightDeposits, result
+}
+
+// PushLeaf adds a new leaf node at the next available zero node.
+func (n *InnerNode) PushLeaf(leaf [32]byte, depth uint64) (MerkleTreeNode, error) {
+	if depth == 0 {
+		return nil, ErrZeroDepth
+	}
+	if !n.left.IsFull() {
+		left, err := n.left.PushLeaf(leaf, depth-1)
+		if err != nil {
+			return nil, err
+		}
+		return newInnerNode(left, n.right), nil
+	}
+	right, err := n.right.PushLeaf(leaf, depth-1)
+	if err != nil {
+		return nil, err
+	}
+	return newInnerNode(n.left, right), nil
+}
+
+// ZeroNode represents an empty subtree of the given depth and satisfies the MerkleTreeNode interface.
+type ZeroNode struct {
+	depth uint64
+}
+
+// GetRoot returns the root of the Merkle tree.
+func (z *ZeroNode) GetRoot() [32]byte {
+	return trie.ZeroHashes[z.depth]
+}
+
+// IsFull returns whether there is space left for deposits.
+// A ZeroNode will always return false as a ZeroNode is an empty node
+// that gets replaced by a deposit.
+func (_ *ZeroNode) IsFull() bool {
+	return false
+}
+
+// Finalize marks deposits of the Merkle tree as finalized.
+func (z *ZeroNode) Finalize(_, _ uint64) (MerkleTreeNode, error) {
+	return z, nil
+}
+
+// GetFinalized returns a list of hashes of all the finalized nodes and the number of deposits.
+func (_ *ZeroNode) GetFinalized(result [][32]byte) (uint64, [][32]byte) {
+	return 0, result
+}
+
+// PushLeaf adds a new leaf node at the next available zero node.
+func (_ *ZeroNode) PushLeaf(leaf [32]byte, depth uint64) (MerkleTreeNode, error) {
+	return create([][32]byte{leaf}, depth), nil
 }
@@ -237,6 +237,31 @@ func TestAttestationsDelta(t *testing.T) {
 	require.DeepEqual(t, want, penalties)
 }
 
+func TestAttestationDeltas(t *testing.T) {
+	s, err := testState()
+	require.NoError(t, err)
+	validators, balance, err := InitializePrecomputeValidators(context.Background(), s)
+	require.NoError(t, err)
+	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
+	require.NoError(t, err)
+	deltas, err := AttestationDeltas(s, balance, validators)
+	require.NoError(t, err)
+	require.Equal(t, len(validators), len(deltas))
+
+	// The breakdown adds up to the totals reported by AttestationsDelta.
+	rewards, penalties, err := AttestationsDelta(s, balance, validators)
+	require.NoError(t, err)
+	for i, d := range deltas {
+		require.Equal(t, rewards[i], d.HeadReward+d.SourceReward+d.TargetReward)
+		require.Equal(t, penalties[i], d.SourcePenalty+d.TargetPenalty+d.InactivityPenalty)
+	}
+
+	// Validator 0 missed every flag, validator 3 hit every flag.
+	require.DeepEqual(t, &AttDelta{SourcePenalty: 1252195, TargetPenalty: 2325505}, deltas[0])
+	require.Equal(t, uint64(0), deltas[3].SourcePenalty+deltas[3].TargetPenalty+deltas[3].InactivityPenalty)
+	require.NotEqual(t, uint64(0), deltas[3].HeadReward)
+}
+
 func TestAttestationsDeltaBellatrix(t *testing.T) {
 	s, err := testStateBellatrix()
 	require.NoError(t, err)
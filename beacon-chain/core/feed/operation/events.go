@@ -2,6 +2,7 @@
 package operation
 
 import (
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 )
 
@@ -22,6 +23,10 @@ const (
 
 	// BLSToExecutionChangeReceived is sent after a BLS to execution change object has been received from gossip or rpc.
 	BLSToExecutionChangeReceived
+
+	// BlockGossipReceived is sent after a block has been received from gossip and passed gossip validation,
+	// before it is imported.
+	BlockGossipReceived
 )
 
 // UnAggregatedAttReceivedData is the data sent with UnaggregatedAttReceived events.
@@ -52,3 +57,9 @@ type SyncCommitteeContributionReceivedData struct {
 type BLSToExecutionChangeReceivedData struct {
 	Change *ethpb.SignedBLSToExecutionChange
 }
+
+// BlockGossipReceivedData is the data sent with BlockGossipReceived events.
+type BlockGossipReceivedData struct {
+	// SignedBlock is the block received from gossip.
+	SignedBlock interfaces.SignedBeaconBlock
+}
@@ -26,6 +26,9 @@ const (
 	FinalizedCheckpoint
 	// NewHead of the chain event.
 	NewHead
+	// PayloadAttributes is sent when the node sends payload attributes to the execution engine
+	// along with a forkchoice update, in order to build a payload for the next proposal.
+	PayloadAttributes
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
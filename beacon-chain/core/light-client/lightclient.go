@@ -0,0 +1,446 @@
+// Package lightclient implements the light client server helpers defined in the altair light client
+// specification: building bootstrap objects and updates from beacon states and deciding which update
+// of a sync committee period is the best one to serve.
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+const (
+	// FinalityBranchLength is the depth of the finalized checkpoint root in the beacon state tree.
+	FinalityBranchLength = 6
+	// SyncCommitteeBranchLength is the depth of the sync committee fields in the beacon state tree.
+	SyncCommitteeBranchLength = 5
+)
+
+// ErrInsufficientParticipation is returned when a block's sync aggregate has fewer participants
+// than MIN_SYNC_COMMITTEE_PARTICIPANTS, so no light client update can be created from it.
+var ErrInsufficientParticipation = errors.New("insufficient sync committee participation")
+
+// SyncCommitteePeriodAtSlot returns the sync committee period of the given slot.
+func SyncCommitteePeriodAtSlot(slot types.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
+
+// NewLightClientBootstrapFromBeaconState creates a light client bootstrap from the post state of the given block.
+//
+// Spec code:
+// def create_light_client_bootstrap(state: BeaconState,
+//
+//	                                block: SignedBeaconBlock) -> LightClientBootstrap:
+//	assert compute_epoch_at_slot(state.slot) >= ALTAIR_FORK_EPOCH
+//	assert state.slot == state.latest_block_header.slot
+//	header = state.latest_block_header.copy()
+//	header.state_root = hash_tree_root(state)
+//	assert hash_tree_root(header) == hash_tree_root(block.message)
+//
+//	return LightClientBootstrap(
+//	    header=block_to_light_client_header(block),
+//	    current_sync_committee=state.current_sync_committee,
+//	    current_sync_committee_branch=compute_merkle_proof_for_state(state, CURRENT_SYNC_COMMITTEE_INDEX),
+//	)
+func NewLightClientBootstrapFromBeaconState(
+	ctx context.Context,
+	st state.BeaconState,
+	block interfaces.SignedBeaconBlock,
+) (*ethpb.LightClientBootstrap, error) {
+	if st.Version() == version.Phase0 {
+		return nil, errors.New("light client bootstrap is not supported before altair")
+	}
+	header, err := headerFromState(ctx, st)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBlockHeader(header, block); err != nil {
+		return nil, err
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	branch, err := st.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee proof")
+	}
+	return &ethpb.LightClientBootstrap{
+		Header:                     &ethpb.LightClientHeader{Beacon: header},
+		CurrentSyncCommittee:       committee,
+		CurrentSyncCommitteeBranch: branch,
+	}, nil
+}
+
+// NewLightClientUpdateFromBeaconState creates a light client update from the post state of a block,
+// the block itself, the post state of its parent (the attested state) and the block of the attested
+// state's finalized checkpoint. The finalized block may be nil when it is not available, in which case
+// the update carries no finality information.
+//
+// Spec code:
+// def create_light_client_update(state: BeaconState,
+//
+//	                             block: SignedBeaconBlock,
+//	                             attested_state: BeaconState,
+//	                             finalized_block: Optional[SignedBeaconBlock]) -> LightClientUpdate:
+//	assert compute_epoch_at_slot(attested_state.slot) >= ALTAIR_FORK_EPOCH
+//	assert sum(block.message.body.sync_aggregate.sync_committee_bits) >= MIN_SYNC_COMMITTEE_PARTICIPANTS
+//
+//	assert state.slot == state.latest_block_header.slot
+//	header = state.latest_block_header.copy()
+//	header.state_root = hash_tree_root(state)
+//	assert hash_tree_root(header) == hash_tree_root(block.message)
+//	update_signature_period = compute_sync_committee_period_at_slot(block.message.slot)
+//
+//	assert attested_state.slot == attested_state.latest_block_header.slot
+//	attested_header = attested_state.latest_block_header.copy()
+//	attested_header.state_root = hash_tree_root(attested_state)
+//	assert hash_tree_root(attested_header) == block.message.parent_root
+//	update_attested_period = compute_sync_committee_period_at_slot(attested_header.slot)
+//
+//	# `next_sync_committee` is only useful if the message is signed by the current sync committee
+//	if update_attested_period == update_signature_period:
+//	    next_sync_committee = attested_state.next_sync_committee
+//	    next_sync_committee_branch = compute_merkle_proof_for_state(attested_state, NEXT_SYNC_COMMITTEE_INDEX)
+//	else:
+//	    next_sync_committee = SyncCommittee()
+//	    next_sync_committee_branch = [Bytes32() for _ in range(floorlog2(NEXT_SYNC_COMMITTEE_INDEX))]
+//
+//	# Indicate finality whenever possible
+//	if finalized_block is not None:
+//	    if finalized_block.message.slot != GENESIS_SLOT:
+//	        finalized_header = block_to_light_client_header(finalized_block)
+//	        assert hash_tree_root(finalized_header.beacon) == attested_state.finalized_checkpoint.root
+//	    else:
+//	        assert attested_state.finalized_checkpoint.root == Bytes32()
+//	        finalized_header = LightClientHeader()
+//	    finality_branch = compute_merkle_proof_for_state(attested_state, FINALIZED_ROOT_INDEX)
+//	else:
+//	    finalized_header = LightClientHeader()
+//	    finality_branch = [Bytes32() for _ in range(floorlog2(FINALIZED_ROOT_INDEX))]
+//
+//	return LightClientUpdate(
+//	    attested_header=attested_header,
+//	    next_sync_committee=next_sync_committee,
+//	    next_sync_committee_branch=next_sync_committee_branch,
+//	    finalized_header=finalized_header,
+//	    finality_branch=finality_branch,
+//	    sync_aggregate=block.message.body.sync_aggregate,
+//	    signature_slot=block.message.slot,
+//	)
+func NewLightClientUpdateFromBeaconState(
+	ctx context.Context,
+	st state.BeaconState,
+	block interfaces.SignedBeaconBlock,
+	attestedState state.BeaconState,
+	finalizedBlock interfaces.SignedBeaconBlock,
+) (*ethpb.LightClientUpdate, error) {
+	if attestedState.Version() == version.Phase0 {
+		return nil, errors.New("light client updates are not supported before altair")
+	}
+	syncAggregate, err := block.Block().Body().SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate")
+	}
+	if syncAggregate.SyncCommitteeBits.Count() < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return nil, errors.Wrapf(ErrInsufficientParticipation, "%d participants is below the minimum of %d",
+			syncAggregate.SyncCommitteeBits.Count(), params.BeaconConfig().MinSyncCommitteeParticipants)
+	}
+
+	header, err := headerFromState(ctx, st)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBlockHeader(header, block); err != nil {
+		return nil, err
+	}
+	signaturePeriod := SyncCommitteePeriodAtSlot(block.Block().Slot())
+
+	attestedHeader, err := headerFromState(ctx, attestedState)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attested header")
+	}
+	attestedRoot, err := attestedHeader.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attested header root")
+	}
+	if parentRoot := block.Block().ParentRoot(); attestedRoot != parentRoot {
+		return nil, fmt.Errorf("attested header root %#x does not match block parent root %#x", attestedRoot, parentRoot)
+	}
+	attestedPeriod := SyncCommitteePeriodAtSlot(attestedHeader.Slot)
+
+	update := &ethpb.LightClientUpdate{
+		AttestedHeader: &ethpb.LightClientHeader{Beacon: attestedHeader},
+		SyncAggregate:  syncAggregate,
+		SignatureSlot:  block.Block().Slot(),
+	}
+
+	if attestedPeriod == signaturePeriod {
+		committee, err := attestedState.NextSyncCommittee()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get next sync committee")
+		}
+		branch, err := attestedState.NextSyncCommitteeProof(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get next sync committee proof")
+		}
+		update.NextSyncCommittee = committee
+		update.NextSyncCommitteeBranch = branch
+	} else {
+		update.NextSyncCommittee = emptySyncCommittee()
+		update.NextSyncCommitteeBranch = emptyBranch(SyncCommitteeBranchLength)
+	}
+
+	if finalizedBlock != nil && !finalizedBlock.IsNil() {
+		finalizedRoot := attestedState.FinalizedCheckpoint().Root
+		if finalizedBlock.Block().Slot() != params.BeaconConfig().GenesisSlot {
+			signed, err := finalizedBlock.Header()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get finalized header")
+			}
+			root, err := signed.Header.HashTreeRoot()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not compute finalized header root")
+			}
+			if !bytes.Equal(root[:], finalizedRoot) {
+				return nil, fmt.Errorf("finalized header root %#x does not match finalized checkpoint root %#x", root, finalizedRoot)
+			}
+			update.FinalizedHeader = &ethpb.LightClientHeader{Beacon: signed.Header}
+		} else {
+			if !bytes.Equal(finalizedRoot, params.BeaconConfig().ZeroHash[:]) {
+				return nil, fmt.Errorf("finalized checkpoint root %#x of a genesis finalized block is not zero", finalizedRoot)
+			}
+			update.FinalizedHeader = emptyHeader()
+		}
+		branch, err := attestedState.FinalizedRootProof(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get finalized root proof")
+		}
+		update.FinalityBranch = branch
+	} else {
+		update.FinalizedHeader = emptyHeader()
+		update.FinalityBranch = emptyBranch(FinalityBranchLength)
+	}
+	return update, nil
+}
+
+// NewLightClientFinalityUpdateFromUpdate returns the finality update carried by the given update.
+func NewLightClientFinalityUpdateFromUpdate(update *ethpb.LightClientUpdate) *ethpb.LightClientFinalityUpdate {
+	return &ethpb.LightClientFinalityUpdate{
+		AttestedHeader:  update.AttestedHeader,
+		FinalizedHeader: update.FinalizedHeader,
+		FinalityBranch:  update.FinalityBranch,
+		SyncAggregate:   update.SyncAggregate,
+		SignatureSlot:   update.SignatureSlot,
+	}
+}
+
+// NewLightClientOptimisticUpdateFromUpdate returns the optimistic update carried by the given update.
+func NewLightClientOptimisticUpdateFromUpdate(update *ethpb.LightClientUpdate) *ethpb.LightClientOptimisticUpdate {
+	return &ethpb.LightClientOptimisticUpdate{
+		AttestedHeader: update.AttestedHeader,
+		SyncAggregate:  update.SyncAggregate,
+		SignatureSlot:  update.SignatureSlot,
+	}
+}
+
+// IsSyncCommitteeUpdate returns true if the update carries a next sync committee.
+func IsSyncCommitteeUpdate(update *ethpb.LightClientUpdate) bool {
+	return !isEmptyBranch(update.NextSyncCommitteeBranch)
+}
+
+// IsFinalityUpdate returns true if the update carries a finalized header.
+func IsFinalityUpdate(update *ethpb.LightClientUpdate) bool {
+	return !isEmptyBranch(update.FinalityBranch)
+}
+
+// HasSupermajority returns true if at least two thirds of the sync committee participated in the aggregate.
+func HasSupermajority(aggregate *ethpb.SyncAggregate) bool {
+	return aggregate.SyncCommitteeBits.Count()*3 >= aggregate.SyncCommitteeBits.Len()*2
+}
+
+// IsBetterUpdate returns true if newUpdate should replace oldUpdate as the best update of a sync committee period.
+//
+// Spec code:
+// def is_better_update(new_update: LightClientUpdate, old_update: LightClientUpdate) -> bool:
+//
+//	# Compare supermajority (> 2/3) sync committee participation
+//	max_active_participants = len(new_update.sync_aggregate.sync_committee_bits)
+//	new_num_active_participants = sum(new_update.sync_aggregate.sync_committee_bits)
+//	old_num_active_participants = sum(old_update.sync_aggregate.sync_committee_bits)
+//	new_has_supermajority = new_num_active_participants * 3 >= max_active_participants * 2
+//	old_has_supermajority = old_num_active_participants * 3 >= max_active_participants * 2
+//	if new_has_supermajority != old_has_supermajority:
+//	    return new_has_supermajority > old_has_supermajority
+//	if not new_has_supermajority and new_num_active_participants != old_num_active_participants:
+//	    return new_num_active_participants > old_num_active_participants
+//
+//	# Compare presence of relevant sync committee
+//	new_has_relevant_sync_committee = is_sync_committee_update(new_update) and (
+//	    compute_sync_committee_period_at_slot(new_update.attested_header.beacon.slot)
+//	    == compute_sync_committee_period_at_slot(new_update.signature_slot)
+//	)
+//	old_has_relevant_sync_committee = is_sync_committee_update(old_update) and (
+//	    compute_sync_committee_period_at_slot(old_update.attested_header.beacon.slot)
+//	    == compute_sync_committee_period_at_slot(old_update.signature_slot)
+//	)
+//	if new_has_relevant_sync_committee != old_has_relevant_sync_committee:
+//	    return new_has_relevant_sync_committee
+//
+//	# Compare indication of any finality
+//	new_has_finality = is_finality_update(new_update)
+//	old_has_finality = is_finality_update(old_update)
+//	if new_has_finality != old_has_finality:
+//	    return new_has_finality
+//
+//	# Compare sync committee finality
+//	if new_has_finality:
+//	    new_has_sync_committee_finality = (
+//	        compute_sync_committee_period_at_slot(new_update.finalized_header.beacon.slot)
+//	        == compute_sync_committee_period_at_slot(new_update.attested_header.beacon.slot)
+//	    )
+//	    old_has_sync_committee_finality = (
+//	        compute_sync_committee_period_at_slot(old_update.finalized_header.beacon.slot)
+//	        == compute_sync_committee_period_at_slot(old_update.attested_header.beacon.slot)
+//	    )
+//	    if new_has_sync_committee_finality != old_has_sync_committee_finality:
+//	        return new_has_sync_committee_finality
+//
+//	# Tiebreaker 1: Sync committee participation beyond supermajority
+//	if new_num_active_participants != old_num_active_participants:
+//	    return new_num_active_participants > old_num_active_participants
+//
+//	# Tiebreaker 2: Prefer older data (fewer changes to best)
+//	if new_update.attested_header.beacon.slot != old_update.attested_header.beacon.slot:
+//	    return new_update.attested_header.beacon.slot < old_update.attested_header.beacon.slot
+//	return new_update.signature_slot < old_update.signature_slot
+func IsBetterUpdate(newUpdate, oldUpdate *ethpb.LightClientUpdate) bool {
+	newParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	oldParticipants := oldUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	newHasSupermajority := HasSupermajority(newUpdate.SyncAggregate)
+	oldHasSupermajority := HasSupermajority(oldUpdate.SyncAggregate)
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newParticipants != oldParticipants {
+		return newParticipants > oldParticipants
+	}
+
+	newHasRelevantSyncCommittee := IsSyncCommitteeUpdate(newUpdate) &&
+		SyncCommitteePeriodAtSlot(newUpdate.AttestedHeader.Beacon.Slot) == SyncCommitteePeriodAtSlot(newUpdate.SignatureSlot)
+	oldHasRelevantSyncCommittee := IsSyncCommitteeUpdate(oldUpdate) &&
+		SyncCommitteePeriodAtSlot(oldUpdate.AttestedHeader.Beacon.Slot) == SyncCommitteePeriodAtSlot(oldUpdate.SignatureSlot)
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee
+	}
+
+	newHasFinality := IsFinalityUpdate(newUpdate)
+	oldHasFinality := IsFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	if newHasFinality {
+		newHasSyncCommitteeFinality := SyncCommitteePeriodAtSlot(newUpdate.FinalizedHeader.Beacon.Slot) ==
+			SyncCommitteePeriodAtSlot(newUpdate.AttestedHeader.Beacon.Slot)
+		oldHasSyncCommitteeFinality := SyncCommitteePeriodAtSlot(oldUpdate.FinalizedHeader.Beacon.Slot) ==
+			SyncCommitteePeriodAtSlot(oldUpdate.AttestedHeader.Beacon.Slot)
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	if newParticipants != oldParticipants {
+		return newParticipants > oldParticipants
+	}
+	if newUpdate.AttestedHeader.Beacon.Slot != oldUpdate.AttestedHeader.Beacon.Slot {
+		return newUpdate.AttestedHeader.Beacon.Slot < oldUpdate.AttestedHeader.Beacon.Slot
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
+
+// headerFromState returns the latest block header of the state with its state root filled in.
+func headerFromState(ctx context.Context, st state.BeaconState) (*ethpb.BeaconBlockHeader, error) {
+	latest := st.LatestBlockHeader()
+	if latest == nil {
+		return nil, errors.New("nil latest block header")
+	}
+	if st.Slot() != latest.Slot {
+		return nil, fmt.Errorf("state slot %d is not equal to latest block header slot %d", st.Slot(), latest.Slot)
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute state root")
+	}
+	return &ethpb.BeaconBlockHeader{
+		Slot:          latest.Slot,
+		ProposerIndex: latest.ProposerIndex,
+		ParentRoot:    latest.ParentRoot,
+		StateRoot:     stateRoot[:],
+		BodyRoot:      latest.BodyRoot,
+	}, nil
+}
+
+// verifyBlockHeader checks that the header derived from a post state matches the block.
+func verifyBlockHeader(header *ethpb.BeaconBlockHeader, block interfaces.SignedBeaconBlock) error {
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute header root")
+	}
+	blockRoot, err := block.Block().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute block root")
+	}
+	if headerRoot != blockRoot {
+		return fmt.Errorf("state header root %#x does not match block root %#x", headerRoot, blockRoot)
+	}
+	return nil
+}
+
+func emptyHeader() *ethpb.LightClientHeader {
+	return &ethpb.LightClientHeader{
+		Beacon: &ethpb.BeaconBlockHeader{
+			ParentRoot: make([]byte, fieldparams.RootLength),
+			StateRoot:  make([]byte, fieldparams.RootLength),
+			BodyRoot:   make([]byte, fieldparams.RootLength),
+		},
+	}
+}
+
+func emptySyncCommittee() *ethpb.SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpb.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+	}
+}
+
+func emptyBranch(length int) [][]byte {
+	branch := make([][]byte, length)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
+
+func isEmptyBranch(branch [][]byte) bool {
+	for _, b := range branch {
+		if !bytes.Equal(b, params.BeaconConfig().ZeroHash[:]) {
+			return false
+		}
+	}
+	return true
+}
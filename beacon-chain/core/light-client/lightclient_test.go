@@ -0,0 +1,204 @@
+package lightclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+// testChain returns the genesis state, a block on top of it with a full sync aggregate and the post state of that block.
+func testChain(t *testing.T) (state.BeaconState, interfaces.SignedBeaconBlock, state.BeaconState) {
+	ctx := context.Background()
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	c, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(c))
+	require.NoError(t, genesis.SetNextSyncCommittee(c))
+	conf := util.DefaultBlockGenConfig()
+	conf.FullSyncAggregate = true
+	b, err := util.GenerateFullBlockAltair(genesis, keys, conf, 1)
+	require.NoError(t, err)
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	post, err := transition.ExecuteStateTransition(ctx, genesis.Copy(), blk)
+	require.NoError(t, err)
+	return genesis, blk, post
+}
+
+func TestNewLightClientUpdateFromBeaconState(t *testing.T) {
+	ctx := context.Background()
+	attested, blk, st := testChain(t)
+
+	update, err := NewLightClientUpdateFromBeaconState(ctx, st, blk, attested, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, blk.Block().Slot(), update.SignatureSlot)
+	assert.Equal(t, types.Slot(0), update.AttestedHeader.Beacon.Slot)
+	root, err := update.AttestedHeader.Beacon.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, blk.Block().ParentRoot(), root)
+	committee, err := attested.NextSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepEqual(t, committee, update.NextSyncCommittee)
+	assert.Equal(t, true, IsSyncCommitteeUpdate(update))
+	assert.Equal(t, false, IsFinalityUpdate(update))
+	assert.Equal(t, FinalityBranchLength, len(update.FinalityBranch))
+
+	// The update must be serializable as a p2p message.
+	_, err = update.MarshalSSZ()
+	require.NoError(t, err)
+}
+
+func TestNewLightClientUpdateFromBeaconState_GenesisFinalizedBlock(t *testing.T) {
+	ctx := context.Background()
+	attested, blk, st := testChain(t)
+	finalized, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockAltair())
+	require.NoError(t, err)
+
+	update, err := NewLightClientUpdateFromBeaconState(ctx, st, blk, attested, finalized)
+	require.NoError(t, err)
+	assert.Equal(t, true, IsFinalityUpdate(update))
+	assert.Equal(t, types.Slot(0), update.FinalizedHeader.Beacon.Slot)
+	assert.DeepEqual(t, make([]byte, 32), update.FinalizedHeader.Beacon.BodyRoot)
+}
+
+func TestNewLightClientUpdateFromBeaconState_WrongAttestedState(t *testing.T) {
+	ctx := context.Background()
+	_, blk, st := testChain(t)
+
+	_, err := NewLightClientUpdateFromBeaconState(ctx, st, blk, st, nil)
+	require.ErrorContains(t, "does not match block parent root", err)
+}
+
+func TestNewLightClientUpdateFromBeaconState_NotEnoughParticipants(t *testing.T) {
+	ctx := context.Background()
+	attested, blk, st := testChain(t)
+	b, err := blk.PbAltairBlock()
+	require.NoError(t, err)
+	b.Block.Body.SyncAggregate.SyncCommitteeBits = bitfield.NewBitvector512()
+	blk, err = blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+
+	_, err = NewLightClientUpdateFromBeaconState(ctx, st, blk, attested, nil)
+	require.ErrorIs(t, err, ErrInsufficientParticipation)
+}
+
+func TestNewLightClientBootstrapFromBeaconState(t *testing.T) {
+	ctx := context.Background()
+	_, blk, st := testChain(t)
+
+	bootstrap, err := NewLightClientBootstrapFromBeaconState(ctx, st, blk)
+	require.NoError(t, err)
+	root, err := bootstrap.Header.Beacon.HashTreeRoot()
+	require.NoError(t, err)
+	blockRoot, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, blockRoot, root)
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepEqual(t, committee, bootstrap.CurrentSyncCommittee)
+	assert.Equal(t, SyncCommitteeBranchLength, len(bootstrap.CurrentSyncCommitteeBranch))
+}
+
+func TestIsBetterUpdate(t *testing.T) {
+	periodSlots := types.Slot(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * params.BeaconConfig().SlotsPerEpoch
+	update := func(participants uint64, attestedSlot, signatureSlot, finalizedSlot types.Slot, committee, finality bool) *ethpb.LightClientUpdate {
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		u := &ethpb.LightClientUpdate{
+			AttestedHeader:          &ethpb.LightClientHeader{Beacon: &ethpb.BeaconBlockHeader{Slot: attestedSlot}},
+			FinalizedHeader:         &ethpb.LightClientHeader{Beacon: &ethpb.BeaconBlockHeader{Slot: finalizedSlot}},
+			NextSyncCommitteeBranch: emptyBranch(SyncCommitteeBranchLength),
+			FinalityBranch:          emptyBranch(FinalityBranchLength),
+			SyncAggregate:           &ethpb.SyncAggregate{SyncCommitteeBits: bits},
+			SignatureSlot:           signatureSlot,
+		}
+		if committee {
+			u.NextSyncCommitteeBranch[0] = []byte{1}
+		}
+		if finality {
+			u.FinalityBranch[0] = []byte{1}
+		}
+		return u
+	}
+
+	tests := []struct {
+		name     string
+		new, old *ethpb.LightClientUpdate
+		want     bool
+	}{
+		{
+			name: "supermajority wins",
+			new:  update(400, 10, 11, 0, false, false),
+			old:  update(300, 10, 11, 0, true, true),
+			want: true,
+		},
+		{
+			name: "more participants without supermajority",
+			new:  update(200, 10, 11, 0, false, false),
+			old:  update(100, 10, 11, 0, true, true),
+			want: true,
+		},
+		{
+			name: "relevant sync committee",
+			new:  update(400, 10, 11, 0, true, false),
+			old:  update(500, 10, 11, 0, false, true),
+			want: true,
+		},
+		{
+			name: "sync committee from another period is not relevant",
+			new:  update(400, periodSlots-1, periodSlots, 0, true, false),
+			old:  update(400, 10, 11, 0, false, false),
+			want: false,
+		},
+		{
+			name: "finality",
+			new:  update(400, 10, 11, 0, true, true),
+			old:  update(500, 10, 11, 0, true, false),
+			want: true,
+		},
+		{
+			name: "sync committee finality",
+			new:  update(400, periodSlots+10, periodSlots+11, periodSlots, true, true),
+			old:  update(500, periodSlots+10, periodSlots+11, 0, true, true),
+			want: true,
+		},
+		{
+			name: "more participants",
+			new:  update(500, 10, 11, 0, true, true),
+			old:  update(400, 10, 11, 0, true, true),
+			want: true,
+		},
+		{
+			name: "older attested header",
+			new:  update(400, 10, 12, 0, true, true),
+			old:  update(400, 11, 12, 0, true, true),
+			want: true,
+		},
+		{
+			name: "older signature slot",
+			new:  update(400, 10, 12, 0, true, true),
+			old:  update(400, 10, 11, 0, true, true),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsBetterUpdate(tt.new, tt.old))
+		})
+	}
+}
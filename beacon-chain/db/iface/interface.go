@@ -31,12 +31,14 @@ type ReadOnlyDatabase interface {
 	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (interfaces.SignedBeaconBlock, error)
 	HighestRootsBelowSlot(ctx context.Context, slot types.Slot) (types.Slot, [][32]byte, error)
+	ExecutionPayload(ctx context.Context, slot types.Slot, blockRoot [32]byte) (interfaces.ExecutionData, error)
 	// State related methods.
 	State(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 	GenesisState(ctx context.Context) (state.BeaconState, error)
 	HasState(ctx context.Context, blockRoot [32]byte) bool
 	StateSummary(ctx context.Context, blockRoot [32]byte) (*ethpb.StateSummary, error)
+	ArchivedState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 	HasStateSummary(ctx context.Context, blockRoot [32]byte) bool
 	HighestSlotStatesBelow(ctx context.Context, slot types.Slot) ([]state.ReadOnlyBeaconState, error)
 	// Checkpoint operations.
@@ -57,6 +59,9 @@ type ReadOnlyDatabase interface {
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
+	// Light client operations.
+	LightClientUpdate(ctx context.Context, period uint64) (*ethpb.LightClientUpdate, error)
+	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) ([]*ethpb.LightClientUpdate, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -73,6 +78,7 @@ type NoHeadAccessDatabase interface {
 	SaveStates(ctx context.Context, states []state.ReadOnlyBeaconState, blockRoots [][32]byte) error
 	DeleteState(ctx context.Context, blockRoot [32]byte) error
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
+	SaveArchivedState(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot [32]byte, snapshot bool) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
 	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
 	// Checkpoint operations.
@@ -88,6 +94,8 @@ type NoHeadAccessDatabase interface {
 	// Fee recipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []types.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []types.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Light client operations.
+	SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpb.LightClientUpdate) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint types.Slot) error
 }
@@ -141,6 +149,7 @@ type SlasherDatabase interface {
 	LoadSlasherChunks(
 		ctx context.Context, kind slashertypes.ChunkKind, diskKeys [][]byte,
 	) ([][]uint16, []bool, error)
+	FlushSlasherChunks(ctx context.Context) error
 	CheckDoubleBlockProposals(
 		ctx context.Context, proposals []*slashertypes.SignedBlockHeaderWrapper,
 	) ([]*ethpb.ProposerSlashing, error)
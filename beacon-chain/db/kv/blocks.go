@@ -237,6 +237,7 @@ func (s *Store) DeleteBlock(ctx context.Context, root [32]byte) error {
 		return err
 	}
 
+	slot, hasSlot := s.blockSlot(ctx, root)
 	if err := s.deleteStateSummary(root); err != nil {
 		return err
 	}
@@ -253,11 +254,32 @@ func (s *Store) DeleteBlock(ctx context.Context, root [32]byte) error {
 		if err := tx.Bucket(blockParentRootIndicesBucket).Delete(root[:]); err != nil {
 			return err
 		}
+		if hasSlot {
+			if err := tx.Bucket(executionPayloadsBucket).Delete(executionPayloadKey(slot, root)); err != nil {
+				return err
+			}
+		}
 		s.blockCache.Del(string(root[:]))
 		return nil
 	})
 }
 
+// blockSlot returns the slot of the block with the given root, which locates its execution payload in
+// the local payload store. The slot is read from the state summary of the block if there is one, or else
+// from the block itself. Blocks which cannot be decoded are reported as having no slot, so that they can
+// still be deleted.
+func (s *Store) blockSlot(ctx context.Context, root [32]byte) (types.Slot, bool) {
+	summary, err := s.StateSummary(ctx, root)
+	if err == nil && summary != nil {
+		return summary.Slot, true
+	}
+	blk, err := s.Block(ctx, root)
+	if err != nil || blocks.BeaconBlockIsNil(blk) != nil {
+		return 0, false
+	}
+	return blk.Block().Slot(), true
+}
+
 // SaveBlock to the db.
 func (s *Store) SaveBlock(ctx context.Context, signed interfaces.SignedBeaconBlock) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBlock")
@@ -282,6 +304,7 @@ func (s *Store) SaveBlocks(ctx context.Context, blks []interfaces.SignedBeaconBl
 	blockRoots := make([][]byte, len(blks))
 	encodedBlocks := make([][]byte, len(blks))
 	indicesForBlocks := make([]map[string][]byte, len(blks))
+	encodedPayloads := make([][]byte, len(blks))
 	for i, blk := range blks {
 		blockRoot, err := blk.Block().HashTreeRoot()
 		if err != nil {
@@ -295,6 +318,12 @@ func (s *Store) SaveBlocks(ctx context.Context, blks []interfaces.SignedBeaconBl
 		encodedBlocks[i] = enc
 		indicesByBucket := createBlockIndicesFromBlock(ctx, blk.Block())
 		indicesForBlocks[i] = indicesByBucket
+		if localPayloadStoreEnabled() {
+			encodedPayloads[i], err = encodeExecutionPayload(blk)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
@@ -319,6 +348,11 @@ func (s *Store) SaveBlocks(ctx context.Context, blks []interfaces.SignedBeaconBl
 			if err := bkt.Put(blockRoots[i], encodedBlocks[i]); err != nil {
 				return err
 			}
+			if encodedPayloads[i] != nil {
+				if err := saveExecutionPayload(tx, blk.Block().Slot(), blockRoots[i], encodedPayloads[i]); err != nil {
+					return errors.Wrap(err, "could not save execution payload")
+				}
+			}
 		}
 		return nil
 	})
@@ -0,0 +1,126 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// The local payload store keeps the full execution payloads of the blocks saved in the last
+// --local-payload-store-epochs epochs, when only blinded blocks are stored. Payloads are keyed by
+// slot and block root, so that the oldest ones can be pruned with a cursor scan.
+
+// ExecutionPayload retrieves the full execution payload of the block with the given slot and root
+// from the local payload store. It returns nil if the payload is not in the store.
+func (s *Store) ExecutionPayload(ctx context.Context, slot types.Slot, blockRoot [32]byte) (interfaces.ExecutionData, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ExecutionPayload")
+	defer span.End()
+	var enc []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc = bytesutil.SafeCopyBytes(tx.Bucket(executionPayloadsBucket).Get(executionPayloadKey(slot, blockRoot)))
+		return nil
+	})
+	if err != nil || enc == nil {
+		return nil, err
+	}
+	return unmarshalExecutionPayload(enc)
+}
+
+// localPayloadStoreEnabled tells whether the full execution payloads of saved blocks should be kept
+// in the local payload store.
+func localPayloadStoreEnabled() bool {
+	return features.Get().EnableOnlyBlindedBeaconBlocks && features.Get().LocalPayloadStoreEpochs > 0
+}
+
+// encodeExecutionPayload returns the local payload store encoding of the execution payload of a full
+// block, or nil if the block has no execution payload worth storing.
+func encodeExecutionPayload(blk interfaces.SignedBeaconBlock) ([]byte, error) {
+	if blk.Version() < version.Bellatrix || blk.IsBlinded() {
+		return nil, nil
+	}
+	payload, err := blk.Block().Body().Execution()
+	if err != nil {
+		return nil, err
+	}
+	// Pre-merge payloads are empty and reconstructed without querying the execution client.
+	if payload.IsNil() || bytes.Equal(payload.BlockHash(), params.BeaconConfig().ZeroHash[:]) {
+		return nil, nil
+	}
+	enc, err := payload.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal execution payload")
+	}
+	switch blk.Version() {
+	case version.Capella:
+		return snappy.Encode(nil, append(capellaKey, enc...)), nil
+	case version.Bellatrix:
+		return snappy.Encode(nil, append(bellatrixKey, enc...)), nil
+	default:
+		return nil, errors.New("unknown execution payload version")
+	}
+}
+
+func unmarshalExecutionPayload(enc []byte) (interfaces.ExecutionData, error) {
+	enc, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(enc, capellaKey):
+		payload := &enginev1.ExecutionPayloadCapella{}
+		if err := payload.UnmarshalSSZ(enc[len(capellaKey):]); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal Capella execution payload")
+		}
+		return blocks.WrappedExecutionPayloadCapella(payload)
+	case bytes.HasPrefix(enc, bellatrixKey):
+		payload := &enginev1.ExecutionPayload{}
+		if err := payload.UnmarshalSSZ(enc[len(bellatrixKey):]); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal Bellatrix execution payload")
+		}
+		return blocks.WrappedExecutionPayload(payload)
+	default:
+		return nil, errors.New("unknown execution payload version")
+	}
+}
+
+// saveExecutionPayload saves an encoded execution payload to the local payload store, and prunes the payloads
+// of the blocks older than --local-payload-store-epochs before it.
+func saveExecutionPayload(tx *bolt.Tx, slot types.Slot, blockRoot []byte, enc []byte) error {
+	bkt := tx.Bucket(executionPayloadsBucket)
+	if err := bkt.Put(executionPayloadKey(slot, bytesutil.ToBytes32(blockRoot)), enc); err != nil {
+		return err
+	}
+
+	retention := types.Slot(features.Get().LocalPayloadStoreEpochs).Mul(uint64(params.BeaconConfig().SlotsPerEpoch))
+	if slot <= retention {
+		return nil
+	}
+	cutoff := bytesutil.SlotToBytesBigEndian(slot - retention)
+	var prunedKeys [][]byte
+	c := bkt.Cursor()
+	for k, _ := c.First(); k != nil && bytes.Compare(k[:len(cutoff)], cutoff) < 0; k, _ = c.Next() {
+		prunedKeys = append(prunedKeys, bytesutil.SafeCopyBytes(k))
+	}
+	for _, k := range prunedKeys {
+		if err := bkt.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func executionPayloadKey(slot types.Slot, blockRoot [32]byte) []byte {
+	return append(bytesutil.SlotToBytesBigEndian(slot), blockRoot[:]...)
+}
@@ -0,0 +1,148 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+func executionPayloadTestBlocks(t *testing.T, slots ...types.Slot) []interfaces.SignedBeaconBlock {
+	blks := make([]interfaces.SignedBeaconBlock, len(slots))
+	for i, slot := range slots {
+		var err error
+		if i%2 == 0 {
+			b := util.NewBeaconBlockBellatrix()
+			b.Block.Slot = slot
+			b.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo(bytesutil.Bytes8(uint64(slot)), fieldparams.RootLength)
+			b.Block.Body.ExecutionPayload.Transactions = [][]byte{{0x01, 0x02}}
+			blks[i], err = blocks.NewSignedBeaconBlock(b)
+		} else {
+			b := util.NewBeaconBlockCapella()
+			b.Block.Slot = slot
+			b.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo(bytesutil.Bytes8(uint64(slot)), fieldparams.RootLength)
+			b.Block.Body.ExecutionPayload.Transactions = [][]byte{{0x03}}
+			blks[i], err = blocks.NewSignedBeaconBlock(b)
+		}
+		require.NoError(t, err)
+	}
+	return blks
+}
+
+func TestStore_ExecutionPayloads(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableOnlyBlindedBeaconBlocks: true,
+		LocalPayloadStoreEpochs:       1,
+	})
+	defer resetCfg()
+	ctx := context.Background()
+	db := setupDB(t)
+
+	blks := executionPayloadTestBlocks(t, 1, 2)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	for _, blk := range blks {
+		root, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		saved, err := db.Block(ctx, root)
+		require.NoError(t, err)
+		assert.Equal(t, true, saved.IsBlinded())
+
+		want, err := blk.Block().Body().Execution()
+		require.NoError(t, err)
+		got, err := db.ExecutionPayload(ctx, blk.Block().Slot(), root)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.DeepEqual(t, want.Proto(), got.Proto())
+	}
+
+	// Payloads are pruned once their block is older than the retention period.
+	later := executionPayloadTestBlocks(t, params.BeaconConfig().SlotsPerEpoch+2)
+	require.NoError(t, db.SaveBlock(ctx, later[0]))
+	root, err := blks[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	got, err := db.ExecutionPayload(ctx, blks[0].Block().Slot(), root)
+	require.NoError(t, err)
+	assert.Equal(t, nil, got)
+	root, err = blks[1].Block().HashTreeRoot()
+	require.NoError(t, err)
+	got, err = db.ExecutionPayload(ctx, blks[1].Block().Slot(), root)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+
+	// Payloads are deleted along with their block.
+	require.NoError(t, db.DeleteBlock(ctx, root))
+	got, err = db.ExecutionPayload(ctx, blks[1].Block().Slot(), root)
+	require.NoError(t, err)
+	assert.Equal(t, nil, got)
+}
+
+func TestStore_DeleteBlock_Undecodable(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableOnlyBlindedBeaconBlocks: true,
+		LocalPayloadStoreEpochs:       1,
+	})
+	defer resetCfg()
+	ctx := context.Background()
+	db := setupDB(t)
+
+	blks := executionPayloadTestBlocks(t, 1, 2)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, blk := range blks {
+		var err error
+		roots[i], err = blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: blks[0].Block().Slot(), Root: roots[0][:]}))
+	// Corrupt the stored blocks, so that they can no longer be decoded.
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		for _, root := range roots {
+			if err := tx.Bucket(blocksBucket).Put(root[:], []byte("corrupt")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	for _, root := range roots {
+		db.blockCache.Del(string(root[:]))
+	}
+
+	// The payload is found through the slot of the state summary.
+	require.NoError(t, db.DeleteBlock(ctx, roots[0]))
+	assert.Equal(t, false, db.HasBlock(ctx, roots[0]))
+	got, err := db.ExecutionPayload(ctx, blks[0].Block().Slot(), roots[0])
+	require.NoError(t, err)
+	assert.Equal(t, nil, got)
+
+	// Without a state summary, the block is deleted even though its payload cannot be located.
+	require.NoError(t, db.DeleteBlock(ctx, roots[1]))
+	assert.Equal(t, false, db.HasBlock(ctx, roots[1]))
+}
+
+func TestStore_ExecutionPayloads_Disabled(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableOnlyBlindedBeaconBlocks: true,
+	})
+	defer resetCfg()
+	ctx := context.Background()
+	db := setupDB(t)
+
+	blks := executionPayloadTestBlocks(t, 1)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	root, err := blks[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	got, err := db.ExecutionPayload(ctx, 1, root)
+	require.NoError(t, err)
+	assert.Equal(t, nil, got)
+}
@@ -78,6 +78,7 @@ var blockedBuckets = [][]byte{
 	blockParentRootIndicesBucket,
 	blockSlotIndicesBucket,
 	finalizedBlockRootsIndexBucket,
+	executionPayloadsBucket,
 }
 
 // Store defines an implementation of the Prysm Database interface
@@ -89,6 +90,8 @@ type Store struct {
 	validatorEntryCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
 	ctx                 context.Context
+
+	stateArchiveBase archivedStateBase
 }
 
 // KVStoreDatafilePath is the canonical construction of a full
@@ -128,6 +131,11 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+	lightClientUpdatesBucket,
+	executionPayloadsBucket,
+	stateArchiveSnapshotsBucket,
+	stateArchiveDiffsBucket,
+	stateArchiveRootsBucket,
 }
 
 // NewKVStore initializes a new boltDB key-value store at the directory
@@ -0,0 +1,71 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveLightClientUpdate saves the best light client update of a sync committee period,
+// replacing any update previously stored for that period.
+func (s *Store) SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpb.LightClientUpdate) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientUpdate")
+	defer span.End()
+
+	enc, err := encode(ctx, update)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(lightClientUpdatesBucket)
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(period), enc)
+	})
+}
+
+// LightClientUpdate retrieves the best light client update of a sync committee period.
+// A nil update is returned if there is no update for the period.
+func (s *Store) LightClientUpdate(ctx context.Context, period uint64) (*ethpb.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdate")
+	defer span.End()
+
+	var update *ethpb.LightClientUpdate
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(lightClientUpdatesBucket).Get(bytesutil.Uint64ToBytesBigEndian(period))
+		if enc == nil {
+			return nil
+		}
+		update = &ethpb.LightClientUpdate{}
+		return decode(ctx, enc, update)
+	})
+	return update, err
+}
+
+// LightClientUpdates retrieves the best light client updates of the sync committee periods in the
+// inclusive range [startPeriod, endPeriod], ordered by period. Periods without an update are skipped.
+func (s *Store) LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) ([]*ethpb.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdates")
+	defer span.End()
+
+	if startPeriod > endPeriod {
+		return nil, errors.Errorf("start period %d is greater than end period %d", startPeriod, endPeriod)
+	}
+	updates := make([]*ethpb.LightClientUpdate, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(lightClientUpdatesBucket).Cursor()
+		end := bytesutil.Uint64ToBytesBigEndian(endPeriod)
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(startPeriod)); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
+			update := &ethpb.LightClientUpdate{}
+			if err := decode(ctx, v, update); err != nil {
+				return err
+			}
+			updates = append(updates, update)
+		}
+		return nil
+	})
+	return updates, err
+}
@@ -0,0 +1,86 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func testLightClientUpdate(signatureSlot types.Slot) *ethpb.LightClientUpdate {
+	header := func(slot types.Slot) *ethpb.LightClientHeader {
+		return &ethpb.LightClientHeader{Beacon: util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: slot})}
+	}
+	branch := func(n int) [][]byte {
+		b := make([][]byte, n)
+		for i := range b {
+			b[i] = make([]byte, 32)
+		}
+		return b
+	}
+	pubkeys := make([][]byte, 512)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, 48)
+	}
+	return &ethpb.LightClientUpdate{
+		AttestedHeader:          header(signatureSlot - 1),
+		NextSyncCommittee:       &ethpb.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: make([]byte, 48)},
+		NextSyncCommitteeBranch: branch(5),
+		FinalizedHeader:         header(0),
+		FinalityBranch:          branch(6),
+		SyncAggregate: &ethpb.SyncAggregate{
+			SyncCommitteeBits:      bitfield.NewBitvector512(),
+			SyncCommitteeSignature: make([]byte, 96),
+		},
+		SignatureSlot: signatureSlot,
+	}
+}
+
+func TestStore_LightClientUpdate_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	update, err := db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpb.LightClientUpdate)(nil), update)
+
+	want := testLightClientUpdate(10)
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, want))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, want, update)
+
+	// Saving an update for the same period overwrites the previous one.
+	want = testLightClientUpdate(20)
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, want))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, want, update)
+}
+
+func TestStore_LightClientUpdates(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	for _, period := range []uint64{1, 2, 4, 300} {
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, testLightClientUpdate(types.Slot(period+1))))
+	}
+
+	updates, err := db.LightClientUpdates(ctx, 2, 299)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(updates))
+	assert.Equal(t, types.Slot(3), updates[0].SignatureSlot)
+	assert.Equal(t, types.Slot(5), updates[1].SignatureSlot)
+
+	updates, err = db.LightClientUpdates(ctx, 5, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(updates))
+
+	_, err = db.LightClientUpdates(ctx, 3, 2)
+	require.ErrorContains(t, "greater than end period", err)
+}
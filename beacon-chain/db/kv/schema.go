@@ -21,6 +21,17 @@ var (
 	feeRecipientBucket      = []byte("fee-recipient")
 	registrationBucket      = []byte("registration")
 
+	// Local payload store bucket, holding the full execution payloads of recent blinded blocks.
+	executionPayloadsBucket = []byte("execution-payloads")
+
+	// Historical state archive buckets, holding full snapshots and diffs of finalized states.
+	stateArchiveSnapshotsBucket = []byte("state-archive-snapshots")
+	stateArchiveDiffsBucket     = []byte("state-archive-diffs")
+	stateArchiveRootsBucket     = []byte("state-archive-roots")
+
+	// Light client server buckets.
+	lightClientUpdatesBucket = []byte("light-client-updates")
+
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
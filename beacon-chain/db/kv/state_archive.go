@@ -0,0 +1,626 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	statenative "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/state-native"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// The historical state archive stores the states of finalized blocks either as full snapshots, or as diffs
+// against the latest snapshot before them. Snapshots and diffs are keyed by the slot of their state, and
+// the block roots of the archived states are indexed to these slots.
+//
+// A diff holds the fields of the state which grow with the validator registry or the history of the chain
+// as changes against the snapshot, and the remaining fields as they are.
+
+// validatorSSZSize is the size of the SSZ encoding of a validator.
+const validatorSSZSize = 121
+
+// archivedStateBase is the decoded snapshot used as the base of the latest diff read or written.
+// It is kept in memory as consecutive queries of historical states tend to share their snapshot.
+type archivedStateBase struct {
+	lock   sync.Mutex
+	slot   types.Slot
+	fields *archivedFields
+}
+
+// archivedFields are the fields of a beacon state stored as changes against a snapshot in diffs.
+type archivedFields struct {
+	validators                 []*ethpb.Validator
+	balances                   []uint64
+	blockRoots                 [][]byte
+	stateRoots                 [][]byte
+	randaoMixes                [][]byte
+	historicalRoots            [][]byte
+	previousEpochParticipation []byte
+	currentEpochParticipation  []byte
+	inactivityScores           []uint64
+}
+
+// SaveArchivedState saves the state of a finalized block to the historical state archive, as a full snapshot if
+// requested or if there is no snapshot before the state, or as a diff against the latest snapshot before it otherwise.
+func (s *Store) SaveArchivedState(ctx context.Context, st state.ReadOnlyBeaconState, blockRoot [32]byte, snapshot bool) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveArchivedState")
+	defer span.End()
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+	slotKey := bytesutil.SlotToBytesBigEndian(st.Slot())
+
+	var baseSlot types.Slot
+	var baseEnc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		baseSlot, baseEnc = snapshotAtOrBefore(tx, st.Slot())
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var bkt []byte
+	var enc []byte
+	switch {
+	case baseEnc != nil && baseSlot == st.Slot():
+		// The state is already archived as a snapshot, only its block root has to be indexed.
+	case snapshot || baseEnc == nil:
+		var err error
+		enc, err = marshalState(ctx, st)
+		if err != nil {
+			return errors.Wrap(err, "could not encode state snapshot")
+		}
+		bkt = stateArchiveSnapshotsBucket
+	default:
+		base, err := s.archivedStateBase(baseSlot, baseEnc)
+		if err != nil {
+			return err
+		}
+		enc, err = encodeStateDiff(st, baseSlot, base)
+		if err != nil {
+			return errors.Wrap(err, "could not encode state diff")
+		}
+		bkt = stateArchiveDiffsBucket
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if bkt != nil {
+			if err := tx.Bucket(bkt).Put(slotKey, enc); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(stateArchiveRootsBucket).Put(blockRoot[:], slotKey)
+	})
+}
+
+// ArchivedState reconstructs the state of the given block root from the historical state archive.
+// It returns nil if the state is not archived.
+func (s *Store) ArchivedState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ArchivedState")
+	defer span.End()
+
+	var snapshotEnc, diffEnc, baseEnc []byte
+	var baseSlot types.Slot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		slotKey := tx.Bucket(stateArchiveRootsBucket).Get(blockRoot[:])
+		if slotKey == nil {
+			return nil
+		}
+		snapshotEnc = bytesutil.SafeCopyBytes(tx.Bucket(stateArchiveSnapshotsBucket).Get(slotKey))
+		if snapshotEnc != nil {
+			return nil
+		}
+		diffEnc = bytesutil.SafeCopyBytes(tx.Bucket(stateArchiveDiffsBucket).Get(slotKey))
+		if diffEnc == nil {
+			return errors.Errorf("no archived state at slot %d", bytesutil.BytesToSlotBigEndian(slotKey))
+		}
+		if len(diffEnc) < 8 {
+			return errors.New("invalid state diff")
+		}
+		baseSlot = bytesutil.BytesToSlotBigEndian(diffEnc[:8])
+		baseEnc = bytesutil.SafeCopyBytes(tx.Bucket(stateArchiveSnapshotsBucket).Get(diffEnc[:8]))
+		if baseEnc == nil {
+			return errors.Errorf("no state snapshot at slot %d", baseSlot)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshotEnc != nil {
+		pb, err := unmarshalStateProto(snapshotEnc)
+		if err != nil {
+			return nil, err
+		}
+		return initializeStateFromProto(pb)
+	}
+	if diffEnc == nil {
+		return nil, nil
+	}
+	base, err := s.archivedStateBase(baseSlot, baseEnc)
+	if err != nil {
+		return nil, err
+	}
+	return decodeStateDiff(diffEnc[8:], base)
+}
+
+// snapshotAtOrBefore returns the slot and encoding of the latest state snapshot at or before the given slot.
+func snapshotAtOrBefore(tx *bolt.Tx, slot types.Slot) (types.Slot, []byte) {
+	c := tx.Bucket(stateArchiveSnapshotsBucket).Cursor()
+	k, v := c.Seek(bytesutil.SlotToBytesBigEndian(slot))
+	if k == nil || bytesutil.BytesToSlotBigEndian(k) != slot {
+		k, v = c.Prev()
+	}
+	if k == nil {
+		return 0, nil
+	}
+	return bytesutil.BytesToSlotBigEndian(k), bytesutil.SafeCopyBytes(v)
+}
+
+// archivedStateBase returns the archived fields of the snapshot at the given slot, decoding them only if
+// they are not the fields of the snapshot used last. The returned fields must not be modified.
+func (s *Store) archivedStateBase(slot types.Slot, enc []byte) (*archivedFields, error) {
+	s.stateArchiveBase.lock.Lock()
+	defer s.stateArchiveBase.lock.Unlock()
+	if s.stateArchiveBase.fields != nil && s.stateArchiveBase.slot == slot {
+		return s.stateArchiveBase.fields, nil
+	}
+	pb, err := unmarshalStateProto(enc)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := takeArchivedFields(pb)
+	if err != nil {
+		return nil, err
+	}
+	s.stateArchiveBase.slot = slot
+	s.stateArchiveBase.fields = fields
+	return fields, nil
+}
+
+// encodeStateDiff encodes a state as a diff against the archived fields of the snapshot at the given slot.
+func encodeStateDiff(st state.ReadOnlyBeaconState, baseSlot types.Slot, base *archivedFields) ([]byte, error) {
+	pb := st.ToProto()
+	fields, err := takeArchivedFields(pb)
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := pb.(proto.Message)
+	if !ok {
+		return nil, errors.New("invalid inner state")
+	}
+	residual, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := binary.AppendUvarint(nil, uint64(st.Version()))
+	buf = binary.AppendUvarint(buf, uint64(len(residual)))
+	buf = append(buf, residual...)
+	buf, err = appendValidatorsDiff(buf, base.validators, fields.validators)
+	if err != nil {
+		return nil, err
+	}
+	buf = appendUint64sDiff(buf, base.balances, fields.balances)
+	buf = appendRootsDiff(buf, base.blockRoots, fields.blockRoots)
+	buf = appendRootsDiff(buf, base.stateRoots, fields.stateRoots)
+	buf = appendRootsDiff(buf, base.randaoMixes, fields.randaoMixes)
+	buf = appendRootsDiff(buf, base.historicalRoots, fields.historicalRoots)
+	buf = appendBytesDiff(buf, base.previousEpochParticipation, fields.previousEpochParticipation)
+	buf = appendBytesDiff(buf, base.currentEpochParticipation, fields.currentEpochParticipation)
+	buf = appendUint64sDiff(buf, base.inactivityScores, fields.inactivityScores)
+	return append(bytesutil.SlotToBytesBigEndian(baseSlot), snappy.Encode(nil, buf)...), nil
+}
+
+// decodeStateDiff reconstructs a state from its diff against the archived fields of a snapshot.
+func decodeStateDiff(enc []byte, base *archivedFields) (state.BeaconState, error) {
+	buf, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, err
+	}
+	r := &diffReader{buf: buf}
+	var pb proto.Message
+	switch v := int(r.uvarint()); v {
+	case version.Phase0:
+		pb = &ethpb.BeaconState{}
+	case version.Altair:
+		pb = &ethpb.BeaconStateAltair{}
+	case version.Bellatrix:
+		pb = &ethpb.BeaconStateBellatrix{}
+	case version.Capella:
+		pb = &ethpb.BeaconStateCapella{}
+	default:
+		return nil, errors.Errorf("unknown state version %d", v)
+	}
+	residual := r.bytes(int(r.uvarint()))
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := proto.Unmarshal(residual, pb); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal state")
+	}
+
+	fields := &archivedFields{}
+	fields.validators, err = r.validatorsDiff(base.validators)
+	if err != nil {
+		return nil, err
+	}
+	fields.balances = r.uint64sDiff(base.balances)
+	fields.blockRoots = r.rootsDiff(base.blockRoots)
+	fields.stateRoots = r.rootsDiff(base.stateRoots)
+	fields.randaoMixes = r.rootsDiff(base.randaoMixes)
+	fields.historicalRoots = r.rootsDiff(base.historicalRoots)
+	fields.previousEpochParticipation = r.bytesDiff(base.previousEpochParticipation)
+	fields.currentEpochParticipation = r.bytesDiff(base.currentEpochParticipation)
+	fields.inactivityScores = r.uint64sDiff(base.inactivityScores)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := putArchivedFields(pb, fields); err != nil {
+		return nil, err
+	}
+	return initializeStateFromProto(pb)
+}
+
+// takeArchivedFields moves the archived fields out of a state proto.
+func takeArchivedFields(pb interface{}) (*archivedFields, error) {
+	f := &archivedFields{}
+	switch st := pb.(type) {
+	case *ethpb.BeaconState:
+		f.validators, st.Validators = st.Validators, nil
+		f.balances, st.Balances = st.Balances, nil
+		f.blockRoots, st.BlockRoots = st.BlockRoots, nil
+		f.stateRoots, st.StateRoots = st.StateRoots, nil
+		f.randaoMixes, st.RandaoMixes = st.RandaoMixes, nil
+		f.historicalRoots, st.HistoricalRoots = st.HistoricalRoots, nil
+	case *ethpb.BeaconStateAltair:
+		f.validators, st.Validators = st.Validators, nil
+		f.balances, st.Balances = st.Balances, nil
+		f.blockRoots, st.BlockRoots = st.BlockRoots, nil
+		f.stateRoots, st.StateRoots = st.StateRoots, nil
+		f.randaoMixes, st.RandaoMixes = st.RandaoMixes, nil
+		f.historicalRoots, st.HistoricalRoots = st.HistoricalRoots, nil
+		f.previousEpochParticipation, st.PreviousEpochParticipation = st.PreviousEpochParticipation, nil
+		f.currentEpochParticipation, st.CurrentEpochParticipation = st.CurrentEpochParticipation, nil
+		f.inactivityScores, st.InactivityScores = st.InactivityScores, nil
+	case *ethpb.BeaconStateBellatrix:
+		f.validators, st.Validators = st.Validators, nil
+		f.balances, st.Balances = st.Balances, nil
+		f.blockRoots, st.BlockRoots = st.BlockRoots, nil
+		f.stateRoots, st.StateRoots = st.StateRoots, nil
+		f.randaoMixes, st.RandaoMixes = st.RandaoMixes, nil
+		f.historicalRoots, st.HistoricalRoots = st.HistoricalRoots, nil
+		f.previousEpochParticipation, st.PreviousEpochParticipation = st.PreviousEpochParticipation, nil
+		f.currentEpochParticipation, st.CurrentEpochParticipation = st.CurrentEpochParticipation, nil
+		f.inactivityScores, st.InactivityScores = st.InactivityScores, nil
+	case *ethpb.BeaconStateCapella:
+		f.validators, st.Validators = st.Validators, nil
+		f.balances, st.Balances = st.Balances, nil
+		f.blockRoots, st.BlockRoots = st.BlockRoots, nil
+		f.stateRoots, st.StateRoots = st.StateRoots, nil
+		f.randaoMixes, st.RandaoMixes = st.RandaoMixes, nil
+		f.historicalRoots, st.HistoricalRoots = st.HistoricalRoots, nil
+		f.previousEpochParticipation, st.PreviousEpochParticipation = st.PreviousEpochParticipation, nil
+		f.currentEpochParticipation, st.CurrentEpochParticipation = st.CurrentEpochParticipation, nil
+		f.inactivityScores, st.InactivityScores = st.InactivityScores, nil
+	default:
+		return nil, errors.New("invalid inner state")
+	}
+	return f, nil
+}
+
+// putArchivedFields sets the archived fields of a state proto.
+func putArchivedFields(pb interface{}, f *archivedFields) error {
+	switch st := pb.(type) {
+	case *ethpb.BeaconState:
+		st.Validators = f.validators
+		st.Balances = f.balances
+		st.BlockRoots = f.blockRoots
+		st.StateRoots = f.stateRoots
+		st.RandaoMixes = f.randaoMixes
+		st.HistoricalRoots = f.historicalRoots
+	case *ethpb.BeaconStateAltair:
+		st.Validators = f.validators
+		st.Balances = f.balances
+		st.BlockRoots = f.blockRoots
+		st.StateRoots = f.stateRoots
+		st.RandaoMixes = f.randaoMixes
+		st.HistoricalRoots = f.historicalRoots
+		st.PreviousEpochParticipation = f.previousEpochParticipation
+		st.CurrentEpochParticipation = f.currentEpochParticipation
+		st.InactivityScores = f.inactivityScores
+	case *ethpb.BeaconStateBellatrix:
+		st.Validators = f.validators
+		st.Balances = f.balances
+		st.BlockRoots = f.blockRoots
+		st.StateRoots = f.stateRoots
+		st.RandaoMixes = f.randaoMixes
+		st.HistoricalRoots = f.historicalRoots
+		st.PreviousEpochParticipation = f.previousEpochParticipation
+		st.CurrentEpochParticipation = f.currentEpochParticipation
+		st.InactivityScores = f.inactivityScores
+	case *ethpb.BeaconStateCapella:
+		st.Validators = f.validators
+		st.Balances = f.balances
+		st.BlockRoots = f.blockRoots
+		st.StateRoots = f.stateRoots
+		st.RandaoMixes = f.randaoMixes
+		st.HistoricalRoots = f.historicalRoots
+		st.PreviousEpochParticipation = f.previousEpochParticipation
+		st.CurrentEpochParticipation = f.currentEpochParticipation
+		st.InactivityScores = f.inactivityScores
+	default:
+		return errors.New("invalid inner state")
+	}
+	return nil
+}
+
+// unmarshalStateProto decodes a state encoded by marshalState into its proto.
+func unmarshalStateProto(enc []byte) (interface{}, error) {
+	enc, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case hasCapellaKey(enc):
+		pb := &ethpb.BeaconStateCapella{}
+		if err := pb.UnmarshalSSZ(enc[len(capellaKey):]); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding for capella")
+		}
+		return pb, nil
+	case hasBellatrixKey(enc):
+		pb := &ethpb.BeaconStateBellatrix{}
+		if err := pb.UnmarshalSSZ(enc[len(bellatrixKey):]); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding for bellatrix")
+		}
+		return pb, nil
+	case hasAltairKey(enc):
+		pb := &ethpb.BeaconStateAltair{}
+		if err := pb.UnmarshalSSZ(enc[len(altairKey):]); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding for altair")
+		}
+		return pb, nil
+	default:
+		pb := &ethpb.BeaconState{}
+		if err := pb.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding")
+		}
+		return pb, nil
+	}
+}
+
+func initializeStateFromProto(pb interface{}) (state.BeaconState, error) {
+	switch st := pb.(type) {
+	case *ethpb.BeaconState:
+		return statenative.InitializeFromProtoUnsafePhase0(st)
+	case *ethpb.BeaconStateAltair:
+		return statenative.InitializeFromProtoUnsafeAltair(st)
+	case *ethpb.BeaconStateBellatrix:
+		return statenative.InitializeFromProtoUnsafeBellatrix(st)
+	case *ethpb.BeaconStateCapella:
+		return statenative.InitializeFromProtoUnsafeCapella(st)
+	default:
+		return nil, errors.New("invalid inner state")
+	}
+}
+
+// appendValidatorsDiff appends the number of validators, followed by the SSZ encodings of the validators
+// which differ from the base.
+func appendValidatorsDiff(buf []byte, base, vals []*ethpb.Validator) ([]byte, error) {
+	var changed []int
+	for i, v := range vals {
+		if i >= len(base) || !validatorsEqual(base[i], v) {
+			changed = append(changed, i)
+		}
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(vals)))
+	buf = binary.AppendUvarint(buf, uint64(len(changed)))
+	for _, i := range changed {
+		enc, err := vals[i].MarshalSSZ()
+		if err != nil {
+			return nil, err
+		}
+		buf = binary.AppendUvarint(buf, uint64(i))
+		buf = append(buf, enc...)
+	}
+	return buf, nil
+}
+
+func validatorsEqual(a, b *ethpb.Validator) bool {
+	return a.EffectiveBalance == b.EffectiveBalance &&
+		a.Slashed == b.Slashed &&
+		a.ActivationEligibilityEpoch == b.ActivationEligibilityEpoch &&
+		a.ActivationEpoch == b.ActivationEpoch &&
+		a.ExitEpoch == b.ExitEpoch &&
+		a.WithdrawableEpoch == b.WithdrawableEpoch &&
+		bytes.Equal(a.WithdrawalCredentials, b.WithdrawalCredentials) &&
+		bytes.Equal(a.PublicKey, b.PublicKey)
+}
+
+// appendUint64sDiff appends the number of values, followed by the difference of every value with the base.
+func appendUint64sDiff(buf []byte, base, vals []uint64) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(vals)))
+	for i, v := range vals {
+		if i < len(base) {
+			v -= base[i]
+		}
+		buf = binary.AppendVarint(buf, int64(v))
+	}
+	return buf
+}
+
+// appendRootsDiff appends the number of roots, followed by the roots which differ from the base.
+func appendRootsDiff(buf []byte, base, roots [][]byte) []byte {
+	var changed []int
+	for i, r := range roots {
+		if i >= len(base) || !bytes.Equal(base[i], r) {
+			changed = append(changed, i)
+		}
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(roots)))
+	buf = binary.AppendUvarint(buf, uint64(len(changed)))
+	for _, i := range changed {
+		buf = binary.AppendUvarint(buf, uint64(i))
+		buf = binary.AppendUvarint(buf, uint64(len(roots[i])))
+		buf = append(buf, roots[i]...)
+	}
+	return buf
+}
+
+// appendBytesDiff appends the number of bytes, followed by the bytes xor-ed with the base, which mostly
+// yields zeroes that compress well.
+func appendBytesDiff(buf []byte, base, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	for i, c := range b {
+		if i < len(base) {
+			c ^= base[i]
+		}
+		buf = append(buf, c)
+	}
+	return buf
+}
+
+type diffReader struct {
+	buf []byte
+	err error
+}
+
+func (r *diffReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errors.New("invalid state diff")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *diffReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.err = errors.New("invalid state diff")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *diffReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.buf) {
+		r.err = errors.New("invalid state diff")
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+// length reads a number of elements, each of which takes at least the given number of bytes in the diff
+// or in the base.
+func (r *diffReader) length(baseLen, elemSize int) int {
+	n := r.uvarint()
+	if n > uint64(baseLen+len(r.buf)/elemSize) {
+		r.err = errors.New("invalid state diff")
+		return 0
+	}
+	return int(n)
+}
+
+func (r *diffReader) validatorsDiff(base []*ethpb.Validator) ([]*ethpb.Validator, error) {
+	n := r.length(len(base), validatorSSZSize)
+	vals := make([]*ethpb.Validator, n)
+	for i := 0; i < n && i < len(base); i++ {
+		vals[i] = ethpb.CopyValidator(base[i])
+	}
+	changed := r.uvarint()
+	for j := uint64(0); j < changed && r.err == nil; j++ {
+		i := r.uvarint()
+		enc := r.bytes(validatorSSZSize)
+		if r.err != nil {
+			break
+		}
+		if i >= uint64(n) {
+			return nil, errors.New("invalid state diff")
+		}
+		v := &ethpb.Validator{}
+		if err := v.UnmarshalSSZ(enc); err != nil {
+			return nil, err
+		}
+		vals[i] = v
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, v := range vals {
+		if v == nil {
+			return nil, errors.New("invalid state diff")
+		}
+	}
+	return vals, nil
+}
+
+func (r *diffReader) uint64sDiff(base []uint64) []uint64 {
+	n := r.length(0, 1)
+	vals := make([]uint64, n)
+	for i := range vals {
+		vals[i] = uint64(r.varint())
+		if i < len(base) {
+			vals[i] += base[i]
+		}
+	}
+	return vals
+}
+
+func (r *diffReader) rootsDiff(base [][]byte) [][]byte {
+	n := r.length(len(base), 1)
+	roots := make([][]byte, n)
+	copy(roots, base)
+	changed := r.uvarint()
+	for j := uint64(0); j < changed && r.err == nil; j++ {
+		i := r.uvarint()
+		root := bytesutil.SafeCopyBytes(r.bytes(int(r.uvarint())))
+		if r.err == nil && i >= uint64(n) {
+			r.err = errors.New("invalid state diff")
+		}
+		if r.err != nil {
+			return nil
+		}
+		roots[i] = root
+	}
+	for _, root := range roots {
+		if root == nil && r.err == nil {
+			r.err = errors.New("invalid state diff")
+		}
+	}
+	return roots
+}
+
+func (r *diffReader) bytesDiff(base []byte) []byte {
+	b := bytesutil.SafeCopyBytes(r.bytes(int(r.uvarint())))
+	for i := range b {
+		if i < len(base) {
+			b[i] ^= base[i]
+		}
+	}
+	return b
+}
@@ -0,0 +1,94 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+func requireSameState(t *testing.T, want, got state.BeaconState) {
+	require.NotNil(t, got)
+	wantRoot, err := want.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, wantRoot, gotRoot)
+	assert.Equal(t, want.Version(), got.Version())
+}
+
+func TestStore_ArchivedState(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	got, err := db.ArchivedState(ctx, [32]byte{'a'})
+	require.NoError(t, err)
+	assert.Equal(t, nil, got)
+
+	// The first archived state is always a snapshot.
+	base, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, base.SetSlot(64))
+	require.NoError(t, db.SaveArchivedState(ctx, base, [32]byte{'a'}, false))
+
+	diff := base.Copy()
+	require.NoError(t, diff.SetSlot(96))
+	require.NoError(t, diff.UpdateBalancesAtIndex(3, 31e9))
+	val, err := diff.ValidatorAtIndex(5)
+	require.NoError(t, err)
+	val.ExitEpoch = 10
+	val.Slashed = true
+	require.NoError(t, diff.UpdateValidatorAtIndex(5, val))
+	require.NoError(t, diff.AppendValidator(&ethpb.Validator{
+		PublicKey:             make([]byte, 48),
+		WithdrawalCredentials: make([]byte, 32),
+		EffectiveBalance:      32e9,
+	}))
+	require.NoError(t, diff.AppendBalance(32e9))
+	require.NoError(t, diff.AppendInactivityScore(0))
+	require.NoError(t, diff.AppendCurrentParticipationBits(7))
+	require.NoError(t, diff.AppendPreviousParticipationBits(0))
+	require.NoError(t, diff.ModifyCurrentParticipationBits(func(val []byte) ([]byte, error) {
+		val[0] = 3
+		return val, nil
+	}))
+	require.NoError(t, diff.UpdateBlockRootAtIndex(7, [32]byte{'b'}))
+	require.NoError(t, diff.UpdateRandaoMixesAtIndex(9, []byte{'c'}))
+	require.NoError(t, diff.AppendHistoricalRoots([32]byte{'d'}))
+	require.NoError(t, db.SaveArchivedState(ctx, diff, [32]byte{'b'}, false))
+
+	// States of a later fork are diffed against the snapshot of an earlier one.
+	upgraded, _ := util.DeterministicGenesisStateCapella(t, 64)
+	require.NoError(t, upgraded.SetSlot(128))
+	require.NoError(t, db.SaveArchivedState(ctx, upgraded, [32]byte{'c'}, false))
+
+	err = db.db.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, 1, tx.Bucket(stateArchiveSnapshotsBucket).Stats().KeyN)
+		assert.Equal(t, 2, tx.Bucket(stateArchiveDiffsBucket).Stats().KeyN)
+		return nil
+	})
+	require.NoError(t, err)
+
+	got, err = db.ArchivedState(ctx, [32]byte{'a'})
+	require.NoError(t, err)
+	requireSameState(t, base, got)
+	got, err = db.ArchivedState(ctx, [32]byte{'b'})
+	require.NoError(t, err)
+	requireSameState(t, diff, got)
+	got, err = db.ArchivedState(ctx, [32]byte{'c'})
+	require.NoError(t, err)
+	requireSameState(t, upgraded, got)
+
+	// Diffs keep referring to their own snapshot after a newer one is saved.
+	require.NoError(t, db.SaveArchivedState(ctx, diff, [32]byte{'d'}, true))
+	got, err = db.ArchivedState(ctx, [32]byte{'c'})
+	require.NoError(t, err)
+	requireSameState(t, upgraded, got)
+	got, err = db.ArchivedState(ctx, [32]byte{'d'})
+	require.NoError(t, err)
+	requireSameState(t, diff, got)
+}
@@ -0,0 +1,173 @@
+package slasherkv
+
+import (
+	"context"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	lruwrpr "github.com/prysmaticlabs/prysm/v3/cache/lru"
+	"go.opencensus.io/trace"
+)
+
+// chunkCacheEntrySize is the size in bytes of a slasher chunk with the default slasher parameters,
+// used to turn a size budget into a number of cached chunks.
+const chunkCacheEntrySize = 16 * 256 * 2
+
+// chunkCache keeps recently used min and max span chunks in memory. Saved chunks are only written
+// to disk when they are evicted or flushed, so that a chunk updated by consecutive batches of
+// attestations is written once per flush instead of once per batch.
+type chunkCache struct {
+	// lock is held across disk writes, so that a chunk being written back cannot be loaded
+	// from disk before the write completes.
+	lock  sync.Mutex
+	cache *lru.Cache
+	// evicted holds modified chunks evicted from the cache which are not yet written to disk.
+	evicted map[string][]uint16
+}
+
+type cachedChunk struct {
+	chunk []uint16
+	dirty bool
+}
+
+func newChunkCache(size int) *chunkCache {
+	c := &chunkCache{
+		evicted: make(map[string][]uint16),
+	}
+	c.cache = lruwrpr.NewWithEvict(size, func(key interface{}, value interface{}) {
+		entry, ok := value.(*cachedChunk)
+		if ok && entry.dirty {
+			c.evicted[key.(string)] = entry.chunk
+		}
+	})
+	return c
+}
+
+// loadCachedSlasherChunks retrieves chunks from the chunk cache, loading the missing ones from disk.
+func (s *Store) loadCachedSlasherChunks(encodedKeys [][]byte) ([][]uint16, []bool, error) {
+	c := s.chunkCache
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	chunks := make([][]uint16, len(encodedKeys))
+	exists := make([]bool, len(encodedKeys))
+	missing := make([][]byte, 0)
+	missingIndices := make([]int, 0)
+	for i, key := range encodedKeys {
+		if value, ok := c.cache.Get(string(key)); ok {
+			chunks[i] = copyChunk(value.(*cachedChunk).chunk)
+			exists[i] = true
+			continue
+		}
+		if chunk, ok := c.evicted[string(key)]; ok {
+			chunks[i] = copyChunk(chunk)
+			exists[i] = true
+			continue
+		}
+		missing = append(missing, key)
+		missingIndices = append(missingIndices, i)
+	}
+	slasherChunkCacheHitsTotal.Add(float64(len(encodedKeys) - len(missing)))
+	slasherChunkCacheMissesTotal.Add(float64(len(missing)))
+	if len(missing) == 0 {
+		return chunks, exists, nil
+	}
+	loaded, loadedExists, err := s.loadSlasherChunks(missing)
+	if err != nil {
+		return nil, nil, err
+	}
+	for j, i := range missingIndices {
+		chunks[i] = loaded[j]
+		exists[i] = loadedExists[j]
+		if loadedExists[j] {
+			c.cache.Add(string(missing[j]), &cachedChunk{chunk: copyChunk(loaded[j])})
+		}
+	}
+	if err := s.writeEvictedChunks(); err != nil {
+		return nil, nil, err
+	}
+	return chunks, exists, nil
+}
+
+// saveCachedSlasherChunks saves chunks to the chunk cache, writing the modified chunks it evicts to disk.
+func (s *Store) saveCachedSlasherChunks(encodedKeys [][]byte, chunks [][]uint16) error {
+	c := s.chunkCache
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i, key := range encodedKeys {
+		if len(chunks[i]) == 0 {
+			return errors.New("cannot encode empty chunk")
+		}
+		delete(c.evicted, string(key))
+		c.cache.Add(string(key), &cachedChunk{chunk: copyChunk(chunks[i]), dirty: true})
+	}
+	return s.writeEvictedChunks()
+}
+
+// writeEvictedChunks writes the modified chunks evicted from the chunk cache to disk.
+// The chunk cache lock must be held by the caller.
+func (s *Store) writeEvictedChunks() error {
+	c := s.chunkCache
+	if len(c.evicted) == 0 {
+		return nil
+	}
+	keys := make([][]byte, 0, len(c.evicted))
+	chunks := make([][]uint16, 0, len(c.evicted))
+	for key, chunk := range c.evicted {
+		keys = append(keys, []byte(key))
+		chunks = append(chunks, chunk)
+	}
+	if err := s.saveSlasherChunks(keys, chunks); err != nil {
+		return errors.Wrap(err, "could not write evicted slasher chunks")
+	}
+	c.evicted = make(map[string][]uint16)
+	return nil
+}
+
+// FlushSlasherChunks writes the slasher chunks modified since the last flush to disk,
+// keeping them in the chunk cache.
+func (s *Store) FlushSlasherChunks(ctx context.Context) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.FlushSlasherChunks")
+	defer span.End()
+	c := s.chunkCache
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := s.writeEvictedChunks(); err != nil {
+		return err
+	}
+	keys := make([][]byte, 0)
+	chunks := make([][]uint16, 0)
+	entries := make([]*cachedChunk, 0)
+	for _, key := range c.cache.Keys() {
+		value, ok := c.cache.Peek(key)
+		if !ok {
+			continue
+		}
+		entry := value.(*cachedChunk)
+		if !entry.dirty {
+			continue
+		}
+		keys = append(keys, []byte(key.(string)))
+		chunks = append(chunks, entry.chunk)
+		entries = append(entries, entry)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := s.saveSlasherChunks(keys, chunks); err != nil {
+		return errors.Wrap(err, "could not flush slasher chunks")
+	}
+	for _, entry := range entries {
+		entry.dirty = false
+	}
+	return nil
+}
+
+func copyChunk(chunk []uint16) []uint16 {
+	c := make([]uint16, len(chunk))
+	copy(c, chunk)
+	return c
+}
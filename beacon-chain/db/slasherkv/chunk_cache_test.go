@@ -0,0 +1,71 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+	slashertypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestStore_SlasherChunkCache_WriteBack(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewKVStore(ctx, t.TempDir(), WithChunkCacheSize(2*chunkCacheEntrySize))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	keys := [][]byte{
+		ssz.MarshalUint64(make([]byte, 0), 1),
+		ssz.MarshalUint64(make([]byte, 0), 2),
+		ssz.MarshalUint64(make([]byte, 0), 3),
+	}
+	diskKey := func(i int) [][]byte {
+		return [][]byte{append(ssz.MarshalUint8(make([]byte, 0), uint8(slashertypes.MinSpan)), keys[i]...)}
+	}
+	chunks := [][]uint16{{1, 2}, {3, 4}, {5, 6}}
+
+	require.NoError(t, db.SaveSlasherChunks(ctx, slashertypes.MinSpan, keys[:2], chunks[:2]))
+	// Saved chunks are served from memory but not written to disk yet.
+	loaded, exists, err := db.LoadSlasherChunks(ctx, slashertypes.MinSpan, keys[:1])
+	require.NoError(t, err)
+	require.Equal(t, true, exists[0])
+	require.DeepEqual(t, chunks[0], loaded[0])
+	_, exists, err = db.loadSlasherChunks(diskKey(0))
+	require.NoError(t, err)
+	require.Equal(t, false, exists[0])
+
+	// Modifying a loaded chunk does not modify the cached one.
+	loaded[0][0] = 100
+	loaded, _, err = db.LoadSlasherChunks(ctx, slashertypes.MinSpan, keys[:1])
+	require.NoError(t, err)
+	require.DeepEqual(t, chunks[0], loaded[0])
+
+	// Saving a third chunk evicts the least recently used one, which is written to disk.
+	require.NoError(t, db.SaveSlasherChunks(ctx, slashertypes.MinSpan, keys[2:], chunks[2:]))
+	onDisk, exists, err := db.loadSlasherChunks(diskKey(1))
+	require.NoError(t, err)
+	require.Equal(t, true, exists[0])
+	require.DeepEqual(t, chunks[1], onDisk[0])
+	_, exists, err = db.loadSlasherChunks(diskKey(2))
+	require.NoError(t, err)
+	require.Equal(t, false, exists[0])
+
+	// Flushing writes the remaining modified chunks.
+	require.NoError(t, db.FlushSlasherChunks(ctx))
+	for i := range keys {
+		onDisk, exists, err = db.loadSlasherChunks(diskKey(i))
+		require.NoError(t, err)
+		require.Equal(t, true, exists[0])
+		require.DeepEqual(t, chunks[i], onDisk[0])
+	}
+
+	// Evicted chunks are loaded back from disk.
+	loaded, exists, err = db.LoadSlasherChunks(ctx, slashertypes.MinSpan, keys)
+	require.NoError(t, err)
+	for i := range keys {
+		require.Equal(t, true, exists[i])
+		require.DeepEqual(t, chunks[i], loaded[i])
+	}
+}
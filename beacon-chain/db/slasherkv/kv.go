@@ -31,12 +31,28 @@ type Store struct {
 	db           *bolt.DB
 	databasePath string
 	ctx          context.Context
+
+	chunkCache *chunkCache
+}
+
+// StoreOption is a functional option that modifies a Store.
+type StoreOption func(*Store)
+
+// WithChunkCacheSize keeps up to the given number of bytes of recently used slasher chunks in memory,
+// writing modified chunks back to disk when they are evicted or flushed with FlushSlasherChunks.
+// Chunks are read from and written to disk directly when the size is zero.
+func WithChunkCacheSize(sizeBytes uint64) StoreOption {
+	return func(s *Store) {
+		if entries := sizeBytes / chunkCacheEntrySize; entries > 0 {
+			s.chunkCache = newChunkCache(int(entries))
+		}
+	}
 }
 
 // NewKVStore initializes a new boltDB key-value store at the directory
 // path specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
-func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
+func NewKVStore(ctx context.Context, dirPath string, opts ...StoreOption) (*Store, error) {
 	hasDir, err := file.HasDir(dirPath)
 	if err != nil {
 		return nil, err
@@ -67,6 +83,9 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 		databasePath: dirPath,
 		ctx:          ctx,
 	}
+	for _, o := range opts {
+		o(kv)
+	}
 
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
 		return createBuckets(
@@ -77,6 +96,7 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 			attestationDataRootsBucket,
 			proposalRecordsBucket,
 			slasherChunksBucket,
+			migrationsBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -96,8 +116,11 @@ func (s *Store) ClearDB() error {
 	return nil
 }
 
-// Close closes the underlying BoltDB database.
+// Close writes any modified slasher chunks still in memory to disk and closes the underlying BoltDB database.
 func (s *Store) Close() error {
+	if err := s.FlushSlasherChunks(s.ctx); err != nil {
+		log.WithError(err).Error("Could not flush slasher chunks")
+	}
 	return s.db.Close()
 }
 
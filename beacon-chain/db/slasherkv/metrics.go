@@ -14,4 +14,16 @@ var (
 		Name: "slasher_proposals_pruned_total",
 		Help: "Total number of old proposals pruned by slasher",
 	})
+	slasherChunkCacheHitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_chunk_cache_hits_total",
+		Help: "Total number of slasher chunks loaded from the in-memory chunk cache",
+	})
+	slasherChunkCacheMissesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_chunk_cache_misses_total",
+		Help: "Total number of slasher chunks not found in the in-memory chunk cache",
+	})
+	slasherChunkBytesWrittenTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_chunk_bytes_written_total",
+		Help: "Total number of encoded slasher chunk bytes written to disk",
+	})
 )
@@ -0,0 +1,74 @@
+package slasherkv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/monitoring/progress"
+	bolt "go.etcd.io/bbolt"
+)
+
+const compressedChunksBatchSize = 1000
+
+var migrationCompressedChunks0Key = []byte("compressed-slasher-chunks-0")
+
+// migrateCompressedChunks re-encodes the slasher chunks written by earlier versions
+// as raw distances with the more compact delta encoding of encodeSlasherChunk.
+func migrateCompressedChunks(ctx context.Context, db *bolt.DB) error {
+	var keys [][]byte
+	if err := db.View(func(tx *bolt.Tx) error {
+		mb := tx.Bucket(migrationsBucket)
+		if b := mb.Get(migrationCompressedChunks0Key); bytes.Equal(b, migrationCompleted) {
+			return nil // Migration already completed.
+		}
+		return tx.Bucket(slasherChunksBucket).ForEach(func(k, v []byte) error {
+			if len(v) > 0 && v[0] != compressedChunkPrefix {
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+			}
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		log.WithField("numChunks", len(keys)).Info("Performing a one-time migration to a more compact encoding of slasher chunks")
+		bar := progress.InitializeProgressBar(len(keys), "Migrating slasher chunks to compact encoding.")
+		for start := 0; start < len(keys); start += compressedChunksBatchSize {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			end := start + compressedChunksBatchSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+			if err := db.Update(func(tx *bolt.Tx) error {
+				bkt := tx.Bucket(slasherChunksBucket)
+				for _, k := range keys[start:end] {
+					chunk, err := decodeLegacySlasherChunk(bkt.Get(k))
+					if err != nil {
+						return err
+					}
+					enc, err := encodeSlasherChunk(chunk)
+					if err != nil {
+						return err
+					}
+					if err := bkt.Put(k, enc); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := bar.Add(end - start); err != nil {
+				return err
+			}
+		}
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(migrationsBucket).Put(migrationCompressedChunks0Key, migrationCompleted)
+	})
+}
@@ -0,0 +1,67 @@
+package slasherkv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/snappy"
+	ssz "github.com/prysmaticlabs/fastssz"
+	slashertypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func Test_migrateCompressedChunks(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	chunks := [][]uint16{{1, 2, 3}, {65535, 0, 7}}
+	keys := [][]byte{
+		ssz.MarshalUint64(make([]byte, 0), 1),
+		ssz.MarshalUint64(make([]byte, 0), 2),
+	}
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(slasherChunksBucket)
+		for i, chunk := range chunks {
+			key := append(ssz.MarshalUint8(make([]byte, 0), uint8(slashertypes.MinSpan)), keys[i]...)
+			if err := bkt.Put(key, encodeLegacySlasherChunk(chunk)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	require.NoError(t, migrateCompressedChunks(ctx, db.db))
+
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		require.DeepEqual(t, migrationCompleted, tx.Bucket(migrationsBucket).Get(migrationCompressedChunks0Key))
+		return tx.Bucket(slasherChunksBucket).ForEach(func(k, v []byte) error {
+			require.Equal(t, byte(compressedChunkPrefix), v[0])
+			return nil
+		})
+	}))
+	loaded, exists, err := db.LoadSlasherChunks(ctx, slashertypes.MinSpan, keys)
+	require.NoError(t, err)
+	for i := range chunks {
+		require.Equal(t, true, exists[i])
+		require.DeepEqual(t, chunks[i], loaded[i])
+	}
+
+	// A completed migration is not run again.
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(slasherChunksBucket).Put([]byte("legacy"), encodeLegacySlasherChunk(chunks[0]))
+	}))
+	require.NoError(t, db.RunMigrations(ctx))
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		require.Equal(t, false, bytes.HasPrefix(tx.Bucket(slasherChunksBucket).Get([]byte("legacy")), []byte{compressedChunkPrefix}))
+		return nil
+	}))
+}
+
+func encodeLegacySlasherChunk(chunk []uint16) []byte {
+	val := make([]byte, 0)
+	for _, distance := range chunk {
+		val = ssz.MarshalUint16(val, distance)
+	}
+	return snappy.Encode(nil, val)
+}
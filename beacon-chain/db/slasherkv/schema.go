@@ -13,4 +13,7 @@ var (
 	attestationDataRootsBucket = []byte("attestation-data-roots")
 	proposalRecordsBucket      = []byte("proposal-records")
 	slasherChunksBucket        = []byte("slasher-chunks")
+
+	// Migrations
+	migrationsBucket = []byte("migrations")
 )
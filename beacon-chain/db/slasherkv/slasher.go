@@ -261,12 +261,23 @@ func (s *Store) LoadSlasherChunks(
 ) ([][]uint16, []bool, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.LoadSlasherChunk")
 	defer span.End()
+	encodedKeys := make([][]byte, len(diskKeys))
+	for i, diskKey := range diskKeys {
+		encodedKeys[i] = append(ssz.MarshalUint8(make([]byte, 0), uint8(kind)), diskKey...)
+	}
+	if s.chunkCache != nil {
+		return s.loadCachedSlasherChunks(encodedKeys)
+	}
+	return s.loadSlasherChunks(encodedKeys)
+}
+
+// loadSlasherChunks retrieves chunks from disk given their encoded keys.
+func (s *Store) loadSlasherChunks(encodedKeys [][]byte) ([][]uint16, []bool, error) {
 	chunks := make([][]uint16, 0)
 	var exists []bool
 	err := s.db.View(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(slasherChunksBucket)
-		for _, diskKey := range diskKeys {
-			key := append(ssz.MarshalUint8(make([]byte, 0), uint8(kind)), diskKey...)
+		for _, key := range encodedKeys {
 			chunkBytes := bkt.Get(key)
 			if chunkBytes == nil {
 				chunks = append(chunks, []uint16{})
@@ -293,24 +304,40 @@ func (s *Store) SaveSlasherChunks(
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveSlasherChunks")
 	defer span.End()
 	encodedKeys := make([][]byte, len(chunkKeys))
-	encodedChunks := make([][]byte, len(chunkKeys))
 	for i := 0; i < len(chunkKeys); i++ {
 		encodedKeys[i] = append(ssz.MarshalUint8(make([]byte, 0), uint8(kind)), chunkKeys[i]...)
+	}
+	if s.chunkCache != nil {
+		return s.saveCachedSlasherChunks(encodedKeys, chunks)
+	}
+	return s.saveSlasherChunks(encodedKeys, chunks)
+}
+
+// saveSlasherChunks writes chunks to disk given their encoded keys.
+func (s *Store) saveSlasherChunks(encodedKeys [][]byte, chunks [][]uint16) error {
+	encodedChunks := make([][]byte, len(encodedKeys))
+	var numBytes int
+	for i := 0; i < len(encodedKeys); i++ {
 		encodedChunk, err := encodeSlasherChunk(chunks[i])
 		if err != nil {
 			return err
 		}
 		encodedChunks[i] = encodedChunk
+		numBytes += len(encodedChunk)
 	}
-	return s.db.Update(func(tx *bolt.Tx) error {
+	if err := s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(slasherChunksBucket)
-		for i := 0; i < len(chunkKeys); i++ {
+		for i := 0; i < len(encodedKeys); i++ {
 			if err := bkt.Put(encodedKeys[i], encodedChunks[i]); err != nil {
 				return err
 			}
 		}
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+	slasherChunkBytesWrittenTotal.Add(float64(numBytes))
+	return nil
 }
 
 // CheckDoubleBlockProposals takes in a list of proposals and for each,
@@ -482,18 +509,55 @@ func keyForValidatorProposal(slot types.Slot, proposerIndex types.ValidatorIndex
 	return append(encSlot, encValidatorIdx...), nil
 }
 
+// compressedChunkPrefix marks chunks encoded as the differences between consecutive distances, which are
+// mostly small as the distances of a validator at consecutive epochs differ by at most one, written as
+// varints and compressed with snappy. Chunks encoded by earlier versions as the raw distances compressed
+// with snappy never start with this byte, as snappy starts with the length of the raw distances, which is
+// even and either below 128 or has its continuation bit set.
+const compressedChunkPrefix = 0x01
+
 func encodeSlasherChunk(chunk []uint16) ([]byte, error) {
-	val := make([]byte, 0)
-	for i := 0; i < len(chunk); i++ {
-		val = append(val, ssz.MarshalUint16(make([]byte, 0), chunk[i])...)
-	}
-	if len(val) == 0 {
+	if len(chunk) == 0 {
 		return nil, errors.New("cannot encode empty chunk")
 	}
-	return snappy.Encode(nil, val), nil
+	val := binary.AppendUvarint(make([]byte, 0, len(chunk)+binary.MaxVarintLen64), uint64(len(chunk)))
+	var prev int64
+	for _, distance := range chunk {
+		val = binary.AppendVarint(val, int64(distance)-prev)
+		prev = int64(distance)
+	}
+	return append([]byte{compressedChunkPrefix}, snappy.Encode(nil, val)...), nil
 }
 
 func decodeSlasherChunk(enc []byte) ([]uint16, error) {
+	if len(enc) == 0 || enc[0] != compressedChunkPrefix {
+		return decodeLegacySlasherChunk(enc)
+	}
+	val, err := snappy.Decode(nil, enc[1:])
+	if err != nil {
+		return nil, err
+	}
+	length, n := binary.Uvarint(val)
+	if n <= 0 || length > uint64(len(val)) {
+		return nil, errors.New("cannot decode slasher chunk length")
+	}
+	val = val[n:]
+	chunk := make([]uint16, length)
+	var prev int64
+	for i := range chunk {
+		delta, n := binary.Varint(val)
+		if n <= 0 {
+			return nil, fmt.Errorf("cannot decode slasher chunk distance %d", i)
+		}
+		val = val[n:]
+		prev += delta
+		chunk[i] = uint16(prev)
+	}
+	return chunk, nil
+}
+
+// decodeLegacySlasherChunk decodes chunks encoded as raw distances compressed with snappy.
+func decodeLegacySlasherChunk(enc []byte) ([]uint16, error) {
 	chunkBytes, err := snappy.Decode(nil, enc)
 	if err != nil {
 		return nil, err
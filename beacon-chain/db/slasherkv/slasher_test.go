@@ -205,6 +205,25 @@ func TestStore_SlasherChunk_PreventsSavingWrongLength(t *testing.T) {
 	require.ErrorContains(t, "cannot encode empty chunk", err)
 }
 
+func Test_encodeDecodeSlasherChunk(t *testing.T) {
+	chunk := make([]uint16, 16*256)
+	for i := range chunk {
+		chunk[i] = uint16(rand.Intn(65536))
+	}
+	for _, tt := range [][]uint16{{0}, {65535, 0, 65535}, chunk} {
+		enc, err := encodeSlasherChunk(tt)
+		require.NoError(t, err)
+		decoded, err := decodeSlasherChunk(enc)
+		require.NoError(t, err)
+		require.DeepEqual(t, tt, decoded)
+
+		// Chunks encoded as raw distances are still decoded.
+		decoded, err = decodeSlasherChunk(encodeLegacySlasherChunk(tt))
+		require.NoError(t, err)
+		require.DeepEqual(t, tt, decoded)
+	}
+}
+
 func TestStore_ExistingBlockProposals(t *testing.T) {
 	ctx := context.Background()
 	beaconDB := setupDB(t)
@@ -15,6 +15,7 @@ import (
 	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution/types"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
@@ -44,14 +45,39 @@ const (
 	GetPayloadMethodV2 = "engine_getPayloadV2"
 	// ExchangeTransitionConfigurationMethod v1 request string for JSON-RPC.
 	ExchangeTransitionConfigurationMethod = "engine_exchangeTransitionConfigurationV1"
+	// ExchangeCapabilitiesMethod request string for JSON-RPC.
+	ExchangeCapabilitiesMethod = "engine_exchangeCapabilities"
+	// GetPayloadBodiesByHashMethod v1 request string for JSON-RPC.
+	GetPayloadBodiesByHashMethod = "engine_getPayloadBodiesByHashV1"
+	// GetPayloadBodiesByRangeMethod v1 request string for JSON-RPC.
+	GetPayloadBodiesByRangeMethod = "engine_getPayloadBodiesByRangeV1"
 	// ExecutionBlockByHashMethod request string for JSON-RPC.
 	ExecutionBlockByHashMethod = "eth_getBlockByHash"
 	// ExecutionBlockByNumberMethod request string for JSON-RPC.
 	ExecutionBlockByNumberMethod = "eth_getBlockByNumber"
 	// Defines the seconds before timing out engine endpoints with non-block execution semantics.
 	defaultEngineTimeout = time.Second
+	// Defines the time to wait before exchanging capabilities again after a failed exchange.
+	capabilitiesRetryInterval = time.Minute
+	// Defines the maximum number of payload bodies requested from the execution client at once.
+	// The engine API requires execution clients to serve at least 32 bodies per request.
+	payloadBodiesRequestLimit = 32
 )
 
+// supportedEngineEndpoints are the engine API methods advertised to the execution client
+// through engine_exchangeCapabilities.
+var supportedEngineEndpoints = []string{
+	NewPayloadMethod,
+	NewPayloadMethodV2,
+	ForkchoiceUpdatedMethod,
+	ForkchoiceUpdatedMethodV2,
+	GetPayloadMethod,
+	GetPayloadMethodV2,
+	ExchangeTransitionConfigurationMethod,
+	GetPayloadBodiesByHashMethod,
+	GetPayloadBodiesByRangeMethod,
+}
+
 // ForkchoiceUpdatedResponse is the response kind received by the
 // engine_forkchoiceUpdatedV1 endpoint.
 type ForkchoiceUpdatedResponse struct {
@@ -78,7 +104,7 @@ type EngineCaller interface {
 	ForkchoiceUpdated(
 		ctx context.Context, state *pb.ForkchoiceState, attrs payloadattribute.Attributer,
 	) (*pb.PayloadIDBytes, []byte, error)
-	GetPayload(ctx context.Context, payloadId [8]byte, slot prysmType.Slot) (interfaces.ExecutionData, error)
+	GetPayload(ctx context.Context, payloadId [8]byte, slot prysmType.Slot) (interfaces.ExecutionData, *big.Int, error)
 	ExchangeTransitionConfiguration(
 		ctx context.Context, cfg *pb.TransitionConfiguration,
 	) error
@@ -197,8 +223,9 @@ func (s *Service) ForkchoiceUpdated(
 	}
 }
 
-// GetPayload calls the engine_getPayloadVX method via JSON-RPC.
-func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot prysmType.Slot) (interfaces.ExecutionData, error) {
+// GetPayload calls the engine_getPayloadVX method via JSON-RPC. It also returns the value of the
+// block in wei as reported by the execution client, which is nil before capella.
+func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot prysmType.Slot) (interfaces.ExecutionData, *big.Int, error) {
 	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.GetPayload")
 	defer span.End()
 	start := time.Now()
@@ -211,20 +238,28 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot prysmT
 	defer cancel()
 
 	if slots.ToEpoch(slot) >= params.BeaconConfig().CapellaForkEpoch {
-		result := &pb.ExecutionPayloadCapella{}
+		result := &pb.ExecutionPayloadCapellaWithValue{}
 		err := s.rpcClient.CallContext(ctx, result, GetPayloadMethodV2, pb.PayloadIDBytes(payloadId))
 		if err != nil {
-			return nil, handleRPCError(err)
+			return nil, nil, handleRPCError(err)
 		}
-		return blocks.WrappedExecutionPayloadCapella(result)
+		payload, err := blocks.WrappedExecutionPayloadCapella(result.Payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		return payload, result.Value, nil
 	}
 
 	result := &pb.ExecutionPayload{}
 	err := s.rpcClient.CallContext(ctx, result, GetPayloadMethod, pb.PayloadIDBytes(payloadId))
 	if err != nil {
-		return nil, handleRPCError(err)
+		return nil, nil, handleRPCError(err)
 	}
-	return blocks.WrappedExecutionPayload(result)
+	payload, err := blocks.WrappedExecutionPayload(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return payload, nil, nil
 }
 
 // ExchangeTransitionConfiguration calls the engine_exchangeTransitionConfigurationV1 method via JSON-RPC.
@@ -272,6 +307,129 @@ func (s *Service) ExchangeTransitionConfiguration(
 	return nil
 }
 
+// ExchangeCapabilities calls the engine_exchangeCapabilities method via JSON-RPC, advertising the
+// engine API methods supported by the beacon node. The methods supported by the execution client
+// are returned and cached until the next execution client connection.
+func (s *Service) ExchangeCapabilities(ctx context.Context) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.ExchangeCapabilities")
+	defer span.End()
+
+	d := time.Now().Add(defaultEngineTimeout)
+	ctx, cancel := context.WithDeadline(ctx, d)
+	defer cancel()
+	var result []string
+	if err := s.rpcClient.CallContext(ctx, &result, ExchangeCapabilitiesMethod, supportedEngineEndpoints); err != nil {
+		return nil, handleRPCError(err)
+	}
+	s.setCapabilities(result)
+	log.WithField("capabilities", result).Debug("Exchanged capabilities with execution client")
+	return result, nil
+}
+
+// supportsCapability returns true if the execution client advertised support for the given engine
+// API method. Capabilities are exchanged lazily, and execution clients which do not implement
+// engine_exchangeCapabilities are treated as not supporting any optional method. After a failed
+// exchange, no method is deemed supported until the exchange is retried a while later.
+func (s *Service) supportsCapability(ctx context.Context, method string) bool {
+	s.capabilitiesLock.RLock()
+	capabilities, retry := s.capabilities, s.capabilitiesRetry
+	s.capabilitiesLock.RUnlock()
+	if capabilities != nil {
+		return capabilities[method]
+	}
+	if time.Now().Before(retry) {
+		return false
+	}
+	result, err := s.ExchangeCapabilities(ctx)
+	if err != nil {
+		if errors.Is(err, ErrMethodNotFound) {
+			s.setCapabilities([]string{})
+		} else {
+			log.WithError(err).Debug("Could not exchange capabilities with execution client")
+			s.capabilitiesLock.Lock()
+			s.capabilitiesRetry = time.Now().Add(capabilitiesRetryInterval)
+			s.capabilitiesLock.Unlock()
+		}
+		return false
+	}
+	for _, m := range result {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) setCapabilities(methods []string) {
+	capabilities := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		capabilities[m] = true
+	}
+	s.capabilitiesLock.Lock()
+	defer s.capabilitiesLock.Unlock()
+	s.capabilities = capabilities
+}
+
+func (s *Service) resetCapabilities() {
+	s.capabilitiesLock.Lock()
+	defer s.capabilitiesLock.Unlock()
+	s.capabilities = nil
+	s.capabilitiesRetry = time.Time{}
+}
+
+// GetPayloadBodiesByHash calls the engine_getPayloadBodiesByHashV1 method via JSON-RPC. Bodies are
+// returned in the order of the requested hashes, with nil entries for payloads unknown to the
+// execution client.
+func (s *Service) GetPayloadBodiesByHash(ctx context.Context, hashes []common.Hash) ([]*pb.ExecutionPayloadBodyV1, error) {
+	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.GetPayloadBodiesByHash")
+	defer span.End()
+
+	bodies := make([]*pb.ExecutionPayloadBodyV1, 0, len(hashes))
+	for i := 0; i < len(hashes); i += payloadBodiesRequestLimit {
+		end := i + payloadBodiesRequestLimit
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		var result []*pb.ExecutionPayloadBodyV1
+		if err := s.rpcClient.CallContext(ctx, &result, GetPayloadBodiesByHashMethod, hashes[i:end]); err != nil {
+			return nil, handleRPCError(err)
+		}
+		if len(result) != end-i {
+			return nil, fmt.Errorf("requested %d payload bodies by hash, received %d", end-i, len(result))
+		}
+		bodies = append(bodies, result...)
+	}
+	return bodies, nil
+}
+
+// GetPayloadBodiesByRange calls the engine_getPayloadBodiesByRangeV1 method via JSON-RPC. The
+// result may be shorter than the requested count if the range goes past the latest known block.
+func (s *Service) GetPayloadBodiesByRange(ctx context.Context, start, count uint64) ([]*pb.ExecutionPayloadBodyV1, error) {
+	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.GetPayloadBodiesByRange")
+	defer span.End()
+
+	bodies := make([]*pb.ExecutionPayloadBodyV1, 0, count)
+	for i := uint64(0); i < count; i += payloadBodiesRequestLimit {
+		n := count - i
+		if n > payloadBodiesRequestLimit {
+			n = payloadBodiesRequestLimit
+		}
+		var result []*pb.ExecutionPayloadBodyV1
+		err := s.rpcClient.CallContext(ctx, &result, GetPayloadBodiesByRangeMethod, hexutil.Uint64(start+i), hexutil.Uint64(n))
+		if err != nil {
+			return nil, handleRPCError(err)
+		}
+		if uint64(len(result)) > n {
+			return nil, fmt.Errorf("requested %d payload bodies by range, received %d", n, len(result))
+		}
+		bodies = append(bodies, result...)
+		if uint64(len(result)) < n {
+			break
+		}
+	}
+	return bodies, nil
+}
+
 // GetTerminalBlockHash returns the valid terminal block hash based on total difficulty.
 //
 // Spec code:
@@ -390,30 +548,26 @@ func (s *Service) ExecutionBlocksByHashes(ctx context.Context, hashes []common.H
 	numOfHashes := len(hashes)
 	elems := make([]gethRPC.BatchElem, 0, numOfHashes)
 	execBlks := make([]*pb.ExecutionBlock, 0, numOfHashes)
-	errs := make([]error, 0, numOfHashes)
 	if numOfHashes == 0 {
 		return execBlks, nil
 	}
 	for _, h := range hashes {
 		blk := &pb.ExecutionBlock{}
-		err := error(nil)
 		newH := h
 		elems = append(elems, gethRPC.BatchElem{
 			Method: ExecutionBlockByHashMethod,
 			Args:   []interface{}{newH, withTxs},
 			Result: blk,
-			Error:  err,
 		})
 		execBlks = append(execBlks, blk)
-		errs = append(errs, err)
 	}
 	ioErr := s.rpcClient.BatchCall(elems)
 	if ioErr != nil {
 		return nil, ioErr
 	}
-	for _, e := range errs {
-		if e != nil {
-			return nil, handleRPCError(e)
+	for _, e := range elems {
+		if e.Error != nil {
+			return nil, handleRPCError(e.Error)
 		}
 	}
 	return execBlks, nil
@@ -465,22 +619,35 @@ func (s *Service) ReconstructFullBlock(
 		return blocks.BuildSignedBeaconBlockFromExecutionPayload(blindedBlock, payload)
 	}
 
-	executionBlockHash := common.BytesToHash(header.BlockHash())
-	executionBlock, err := s.ExecutionBlockByHash(ctx, executionBlockHash, true /* with txs */)
-	if err != nil {
-		return nil, fmt.Errorf("could not fetch execution block with txs by hash %#x: %v", executionBlockHash, err)
-	}
-	if executionBlock == nil {
-		return nil, fmt.Errorf("received nil execution block for request by hash %#x", executionBlockHash)
-	}
-	if bytes.Equal(executionBlock.Hash.Bytes(), []byte{}) {
-		return nil, EmptyBlockHash
+	if payload := s.storedPayload(ctx, blindedBlock, header); payload != nil {
+		return blocks.BuildSignedBeaconBlockFromExecutionPayload(blindedBlock, payload.Proto())
 	}
 
-	executionBlock.Version = blindedBlock.Version()
-	payload, err := fullPayloadFromExecutionBlock(header, executionBlock)
-	if err != nil {
-		return nil, err
+	executionBlockHash := common.BytesToHash(header.BlockHash())
+	var payload interfaces.ExecutionData
+	if s.supportsCapability(ctx, GetPayloadBodiesByHashMethod) {
+		payloads, err := s.payloadsFromBodies(ctx, []interfaces.ExecutionData{header}, []common.Hash{executionBlockHash})
+		if err != nil {
+			return nil, err
+		}
+		payload = payloads[0]
+	} else {
+		executionBlock, err := s.ExecutionBlockByHash(ctx, executionBlockHash, true /* with txs */)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch execution block with txs by hash %#x: %v", executionBlockHash, err)
+		}
+		if executionBlock == nil {
+			return nil, fmt.Errorf("received nil execution block for request by hash %#x", executionBlockHash)
+		}
+		if bytes.Equal(executionBlock.Hash.Bytes(), []byte{}) {
+			return nil, EmptyBlockHash
+		}
+
+		executionBlock.Version = blindedBlock.Version()
+		payload, err = fullPayloadFromExecutionBlock(header, executionBlock)
+		if err != nil {
+			return nil, err
+		}
 	}
 	fullBlock, err := blocks.BuildSignedBeaconBlockFromExecutionPayload(blindedBlock, payload.Proto())
 	if err != nil {
@@ -499,6 +666,7 @@ func (s *Service) ReconstructFullBellatrixBlockBatch(
 		return []interfaces.SignedBeaconBlock{}, nil
 	}
 	executionHashes := []common.Hash{}
+	executionHeaders := []interfaces.ExecutionData{}
 	validExecPayloads := []int{}
 	zeroExecPayloads := []int{}
 	for i, b := range blindedBlocks {
@@ -523,29 +691,35 @@ func (s *Service) ReconstructFullBellatrixBlockBatch(
 			executionBlockHash := common.BytesToHash(header.BlockHash())
 			validExecPayloads = append(validExecPayloads, i)
 			executionHashes = append(executionHashes, executionBlockHash)
+			executionHeaders = append(executionHeaders, header)
+		}
+	}
+	// Payloads found in the local payload store are not requested from the execution client.
+	payloads := make([]interfaces.ExecutionData, len(validExecPayloads))
+	missingHeaders := []interfaces.ExecutionData{}
+	missingHashes := []common.Hash{}
+	missingPayloads := []int{}
+	for sliceIdx, realIdx := range validExecPayloads {
+		if payload := s.storedPayload(ctx, blindedBlocks[realIdx], executionHeaders[sliceIdx]); payload != nil {
+			payloads[sliceIdx] = payload
+			continue
 		}
+		missingHeaders = append(missingHeaders, executionHeaders[sliceIdx])
+		missingHashes = append(missingHashes, executionHashes[sliceIdx])
+		missingPayloads = append(missingPayloads, sliceIdx)
 	}
-	execBlocks, err := s.ExecutionBlocksByHashes(ctx, executionHashes, true /* with txs*/)
+	retrievedPayloads, err := s.retrievePayloads(ctx, missingHeaders, missingHashes)
 	if err != nil {
-		return nil, fmt.Errorf("could not fetch execution blocks with txs by hash %#x: %v", executionHashes, err)
+		return nil, err
+	}
+	for i, sliceIdx := range missingPayloads {
+		payloads[sliceIdx] = retrievedPayloads[i]
 	}
 
 	// For each valid payload, we reconstruct the full block from it with the
 	// blinded block.
 	for sliceIdx, realIdx := range validExecPayloads {
-		b := execBlocks[sliceIdx]
-		if b == nil {
-			return nil, fmt.Errorf("received nil execution block for request by hash %#x", executionHashes[sliceIdx])
-		}
-		header, err := blindedBlocks[realIdx].Block().Body().Execution()
-		if err != nil {
-			return nil, err
-		}
-		payload, err := fullPayloadFromExecutionBlock(header, b)
-		if err != nil {
-			return nil, err
-		}
-		fullBlock, err := blocks.BuildSignedBeaconBlockFromExecutionPayload(blindedBlocks[realIdx], payload.Proto())
+		fullBlock, err := blocks.BuildSignedBeaconBlockFromExecutionPayload(blindedBlocks[realIdx], payloads[sliceIdx].Proto())
 		if err != nil {
 			return nil, err
 		}
@@ -561,10 +735,173 @@ func (s *Service) ReconstructFullBellatrixBlockBatch(
 		}
 		blindedBlocks[realIdx] = fullBlock
 	}
-	reconstructedExecutionPayloadCount.Add(float64(len(blindedBlocks)))
+	reconstructedExecutionPayloadCount.Add(float64(len(blindedBlocks) - len(validExecPayloads) + len(missingPayloads)))
 	return blindedBlocks, nil
 }
 
+// storedPayload looks up the execution payload of a blinded block in the local payload store of the database,
+// which spares a round-trip to the execution client for recent blocks. It returns nil if the payload is not found.
+func (s *Service) storedPayload(
+	ctx context.Context, blindedBlock interfaces.SignedBeaconBlock, header interfaces.ExecutionData,
+) interfaces.ExecutionData {
+	if features.Get().LocalPayloadStoreEpochs == 0 || s.cfg == nil || s.cfg.beaconDB == nil {
+		return nil
+	}
+	blockRoot, err := blindedBlock.Block().HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Debug("Could not compute block root")
+		return nil
+	}
+	payload, err := s.cfg.beaconDB.ExecutionPayload(ctx, blindedBlock.Block().Slot(), blockRoot)
+	if err != nil {
+		log.WithError(err).Debug("Could not read execution payload from the local payload store")
+	}
+	if payload == nil || payload.IsNil() || !bytes.Equal(payload.BlockHash(), header.BlockHash()) {
+		localPayloadStoreMissCount.Inc()
+		return nil
+	}
+	localPayloadStoreHitCount.Inc()
+	return payload
+}
+
+// retrievePayloads reconstructs full execution payloads from their headers, preferring the payload
+// bodies endpoints of the engine API and falling back to eth_getBlockByHash for execution clients
+// which do not support them.
+func (s *Service) retrievePayloads(
+	ctx context.Context, headers []interfaces.ExecutionData, hashes []common.Hash,
+) ([]interfaces.ExecutionData, error) {
+	if len(headers) == 0 {
+		return []interfaces.ExecutionData{}, nil
+	}
+	if s.supportsCapability(ctx, GetPayloadBodiesByHashMethod) {
+		return s.payloadsFromBodies(ctx, headers, hashes)
+	}
+	return s.payloadsFromExecutionBlocks(ctx, headers, hashes)
+}
+
+// payloadsFromExecutionBlocks reconstructs full execution payloads from their headers by fetching
+// the matching execution blocks with a single batch of eth_getBlockByHash calls.
+func (s *Service) payloadsFromExecutionBlocks(
+	ctx context.Context, headers []interfaces.ExecutionData, hashes []common.Hash,
+) ([]interfaces.ExecutionData, error) {
+	execBlocks, err := s.ExecutionBlocksByHashes(ctx, hashes, true /* with txs*/)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch execution blocks with txs by hash %#x: %v", hashes, err)
+	}
+	payloads := make([]interfaces.ExecutionData, len(headers))
+	for i, header := range headers {
+		b := execBlocks[i]
+		if b == nil {
+			return nil, fmt.Errorf("received nil execution block for request by hash %#x", hashes[i])
+		}
+		payloads[i], err = fullPayloadFromExecutionBlock(header, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return payloads, nil
+}
+
+// payloadsFromBodies reconstructs full execution payloads from their headers and the payload bodies
+// served by the execution client. Consecutive block numbers, as requested during range sync, are
+// fetched with engine_getPayloadBodiesByRangeV1 when supported, and anything else by hash.
+func (s *Service) payloadsFromBodies(
+	ctx context.Context, headers []interfaces.ExecutionData, hashes []common.Hash,
+) ([]interfaces.ExecutionData, error) {
+	var bodies []*pb.ExecutionPayloadBodyV1
+	var err error
+	if consecutiveBlockNumbers(headers) && s.supportsCapability(ctx, GetPayloadBodiesByRangeMethod) {
+		start := headers[0].BlockNumber()
+		bodies, err = s.GetPayloadBodiesByRange(ctx, start, uint64(len(headers)))
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch %d payload bodies by range from block %d: %v", len(headers), start, err)
+		}
+	} else {
+		bodies, err = s.GetPayloadBodiesByHash(ctx, hashes)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch payload bodies by hash %#x: %v", hashes, err)
+		}
+	}
+	payloads := make([]interfaces.ExecutionData, len(headers))
+	for i, header := range headers {
+		if i >= len(bodies) || bodies[i] == nil {
+			return nil, fmt.Errorf("received nil payload body for request by hash %#x", hashes[i])
+		}
+		payloads[i], err = fullPayloadFromPayloadBody(header, bodies[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return payloads, nil
+}
+
+func consecutiveBlockNumbers(headers []interfaces.ExecutionData) bool {
+	if len(headers) < 2 {
+		return false
+	}
+	for i := 1; i < len(headers); i++ {
+		if headers[i].BlockNumber() != headers[i-1].BlockNumber()+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func fullPayloadFromPayloadBody(
+	header interfaces.ExecutionData, body *pb.ExecutionPayloadBodyV1,
+) (interfaces.ExecutionData, error) {
+	if header.IsNil() || body == nil {
+		return nil, errors.New("execution payload header and body cannot be nil")
+	}
+	txs := make([][]byte, len(body.Transactions))
+	for i, tx := range body.Transactions {
+		txs[i] = tx
+	}
+
+	switch header.Proto().(type) {
+	case *pb.ExecutionPayloadHeader:
+		return blocks.WrappedExecutionPayload(&pb.ExecutionPayload{
+			ParentHash:    header.ParentHash(),
+			FeeRecipient:  header.FeeRecipient(),
+			StateRoot:     header.StateRoot(),
+			ReceiptsRoot:  header.ReceiptsRoot(),
+			LogsBloom:     header.LogsBloom(),
+			PrevRandao:    header.PrevRandao(),
+			BlockNumber:   header.BlockNumber(),
+			GasLimit:      header.GasLimit(),
+			GasUsed:       header.GasUsed(),
+			Timestamp:     header.Timestamp(),
+			ExtraData:     header.ExtraData(),
+			BaseFeePerGas: header.BaseFeePerGas(),
+			BlockHash:     header.BlockHash(),
+			Transactions:  txs,
+		})
+	case *pb.ExecutionPayloadHeaderCapella:
+		if body.Withdrawals == nil {
+			return nil, fmt.Errorf("payload body for capella block %#x has no withdrawals", header.BlockHash())
+		}
+		return blocks.WrappedExecutionPayloadCapella(&pb.ExecutionPayloadCapella{
+			ParentHash:    header.ParentHash(),
+			FeeRecipient:  header.FeeRecipient(),
+			StateRoot:     header.StateRoot(),
+			ReceiptsRoot:  header.ReceiptsRoot(),
+			LogsBloom:     header.LogsBloom(),
+			PrevRandao:    header.PrevRandao(),
+			BlockNumber:   header.BlockNumber(),
+			GasLimit:      header.GasLimit(),
+			GasUsed:       header.GasUsed(),
+			Timestamp:     header.Timestamp(),
+			ExtraData:     header.ExtraData(),
+			BaseFeePerGas: header.BaseFeePerGas(),
+			BlockHash:     header.BlockHash(),
+			Transactions:  txs,
+			Withdrawals:   body.Withdrawals,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported execution payload header type %T", header.Proto())
+	}
+}
+
 func fullPayloadFromExecutionBlock(
 	header interfaces.ExecutionData, block *pb.ExecutionBlock,
 ) (interfaces.ExecutionData, error) {
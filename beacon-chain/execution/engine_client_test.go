@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -19,12 +20,15 @@ import (
 	gethRPC "github.com/ethereum/go-ethereum/rpc"
 	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
+	dbutil "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
 	mocks "github.com/prysmaticlabs/prysm/v3/beacon-chain/execution/testing"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	payloadattribute "github.com/prysmaticlabs/prysm/v3/consensus-types/payload-attribute"
+	prysmType "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	pb "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
 	"github.com/prysmaticlabs/prysm/v3/runtime/version"
@@ -73,7 +77,7 @@ func TestClient_IPC(t *testing.T) {
 		want, ok := fix["ExecutionPayload"].(*pb.ExecutionPayload)
 		require.Equal(t, true, ok)
 		payloadId := [8]byte{1}
-		resp, err := srv.GetPayload(ctx, payloadId, 1)
+		resp, _, err := srv.GetPayload(ctx, payloadId, 1)
 		require.NoError(t, err)
 		resPb, err := resp.PbBellatrix()
 		require.NoError(t, err)
@@ -83,7 +87,7 @@ func TestClient_IPC(t *testing.T) {
 		want, ok := fix["ExecutionPayloadCapella"].(*pb.ExecutionPayloadCapella)
 		require.Equal(t, true, ok)
 		payloadId := [8]byte{1}
-		resp, err := srv.GetPayload(ctx, payloadId, params.BeaconConfig().SlotsPerEpoch)
+		resp, _, err := srv.GetPayload(ctx, payloadId, params.BeaconConfig().SlotsPerEpoch)
 		require.NoError(t, err)
 		resPb, err := resp.PbCapella()
 		require.NoError(t, err)
@@ -203,7 +207,7 @@ func TestClient_HTTP(t *testing.T) {
 		client.rpcClient = rpcClient
 
 		// We call the RPC method via HTTP and expect a proper result.
-		resp, err := client.GetPayload(ctx, payloadId, 1)
+		resp, _, err := client.GetPayload(ctx, payloadId, 1)
 		require.NoError(t, err)
 		pb, err := resp.PbBellatrix()
 		require.NoError(t, err)
@@ -247,7 +251,7 @@ func TestClient_HTTP(t *testing.T) {
 		client.rpcClient = rpcClient
 
 		// We call the RPC method via HTTP and expect a proper result.
-		resp, err := client.GetPayload(ctx, payloadId, params.BeaconConfig().SlotsPerEpoch)
+		resp, _, err := client.GetPayload(ctx, payloadId, params.BeaconConfig().SlotsPerEpoch)
 		require.NoError(t, err)
 		pb, err := resp.PbCapella()
 		require.NoError(t, err)
@@ -724,6 +728,8 @@ func TestReconstructFullBellatrixBlock(t *testing.T) {
 
 		service := &Service{}
 		service.rpcClient = rpcClient
+		// The execution client does not support the payload bodies endpoints.
+		service.setCapabilities([]string{})
 		blindedBlock := util.NewBlindedBeaconBlockBellatrix()
 
 		blindedBlock.Block.Body.ExecutionPayloadHeader = header
@@ -856,6 +862,8 @@ func TestReconstructFullBellatrixBlockBatch(t *testing.T) {
 
 		service := &Service{}
 		service.rpcClient = rpcClient
+		// The execution client does not support the payload bodies endpoints.
+		service.setCapabilities([]string{})
 		blindedBlock := util.NewBlindedBeaconBlockBellatrix()
 
 		blindedBlock.Block.Body.ExecutionPayloadHeader = header
@@ -881,6 +889,387 @@ func TestReconstructFullBellatrixBlockBatch(t *testing.T) {
 	})
 }
 
+func TestGetPayload_BlockValue(t *testing.T) {
+	ctx := context.Background()
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	want, ok := fixtures()["ExecutionPayloadCapella"].(*pb.ExecutionPayloadCapella)
+	require.Equal(t, true, ok)
+	srv := newEngineMethodServer(t, func(method string, _ []json.RawMessage) interface{} {
+		require.Equal(t, GetPayloadMethodV2, method)
+		return map[string]interface{}{
+			"executionPayload": want,
+			"blockValue":       "0x7b",
+		}
+	})
+	defer srv.Close()
+	rpcClient, err := rpc.DialHTTP(srv.URL)
+	require.NoError(t, err)
+	defer rpcClient.Close()
+
+	service := &Service{}
+	service.rpcClient = rpcClient
+	payload, value, err := service.GetPayload(ctx, [8]byte{1}, params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, err)
+	require.Equal(t, "123", value.String())
+	got, err := payload.PbCapella()
+	require.NoError(t, err)
+	require.DeepEqual(t, want, got)
+}
+
+func TestExchangeCapabilities(t *testing.T) {
+	ctx := context.Background()
+	t.Run("caches supported methods", func(t *testing.T) {
+		calls := 0
+		srv := newEngineMethodServer(t, func(method string, params []json.RawMessage) interface{} {
+			calls++
+			require.Equal(t, ExchangeCapabilitiesMethod, method)
+			var advertised []string
+			require.NoError(t, json.Unmarshal(params[0], &advertised))
+			require.DeepEqual(t, supportedEngineEndpoints, advertised)
+			return []string{NewPayloadMethod, GetPayloadBodiesByHashMethod}
+		})
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+
+		service := &Service{}
+		service.rpcClient = rpcClient
+		require.Equal(t, true, service.supportsCapability(ctx, GetPayloadBodiesByHashMethod))
+		require.Equal(t, false, service.supportsCapability(ctx, GetPayloadBodiesByRangeMethod))
+		require.Equal(t, 1, calls)
+
+		service.resetCapabilities()
+		require.Equal(t, true, service.supportsCapability(ctx, NewPayloadMethod))
+		require.Equal(t, 2, calls)
+	})
+	t.Run("method not found", func(t *testing.T) {
+		calls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Content-Type", "application/json")
+			defer func() {
+				require.NoError(t, r.Body.Close())
+			}()
+			respJSON := map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      1,
+				"error": map[string]interface{}{
+					"code":    -32601,
+					"message": "the method engine_exchangeCapabilities does not exist/is not available",
+				},
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(respJSON))
+		}))
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+
+		service := &Service{}
+		service.rpcClient = rpcClient
+		_, err = service.ExchangeCapabilities(ctx)
+		require.ErrorIs(t, err, ErrMethodNotFound)
+		require.Equal(t, false, service.supportsCapability(ctx, GetPayloadBodiesByHashMethod))
+		require.Equal(t, false, service.supportsCapability(ctx, GetPayloadBodiesByRangeMethod))
+		require.Equal(t, 2, calls)
+	})
+	t.Run("failed exchange is retried later", func(t *testing.T) {
+		calls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+
+		service := &Service{}
+		service.rpcClient = rpcClient
+		require.Equal(t, false, service.supportsCapability(ctx, GetPayloadBodiesByHashMethod))
+		require.Equal(t, false, service.supportsCapability(ctx, GetPayloadBodiesByRangeMethod))
+		require.Equal(t, 1, calls)
+
+		service.capabilitiesRetry = time.Now()
+		require.Equal(t, false, service.supportsCapability(ctx, GetPayloadBodiesByHashMethod))
+		require.Equal(t, 2, calls)
+
+		// A reconnection to the execution client retries right away.
+		service.resetCapabilities()
+		require.Equal(t, false, service.supportsCapability(ctx, GetPayloadBodiesByHashMethod))
+		require.Equal(t, 3, calls)
+	})
+}
+
+func TestReconstructFullBlock_PayloadBodies(t *testing.T) {
+	ctx := context.Background()
+	payloads := []*pb.ExecutionPayloadCapella{
+		payloadCapellaFixture(10, [][]byte{{0x01}, {0x02, 0x03}}),
+		payloadCapellaFixture(11, [][]byte{{0x04}}),
+	}
+	bodies := make([]*pb.ExecutionPayloadBodyV1, len(payloads))
+	blindedBlocks := make([]interfaces.SignedBeaconBlock, len(payloads))
+	for i, p := range payloads {
+		txs := make([]hexutil.Bytes, len(p.Transactions))
+		for j, tx := range p.Transactions {
+			txs[j] = tx
+		}
+		bodies[i] = &pb.ExecutionPayloadBodyV1{Transactions: txs, Withdrawals: p.Withdrawals}
+
+		wrappedPayload, err := blocks.WrappedExecutionPayloadCapella(p)
+		require.NoError(t, err)
+		header, err := blocks.PayloadToHeaderCapella(wrappedPayload)
+		require.NoError(t, err)
+		blindedBlock := util.NewBlindedBeaconBlockCapella()
+		blindedBlock.Block.Body.ExecutionPayloadHeader = header
+		blindedBlocks[i], err = blocks.NewSignedBeaconBlock(blindedBlock)
+		require.NoError(t, err)
+	}
+
+	t.Run("single block by hash", func(t *testing.T) {
+		srv := newEngineMethodServer(t, func(method string, params []json.RawMessage) interface{} {
+			switch method {
+			case ExchangeCapabilitiesMethod:
+				return []string{GetPayloadBodiesByHashMethod, GetPayloadBodiesByRangeMethod}
+			case GetPayloadBodiesByHashMethod:
+				var hashes []common.Hash
+				require.NoError(t, json.Unmarshal(params[0], &hashes))
+				require.DeepEqual(t, []common.Hash{common.BytesToHash(payloads[0].BlockHash)}, hashes)
+				return bodies[:1]
+			default:
+				t.Fatalf("unexpected method %s", method)
+				return nil
+			}
+		})
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+
+		service := &Service{}
+		service.rpcClient = rpcClient
+		reconstructed, err := service.ReconstructFullBlock(ctx, blindedBlocks[0])
+		require.NoError(t, err)
+		got, err := reconstructed.Block().Body().Execution()
+		require.NoError(t, err)
+		require.DeepEqual(t, payloads[0], got.Proto())
+	})
+	t.Run("consecutive blocks by range", func(t *testing.T) {
+		srv := newEngineMethodServer(t, func(method string, params []json.RawMessage) interface{} {
+			switch method {
+			case ExchangeCapabilitiesMethod:
+				return []string{GetPayloadBodiesByHashMethod, GetPayloadBodiesByRangeMethod}
+			case GetPayloadBodiesByRangeMethod:
+				var start, count hexutil.Uint64
+				require.NoError(t, json.Unmarshal(params[0], &start))
+				require.NoError(t, json.Unmarshal(params[1], &count))
+				require.Equal(t, hexutil.Uint64(10), start)
+				require.Equal(t, hexutil.Uint64(2), count)
+				return bodies
+			default:
+				t.Fatalf("unexpected method %s", method)
+				return nil
+			}
+		})
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+
+		service := &Service{}
+		service.rpcClient = rpcClient
+		blks := make([]interfaces.SignedBeaconBlock, len(blindedBlocks))
+		for i, b := range blindedBlocks {
+			blks[i], err = b.Copy()
+			require.NoError(t, err)
+		}
+		reconstructed, err := service.ReconstructFullBellatrixBlockBatch(ctx, blks)
+		require.NoError(t, err)
+		for i, b := range reconstructed {
+			got, err := b.Block().Body().Execution()
+			require.NoError(t, err)
+			require.DeepEqual(t, payloads[i], got.Proto())
+		}
+	})
+	t.Run("unknown payload", func(t *testing.T) {
+		srv := newEngineMethodServer(t, func(method string, params []json.RawMessage) interface{} {
+			switch method {
+			case ExchangeCapabilitiesMethod:
+				return []string{GetPayloadBodiesByHashMethod}
+			case GetPayloadBodiesByHashMethod:
+				return []*pb.ExecutionPayloadBodyV1{nil, nil}
+			default:
+				t.Fatalf("unexpected method %s", method)
+				return nil
+			}
+		})
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+
+		service := &Service{}
+		service.rpcClient = rpcClient
+		blks := make([]interfaces.SignedBeaconBlock, len(blindedBlocks))
+		for i, b := range blindedBlocks {
+			blks[i], err = b.Copy()
+			require.NoError(t, err)
+		}
+		_, err = service.ReconstructFullBellatrixBlockBatch(ctx, blks)
+		require.ErrorContains(t, "received nil payload body", err)
+	})
+}
+
+func Test_fullPayloadFromPayloadBody(t *testing.T) {
+	p := payloadCapellaFixture(1, [][]byte{{0x01}})
+	wrappedPayload, err := blocks.WrappedExecutionPayloadCapella(p)
+	require.NoError(t, err)
+	capellaHeader, err := blocks.PayloadToHeaderCapella(wrappedPayload)
+	require.NoError(t, err)
+	wrappedCapellaHeader, err := blocks.WrappedExecutionPayloadHeaderCapella(capellaHeader)
+	require.NoError(t, err)
+
+	_, err = fullPayloadFromPayloadBody(wrappedCapellaHeader, &pb.ExecutionPayloadBodyV1{Transactions: []hexutil.Bytes{{0x01}}})
+	require.ErrorContains(t, "has no withdrawals", err)
+
+	got, err := fullPayloadFromPayloadBody(wrappedCapellaHeader, &pb.ExecutionPayloadBodyV1{
+		Transactions: []hexutil.Bytes{{0x01}},
+		Withdrawals:  p.Withdrawals,
+	})
+	require.NoError(t, err)
+	require.DeepEqual(t, p, got.Proto())
+}
+
+// newEngineMethodServer starts a JSON-RPC server which answers single requests with the result
+// of the given handler for the requested method.
+func newEngineMethodServer(t *testing.T, handler func(method string, params []json.RawMessage) interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		defer func() {
+			require.NoError(t, r.Body.Close())
+		}()
+		req := struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		respJSON := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  handler(req.Method, req.Params),
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(respJSON))
+	}))
+}
+
+func TestReconstructFullBlock_LocalPayloadStore(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableOnlyBlindedBeaconBlocks: true,
+		LocalPayloadStoreEpochs:       1,
+	})
+	defer resetCfg()
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+
+	payloads := []*pb.ExecutionPayloadCapella{
+		payloadCapellaFixture(10, [][]byte{{0x01}, {0x02, 0x03}}),
+		payloadCapellaFixture(11, [][]byte{{0x04}}),
+	}
+	blindedBlocks := make([]interfaces.SignedBeaconBlock, len(payloads))
+	for i, p := range payloads {
+		b := util.NewBeaconBlockCapella()
+		b.Block.Slot = prysmType.Slot(i + 1)
+		b.Block.Body.ExecutionPayload = p
+		fullBlock, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		blindedBlocks[i], err = fullBlock.ToBlinded()
+		require.NoError(t, err)
+		// Only the payload of the first block is kept in the local payload store.
+		if i == 0 {
+			require.NoError(t, beaconDB.SaveBlock(ctx, fullBlock))
+		}
+	}
+
+	t.Run("single block from the store", func(t *testing.T) {
+		service := &Service{cfg: &config{beaconDB: beaconDB}}
+		reconstructed, err := service.ReconstructFullBlock(ctx, blindedBlocks[0])
+		require.NoError(t, err)
+		got, err := reconstructed.Block().Body().Execution()
+		require.NoError(t, err)
+		require.DeepEqual(t, payloads[0], got.Proto())
+	})
+	t.Run("batch only requests missing payloads", func(t *testing.T) {
+		srv := newEngineMethodServer(t, func(method string, params []json.RawMessage) interface{} {
+			switch method {
+			case ExchangeCapabilitiesMethod:
+				return []string{GetPayloadBodiesByHashMethod}
+			case GetPayloadBodiesByHashMethod:
+				var hashes []common.Hash
+				require.NoError(t, json.Unmarshal(params[0], &hashes))
+				require.DeepEqual(t, []common.Hash{common.BytesToHash(payloads[1].BlockHash)}, hashes)
+				txs := make([]hexutil.Bytes, len(payloads[1].Transactions))
+				for j, tx := range payloads[1].Transactions {
+					txs[j] = tx
+				}
+				return []*pb.ExecutionPayloadBodyV1{{Transactions: txs, Withdrawals: payloads[1].Withdrawals}}
+			default:
+				t.Fatalf("unexpected method %s", method)
+				return nil
+			}
+		})
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+
+		service := &Service{cfg: &config{beaconDB: beaconDB}}
+		service.rpcClient = rpcClient
+		blks := make([]interfaces.SignedBeaconBlock, len(blindedBlocks))
+		for i, b := range blindedBlocks {
+			blks[i], err = b.Copy()
+			require.NoError(t, err)
+		}
+		reconstructed, err := service.ReconstructFullBellatrixBlockBatch(ctx, blks)
+		require.NoError(t, err)
+		for i, b := range reconstructed {
+			got, err := b.Block().Body().Execution()
+			require.NoError(t, err)
+			require.DeepEqual(t, payloads[i], got.Proto())
+		}
+	})
+}
+
+func payloadCapellaFixture(blockNumber uint64, txs [][]byte) *pb.ExecutionPayloadCapella {
+	return &pb.ExecutionPayloadCapella{
+		ParentHash:    bytesutil.PadTo([]byte("parent"), fieldparams.RootLength),
+		FeeRecipient:  bytesutil.PadTo([]byte("feeRecipient"), fieldparams.FeeRecipientLength),
+		StateRoot:     bytesutil.PadTo([]byte("stateRoot"), fieldparams.RootLength),
+		ReceiptsRoot:  bytesutil.PadTo([]byte("receiptsRoot"), fieldparams.RootLength),
+		LogsBloom:     bytesutil.PadTo([]byte("logs"), fieldparams.LogsBloomLength),
+		PrevRandao:    bytesutil.PadTo([]byte("randao"), fieldparams.RootLength),
+		BlockNumber:   blockNumber,
+		GasLimit:      2,
+		GasUsed:       1,
+		Timestamp:     3,
+		ExtraData:     []byte("extra"),
+		BaseFeePerGas: bytesutil.PadTo([]byte{1}, fieldparams.RootLength),
+		BlockHash:     bytesutil.PadTo(bytesutil.Bytes8(blockNumber), fieldparams.RootLength),
+		Transactions:  txs,
+		Withdrawals: []*pb.Withdrawal{{
+			Index:          blockNumber,
+			ValidatorIndex: 1,
+			Address:        bytesutil.PadTo([]byte("address"), fieldparams.FeeRecipientLength),
+			Amount:         100,
+		}},
+	}
+}
+
 func TestServer_getPowBlockHashAtTerminalTotalDifficulty(t *testing.T) {
 	tests := []struct {
 		name                  string
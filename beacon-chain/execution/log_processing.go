@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
 	coreState "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution/types"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	statenative "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	contracts "github.com/prysmaticlabs/prysm/v3/contracts/deposit"
@@ -338,10 +339,37 @@ func (s *Service) processPastLogs(ctx context.Context) error {
 	}
 	if fState != nil && !fState.IsNil() && fState.Eth1DepositIndex() > 0 {
 		s.cfg.depositCache.PrunePendingDeposits(ctx, int64(fState.Eth1DepositIndex())) // lint:ignore uintcast -- Deposit index should not exceed int64 in your lifetime.
+		if err := s.finalizeDeposits(ctx, fState); err != nil {
+			log.WithError(err).Warn("Could not finalize deposits of the finalized state")
+		}
 	}
 	return nil
 }
 
+// finalizeDeposits finalizes the deposits included in the given finalized state. When a node
+// pauses for some time and starts again, the deposits to finalize accumulate. We finalize them
+// here, once the execution block of the finalized eth1 data can be retrieved, rather than on the
+// next finalized checkpoint.
+func (s *Service) finalizeDeposits(ctx context.Context, fState state.BeaconState) error {
+	eth1Data := fState.Eth1Data()
+	// The execution block of the eth1 data only matches the deposit
+	// tree once all of its deposits have been included.
+	if eth1Data == nil || fState.Eth1DepositIndex() != eth1Data.DepositCount {
+		return nil
+	}
+	executionHash := common.BytesToHash(eth1Data.BlockHash)
+	exists, executionNumber, err := s.BlockExists(ctx, executionHash)
+	if err != nil {
+		return errors.Wrap(err, "could not get execution block of finalized eth1 data")
+	}
+	if !exists || executionNumber == nil {
+		return errors.Errorf("execution block %#x of finalized eth1 data not found", executionHash)
+	}
+	// The deposit index in the state is always the index of the next deposit to be included.
+	lastIndex := int64(fState.Eth1DepositIndex()) - 1 // lint:ignore uintcast -- deposit index will not exceed int64 in your lifetime.
+	return s.cfg.depositCache.InsertFinalizedDeposits(ctx, lastIndex, executionHash, executionNumber.Uint64())
+}
+
 func (s *Service) processBlockInBatch(ctx context.Context, currentBlockNum uint64, latestFollowHeight uint64, batchSize uint64, additiveFactor uint64, logCount uint64, headersMap map[uint64]*types.HeaderInfo) (uint64, uint64, error) {
 	// Batch request the desired headers and store them in a
 	// map for quick access.
@@ -555,12 +583,46 @@ func (s *Service) savePowchainData(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	eth1Data := &ethpb.ETH1ChainData{
-		CurrentEth1Data:   s.latestEth1Data,
-		ChainstartData:    s.chainStartData,
-		BeaconState:       pbState, // I promise not to mutate it!
-		Trie:              s.depositTrie.ToProto(),
-		DepositContainers: s.cfg.depositCache.AllDepositContainers(ctx),
+	eth1Data, err := s.executionChainData(ctx, pbState) // I promise not to mutate it!
+	if err != nil {
+		return err
 	}
 	return s.cfg.beaconDB.SaveExecutionChainData(ctx, eth1Data)
 }
+
+// executionChainData assembles the powchain data to persist. Finalized deposits are pruned from the
+// deposit cache and are persisted as part of the EIP-4881 deposit snapshot, along with their deposit
+// data so that the public key index of the cache survives restarts. The deposit tree of the service
+// is finalized alongside, so that it does not hold every deposit in memory either.
+func (s *Service) executionChainData(ctx context.Context, pbState *ethpb.BeaconState) (*ethpb.ETH1ChainData, error) {
+	// Containers are retrieved before the snapshot, so that deposits finalized in
+	// between overlap with the snapshot rather than being missing from both.
+	ctrs := s.cfg.depositCache.AllDepositContainers(ctx)
+	snapshot, err := s.cfg.depositCache.FinalizedDeposits(ctx).Deposits.GetSnapshot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get deposit snapshot")
+	}
+	finalizedCount := snapshot.DepositCount()
+	// Deposits finalized after the containers were retrieved are already part of them.
+	var finalizedCtrs []*ethpb.DepositContainer
+	for _, c := range s.cfg.depositCache.FinalizedDepositContainers(ctx) {
+		if uint64(c.Index) >= finalizedCount || (len(ctrs) > 0 && c.Index >= ctrs[0].Index) {
+			break
+		}
+		finalizedCtrs = append(finalizedCtrs, c)
+	}
+	ctrs = append(finalizedCtrs, ctrs...)
+	if finalizedCount > 0 && uint64(s.depositTrie.NumOfItems()) >= finalizedCount {
+		lastIndex := int64(finalizedCount) - 1 // lint:ignore uintcast -- deposit count will not exceed int64 in your lifetime.
+		if err := s.depositTrie.Finalize(lastIndex, snapshot.ExecutionBlockHash(), snapshot.ExecutionBlockHeight()); err != nil {
+			return nil, errors.Wrap(err, "could not finalize deposit tree")
+		}
+	}
+	return &ethpb.ETH1ChainData{
+		CurrentEth1Data:   s.latestEth1Data,
+		ChainstartData:    s.chainStartData,
+		BeaconState:       pbState,
+		DepositContainers: ctrs,
+		DepositSnapshot:   snapshot.ToProto(),
+	}, nil
+}
@@ -71,4 +71,12 @@ var (
 		Name: "reconstructed_execution_payload_count",
 		Help: "Count the number of execution payloads that are reconstructed using JSON-RPC from payload headers",
 	})
+	localPayloadStoreHitCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "local_payload_store_hit_count",
+		Help: "Count the number of execution payloads of blinded blocks found in the local payload store",
+	})
+	localPayloadStoreMissCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "local_payload_store_miss_count",
+		Help: "Count the number of execution payloads of blinded blocks missing from the local payload store",
+	})
 )
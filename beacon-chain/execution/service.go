@@ -21,6 +21,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositsnapshot"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
@@ -30,7 +31,6 @@ import (
 	native "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
-	"github.com/prysmaticlabs/prysm/v3/container/trie"
 	contracts "github.com/prysmaticlabs/prysm/v3/contracts/deposit"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/clientstats"
@@ -149,22 +149,20 @@ type Service struct {
 	headerCache             *headerCache // cache to store block hash/block height.
 	latestEth1Data          *ethpb.LatestETH1Data
 	depositContractCaller   *contracts.DepositContractCaller
-	depositTrie             *trie.SparseMerkleTrie
+	depositTrie             *depositsnapshot.DepositTree
 	chainStartData          *ethpb.ChainStartData
 	lastReceivedMerkleIndex int64 // Keeps track of the last received index to prevent log spam.
 	runError                error
 	preGenesisState         state.BeaconState
+	capabilitiesLock        sync.RWMutex
+	capabilities            map[string]bool // Engine API methods supported by the execution client, nil until exchanged.
+	capabilitiesRetry       time.Time       // Time before which a failed capabilities exchange is not retried.
 }
 
 // NewService sets up a new instance with an ethclient when given a web3 endpoint as a string in the config.
 func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
-	depositTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-	if err != nil {
-		cancel()
-		return nil, errors.Wrap(err, "could not set up deposit trie")
-	}
 	genState, err := transition.EmptyGenesisState()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not set up genesis state")
@@ -185,7 +183,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 			LastRequestedBlock: 0,
 		},
 		headerCache: newHeaderCache(),
-		depositTrie: depositTrie,
+		depositTrie: depositsnapshot.NewDepositTree(),
 		chainStartData: &ethpb.ChainStartData{
 			Eth1Data:           &ethpb.Eth1Data{},
 			ChainstartDeposits: make([]*ethpb.Deposit, 0),
@@ -332,11 +330,19 @@ func (s *Service) followedBlockHeight(ctx context.Context) (uint64, error) {
 	return blk.Number.Uint64(), nil
 }
 
-func (s *Service) initDepositCaches(ctx context.Context, ctrs []*ethpb.DepositContainer) error {
+func (s *Service) initDepositCaches(ctx context.Context, ctrs []*ethpb.DepositContainer, snapshot *ethpb.DepositSnapshot) error {
+	if len(ctrs) > 0 {
+		s.cfg.depositCache.InsertDepositContainers(ctx, ctrs)
+	}
+	// Finalized deposits are only persisted as part of the deposit snapshot.
+	if snapshot != nil {
+		if err := s.cfg.depositCache.InsertDepositSnapshot(ctx, snapshot); err != nil {
+			return errors.Wrap(err, "could not insert deposit snapshot")
+		}
+	}
 	if len(ctrs) == 0 {
 		return nil
 	}
-	s.cfg.depositCache.InsertDepositContainers(ctx, ctrs)
 	if !s.chainStartData.Chainstarted {
 		// Do not add to pending cache if no genesis state exists.
 		validDepositsCount.Add(float64(s.preGenesisState.Eth1DepositIndex()))
@@ -362,15 +368,12 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*ethpb.DepositCo
 		// Set deposit index to the one in the current archived state.
 		currIndex = fState.Eth1DepositIndex()
 
-		// When a node pauses for some time and starts again, the deposits to finalize
-		// accumulates. We finalize them here before we are ready to receive a block.
-		// Otherwise, the first few blocks will be slower to compute as we will
-		// hold the lock and be busy finalizing the deposits.
 		// The deposit index in the state is always the index of the next deposit
 		// to be included (rather than the last one to be processed). This was most likely
 		// done as the state cannot represent signed integers.
+		// Deposits are finalized once past logs are processed, as finalizing them requires
+		// the execution block of the finalized eth1 data.
 		actualIndex := int64(currIndex) - 1 // lint:ignore uintcast -- deposit index will not exceed int64 in your lifetime.
-		s.cfg.depositCache.InsertFinalizedDeposits(ctx, actualIndex)
 
 		// Deposit proofs are only used during state transition and can be safely removed to save space.
 		if err = s.cfg.depositCache.PruneProofs(ctx, actualIndex); err != nil {
@@ -378,12 +381,13 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*ethpb.DepositCo
 		}
 	}
 	validDepositsCount.Add(float64(currIndex))
-	// Only add pending deposits if the container slice length
-	// is more than the current index in state.
-	if uint64(len(ctrs)) > currIndex {
-		for _, c := range ctrs[currIndex:] {
-			s.cfg.depositCache.InsertPendingDeposit(ctx, c.Deposit, c.Eth1BlockHeight, c.Index, bytesutil.ToBytes32(c.DepositRoot))
+	// Only add pending deposits which have not been
+	// included in the current state yet.
+	for _, c := range ctrs {
+		if uint64(c.Index) < currIndex {
+			continue
 		}
+		s.cfg.depositCache.InsertPendingDeposit(ctx, c.Deposit, c.Eth1BlockHeight, c.Index, bytesutil.ToBytes32(c.DepositRoot))
 	}
 	return nil
 }
@@ -737,9 +741,9 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *ethpb.ET
 		return nil
 	}
 	var err error
-	s.depositTrie, err = trie.CreateTrieFromProto(eth1DataInDB.Trie)
+	s.depositTrie, err = depositTreeFromChainData(eth1DataInDB)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "could not rebuild deposit tree")
 	}
 	s.chainStartData = eth1DataInDB.ChainstartData
 	if !reflect.ValueOf(eth1DataInDB.BeaconState).IsZero() {
@@ -751,15 +755,49 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *ethpb.ET
 	s.latestEth1Data = eth1DataInDB.CurrentEth1Data
 	numOfItems := s.depositTrie.NumOfItems()
 	s.lastReceivedMerkleIndex = int64(numOfItems - 1)
-	if err := s.initDepositCaches(ctx, eth1DataInDB.DepositContainers); err != nil {
+	if err := s.initDepositCaches(ctx, eth1DataInDB.DepositContainers, eth1DataInDB.DepositSnapshot); err != nil {
 		return errors.Wrap(err, "could not initialize caches")
 	}
 	return nil
 }
 
+// depositTreeFromChainData rebuilds the deposit tree from the persisted deposit snapshot and
+// the deposits which were not finalized yet. Data saved before deposit snapshots were introduced
+// holds every deposit, in which case the tree is rebuilt from all of them.
+func depositTreeFromChainData(eth1Data *ethpb.ETH1ChainData) (*depositsnapshot.DepositTree, error) {
+	depositTree := depositsnapshot.NewDepositTree()
+	if eth1Data.DepositSnapshot != nil {
+		var err error
+		depositTree, err = depositsnapshot.DepositTreeFromProto(eth1Data.DepositSnapshot)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ctrs := make([]*ethpb.DepositContainer, len(eth1Data.DepositContainers))
+	copy(ctrs, eth1Data.DepositContainers)
+	sort.Slice(ctrs, func(i, j int) bool {
+		return ctrs[i].Index < ctrs[j].Index
+	})
+	for _, c := range ctrs {
+		// Containers may overlap with the snapshot if deposits were
+		// finalized while the data was being saved.
+		if c.Index < int64(depositTree.NumOfItems()) {
+			continue
+		}
+		depHash, err := c.Deposit.Data.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash deposit data")
+		}
+		if err := depositTree.Insert(depHash[:], int(c.Index)); err != nil {
+			return nil, errors.Wrapf(err, "could not insert deposit %d", c.Index)
+		}
+	}
+	return depositTree, nil
+}
+
 // Validates that all deposit containers are valid and have their relevant indices
-// in order.
-func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
+// in order, starting at or before the number of deposits in the deposit snapshot.
+func validateDepositContainers(ctrs []*ethpb.DepositContainer, finalizedCount int64) bool {
 	ctrLen := len(ctrs)
 	// Exit for empty containers.
 	if ctrLen == 0 {
@@ -769,7 +807,11 @@ func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
 	sort.Slice(ctrs, func(i, j int) bool {
 		return ctrs[i].Index < ctrs[j].Index
 	})
-	startIndex := int64(0)
+	startIndex := ctrs[0].Index
+	if startIndex > finalizedCount {
+		log.Info("Recovering missing deposit containers, node is re-requesting missing deposit data")
+		return false
+	}
 	for _, c := range ctrs {
 		if c.Index != startIndex {
 			log.Info("Recovering missing deposit containers, node is re-requesting missing deposit data")
@@ -795,7 +837,8 @@ func (s *Service) ensureValidPowchainData(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "unable to retrieve eth1 data")
 	}
-	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !validateDepositContainers(eth1Data.DepositContainers) {
+	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted ||
+		!validateDepositContainers(eth1Data.DepositContainers, int64(eth1Data.DepositSnapshot.GetDepositCount())) { // lint:ignore uintcast -- deposit count will not exceed int64 in your lifetime.
 		pbState, err := native.ProtobufBeaconStatePhase0(s.preGenesisState.ToProtoUnsafe())
 		if err != nil {
 			return err
@@ -807,12 +850,9 @@ func (s *Service) ensureValidPowchainData(ctx context.Context) error {
 			Eth1Data:           genState.Eth1Data(),
 			ChainstartDeposits: make([]*ethpb.Deposit, 0),
 		}
-		eth1Data = &ethpb.ETH1ChainData{
-			CurrentEth1Data:   s.latestEth1Data,
-			ChainstartData:    s.chainStartData,
-			BeaconState:       pbState,
-			Trie:              s.depositTrie.ToProto(),
-			DepositContainers: s.cfg.depositCache.AllDepositContainers(ctx),
+		eth1Data, err = s.executionChainData(ctx, pbState)
+		if err != nil {
+			return err
 		}
 		return s.cfg.beaconDB.SaveExecutionChainData(ctx, eth1Data)
 	}
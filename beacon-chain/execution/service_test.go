@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositsnapshot"
 	dbutil "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
 	mockExecution "github.com/prysmaticlabs/prysm/v3/beacon-chain/execution/testing"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution/types"
@@ -349,7 +350,7 @@ func TestInitDepositCache_OK(t *testing.T) {
 	var err error
 	s.cfg.depositCache, err = depositcache.New()
 	require.NoError(t, err)
-	require.NoError(t, s.initDepositCaches(context.Background(), ctrs))
+	require.NoError(t, s.initDepositCaches(context.Background(), ctrs, nil))
 
 	require.Equal(t, 0, len(s.cfg.depositCache.PendingContainers(context.Background(), nil)))
 
@@ -360,7 +361,7 @@ func TestInitDepositCache_OK(t *testing.T) {
 	require.NoError(t, s.cfg.beaconDB.SaveGenesisBlockRoot(context.Background(), blockRootA))
 	require.NoError(t, s.cfg.beaconDB.SaveState(context.Background(), emptyState, blockRootA))
 	s.chainStartData.Chainstarted = true
-	require.NoError(t, s.initDepositCaches(context.Background(), ctrs))
+	require.NoError(t, s.initDepositCaches(context.Background(), ctrs, nil))
 	require.Equal(t, 3, len(s.cfg.depositCache.PendingContainers(context.Background(), nil)))
 }
 
@@ -410,7 +411,7 @@ func TestInitDepositCacheWithFinalization_OK(t *testing.T) {
 	var err error
 	s.cfg.depositCache, err = depositcache.New()
 	require.NoError(t, err)
-	require.NoError(t, s.initDepositCaches(context.Background(), ctrs))
+	require.NoError(t, s.initDepositCaches(context.Background(), ctrs, nil))
 
 	require.Equal(t, 0, len(s.cfg.depositCache.PendingContainers(context.Background(), nil)))
 
@@ -432,10 +433,91 @@ func TestInitDepositCacheWithFinalization_OK(t *testing.T) {
 	s.cfg.finalizedStateAtStartup = emptyState
 
 	s.chainStartData.Chainstarted = true
-	require.NoError(t, s.initDepositCaches(context.Background(), ctrs))
+	require.NoError(t, s.initDepositCaches(context.Background(), ctrs, nil))
+
+	// Deposits are finalized once the execution block of the finalized eth1 data is known.
+	executionHash := common.Hash{'e', 't', 'h', '1'}
+	s.headerCache = newHeaderCache()
+	require.NoError(t, s.headerCache.AddHeader(&types.HeaderInfo{Number: big.NewInt(6), Hash: executionHash}))
+	require.NoError(t, emptyState.SetEth1Data(&ethpb.Eth1Data{DepositCount: 3, BlockHash: executionHash[:], DepositRoot: make([]byte, 32)}))
+	require.NoError(t, s.finalizeDeposits(ctx, emptyState))
+
 	fDeposits := s.cfg.depositCache.FinalizedDeposits(ctx)
 	deps := s.cfg.depositCache.NonFinalizedDeposits(context.Background(), fDeposits.MerkleTrieIndex, nil)
 	assert.Equal(t, 0, len(deps))
+	assert.Equal(t, 0, len(s.cfg.depositCache.AllDepositContainers(ctx)))
+	snapshot, err := fDeposits.Deposits.GetSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), snapshot.DepositCount())
+	assert.Equal(t, [32]byte(executionHash), snapshot.ExecutionBlockHash())
+	assert.Equal(t, uint64(6), snapshot.ExecutionBlockHeight())
+}
+
+func TestExecutionChainData_RestoresFromDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	ctrs := make([]*ethpb.DepositContainer, 0)
+	cache, err := depositcache.New()
+	require.NoError(t, err)
+	s := &Service{
+		chainStartData: &ethpb.ChainStartData{Chainstarted: true},
+		latestEth1Data: &ethpb.LatestETH1Data{},
+		depositTrie:    depositsnapshot.NewDepositTree(),
+		cfg:            &config{depositCache: cache},
+	}
+	for i := 0; i < 5; i++ {
+		ctr := &ethpb.DepositContainer{
+			Index:           int64(i),
+			Eth1BlockHeight: uint64(10 + i),
+			Deposit: &ethpb.Deposit{
+				Data: &ethpb.Deposit_Data{
+					PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+					WithdrawalCredentials: make([]byte, 32),
+					Signature:             make([]byte, 96),
+				},
+			},
+		}
+		depHash, err := ctr.Deposit.Data.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, s.depositTrie.Insert(depHash[:], i))
+		root, err := s.depositTrie.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, cache.InsertDeposit(ctx, ctr.Deposit, ctr.Eth1BlockHeight, ctr.Index, root))
+		ctrs = append(ctrs, ctr)
+	}
+	require.NoError(t, cache.InsertFinalizedDeposits(ctx, 2, common.Hash{'a'}, 12))
+
+	chainData, err := s.executionChainData(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), chainData.DepositSnapshot.DepositCount)
+	// Finalized deposits are persisted without their proofs for the public key index of the cache.
+	assert.Equal(t, 5, len(chainData.DepositContainers))
+	assert.Equal(t, true, validateDepositContainers(chainData.DepositContainers, 3))
+	assert.Equal(t, uint64(3), s.depositTrie.FinalizedCount())
+
+	wantRoot, err := s.depositTrie.HashTreeRoot()
+	require.NoError(t, err)
+	restored, err := depositTreeFromChainData(chainData)
+	require.NoError(t, err)
+	gotRoot, err := restored.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+	assert.Equal(t, 5, restored.NumOfItems())
+
+	restoredCache, err := depositcache.New()
+	require.NoError(t, err)
+	restoredCache.InsertDepositContainers(ctx, chainData.DepositContainers)
+	require.NoError(t, restoredCache.InsertDepositSnapshot(ctx, chainData.DepositSnapshot))
+	assert.Equal(t, 2, len(restoredCache.AllDepositContainers(ctx)))
+	_, blk := restoredCache.DepositByPubkey(ctx, bytesutil.PadTo([]byte{1}, 48))
+	require.NotNil(t, blk)
+	assert.Equal(t, uint64(11), blk.Uint64())
+
+	// Data saved before deposit snapshots were introduced holds every deposit.
+	legacy, err := depositTreeFromChainData(&ethpb.ETH1ChainData{DepositContainers: ctrs})
+	require.NoError(t, err)
+	gotRoot, err = legacy.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
 }
 
 func TestNewService_EarliestVotingBlock(t *testing.T) {
@@ -695,8 +777,19 @@ func TestService_ValidateDepositContainers(t *testing.T) {
 	}
 
 	for _, test := range tt {
-		assert.Equal(t, test.expectedRes, validateDepositContainers(test.ctrsFunc()))
+		assert.Equal(t, test.expectedRes, validateDepositContainers(test.ctrsFunc(), 0))
+	}
+
+	finalizedCtrs := func(start int) []*ethpb.DepositContainer {
+		ctrs := make([]*ethpb.DepositContainer, 0)
+		for i := start; i < 10; i++ {
+			ctrs = append(ctrs, &ethpb.DepositContainer{Index: int64(i), Eth1BlockHeight: uint64(i + 10)})
+		}
+		return ctrs
 	}
+	assert.Equal(t, true, validateDepositContainers(finalizedCtrs(5), 5), "containers following the snapshot")
+	assert.Equal(t, true, validateDepositContainers(finalizedCtrs(3), 5), "containers overlapping the snapshot")
+	assert.Equal(t, false, validateDepositContainers(finalizedCtrs(6), 5), "containers missing after the snapshot")
 }
 
 func TestETH1Endpoints(t *testing.T) {
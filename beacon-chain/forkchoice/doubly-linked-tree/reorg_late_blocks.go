@@ -0,0 +1,111 @@
+package doublylinkedtree
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// arrivedEarly returns whether the node was inserted in forkchoice before the
+// attestation deadline of its slot, that is, whether the block was timely.
+func (n *Node) arrivedEarly(genesisTime uint64) bool {
+	slotStart := genesisTime + uint64(n.slot)*params.BeaconConfig().SecondsPerSlot
+	if n.timestamp < slotStart {
+		return true
+	}
+	boostThreshold := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
+	return n.timestamp-slotStart < boostThreshold
+}
+
+// isReorgCandidate returns whether the given head node may be orphaned by the
+// proposer of proposalSlot, building instead on top of its parent. A head is a
+// candidate when all of the following hold:
+//   - the head block arrived late,
+//   - the proposal slot is not the first slot of an epoch, so that the shuffling is stable,
+//   - the chain has been finalizing recently,
+//   - the head is the only block between its parent and the proposal slot,
+//   - the head does not have better unrealized justification than its parent,
+//   - the head weight is below the re-org threshold,
+//   - the parent weight is above the parent threshold.
+//
+// This function requires a lock on the store nodes.
+func (f *ForkChoice) isReorgCandidate(head *Node, proposalSlot types.Slot) bool {
+	cfg := params.BeaconConfig()
+	if head == nil || head.parent == nil {
+		return false
+	}
+	if head.arrivedEarly(f.store.genesisTime) {
+		return false
+	}
+	if proposalSlot%cfg.SlotsPerEpoch == 0 {
+		return false
+	}
+	f.store.checkpointsLock.RLock()
+	finalizedEpoch := f.store.finalizedCheckpoint.Epoch
+	f.store.checkpointsLock.RUnlock()
+	if slots.ToEpoch(proposalSlot) > finalizedEpoch+cfg.ReorgMaxEpochsSinceFinalization {
+		return false
+	}
+	parent := head.parent
+	if head.slot != parent.slot+1 || proposalSlot != head.slot+1 {
+		return false
+	}
+	if head.unrealizedJustifiedEpoch != parent.unrealizedJustifiedEpoch {
+		return false
+	}
+	if head.weight*100 >= f.store.committeeBalance*cfg.ReorgWeightThreshold {
+		return false
+	}
+	return parent.weight*100 > f.store.committeeBalance*cfg.ReorgParentWeightThreshold
+}
+
+// ShouldOverrideFCU returns whether the current forkchoice head is a late and
+// weak block that the proposer of the next slot may orphan. In that case the
+// caller should not notify the execution engine of the new head, so that it
+// keeps building a payload on top of the parent block.
+//
+// This function is meant to be called right after the head has been updated
+// with an incoming block of the current slot, and only when the node serves
+// the proposer of the next slot.
+func (f *ForkChoice) ShouldOverrideFCU() bool {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	head := f.store.headNode
+	if head == nil {
+		return false
+	}
+	currentSlot := slots.CurrentSlot(f.store.genesisTime)
+	if head.slot != currentSlot {
+		return false
+	}
+	return f.isReorgCandidate(head, currentSlot+1)
+}
+
+// GetProposerHead returns the block root that the proposer of the current slot
+// should use as parent root. This is the forkchoice head, unless the head is a
+// late and weak block from the previous slot and the proposer is on time, in
+// which case it is the parent of the head.
+func (f *ForkChoice) GetProposerHead() [32]byte {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	head := f.store.headNode
+	if head == nil {
+		return [32]byte{}
+	}
+	currentSlot := slots.CurrentSlot(f.store.genesisTime)
+	if !f.isReorgCandidate(head, currentSlot) {
+		return head.root
+	}
+	// Only re-org if the proposal happens early in the slot, otherwise the
+	// block may not get the proposer boost needed to beat the orphaned head.
+	reorgCutoff := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot / 2
+	proposalStart := slots.StartTime(f.store.genesisTime, currentSlot)
+	if time.Since(proposalStart) >= time.Duration(reorgCutoff)*time.Second {
+		return head.root
+	}
+	return head.parent.root
+}
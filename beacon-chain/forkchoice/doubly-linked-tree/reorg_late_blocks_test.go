@@ -0,0 +1,104 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+// setupReorgCandidate inserts a parent block at parentSlot and a late, weak
+// head block right after it, with the current slot set to currentSlot.
+func setupReorgCandidate(t *testing.T, parentSlot, currentSlot types.Slot) (*ForkChoice, *Node) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	f.store.genesisTime = uint64(time.Now().Unix()) - uint64(currentSlot)*secondsPerSlot
+
+	st, root, err := prepareForkchoiceState(ctx, parentSlot, [32]byte{'a'}, params.BeaconConfig().ZeroHash, [32]byte{'A'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	st, root, err = prepareForkchoiceState(ctx, parentSlot+1, [32]byte{'b'}, [32]byte{'a'}, [32]byte{'B'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+
+	head := f.store.nodeByRoot[[32]byte{'b'}]
+	head.timestamp = f.store.genesisTime + uint64(head.slot)*secondsPerSlot + secondsPerSlot/2
+	f.store.headNode = head
+	f.store.committeeBalance = 100
+	head.weight = 10
+	head.parent.weight = 200
+	return f, head
+}
+
+func TestForkChoice_ShouldOverrideFCU(t *testing.T) {
+	t.Run("late and weak head", func(t *testing.T) {
+		f, _ := setupReorgCandidate(t, 1, 2)
+		require.Equal(t, true, f.ShouldOverrideFCU())
+	})
+	t.Run("head arrived early", func(t *testing.T) {
+		f, head := setupReorgCandidate(t, 1, 2)
+		head.timestamp = f.store.genesisTime + uint64(head.slot)*params.BeaconConfig().SecondsPerSlot
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("head is not from the current slot", func(t *testing.T) {
+		f, _ := setupReorgCandidate(t, 1, 3)
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("head is strong", func(t *testing.T) {
+		f, head := setupReorgCandidate(t, 1, 2)
+		head.weight = 20
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("parent is weak", func(t *testing.T) {
+		f, head := setupReorgCandidate(t, 1, 2)
+		head.parent.weight = 160
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("proposal at epoch boundary", func(t *testing.T) {
+		slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+		f, _ := setupReorgCandidate(t, slotsPerEpoch-2, slotsPerEpoch-1)
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("not finalizing", func(t *testing.T) {
+		slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+		maxEpochs := params.BeaconConfig().ReorgMaxEpochsSinceFinalization
+		parentSlot := types.Slot(maxEpochs+1)*slotsPerEpoch + 1
+		f, _ := setupReorgCandidate(t, parentSlot, parentSlot+1)
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("better unrealized justification", func(t *testing.T) {
+		f, head := setupReorgCandidate(t, 1, 2)
+		head.unrealizedJustifiedEpoch = 1
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+}
+
+func TestForkChoice_GetProposerHead(t *testing.T) {
+	t.Run("late and weak head", func(t *testing.T) {
+		f, _ := setupReorgCandidate(t, 1, 3)
+		require.Equal(t, [32]byte{'a'}, f.GetProposerHead())
+	})
+	t.Run("proposing late", func(t *testing.T) {
+		f, _ := setupReorgCandidate(t, 1, 3)
+		f.store.genesisTime -= params.BeaconConfig().SecondsPerSlot / 2
+		require.Equal(t, [32]byte{'b'}, f.GetProposerHead())
+	})
+	t.Run("head arrived early", func(t *testing.T) {
+		f, head := setupReorgCandidate(t, 1, 3)
+		head.timestamp = f.store.genesisTime + uint64(head.slot)*params.BeaconConfig().SecondsPerSlot
+		require.Equal(t, [32]byte{'b'}, f.GetProposerHead())
+	})
+	t.Run("head is strong", func(t *testing.T) {
+		f, head := setupReorgCandidate(t, 1, 3)
+		head.weight = 20
+		require.Equal(t, [32]byte{'b'}, f.GetProposerHead())
+	})
+	t.Run("skipped slot", func(t *testing.T) {
+		f, _ := setupReorgCandidate(t, 1, 4)
+		require.Equal(t, [32]byte{'b'}, f.GetProposerHead())
+	})
+}
@@ -31,6 +31,8 @@ type HeadRetriever interface {
 	Tips() ([][32]byte, []types.Slot)
 	IsOptimistic(root [32]byte) (bool, error)
 	AllTipsAreInvalid() bool
+	ShouldOverrideFCU() bool
+	GetProposerHead() [32]byte
 }
 
 // BlockProcessor processes the block that's used for accounting fork choice.
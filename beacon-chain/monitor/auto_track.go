@@ -0,0 +1,155 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// activityBufferSize defines the number of observed validator activities buffered until the
+// monitor processes them. Activities observed while the buffer is full are dropped.
+const activityBufferSize = 1024
+
+// ActivityObserver is notified of the validators using the validator RPCs of the beacon node, which
+// the validator monitor starts tracking when auto-tracking is enabled.
+type ActivityObserver interface {
+	ObserveValidators(indices []types.ValidatorIndex)
+	ObserveValidatorPubkeys(pubkeys [][]byte)
+	ObserveAttestation(att *ethpb.Attestation)
+}
+
+var _ ActivityObserver = (*Service)(nil)
+
+// observedActivity is either a list of validator indices, or a list of public keys or an attestation
+// whose validator indices are resolved by the monitor.
+type observedActivity struct {
+	indices []types.ValidatorIndex
+	pubkeys [][fieldparams.BLSPubkeyLength]byte
+	att     *ethpb.Attestation
+}
+
+// ObserveValidators notifies the monitor that the given validators requested duties or registered
+// through the validator RPCs. It does not block.
+func (s *Service) ObserveValidators(indices []types.ValidatorIndex) {
+	if !s.config.AutoTrack || len(indices) == 0 {
+		return
+	}
+	s.observe(&observedActivity{indices: indices})
+}
+
+// ObserveValidatorPubkeys notifies the monitor that the validators with the given public keys
+// registered through the validator RPCs. It does not block.
+func (s *Service) ObserveValidatorPubkeys(pubkeys [][]byte) {
+	if !s.config.AutoTrack || len(pubkeys) == 0 {
+		return
+	}
+	keys := make([][fieldparams.BLSPubkeyLength]byte, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		keys[i] = bytesutil.ToBytes48(pubkey)
+	}
+	s.observe(&observedActivity{pubkeys: keys})
+}
+
+// ObserveAttestation notifies the monitor that the given attestation was published through the
+// validator RPCs. It does not block.
+func (s *Service) ObserveAttestation(att *ethpb.Attestation) {
+	if !s.config.AutoTrack || att == nil || att.Data == nil {
+		return
+	}
+	s.observe(&observedActivity{att: att})
+}
+
+func (s *Service) observe(a *observedActivity) {
+	select {
+	case s.activityChannel <- a:
+	default:
+		log.Debug("Dropping observed validator activity, monitor is busy")
+	}
+}
+
+// processActivity records the activity of the given validators, to start tracking them with the next
+// processed block if they are not tracked yet.
+func (s *Service) processActivity(ctx context.Context, a *observedActivity) {
+	indices := a.indices
+	for _, pubkey := range a.pubkeys {
+		if idx, ok := s.config.HeadFetcher.HeadPublicKeyToValidatorIndex(pubkey); ok {
+			indices = append(indices, idx)
+		}
+	}
+	if a.att != nil {
+		root := bytesutil.ToBytes32(a.att.Data.BeaconBlockRoot)
+		st := s.config.StateGen.StateByRootIfCachedNoCopy(root)
+		if st == nil {
+			log.WithField("BeaconBlockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(root[:]))).Debug(
+				"Skipping observed attestation due to state not found in cache")
+			return
+		}
+		attesting, err := attestingIndices(ctx, st, a.att)
+		if err != nil {
+			log.WithError(err).Error("Could not get attesting indices")
+			return
+		}
+		indices = make([]types.ValidatorIndex, len(attesting))
+		for i, idx := range attesting {
+			indices[i] = types.ValidatorIndex(idx)
+		}
+	}
+	epoch := slots.ToEpoch(s.config.HeadFetcher.HeadSlot())
+
+	s.Lock()
+	defer s.Unlock()
+	if s.autoTracked == nil {
+		s.autoTracked = make(map[types.ValidatorIndex]types.Epoch)
+	}
+	for _, idx := range indices {
+		if _, ok := s.autoTracked[idx]; ok {
+			s.autoTracked[idx] = epoch
+			continue
+		}
+		if s.trackedIndex(idx) {
+			// Validators tracked from the command line or the API are never untracked automatically.
+			continue
+		}
+		if len(s.autoTracked) >= s.config.AutoTrackLimit {
+			autoTrackSkippedCounter.Inc()
+			continue
+		}
+		s.autoTracked[idx] = epoch
+	}
+	autoTrackedValidatorsGauge.Set(float64(len(s.autoTracked)))
+}
+
+// updateAutoTracked starts tracking the validators recently observed, and stops tracking the
+// validators which were not observed for more than the configured number of epochs.
+// It assumes the caller holds the service Lock.
+func (s *Service) updateAutoTracked(st state.BeaconState, epoch types.Epoch) {
+	for idx, lastSeen := range s.autoTracked {
+		if lastSeen+s.config.AutoTrackInactiveEpochs < epoch {
+			if s.trackedIndex(idx) {
+				s.untrackValidator(idx)
+				log.WithField("ValidatorIndex", idx).Debug("Stopped tracking inactive validator")
+			} else {
+				delete(s.autoTracked, idx)
+			}
+			continue
+		}
+		if s.trackedIndex(idx) {
+			continue
+		}
+		if uint64(idx) >= uint64(st.NumValidators()) {
+			delete(s.autoTracked, idx)
+			continue
+		}
+		s.TrackedValidators[idx] = true
+		s.initializeValidatorPerformance(st, idx, epoch)
+		s.updateSyncCommitteeIndices(st, idx)
+		log.WithField("ValidatorIndex", idx).Debug("Started tracking active validator")
+	}
+	autoTrackedValidatorsGauge.Set(float64(len(s.autoTracked)))
+}
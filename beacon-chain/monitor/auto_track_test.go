@@ -0,0 +1,98 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestObserveValidators(t *testing.T) {
+	s := setupService(t)
+	s.activityChannel = make(chan *observedActivity, 1)
+
+	s.ObserveValidators([]types.ValidatorIndex{3})
+	require.Equal(t, 0, len(s.activityChannel))
+
+	s.config.AutoTrack = true
+	s.ObserveValidators([]types.ValidatorIndex{3})
+	require.Equal(t, 1, len(s.activityChannel))
+	// Activities are dropped rather than blocking when the buffer is full.
+	s.ObserveValidators([]types.ValidatorIndex{4})
+	require.Equal(t, 1, len(s.activityChannel))
+	require.DeepEqual(t, []types.ValidatorIndex{3}, (<-s.activityChannel).indices)
+
+	s.ObserveValidatorPubkeys([][]byte{{0x01}})
+	require.Equal(t, 1, len(s.activityChannel))
+	require.Equal(t, 1, len((<-s.activityChannel).pubkeys))
+}
+
+func TestAutoTrack(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+	s.config.AutoTrack = true
+	s.config.AutoTrackLimit = 2
+	s.config.AutoTrackInactiveEpochs = 1
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	require.NoError(t, err)
+
+	// Validator 1 is tracked from the command line, and validator 22 exceeds the limit.
+	s.processActivity(ctx, &observedActivity{indices: []types.ValidatorIndex{1, 20, 21, 22}})
+	require.DeepEqual(t, map[types.ValidatorIndex]types.Epoch{20: 0, 21: 0}, s.autoTracked)
+	require.DeepEqual(t, []types.ValidatorIndex{1, 2, 12, 15}, s.TrackedValidatorIndices())
+
+	s.Lock()
+	s.updateAutoTracked(st, 0)
+	s.Unlock()
+	require.DeepEqual(t, []types.ValidatorIndex{1, 2, 12, 15, 20, 21}, s.TrackedValidatorIndices())
+	require.Equal(t, uint64(32000000000), s.latestPerformance[20].balance)
+
+	// Validators which are not seen for more than the inactivity period are no longer tracked.
+	s.Lock()
+	s.autoTracked[21] = 1
+	s.updateAutoTracked(st, 2)
+	s.Unlock()
+	require.DeepEqual(t, []types.ValidatorIndex{1, 2, 12, 15, 21}, s.TrackedValidatorIndices())
+	_, ok := s.latestPerformance[20]
+	require.Equal(t, false, ok)
+
+	// Validators added explicitly are no longer untracked automatically.
+	require.NoError(t, s.AddTrackedValidators(ctx, []types.ValidatorIndex{21}))
+	s.Lock()
+	s.updateAutoTracked(st, 10)
+	s.Unlock()
+	require.DeepEqual(t, []types.ValidatorIndex{1, 2, 12, 15, 21}, s.TrackedValidatorIndices())
+	require.Equal(t, 0, len(s.autoTracked))
+}
+
+func TestAutoTrack_Attestation(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+	s.config.AutoTrack = true
+	s.config.AutoTrackLimit = 10
+	s.TrackedValidators = map[types.ValidatorIndex]bool{}
+	state, _ := util.DeterministicGenesisStateAltair(t, 256)
+
+	var root [32]byte
+	copy(root[:], "hello-world")
+	att := &ethpb.Attestation{
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			CommitteeIndex:  0,
+			BeaconBlockRoot: root[:],
+			Source:          &ethpb.Checkpoint{Epoch: 0, Root: root[:]},
+			Target:          &ethpb.Checkpoint{Epoch: 1, Root: root[:]},
+		},
+		AggregationBits: bitfield.Bitlist{0b11, 0b1},
+	}
+	s.processActivity(ctx, &observedActivity{att: att})
+	require.Equal(t, 0, len(s.autoTracked))
+
+	require.NoError(t, s.config.StateGen.SaveState(ctx, root, state))
+	s.processActivity(ctx, &observedActivity{att: att})
+	require.DeepEqual(t, map[types.ValidatorIndex]types.Epoch{2: 0, 12: 0}, s.autoTracked)
+}
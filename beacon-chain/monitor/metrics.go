@@ -1,8 +1,11 @@
 package monitor
 
 import (
+	"fmt"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/sirupsen/logrus"
 )
 
@@ -90,4 +93,34 @@ var (
 			"validator_index",
 		},
 	)
+	// autoTrackedValidatorsGauge used to track the number of validators tracked
+	// automatically from their use of the validator RPCs
+	autoTrackedValidatorsGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "auto_tracked_validators",
+			Help:      "Number of validators tracked automatically from their use of the validator RPCs",
+		},
+	)
+	// autoTrackSkippedCounter used to track validators not tracked automatically
+	// as the limit of auto-tracked validators is reached
+	autoTrackSkippedCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "auto_track_skipped_total",
+			Help:      "Number of validator activities not tracked as the limit of auto-tracked validators is reached",
+		},
+	)
 )
+
+// deleteValidatorMetrics removes the metrics of the given validator, once it is no longer tracked.
+func deleteValidatorMetrics(idx types.ValidatorIndex) {
+	label := fmt.Sprintf("%d", idx)
+	inclusionSlotGauge.DeleteLabelValues(label)
+	timelyHeadCounter.DeleteLabelValues(label)
+	timelyTargetCounter.DeleteLabelValues(label)
+	timelySourceCounter.DeleteLabelValues(label)
+	proposedSlotsCounter.DeleteLabelValues(label)
+	aggregationCounter.DeleteLabelValues(label)
+	syncCommitteeContributionCounter.DeleteLabelValues(label)
+}
@@ -0,0 +1,243 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// PerformanceHistoryEpochs defines the number of epochs of per-epoch performance kept in memory.
+const PerformanceHistoryEpochs = 64
+
+// Tracker inspects and modifies the validators tracked by the validator monitor at runtime.
+type Tracker interface {
+	TrackedValidatorIndices() []types.ValidatorIndex
+	AddTrackedValidators(ctx context.Context, indices []types.ValidatorIndex) error
+	RemoveTrackedValidators(indices []types.ValidatorIndex)
+	EpochPerformanceHistory() []EpochPerformance
+}
+
+var _ Tracker = (*Service)(nil)
+
+// EpochPerformance is the performance of a tracked validator during an epoch. The attestation fields
+// describe the attestation of the validator for the epoch, which may be included in a block of the next
+// epoch, while proposals, sync committee contributions and balance changes are those of the blocks of the epoch.
+type EpochPerformance struct {
+	ValidatorIndex             types.ValidatorIndex `json:"validator_index"`
+	Epoch                      types.Epoch          `json:"epoch"`
+	AttestationIncluded        bool                 `json:"attestation_included"`
+	AttestedSlot               types.Slot           `json:"attested_slot"`
+	InclusionSlot              types.Slot           `json:"inclusion_slot"`
+	InclusionDistance          types.Slot           `json:"inclusion_distance"`
+	CorrectSource              bool                 `json:"correct_source"`
+	CorrectTarget              bool                 `json:"correct_target"`
+	CorrectHead                bool                 `json:"correct_head"`
+	ProposedBlocks             uint64               `json:"proposed_blocks"`
+	SyncCommitteeExpected      uint64               `json:"sync_committee_expected"`
+	SyncCommitteeContributions uint64               `json:"sync_committee_contributions"`
+	Balance                    uint64               `json:"balance"`
+	BalanceChange              int64                `json:"balance_change"`
+}
+
+// epochPerformanceFor returns the performance of the given validator during the given epoch,
+// creating it if needed. It assumes the caller holds the service Lock.
+func (s *Service) epochPerformanceFor(idx types.ValidatorIndex, epoch types.Epoch) *EpochPerformance {
+	if s.epochPerformance == nil {
+		s.epochPerformance = make(map[types.Epoch]map[types.ValidatorIndex]*EpochPerformance)
+	}
+	perValidator, ok := s.epochPerformance[epoch]
+	if !ok {
+		perValidator = make(map[types.ValidatorIndex]*EpochPerformance)
+		s.epochPerformance[epoch] = perValidator
+	}
+	p, ok := perValidator[idx]
+	if !ok {
+		p = &EpochPerformance{
+			ValidatorIndex: idx,
+			Epoch:          epoch,
+			Balance:        s.latestPerformance[idx].balance,
+		}
+		perValidator[idx] = p
+	}
+	return p
+}
+
+// recordBalance records the balance of the given validator observed in a block of the given epoch.
+// It assumes the caller holds the service Lock.
+func (s *Service) recordBalance(idx types.ValidatorIndex, epoch types.Epoch, balance uint64, balanceChg int64) {
+	p := s.epochPerformanceFor(idx, epoch)
+	p.Balance = balance
+	p.BalanceChange += balanceChg
+}
+
+// completeEpochs completes the performance of the tracked validators for the epochs whose attestations
+// can no longer be included in blocks of the given epoch, exports it and prunes the epochs beyond
+// the performance history. It assumes the caller holds the service Lock.
+func (s *Service) completeEpochs(currEpoch types.Epoch) {
+	if currEpoch > PerformanceHistoryEpochs && s.nextEpochToComplete < currEpoch-PerformanceHistoryEpochs {
+		s.nextEpochToComplete = currEpoch - PerformanceHistoryEpochs
+	}
+	for ; s.nextEpochToComplete+2 <= currEpoch; s.nextEpochToComplete++ {
+		completed := make([]*EpochPerformance, 0, len(s.TrackedValidators))
+		for idx := range s.TrackedValidators {
+			completed = append(completed, s.epochPerformanceFor(idx, s.nextEpochToComplete))
+		}
+		sort.Slice(completed, func(i, j int) bool {
+			return completed[i].ValidatorIndex < completed[j].ValidatorIndex
+		})
+		s.exportEpochPerformance(completed)
+	}
+	for epoch := range s.epochPerformance {
+		if epoch+PerformanceHistoryEpochs < currEpoch {
+			delete(s.epochPerformance, epoch)
+		}
+	}
+}
+
+// exportEpochPerformance appends the given performance to the export file, one JSON object per line.
+// It assumes the caller holds the service Lock.
+func (s *Service) exportEpochPerformance(performance []*EpochPerformance) {
+	if s.exportFile == nil {
+		return
+	}
+	enc := json.NewEncoder(s.exportFile)
+	for _, p := range performance {
+		if err := enc.Encode(p); err != nil {
+			log.WithError(err).Error("Could not export validator performance")
+			return
+		}
+	}
+}
+
+// openExportFile opens the file the performance of completed epochs is appended to, if configured.
+func (s *Service) openExportFile() error {
+	if s.config.ExportPath == "" {
+		return nil
+	}
+	f, err := os.OpenFile(s.config.ExportPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return errors.Wrap(err, "could not open validator performance export file")
+	}
+	s.exportFile = f
+	return nil
+}
+
+// EpochPerformanceHistory returns the recorded per-epoch performance of the tracked validators, sorted
+// by epoch and validator index. The performance of the epochs which are not complete yet is included.
+func (s *Service) EpochPerformanceHistory() []EpochPerformance {
+	s.RLock()
+	defer s.RUnlock()
+	history := make([]EpochPerformance, 0)
+	for _, perValidator := range s.epochPerformance {
+		for _, p := range perValidator {
+			history = append(history, *p)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].Epoch != history[j].Epoch {
+			return history[i].Epoch < history[j].Epoch
+		}
+		return history[i].ValidatorIndex < history[j].ValidatorIndex
+	})
+	return history
+}
+
+// TrackedValidatorIndices returns the sorted indices of the tracked validators.
+func (s *Service) TrackedValidatorIndices() []types.ValidatorIndex {
+	s.RLock()
+	defer s.RUnlock()
+	tracked := make([]types.ValidatorIndex, 0, len(s.TrackedValidators))
+	for idx := range s.TrackedValidators {
+		tracked = append(tracked, idx)
+	}
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
+	return tracked
+}
+
+// AddTrackedValidators starts tracking the given validator indices, which must exist in the head state.
+func (s *Service) AddTrackedValidators(ctx context.Context, indices []types.ValidatorIndex) error {
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if st == nil || st.IsNil() {
+		return errors.New("head state is nil")
+	}
+	for _, idx := range indices {
+		if uint64(idx) >= uint64(st.NumValidators()) {
+			return fmt.Errorf("validator index %d does not exist in head state", idx)
+		}
+	}
+	epoch := slots.ToEpoch(st.Slot())
+
+	s.Lock()
+	defer s.Unlock()
+	for _, idx := range indices {
+		// Validators added explicitly are no longer subject to auto-tracking.
+		if _, ok := s.autoTracked[idx]; ok {
+			delete(s.autoTracked, idx)
+			autoTrackedValidatorsGauge.Set(float64(len(s.autoTracked)))
+		}
+		if s.trackedIndex(idx) {
+			continue
+		}
+		s.TrackedValidators[idx] = true
+		s.initializeValidatorPerformance(st, idx, epoch)
+		s.updateSyncCommitteeIndices(st, idx)
+		log.WithField("ValidatorIndex", idx).Info("Started tracking validator")
+	}
+	return nil
+}
+
+// RemoveTrackedValidators stops tracking the given validator indices, and removes their metrics.
+// Their recorded per-epoch performance is kept until it falls out of the performance history.
+func (s *Service) RemoveTrackedValidators(indices []types.ValidatorIndex) {
+	s.Lock()
+	defer s.Unlock()
+	for _, idx := range indices {
+		if s.trackedIndex(idx) {
+			s.untrackValidator(idx)
+			log.WithField("ValidatorIndex", idx).Info("Stopped tracking validator")
+		}
+	}
+}
+
+// untrackValidator stops tracking the given validator and removes its metrics.
+// It assumes the caller holds the service Lock.
+func (s *Service) untrackValidator(idx types.ValidatorIndex) {
+	delete(s.TrackedValidators, idx)
+	delete(s.latestPerformance, idx)
+	delete(s.aggregatedPerformance, idx)
+	delete(s.trackedSyncCommitteeIndices, idx)
+	if _, ok := s.autoTracked[idx]; ok {
+		delete(s.autoTracked, idx)
+		autoTrackedValidatorsGauge.Set(float64(len(s.autoTracked)))
+	}
+	deleteValidatorMetrics(idx)
+}
+
+// initializeValidatorPerformance initializes the latest and aggregated performance of the given validator.
+// It assumes the caller holds the service Lock.
+func (s *Service) initializeValidatorPerformance(state state.BeaconState, idx types.ValidatorIndex, epoch types.Epoch) {
+	balance, err := state.BalanceAtIndex(idx)
+	if err != nil {
+		log.WithError(err).WithField("ValidatorIndex", idx).Error(
+			"Could not fetch starting balance, skipping aggregated logs.")
+		balance = 0
+	}
+	s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
+		startEpoch:   epoch,
+		startBalance: balance,
+	}
+	s.latestPerformance[idx] = ValidatorLatestPerformance{
+		balance: balance,
+	}
+}
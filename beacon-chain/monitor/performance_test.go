@@ -0,0 +1,107 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestCompleteEpochs(t *testing.T) {
+	s := setupService(t)
+	s.config.ExportPath = filepath.Join(t.TempDir(), "performance.jsonl")
+	require.NoError(t, s.openExportFile())
+
+	s.Lock()
+	p := s.epochPerformanceFor(1, 0)
+	p.AttestationIncluded = true
+	p.AttestedSlot = 3
+	p.InclusionSlot = 4
+	p.InclusionDistance = 1
+	p.CorrectTarget = true
+	s.recordBalance(1, 0, 32000000100, 100)
+	s.recordBalance(1, 0, 32000000300, 200)
+	s.epochPerformanceFor(2, 1).ProposedBlocks++
+
+	// Attestations of epoch 0 can still be included in blocks of epoch 1.
+	s.completeEpochs(1)
+	s.Unlock()
+	history := s.EpochPerformanceHistory()
+	require.Equal(t, 2, len(history))
+
+	s.Lock()
+	s.completeEpochs(2)
+	s.Unlock()
+	history = s.EpochPerformanceHistory()
+	require.Equal(t, 5, len(history))
+	for i, idx := range []types.ValidatorIndex{1, 2, 12, 15} {
+		require.Equal(t, types.Epoch(0), history[i].Epoch)
+		require.Equal(t, idx, history[i].ValidatorIndex)
+	}
+	require.Equal(t, types.Epoch(1), history[4].Epoch)
+	require.Equal(t, uint64(1), history[4].ProposedBlocks)
+
+	require.NoError(t, s.exportFile.Close())
+	f, err := os.Open(s.config.ExportPath)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	scanner := bufio.NewScanner(f)
+	exported := make([]EpochPerformance, 0)
+	for scanner.Scan() {
+		var p EpochPerformance
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &p))
+		exported = append(exported, p)
+	}
+	require.NoError(t, scanner.Err())
+	require.DeepEqual(t, history[:4], exported)
+	require.DeepEqual(t, EpochPerformance{
+		ValidatorIndex:      1,
+		Epoch:               0,
+		AttestationIncluded: true,
+		AttestedSlot:        3,
+		InclusionSlot:       4,
+		InclusionDistance:   1,
+		CorrectTarget:       true,
+		Balance:             32000000300,
+		BalanceChange:       300,
+	}, exported[0])
+	require.DeepEqual(t, EpochPerformance{ValidatorIndex: 12, Epoch: 0, Balance: 31900000000}, exported[2])
+
+	// Epochs beyond the performance history are pruned.
+	s.Lock()
+	s.completeEpochs(PerformanceHistoryEpochs + 2)
+	s.Unlock()
+	history = s.EpochPerformanceHistory()
+	require.Equal(t, types.Epoch(2), history[0].Epoch)
+}
+
+func TestAddRemoveTrackedValidators(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+
+	require.ErrorContains(t, "validator index 256 does not exist", s.AddTrackedValidators(ctx, []types.ValidatorIndex{3, 256}))
+	require.DeepEqual(t, []types.ValidatorIndex{1, 2, 12, 15}, s.TrackedValidatorIndices())
+
+	require.NoError(t, s.AddTrackedValidators(ctx, []types.ValidatorIndex{0, 3}))
+	require.DeepEqual(t, []types.ValidatorIndex{0, 1, 2, 3, 12, 15}, s.TrackedValidatorIndices())
+	require.Equal(t, uint64(32000000000), s.latestPerformance[3].balance)
+	require.Equal(t, uint64(32000000000), s.aggregatedPerformance[3].startBalance)
+	// Validator 0 is a member of the current sync committee of the head state.
+	require.DeepEqual(t, []types.CommitteeIndex{0}, s.trackedSyncCommitteeIndices[0])
+
+	s.RemoveTrackedValidators([]types.ValidatorIndex{0, 1, 3})
+	require.DeepEqual(t, []types.ValidatorIndex{2, 12, 15}, s.TrackedValidatorIndices())
+	_, ok := s.latestPerformance[1]
+	require.Equal(t, false, ok)
+	_, ok = s.aggregatedPerformance[1]
+	require.Equal(t, false, ok)
+	_, ok = s.trackedSyncCommitteeIndices[1]
+	require.Equal(t, false, ok)
+}
@@ -155,6 +155,16 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 
 			s.latestPerformance[types.ValidatorIndex(idx)] = latestPerf
 			s.aggregatedPerformance[types.ValidatorIndex(idx)] = aggregatedPerf
+
+			epochPerf := s.epochPerformanceFor(types.ValidatorIndex(idx), slots.ToEpoch(latestPerf.attestedSlot))
+			epochPerf.AttestationIncluded = true
+			epochPerf.AttestedSlot = latestPerf.attestedSlot
+			epochPerf.InclusionSlot = latestPerf.inclusionSlot
+			epochPerf.InclusionDistance = latestPerf.inclusionSlot - latestPerf.attestedSlot
+			epochPerf.CorrectSource = latestPerf.timelySource
+			epochPerf.CorrectTarget = latestPerf.timelyTarget
+			epochPerf.CorrectHead = latestPerf.timelyHead
+			s.recordBalance(types.ValidatorIndex(idx), slots.ToEpoch(latestPerf.inclusionSlot), balance, balanceChg)
 			log.WithFields(logFields).Info("Attestation included")
 		}
 	}
@@ -32,6 +32,10 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.SignedBeaconBlo
 	s.processSlashings(blk)
 	s.processExitsFromBlock(blk)
 
+	s.Lock()
+	s.completeEpochs(slots.ToEpoch(blk.Slot()))
+	s.Unlock()
+
 	root, err := blk.HashTreeRoot()
 	if err != nil {
 		log.WithError(err).Error("Could not compute block's hash tree root")
@@ -45,9 +49,10 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.SignedBeaconBlo
 	}
 
 	currEpoch := slots.ToEpoch(blk.Slot())
-	s.RLock()
+	s.Lock()
+	s.updateAutoTracked(st, currEpoch)
 	lastSyncedEpoch := s.lastSyncedEpoch
-	s.RUnlock()
+	s.Unlock()
 
 	if currEpoch != lastSyncedEpoch &&
 		slots.SyncCommitteePeriod(currEpoch) == slots.SyncCommitteePeriod(lastSyncedEpoch) {
@@ -84,6 +89,9 @@ func (s *Service) processProposedBlock(state state.BeaconState, root [32]byte, b
 		latestPerf.balance = balance
 		s.latestPerformance[blk.ProposerIndex()] = latestPerf
 
+		s.epochPerformanceFor(blk.ProposerIndex(), slots.ToEpoch(blk.Slot())).ProposedBlocks++
+		s.recordBalance(blk.ProposerIndex(), slots.ToEpoch(blk.Slot()), balance, balanceChg)
+
 		aggPerf := s.aggregatedPerformance[blk.ProposerIndex()]
 		aggPerf.totalProposedCount++
 		s.aggregatedPerformance[blk.ProposerIndex()] = aggPerf
@@ -6,6 +6,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	"github.com/sirupsen/logrus"
 )
 
@@ -57,6 +58,11 @@ func (s *Service) processSyncAggregate(state state.BeaconState, blk interfaces.B
 			latestPerf.balance = balance
 			s.latestPerformance[validatorIdx] = latestPerf
 
+			epochPerf := s.epochPerformanceFor(validatorIdx, slots.ToEpoch(blk.Slot()))
+			epochPerf.SyncCommitteeExpected += uint64(len(committeeIndices))
+			epochPerf.SyncCommitteeContributions += uint64(contrib)
+			s.recordBalance(validatorIdx, slots.ToEpoch(blk.Slot()), balance, balanceChg)
+
 			aggPerf := s.aggregatedPerformance[validatorIdx]
 			aggPerf.totalSyncCommitteeContributions += uint64(contrib)
 			s.aggregatedPerformance[validatorIdx] = aggPerf
@@ -3,6 +3,7 @@ package monitor
 import (
 	"context"
 	"errors"
+	"os"
 	"sort"
 	"sync"
 
@@ -63,6 +64,14 @@ type ValidatorMonitorConfig struct {
 	AttestationNotifier operation.Notifier
 	HeadFetcher         blockchain.HeadFetcher
 	StateGen            stategen.StateManager
+	// ExportPath is the file the performance of each tracked validator is appended to, as JSON lines,
+	// once an epoch is complete. Performance is not exported if empty.
+	ExportPath string
+	// AutoTrack enables tracking the validators using the validator RPCs of the beacon node, up to
+	// AutoTrackLimit validators, until they are not seen for more than AutoTrackInactiveEpochs.
+	AutoTrack               bool
+	AutoTrackLimit          int
+	AutoTrackInactiveEpochs types.Epoch
 }
 
 // Service is the main structure that tracks validators and reports logs and
@@ -82,6 +91,14 @@ type Service struct {
 	aggregatedPerformance       map[types.ValidatorIndex]ValidatorAggregatedPerformance
 	trackedSyncCommitteeIndices map[types.ValidatorIndex][]types.CommitteeIndex
 	lastSyncedEpoch             types.Epoch
+
+	// Also locked by the service lock.
+	epochPerformance    map[types.Epoch]map[types.ValidatorIndex]*EpochPerformance
+	nextEpochToComplete types.Epoch
+	exportFile          *os.File
+	// autoTracked maps the validators tracked automatically to the last epoch they were seen.
+	autoTracked     map[types.ValidatorIndex]types.Epoch
+	activityChannel chan *observedActivity
 }
 
 // NewService sets up a new validator monitor service instance when given a list of validator indices to track.
@@ -96,6 +113,9 @@ func NewService(ctx context.Context, config *ValidatorMonitorConfig, tracked []t
 		aggregatedPerformance:       make(map[types.ValidatorIndex]ValidatorAggregatedPerformance),
 		trackedSyncCommitteeIndices: make(map[types.ValidatorIndex][]types.CommitteeIndex),
 		isLogging:                   false,
+		epochPerformance:            make(map[types.Epoch]map[types.ValidatorIndex]*EpochPerformance),
+		autoTracked:                 make(map[types.ValidatorIndex]types.Epoch),
+		activityChannel:             make(chan *observedActivity, activityBufferSize),
 	}
 	for _, idx := range tracked {
 		r.TrackedValidators[idx] = true
@@ -118,6 +138,10 @@ func (s *Service) Start() {
 		"ValidatorIndices": tracked,
 	}).Info("Starting service")
 
+	if err := s.openExportFile(); err != nil {
+		log.WithError(err).Error("Validator performance will not be exported")
+	}
+
 	stateChannel := make(chan *feed.Event, 1)
 	stateSub := s.config.StateNotifier.StateFeed().Subscribe(stateChannel)
 
@@ -165,20 +189,10 @@ func (s *Service) run(stateChannel chan *feed.Event, stateSub event.Subscription
 // and validatorAggregatedPerformance for each tracked validator.
 func (s *Service) initializePerformanceStructures(state state.BeaconState, epoch types.Epoch) {
 	for idx := range s.TrackedValidators {
-		balance, err := state.BalanceAtIndex(idx)
-		if err != nil {
-			log.WithError(err).WithField("ValidatorIndex", idx).Error(
-				"Could not fetch starting balance, skipping aggregated logs.")
-			balance = 0
-		}
-		s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
-			startEpoch:   epoch,
-			startBalance: balance,
-		}
-		s.latestPerformance[idx] = ValidatorLatestPerformance{
-			balance: balance,
-		}
+		s.initializeValidatorPerformance(state, idx, epoch)
 	}
+	// The blocks of the starting epoch processed before the service started are not accounted for.
+	s.nextEpochToComplete = epoch + 1
 }
 
 // Status retrieves the status of the service.
@@ -193,6 +207,14 @@ func (s *Service) Status() error {
 func (s *Service) Stop() error {
 	defer s.cancel()
 	s.isLogging = false
+	s.Lock()
+	defer s.Unlock()
+	if s.exportFile != nil {
+		if err := s.exportFile.Close(); err != nil {
+			return err
+		}
+		s.exportFile = nil
+	}
 	return nil
 }
 
@@ -272,6 +294,8 @@ func (s *Service) monitorRoutine(stateChannel chan *feed.Event, stateSub event.S
 					s.processSyncCommitteeContribution(data.Contribution)
 				}
 			}
+		case a := <-s.activityChannel:
+			s.processActivity(s.ctx, a)
 		case <-s.ctx.Done():
 			log.Debug("Context closed, exiting goroutine")
 			return
@@ -295,16 +319,22 @@ func (s *Service) updateSyncCommitteeTrackedVals(state state.BeaconState) {
 	s.Lock()
 	defer s.Unlock()
 	for idx := range s.TrackedValidators {
-		syncIdx, err := helpers.CurrentPeriodSyncSubcommitteeIndices(state, idx)
-		if err != nil {
-			log.WithError(err).WithField("ValidatorIndex", idx).Error(
-				"Sync committee assignments will not be reported")
-			delete(s.trackedSyncCommitteeIndices, idx)
-		} else if len(syncIdx) == 0 {
-			delete(s.trackedSyncCommitteeIndices, idx)
-		} else {
-			s.trackedSyncCommitteeIndices[idx] = syncIdx
-		}
+		s.updateSyncCommitteeIndices(state, idx)
 	}
 	s.lastSyncedEpoch = slots.ToEpoch(state.Slot())
 }
+
+// updateSyncCommitteeIndices updates the sync committee assignments of the given tracked validator.
+// It assumes the caller holds the service Lock.
+func (s *Service) updateSyncCommitteeIndices(state state.BeaconState, idx types.ValidatorIndex) {
+	syncIdx, err := helpers.CurrentPeriodSyncSubcommitteeIndices(state, idx)
+	if err != nil {
+		log.WithError(err).WithField("ValidatorIndex", idx).Error(
+			"Sync committee assignments will not be reported")
+		delete(s.trackedSyncCommitteeIndices, idx)
+	} else if len(syncIdx) == 0 {
+		delete(s.trackedSyncCommitteeIndices, idx)
+	} else {
+		s.trackedSyncCommitteeIndices[idx] = syncIdx
+	}
+}
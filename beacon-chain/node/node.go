@@ -16,6 +16,8 @@ import (
 	"syscall"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	apigateway "github.com/prysmaticlabs/prysm/v3/api/gateway"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
@@ -53,10 +55,12 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v3/config/features"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/container/slice"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/prometheus"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/runtime"
 	"github.com/prysmaticlabs/prysm/v3/runtime/debug"
 	"github.com/prysmaticlabs/prysm/v3/runtime/prereqs"
@@ -110,6 +114,7 @@ type BeaconNode struct {
 	GenesisInitializer      genesis.Initializer
 	CheckpointInitializer   checkpoint.Initializer
 	forkChoicer             forkchoice.ForkChoicer
+	router                  *mux.Router
 }
 
 // New creates a new node instance, sets up configuration options, and registers
@@ -178,6 +183,7 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		slasherAttestationsFeed: new(event.Feed),
 		serviceFlagOpts:         &serviceFlagOpts{},
 		proposerIdsCache:        cache.NewProposerPayloadIDsCache(),
+		router:                  mux.NewRouter(),
 	}
 
 	for _, opt := range opts {
@@ -246,6 +252,11 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Backfill Service")
+	if err := beacon.registerBackfillService(bfs); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering Slasher Service")
 	if err := beacon.registerSlasherService(); err != nil {
 		return nil, err
@@ -256,6 +267,11 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Validator Monitoring Service")
+	if err := beacon.registerValidatorMonitorService(); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering RPC Service")
 	if err := beacon.registerRPCService(); err != nil {
 		return nil, err
@@ -266,11 +282,6 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
-	log.Debugln("Registering Validator Monitoring Service")
-	if err := beacon.registerValidatorMonitorService(); err != nil {
-		return nil, err
-	}
-
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		log.Debugln("Registering Prometheus Service")
 		if err := beacon.registerPrometheusService(cliCtx); err != nil {
@@ -459,7 +470,8 @@ func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
 
 	log.WithField("database-path", dbPath).Info("Checking DB")
 
-	d, err := slasherkv.NewKVStore(b.ctx, dbPath)
+	chunkCacheSize := slasherkv.WithChunkCacheSize(cliCtx.Uint64(flags.SlasherChunkCacheMB.Name) * 1024 * 1024)
+	d, err := slasherkv.NewKVStore(b.ctx, dbPath, chunkCacheSize)
 	if err != nil {
 		return err
 	}
@@ -481,18 +493,37 @@ func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
 		if err := d.ClearDB(); err != nil {
 			return errors.Wrap(err, "could not clear database")
 		}
-		d, err = slasherkv.NewKVStore(b.ctx, dbPath)
+		d, err = slasherkv.NewKVStore(b.ctx, dbPath, chunkCacheSize)
 		if err != nil {
 			return errors.Wrap(err, "could not create new database")
 		}
 	}
 
+	if err := d.RunMigrations(b.ctx); err != nil {
+		return err
+	}
+
 	b.slasherDB = d
 	return nil
 }
 
 func (b *BeaconNode) startStateGen(ctx context.Context, bfs *backfill.Status, fc forkchoice.ForkChoicer) error {
 	opts := []stategen.StateGenOption{stategen.WithBackfillStatus(bfs)}
+	if b.cliCtx.IsSet(flags.StateArchiveSnapshotSlots.Name) {
+		snapshotSlots := types.Slot(b.cliCtx.Int(flags.StateArchiveSnapshotSlots.Name))
+		diffSlots := types.Slot(b.cliCtx.Int(flags.StateArchiveDiffSlots.Name))
+		if diffSlots == 0 || diffSlots%params.BeaconConfig().SlotsPerEpoch != 0 {
+			return fmt.Errorf("--%s must be a positive multiple of the slots per epoch", flags.StateArchiveDiffSlots.Name)
+		}
+		if snapshotSlots == 0 || snapshotSlots%diffSlots != 0 {
+			return fmt.Errorf("--%s must be a positive multiple of --%s", flags.StateArchiveSnapshotSlots.Name, flags.StateArchiveDiffSlots.Name)
+		}
+		log.WithFields(logrus.Fields{
+			"snapshotSlots": snapshotSlots,
+			"diffSlots":     diffSlots,
+		}).Info("Historical state archive enabled")
+		opts = append(opts, stategen.WithStateArchive(snapshotSlots, diffSlots))
+	}
 	sg := stategen.New(b.db, fc, opts...)
 
 	cp, err := b.db.FinalizedCheckpoint(ctx)
@@ -708,6 +739,39 @@ func (b *BeaconNode) registerInitialSyncService() error {
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerBackfillService(bfs *backfill.Status) error {
+	if !features.Get().EnableExperimentalBackfill {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	var initSync *initialsync.Service
+	if err := b.services.FetchService(&initSync); err != nil {
+		return err
+	}
+
+	p2pService := b.fetchP2P()
+	requester := func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error) {
+		return regularsync.SendBeaconBlocksByRangeRequest(ctx, chainService, p2pService, pid, req, nil)
+	}
+	bs, err := backfill.NewService(
+		b.ctx,
+		bfs,
+		backfill.WithDatabase(b.db),
+		backfill.WithP2P(p2pService),
+		backfill.WithBlocksByRangeRequester(requester),
+		backfill.WithSyncChecker(initSync),
+		backfill.WithBatchSize(b.cliCtx.Uint64(flags.BackfillBatchSize.Name)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize backfill service")
+	}
+	return b.services.RegisterService(bs)
+}
+
 func (b *BeaconNode) registerSlasherService() error {
 	if !features.Get().EnableSlasher {
 		return nil
@@ -731,6 +795,8 @@ func (b *BeaconNode) registerSlasherService() error {
 		SlashingPoolInserter:    b.slashingsPool,
 		SyncChecker:             syncService,
 		HeadStateFetcher:        chainService,
+		BeaconDB:                b.db,
+		CatchUpEpochs:           types.Epoch(b.cliCtx.Uint64(flags.SlasherCatchUpEpochs.Name)),
 	})
 	if err != nil {
 		return err
@@ -761,6 +827,14 @@ func (b *BeaconNode) registerRPCService() error {
 		}
 	}
 
+	var validatorMonitor monitor.Tracker
+	var activityObserver monitor.ActivityObserver
+	var monitorService *monitor.Service
+	if err := b.services.FetchService(&monitorService); err == nil {
+		validatorMonitor = monitorService
+		activityObserver = monitorService
+	}
+
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
 	genesisStatePath := b.cliCtx.String(flags.InteropGenesisStateFlag.Name)
 	var depositFetcher depositcache.DepositFetcher
@@ -805,6 +879,7 @@ func (b *BeaconNode) registerRPCService() error {
 		Broadcaster:                   p2pService,
 		PeersFetcher:                  p2pService,
 		PeerManager:                   p2pService,
+		PeerBanner:                    p2pService,
 		MetadataProvider:              p2pService,
 		ChainInfoFetcher:              chainService,
 		HeadUpdater:                   chainService,
@@ -817,6 +892,7 @@ func (b *BeaconNode) registerRPCService() error {
 		GenesisTimeFetcher:            chainService,
 		GenesisFetcher:                chainService,
 		OptimisticModeFetcher:         chainService,
+		LightClientUpdateFetcher:      chainService,
 		AttestationsPool:              b.attestationPool,
 		ExitPool:                      b.exitPool,
 		SlashingsPool:                 b.slashingsPool,
@@ -838,6 +914,9 @@ func (b *BeaconNode) registerRPCService() error {
 		MaxMsgSize:                    maxMsgSize,
 		ProposerIdsCache:              b.proposerIdsCache,
 		BlockBuilder:                  b.fetchBuilderService(),
+		Router:                        b.router,
+		ValidatorMonitor:              validatorMonitor,
+		ValidatorActivityObserver:     activityObserver,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -896,6 +975,7 @@ func (b *BeaconNode) registerGRPCGateway() error {
 
 	opts := []apigateway.Option{
 		apigateway.WithGatewayAddr(gatewayAddress),
+		apigateway.WithRouter(b.router),
 		apigateway.WithRemoteAddr(selfAddress),
 		apigateway.WithPbHandlers(muxs),
 		apigateway.WithMuxHandler(gatewayConfig.Handler),
@@ -942,9 +1022,13 @@ func (b *BeaconNode) registerDeterminsticGenesisService() error {
 	return nil
 }
 
+// registerValidatorMonitorService registers the validator monitor if any of the validator monitor flags is set.
+// Without it, the endpoints to add and remove tracked validators at runtime respond that the monitor is disabled.
 func (b *BeaconNode) registerValidatorMonitorService() error {
 	cliSlice := b.cliCtx.IntSlice(cmd.ValidatorMonitorIndicesFlag.Name)
-	if cliSlice == nil {
+	exportPath := b.cliCtx.String(cmd.ValidatorMonitorExportFileFlag.Name)
+	autoTrack := b.cliCtx.Bool(cmd.ValidatorMonitorAutoTrackFlag.Name)
+	if cliSlice == nil && exportPath == "" && !autoTrack {
 		return nil
 	}
 	tracked := make([]types.ValidatorIndex, len(cliSlice))
@@ -957,10 +1041,14 @@ func (b *BeaconNode) registerValidatorMonitorService() error {
 		return err
 	}
 	monitorConfig := &monitor.ValidatorMonitorConfig{
-		StateNotifier:       b,
-		AttestationNotifier: b,
-		StateGen:            b.stateGen,
-		HeadFetcher:         chainService,
+		StateNotifier:           b,
+		AttestationNotifier:     b,
+		StateGen:                b.stateGen,
+		HeadFetcher:             chainService,
+		ExportPath:              exportPath,
+		AutoTrack:               autoTrack,
+		AutoTrackLimit:          b.cliCtx.Int(cmd.ValidatorMonitorAutoTrackLimitFlag.Name),
+		AutoTrackInactiveEpochs: types.Epoch(b.cliCtx.Uint64(cmd.ValidatorMonitorAutoTrackInactiveEpochsFlag.Name)),
 	}
 	svc, err := monitor.NewService(b.ctx, monitorConfig, tracked)
 	if err != nil {
@@ -0,0 +1,67 @@
+package p2p
+
+import (
+	"net"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/sirupsen/logrus"
+)
+
+// BanPeer bans the given peer for the given duration, or indefinitely if the duration
+// is zero, and disconnects it if it is connected.
+func (s *Service) BanPeer(pid peer.ID, duration time.Duration) error {
+	s.peers.Ban(pid, duration)
+	log.WithFields(logrus.Fields{"peer": pid, "duration": duration}).Info("Banned peer")
+	if s.host != nil && s.host.Network().Connectedness(pid) == network.Connected {
+		return s.Disconnect(pid)
+	}
+	return nil
+}
+
+// UnbanPeer lifts the ban of the given peer.
+func (s *Service) UnbanPeer(pid peer.ID) {
+	s.peers.Unban(pid)
+	log.WithField("peer", pid).Info("Unbanned peer")
+}
+
+// BanIPRange denies all connections from and to the given ip range, and disconnects the
+// connected peers within it.
+func (s *Service) BanIPRange(ipnet net.IPNet) error {
+	s.ipBans.AddFilter(ipnet, multiaddr.ActionDeny)
+	log.WithField("ipRange", ipnet.String()).Info("Banned ip range")
+	if s.host == nil {
+		return nil
+	}
+	for _, conn := range s.host.Network().Conns() {
+		if !s.isIPBanned(conn.RemoteMultiaddr()) {
+			continue
+		}
+		if err := s.Disconnect(conn.RemotePeer()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnbanIPRange lifts the ban of the given ip range. It returns false if the range
+// was not banned.
+func (s *Service) UnbanIPRange(ipnet net.IPNet) bool {
+	removed := s.ipBans.RemoveLiteral(ipnet)
+	if removed {
+		log.WithField("ipRange", ipnet.String()).Info("Unbanned ip range")
+	}
+	return removed
+}
+
+// BannedIPRanges returns the ip ranges banned at runtime.
+func (s *Service) BannedIPRanges() []net.IPNet {
+	return s.ipBans.FiltersForAction(multiaddr.ActionDeny)
+}
+
+// isIPBanned checks whether the given address is within a banned ip range.
+func (s *Service) isIPBanned(addr multiaddr.Multiaddr) bool {
+	return s.ipBans != nil && s.ipBans.AddrBlocked(addr)
+}
@@ -0,0 +1,68 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers/scorers"
+	leakybucket "github.com/prysmaticlabs/prysm/v3/container/leaky-bucket"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestService_BanPeer(t *testing.T) {
+	s := &Service{
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &scorers.Config{},
+		}),
+		ipBans: ma.NewFilters(),
+	}
+	var err error
+	s.addrFilter, err = configureFilter(&Config{})
+	require.NoError(t, err)
+	addr, err := ma.NewMultiaddr("/ip4/212.67.10.122/tcp/3000")
+	require.NoError(t, err)
+	pid := peer.ID("banned")
+
+	require.NoError(t, s.BanPeer(pid, time.Hour))
+	assert.Equal(t, false, s.InterceptAddrDial(pid, addr))
+	assert.Equal(t, 1, len(s.peers.Banned()))
+
+	s.UnbanPeer(pid)
+	assert.Equal(t, true, s.InterceptAddrDial(pid, addr))
+	assert.Equal(t, 0, len(s.peers.Banned()))
+}
+
+func TestService_BanIPRange(t *testing.T) {
+	s := &Service{
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, 1*time.Second, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &scorers.Config{},
+		}),
+		ipBans: ma.NewFilters(),
+	}
+	var err error
+	s.addrFilter, err = configureFilter(&Config{AllowListCIDR: "212.67.0.0/16"})
+	require.NoError(t, err)
+	banned, err := ma.NewMultiaddr("/ip4/212.67.10.122/tcp/3000")
+	require.NoError(t, err)
+	allowed, err := ma.NewMultiaddr("/ip4/212.67.11.122/tcp/3000")
+	require.NoError(t, err)
+
+	_, ipnet, err := net.ParseCIDR("212.67.10.0/24")
+	require.NoError(t, err)
+	require.NoError(t, s.BanIPRange(*ipnet))
+	assert.DeepEqual(t, []net.IPNet{*ipnet}, s.BannedIPRanges())
+	assert.Equal(t, false, s.InterceptAddrDial("", banned))
+	assert.Equal(t, true, s.InterceptAddrDial("", allowed))
+
+	assert.Equal(t, true, s.UnbanIPRange(*ipnet))
+	assert.Equal(t, false, s.UnbanIPRange(*ipnet))
+	assert.Equal(t, 0, len(s.BannedIPRanges()))
+	assert.Equal(t, true, s.InterceptAddrDial("", banned))
+}
@@ -36,6 +36,9 @@ func (s *Service) InterceptAddrDial(pid peer.ID, m multiaddr.Multiaddr) (allow b
 	if s.peers.IsBad(pid) {
 		return false
 	}
+	if s.isIPBanned(m) {
+		return false
+	}
 	return filterConnections(s.addrFilter, m)
 }
 
@@ -58,6 +61,11 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 			"reason": "at peer limit"}).Trace("Not accepting inbound dial")
 		return false
 	}
+	if s.isIPBanned(n.RemoteMultiaddr()) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "banned ip range"}).Trace("Not accepting inbound dial")
+		return false
+	}
 	return filterConnections(s.addrFilter, n.RemoteMultiaddr())
 }
 
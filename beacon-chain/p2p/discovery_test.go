@@ -195,6 +195,7 @@ func TestStaticPeering_PeersAreAdded(t *testing.T) {
 	cfg.StaticPeers = staticPeers
 	cfg.StateNotifier = &mock.MockStateNotifier{}
 	cfg.NoDiscovery = true
+	cfg.DataDir = t.TempDir()
 	s, err := NewService(context.Background(), cfg)
 	require.NoError(t, err)
 
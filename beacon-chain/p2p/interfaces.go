@@ -2,6 +2,8 @@ package p2p
 
 import (
 	"context"
+	"net"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -25,6 +27,7 @@ type P2P interface {
 	PubSubTopicUser
 	SenderEncoder
 	PeerManager
+	PeerBanner
 	ConnectionHandler
 	PeersProvider
 	MetadataProvider
@@ -86,6 +89,15 @@ type PeerManager interface {
 	AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error)
 }
 
+// PeerBanner bans and unbans peers and ip ranges at runtime.
+type PeerBanner interface {
+	BanPeer(pid peer.ID, duration time.Duration) error
+	UnbanPeer(pid peer.ID)
+	BanIPRange(ipnet net.IPNet) error
+	UnbanIPRange(ipnet net.IPNet) bool
+	BannedIPRanges() []net.IPNet
+}
+
 // Sender abstracts the sending functionality from libp2p.
 type Sender interface {
 	Send(context.Context, interface{}, string, peer.ID) (network.Stream, error)
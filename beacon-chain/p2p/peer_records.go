@@ -0,0 +1,75 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers/peerdb"
+	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
+)
+
+const (
+	// peerRecordsSaveInterval is the interval at which the peer records are persisted.
+	peerRecordsSaveInterval = 5 * time.Minute
+	// peerRecordExpiry is the amount of time after which the record of a peer that has not
+	// been seen, and is not banned, is removed from the peer database.
+	peerRecordExpiry = 72 * time.Hour
+)
+
+// openPeerDB opens the peer database in the data directory and restores the persisted
+// peer records, so that known-bad peers stay banned and good peers can be redialed.
+func (s *Service) openPeerDB() {
+	db, err := peerdb.NewStore(s.cfg.DataDir)
+	if err != nil {
+		log.WithError(err).Error("Could not open peer database, peers will not be persisted across restarts")
+		return
+	}
+	s.peerDB = db
+	if err := db.PruneExpiredPeerRecords(s.ctx, prysmTime.Now().Add(-peerRecordExpiry)); err != nil {
+		log.WithError(err).Error("Could not prune expired peer records")
+	}
+	records, err := db.PeerRecords(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve persisted peer records")
+		return
+	}
+	s.persistedPeers = s.peers.Restore(records)
+	log.WithField("count", len(records)).Debug("Restored persisted peer records")
+}
+
+// redialPersistedPeers dials the most recently seen good peers restored from the peer
+// database, up to the peer limit.
+func (s *Service) redialPersistedPeers() {
+	infos := s.persistedPeers
+	s.persistedPeers = nil
+	if len(infos) > int(s.cfg.MaxPeers) {
+		infos = infos[:s.cfg.MaxPeers]
+	}
+	for _, info := range infos {
+		// make each dial non-blocking
+		go func(info peer.AddrInfo) {
+			if err := s.connectWithPeer(s.ctx, info); err != nil {
+				log.WithError(err).Tracef("Could not redial persisted peer %s", info.String())
+			}
+		}(info)
+	}
+}
+
+// savePeerRecords persists the current peer records in the peer database.
+func (s *Service) savePeerRecords() {
+	if s.peerDB == nil {
+		return
+	}
+	if err := s.peerDB.SavePeerRecords(s.ctx, s.peers.Records()); err != nil {
+		log.WithError(err).Error("Could not save peer records")
+	}
+}
+
+// closePeerDB saves the current peer records and closes the peer database.
+func (s *Service) closePeerDB() error {
+	if s.peerDB == nil {
+		return nil
+	}
+	s.savePeerRecords()
+	return s.peerDB.Close()
+}
@@ -0,0 +1,55 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestService_PeerRecordsPersistedAcrossRestarts(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := &Config{StateNotifier: &mock.MockStateNotifier{}, DataDir: t.TempDir(), MaxPeers: 30}
+	s, err := NewService(context.Background(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, s.peerDB)
+
+	newPeer := func() peer.ID {
+		key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err)
+		pid, err := peer.IDFromPrivateKey(key)
+		require.NoError(t, err)
+		return pid
+	}
+	addr, err := multiaddr.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	good := newPeer()
+	s.peers.Add(nil, good, addr, network.DirOutbound)
+	s.peers.SetConnectionState(good, peers.PeerConnected)
+	bad := newPeer()
+	s.peers.Add(nil, bad, addr, network.DirOutbound)
+	s.peers.SetConnectionState(bad, peers.PeerConnected)
+	for i := 0; i < maxBadResponses; i++ {
+		s.peers.Scorers().BadResponsesScorer().Increment(bad)
+	}
+	require.NoError(t, s.Stop())
+
+	s, err = NewService(context.Background(), cfg)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Stop())
+	}()
+	assert.Equal(t, true, s.peers.IsBad(bad))
+	assert.Equal(t, false, s.peers.IsBad(good))
+	require.Equal(t, 1, len(s.persistedPeers))
+	assert.Equal(t, good, s.persistedPeers[0].ID)
+}
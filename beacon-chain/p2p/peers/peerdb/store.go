@@ -0,0 +1,135 @@
+// Package peerdb defines a bolt-db, key-value store for the peer records of
+// the beacon node, so that known peers and bans survive restarts.
+package peerdb
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// DatabaseFileName is the name of the peer database.
+const DatabaseFileName = "peers.db"
+
+var peerRecordsBucket = []byte("peer-records")
+
+// Store is a bolt-db backed store of peer records, keyed by peer ID.
+type Store struct {
+	db           *bolt.DB
+	databasePath string
+}
+
+// NewStore initializes a new boltDB key-value store at the directory
+// path specified and creates the peer records bucket.
+func NewStore(dirPath string) (*Store, error) {
+	hasDir, err := file.HasDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	if !hasDir {
+		if err := file.MkdirAll(dirPath); err != nil {
+			return nil, err
+		}
+	}
+	boltDB, err := bolt.Open(
+		path.Join(dirPath, DatabaseFileName),
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{Timeout: 1 * time.Second},
+	)
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	if err := boltDB.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(peerRecordsBucket)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return &Store{db: boltDB, databasePath: dirPath}, nil
+}
+
+// Close closes the underlying BoltDB database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// DatabasePath at which this database writes files.
+func (s *Store) DatabasePath() string {
+	return s.databasePath
+}
+
+// SavePeerRecords saves the given peer records, overriding any existing record for the same peer.
+func (s *Store) SavePeerRecords(ctx context.Context, records []*ethpb.PeerRecord) error {
+	_, span := trace.StartSpan(ctx, "peerdb.SavePeerRecords")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(peerRecordsBucket)
+		for _, record := range records {
+			enc, err := proto.Marshal(record)
+			if err != nil {
+				return errors.Wrapf(err, "could not marshal record of peer %s", record.PeerId)
+			}
+			if err := bkt.Put([]byte(record.PeerId), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PeerRecords retrieves all the persisted peer records.
+func (s *Store) PeerRecords(ctx context.Context) ([]*ethpb.PeerRecord, error) {
+	_, span := trace.StartSpan(ctx, "peerdb.PeerRecords")
+	defer span.End()
+
+	records := make([]*ethpb.PeerRecord, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(peerRecordsBucket).ForEach(func(k, v []byte) error {
+			record := &ethpb.PeerRecord{}
+			if err := proto.Unmarshal(v, record); err != nil {
+				return errors.Wrapf(err, "could not unmarshal record of peer %s", string(k))
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+	return records, err
+}
+
+// PruneExpiredPeerRecords deletes the records of the peers that have not been seen since the
+// given cutoff time and are not banned beyond it.
+func (s *Store) PruneExpiredPeerRecords(ctx context.Context, cutoff time.Time) error {
+	_, span := trace.StartSpan(ctx, "peerdb.PruneExpiredPeerRecords")
+	defer span.End()
+
+	limit := uint64(cutoff.Unix())
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(peerRecordsBucket)
+		c := bkt.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			record := &ethpb.PeerRecord{}
+			if err := proto.Unmarshal(v, record); err != nil {
+				return errors.Wrapf(err, "could not unmarshal record of peer %s", string(k))
+			}
+			if record.LastSeen >= limit || record.BannedUntil >= limit {
+				continue
+			}
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
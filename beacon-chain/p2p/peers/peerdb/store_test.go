@@ -0,0 +1,83 @@
+package peerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+// setupDB instantiates and returns a Store instance.
+func setupDB(t testing.TB) *Store {
+	db, err := NewStore(t.TempDir())
+	require.NoError(t, err, "Failed to instantiate DB")
+	t.Cleanup(func() {
+		require.NoError(t, db.Close(), "Failed to close database")
+	})
+	return db
+}
+
+func TestStore_SavePeerRecords(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	records, err := db.PeerRecords(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	first := &ethpb.PeerRecord{PeerId: "a", Enr: "enr:-a", Multiaddr: []byte{1, 2}, Outbound: true, LastSeen: 10}
+	second := &ethpb.PeerRecord{PeerId: "b", BadResponses: 5, BannedUntil: 20}
+	require.NoError(t, db.SavePeerRecords(ctx, []*ethpb.PeerRecord{first, second}))
+
+	updated := &ethpb.PeerRecord{PeerId: "a", LastSeen: 30, ProcessedBlocks: 64}
+	require.NoError(t, db.SavePeerRecords(ctx, []*ethpb.PeerRecord{updated}))
+
+	records, err = db.PeerRecords(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	assert.DeepSSZEqual(t, updated, records[0])
+	assert.DeepSSZEqual(t, second, records[1])
+}
+
+func TestStore_PersistsAcrossReopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewStore(dir)
+	require.NoError(t, err)
+	record := &ethpb.PeerRecord{PeerId: "a", LastSeen: 10}
+	require.NoError(t, db.SavePeerRecords(ctx, []*ethpb.PeerRecord{record}))
+	require.NoError(t, db.Close())
+
+	db, err = NewStore(dir)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	records, err := db.PeerRecords(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.DeepSSZEqual(t, record, records[0])
+}
+
+func TestStore_PruneExpiredPeerRecords(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	cutoff := time.Unix(100, 0)
+	require.NoError(t, db.SavePeerRecords(ctx, []*ethpb.PeerRecord{
+		{PeerId: "expired", LastSeen: 50},
+		{PeerId: "recent", LastSeen: 150},
+		{PeerId: "banned", LastSeen: 50, BannedUntil: 200},
+		{PeerId: "ban-expired", BannedUntil: 80},
+	}))
+	require.NoError(t, db.PruneExpiredPeerRecords(ctx, cutoff))
+
+	records, err := db.PeerRecords(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, "banned", records[0].PeerId)
+	assert.Equal(t, "recent", records[1].PeerId)
+}
@@ -0,0 +1,133 @@
+package peers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers/peerdata"
+	pb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
+)
+
+// BanDuration is the amount of time a peer, deemed bad at the time its record is exported,
+// stays banned once the record is restored.
+const BanDuration = time.Hour
+
+// Records exports the peers that have been connected at some point, along with the banned peers,
+// as records that can be persisted across restarts. Peers that are currently deemed bad by the
+// scorers are exported with a ban of BanDuration.
+func (p *Status) Records() []*pb.PeerRecord {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	now := prysmTime.Now()
+	records := make([]*pb.PeerRecord, 0)
+	for pid, peerData := range p.store.Peers() {
+		bannedUntil := peerData.BannedUntil
+		if !bannedUntil.After(now) && !p.store.IsTrustedPeer(pid) && p.scorers.IsBadPeerNoLock(pid) {
+			bannedUntil = now.Add(BanDuration)
+		}
+		banned := bannedUntil.After(now)
+		if peerData.LastSeen.IsZero() && !banned {
+			continue
+		}
+		record := &pb.PeerRecord{
+			PeerId:          pid.String(),
+			Outbound:        peerData.Direction == network.DirOutbound,
+			BadResponses:    uint64(peerData.BadResponses),
+			ProcessedBlocks: peerData.ProcessedBlocks,
+		}
+		if !peerData.LastSeen.IsZero() {
+			record.LastSeen = uint64(peerData.LastSeen.Unix())
+		}
+		if banned {
+			record.BannedUntil = uint64(bannedUntil.Unix())
+		}
+		if peerData.Address != nil {
+			record.Multiaddr = peerData.Address.Bytes()
+		}
+		if peerData.Enr != nil {
+			if node, err := enode.New(enode.ValidSchemes, peerData.Enr); err == nil {
+				record.Enr = node.String()
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// Restore adds the peers of the given persisted records as disconnected peers, along with their
+// scorer state and bans. Peers that are already known are left untouched. Restore returns the
+// address info of the peers that were previously dialed by this node and are not deemed bad,
+// most recently seen first, so that they can be redialed.
+func (p *Status) Restore(records []*pb.PeerRecord) []peer.AddrInfo {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	restored := make([]peer.ID, 0, len(records))
+	for _, record := range records {
+		pid, err := peer.Decode(record.PeerId)
+		if err != nil {
+			log.WithError(err).Debug("Could not decode peer ID of persisted record")
+			continue
+		}
+		if _, ok := p.store.PeerData(pid); ok {
+			continue
+		}
+		peerData := &peerdata.PeerData{
+			Direction:       network.DirInbound,
+			ConnState:       PeerDisconnected,
+			BadResponses:    int(record.BadResponses),
+			ProcessedBlocks: record.ProcessedBlocks,
+		}
+		if record.Outbound {
+			peerData.Direction = network.DirOutbound
+		}
+		if record.LastSeen > 0 {
+			peerData.LastSeen = time.Unix(int64(record.LastSeen), 0)
+		}
+		if record.BannedUntil > 0 {
+			peerData.BannedUntil = time.Unix(int64(record.BannedUntil), 0)
+		}
+		if len(record.Multiaddr) > 0 {
+			if addr, err := ma.NewMultiaddrBytes(record.Multiaddr); err == nil {
+				peerData.Address = addr
+			}
+		}
+		if record.Enr != "" {
+			if node, err := enode.Parse(enode.ValidSchemes, record.Enr); err == nil {
+				peerData.Enr = node.Record()
+			}
+		}
+		p.store.SetPeerData(pid, peerData)
+		p.addIpToTracker(pid)
+		restored = append(restored, pid)
+	}
+
+	redial := make([]peer.ID, 0, len(restored))
+	for _, pid := range restored {
+		peerData, ok := p.store.PeerData(pid)
+		if !ok || peerData.Address == nil || peerData.Direction != network.DirOutbound {
+			continue
+		}
+		if p.isBad(pid) {
+			continue
+		}
+		redial = append(redial, pid)
+	}
+	sort.Slice(redial, func(i, j int) bool {
+		iData, _ := p.store.PeerData(redial[i])
+		jData, _ := p.store.PeerData(redial[j])
+		return iData.LastSeen.After(jData.LastSeen)
+	})
+	infos := make([]peer.AddrInfo, 0, len(redial))
+	for _, pid := range redial {
+		peerData, _ := p.store.PeerData(pid)
+		infos = append(infos, peer.AddrInfo{ID: pid, Addrs: []ma.Multiaddr{peerData.Address}})
+	}
+	return infos
+}
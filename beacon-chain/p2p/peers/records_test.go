@@ -0,0 +1,134 @@
+package peers_test
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	gcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func newRecordsTestStatus() *peers.Status {
+	return peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 2,
+			},
+		},
+	})
+}
+
+func newRecordsTestPeer(t *testing.T) peer.ID {
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+	return pid
+}
+
+func TestStatus_Records(t *testing.T) {
+	p := newRecordsTestStatus()
+
+	key, err := gcrypto.GenerateKey()
+	require.NoError(t, err)
+	record := &enr.Record{}
+	require.NoError(t, enode.SignV4(record, key))
+
+	connected := newRecordsTestPeer(t)
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	p.Add(record, connected, address, network.DirOutbound)
+	p.SetConnectionState(connected, peers.PeerConnected)
+	p.Scorers().BlockProviderScorer().IncrementProcessedBlocks(connected, 64)
+
+	neverConnected := newRecordsTestPeer(t)
+	p.Add(nil, neverConnected, address, network.DirOutbound)
+
+	bad := newRecordsTestPeer(t)
+	p.Add(nil, bad, nil, network.DirInbound)
+	for i := 0; i < 2; i++ {
+		p.Scorers().BadResponsesScorer().Increment(bad)
+	}
+
+	records := p.Records()
+	require.Equal(t, 2, len(records))
+	for _, r := range records {
+		switch r.PeerId {
+		case connected.String():
+			assert.Equal(t, true, r.Outbound)
+			assert.Equal(t, true, r.LastSeen > 0)
+			assert.Equal(t, uint64(0), r.BannedUntil)
+			assert.Equal(t, uint64(64), r.ProcessedBlocks)
+			assert.DeepEqual(t, address.Bytes(), r.Multiaddr)
+			assert.Equal(t, enode.MustParse(r.Enr).ID(), enode.PubkeyToIDV4(&key.PublicKey))
+		case bad.String():
+			assert.Equal(t, uint64(2), r.BadResponses)
+			assert.Equal(t, true, r.BannedUntil > uint64(time.Now().Unix()))
+		default:
+			t.Errorf("Unexpected record for peer %s", r.PeerId)
+		}
+	}
+}
+
+func TestStatus_Restore(t *testing.T) {
+	source := newRecordsTestStatus()
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+
+	older := newRecordsTestPeer(t)
+	source.Add(nil, older, address, network.DirOutbound)
+	source.SetConnectionState(older, peers.PeerConnected)
+	newer := newRecordsTestPeer(t)
+	source.Add(nil, newer, address, network.DirOutbound)
+	source.SetConnectionState(newer, peers.PeerConnected)
+	inbound := newRecordsTestPeer(t)
+	source.Add(nil, inbound, address, network.DirInbound)
+	source.SetConnectionState(inbound, peers.PeerConnected)
+	bad := newRecordsTestPeer(t)
+	source.Add(nil, bad, address, network.DirOutbound)
+	source.SetConnectionState(bad, peers.PeerConnected)
+	for i := 0; i < 2; i++ {
+		source.Scorers().BadResponsesScorer().Increment(bad)
+	}
+
+	records := source.Records()
+	require.Equal(t, 4, len(records))
+	for _, r := range records {
+		if r.PeerId == older.String() {
+			r.LastSeen -= 60
+		}
+	}
+
+	p := newRecordsTestStatus()
+	redial := p.Restore(records)
+	require.Equal(t, 2, len(redial))
+	assert.Equal(t, newer, redial[0].ID)
+	assert.Equal(t, older, redial[1].ID)
+	assert.DeepEqual(t, []ma.Multiaddr{address}, redial[0].Addrs)
+
+	for _, pid := range []peer.ID{older, newer, inbound, bad} {
+		state, err := p.ConnectionState(pid)
+		require.NoError(t, err)
+		assert.Equal(t, peers.PeerDisconnected, state)
+	}
+	dir, err := p.Direction(inbound)
+	require.NoError(t, err)
+	assert.Equal(t, network.DirInbound, dir)
+	assert.Equal(t, true, p.IsBad(bad))
+	assert.Equal(t, false, p.IsBad(newer))
+
+	// Known peers are not overridden.
+	assert.Equal(t, 0, len(p.Restore(records)))
+}
@@ -19,7 +19,8 @@
 //
 // Peer information is persistent for the run of the service. This allows for collection of useful
 // long-term statistics such as number of bad responses obtained from the peer, giving the basis for
-// decisions to not talk to known-bad peers (by de-scoring them).
+// decisions to not talk to known-bad peers (by de-scoring them). Peer records can be exported and
+// restored, so that this information survives restarts of the service.
 package peers
 
 import (
@@ -76,6 +77,9 @@ const (
 	MaxBackOffDuration = 5000
 )
 
+// IndefiniteBanExpiry is the ban expiry of peers banned without a duration.
+var IndefiniteBanExpiry = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
 // Status is the structure holding the peer status information.
 type Status struct {
 	ctx       context.Context
@@ -298,6 +302,9 @@ func (p *Status) SetConnectionState(pid peer.ID, state peerdata.PeerConnectionSt
 	defer p.store.Unlock()
 
 	peerData := p.store.PeerDataGetOrCreate(pid)
+	if state == PeerConnected || peerData.ConnState == PeerConnected {
+		peerData.LastSeen = prysmTime.Now()
+	}
 	peerData.ConnState = state
 }
 
@@ -335,9 +342,91 @@ func (p *Status) IsBad(pid peer.ID) bool {
 
 // isBad is the lock-free version of IsBad.
 func (p *Status) isBad(pid peer.ID) bool {
+	if p.isBanned(pid) {
+		return true
+	}
+	// Trusted peers are never deemed bad by scoring.
+	if p.store.IsTrustedPeer(pid) {
+		return false
+	}
 	return p.isfromBadIP(pid) || p.scorers.IsBadPeerNoLock(pid)
 }
 
+// isBanned returns whether the peer is under a ban, either set explicitly or restored from a
+// persisted peer record.
+// This method assumes the store lock is acquired before executing the method.
+func (p *Status) isBanned(pid peer.ID) bool {
+	peerData, ok := p.store.PeerData(pid)
+	return ok && peerData.BannedUntil.After(prysmTime.Now())
+}
+
+// Ban bans the given peer for the given duration, or indefinitely if the duration is zero.
+// Banned peers are deemed bad, even if they are trusted.
+func (p *Status) Ban(pid peer.ID, duration time.Duration) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	bannedUntil := IndefiniteBanExpiry
+	if duration > 0 {
+		bannedUntil = prysmTime.Now().Add(duration)
+	}
+	p.store.PeerDataGetOrCreate(pid).BannedUntil = bannedUntil
+}
+
+// Unban lifts the ban of the given peer, if any.
+func (p *Status) Unban(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		peerData.BannedUntil = time.Time{}
+	}
+}
+
+// Banned returns the currently banned peers, along with the expiry of their ban.
+func (p *Status) Banned() map[peer.ID]time.Time {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	now := prysmTime.Now()
+	banned := make(map[peer.ID]time.Time)
+	for pid, peerData := range p.store.Peers() {
+		if peerData.BannedUntil.After(now) {
+			banned[pid] = peerData.BannedUntil
+		}
+	}
+	return banned
+}
+
+// SetTrustedPeers marks the given peers as trusted. Trusted peers are never pruned
+// and are not deemed bad by the scorers.
+func (p *Status) SetTrustedPeers(pids []peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.SetTrustedPeers(pids)
+}
+
+// GetTrustedPeers returns the trusted peers.
+func (p *Status) GetTrustedPeers() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.store.GetTrustedPeers()
+}
+
+// DeleteTrustedPeers removes the given peers from the trusted peers.
+func (p *Status) DeleteTrustedPeers(pids []peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.DeleteTrustedPeers(pids)
+}
+
+// IsTrustedPeer checks whether the given peer is trusted.
+func (p *Status) IsTrustedPeer(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.store.IsTrustedPeer(pid)
+}
+
 // NextValidTime gets the earliest possible time it is to contact/dial
 // a peer again. This is used to back-off from peers in the event
 // they are 'full' or have banned us.
@@ -769,7 +858,7 @@ func (p *Status) PeersToPrune() []peer.ID {
 	// Select connected and inbound peers to prune.
 	for pid, peerData := range p.store.Peers() {
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound && !p.store.IsTrustedPeer(pid) {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:   pid,
 				score: p.scorers.ScoreNoLock(pid),
@@ -835,7 +924,7 @@ func (p *Status) deprecatedPeersToPrune() []peer.ID {
 	// Select connected and inbound peers to prune.
 	for pid, peerData := range p.store.Peers() {
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound && !p.store.IsTrustedPeer(pid) {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
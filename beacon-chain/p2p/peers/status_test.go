@@ -755,6 +755,86 @@ func TestPrunePeers(t *testing.T) {
 	}
 }
 
+func TestPrunePeers_TrustedPeers(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+		},
+	})
+	for i := 0; i < 15; i++ {
+		createPeer(t, p, nil, network.DirOutbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	var trusted []peer.ID
+	for i := 0; i < 18; i++ {
+		pid := createPeer(t, p, nil, network.DirInbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+		if i < 16 {
+			trusted = append(trusted, pid)
+		}
+	}
+	p.SetTrustedPeers(trusted)
+	assert.Equal(t, 16, len(p.GetTrustedPeers()))
+
+	// Only the two untrusted inbound peers can be pruned.
+	peersToPrune := p.PeersToPrune()
+	assert.Equal(t, 2, len(peersToPrune))
+	for _, pid := range peersToPrune {
+		assert.Equal(t, false, p.IsTrustedPeer(pid))
+	}
+
+	p.DeleteTrustedPeers(trusted[:1])
+	assert.Equal(t, false, p.IsTrustedPeer(trusted[0]))
+	assert.Equal(t, 3, len(p.PeersToPrune()))
+}
+
+func TestStatus_TrustedPeersAreNotBad(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+		},
+	})
+	pid := addPeer(t, p, peers.PeerConnected)
+	p.Scorers().BadResponsesScorer().Increment(pid)
+	assert.Equal(t, true, p.IsBad(pid))
+
+	p.SetTrustedPeers([]peer.ID{pid})
+	assert.Equal(t, false, p.IsBad(pid))
+
+	// An explicit ban takes precedence over trust.
+	p.Ban(pid, 0)
+	assert.Equal(t, true, p.IsBad(pid))
+}
+
+func TestStatus_BanUnban(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	pid := addPeer(t, p, peers.PeerConnected)
+	assert.Equal(t, false, p.IsBad(pid))
+
+	p.Ban(pid, time.Hour)
+	assert.Equal(t, true, p.IsBad(pid))
+	banned := p.Banned()
+	require.Equal(t, 1, len(banned))
+	assert.Equal(t, true, banned[pid].After(time.Now().Add(59*time.Minute)))
+
+	p.Unban(pid)
+	assert.Equal(t, false, p.IsBad(pid))
+	assert.Equal(t, 0, len(p.Banned()))
+
+	// Unknown peers can be banned indefinitely.
+	unknown := peer.ID("unknown")
+	p.Ban(unknown, 0)
+	assert.Equal(t, true, p.IsBad(unknown))
+	assert.Equal(t, peers.IndefiniteBanExpiry, p.Banned()[unknown])
+}
+
 func TestStatus_BestPeer(t *testing.T) {
 	type peerConfig struct {
 		headSlot       types.Slot
@@ -25,6 +25,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers/peerdb"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
@@ -67,6 +68,7 @@ type Service struct {
 	cfg                   *Config
 	peers                 *peers.Status
 	addrFilter            *multiaddr.Filters
+	ipBans                *multiaddr.Filters
 	ipLimiter             *leakybucket.Collector
 	privKey               *ecdsa.PrivateKey
 	metaData              metadata.Metadata
@@ -84,6 +86,8 @@ type Service struct {
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
 	activeValidatorCount  uint64
+	peerDB                *peerdb.Store
+	persistedPeers        []peer.AddrInfo
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -99,6 +103,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		cancel:        cancel,
 		cfg:           cfg,
 		isPreGenesis:  true,
+		ipBans:        multiaddr.NewFilters(),
 		joinedTopics:  make(map[string]*pubsub.Topic, len(gossipTopicMappings)),
 		subnetsLock:   make(map[uint64]*sync.RWMutex),
 	}
@@ -161,6 +166,9 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 			},
 		},
 	})
+	if s.cfg.DataDir != "" {
+		s.openPeerDB()
+	}
 
 	// Initialize Data maps.
 	types.InitializeDataMaps()
@@ -218,6 +226,7 @@ func (s *Service) Start() {
 		}
 		s.connectWithAllPeers(addrs)
 	}
+	s.redialPersistedPeers()
 	// Initialize metadata according to the
 	// current epoch.
 	s.RefreshENR()
@@ -231,6 +240,7 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
+	async.RunEvery(s.ctx, peerRecordsSaveInterval, s.savePeerRecords)
 	async.RunEvery(s.ctx, params.BeaconNetworkConfig().RespTimeout, s.updateMetrics)
 	async.RunEvery(s.ctx, refreshRate, s.RefreshENR)
 	async.RunEvery(s.ctx, 1*time.Minute, func() {
@@ -266,7 +276,7 @@ func (s *Service) Stop() error {
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
 	}
-	return nil
+	return s.closePeerDB()
 }
 
 // Status of the p2p service. Will return an error if the service is considered unhealthy to
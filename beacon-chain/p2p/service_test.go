@@ -83,7 +83,7 @@ func createHost(t *testing.T, port int) (host.Host, *ecdsa.PrivateKey, net.IP) {
 
 func TestService_Stop_SetsStartedToFalse(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
-	s, err := NewService(context.Background(), &Config{StateNotifier: &mock.MockStateNotifier{}})
+	s, err := NewService(context.Background(), &Config{StateNotifier: &mock.MockStateNotifier{}, DataDir: t.TempDir()})
 	require.NoError(t, err)
 	s.started = true
 	s.dv5Listener = &mockListener{}
@@ -93,7 +93,7 @@ func TestService_Stop_SetsStartedToFalse(t *testing.T) {
 
 func TestService_Stop_DontPanicIfDv5ListenerIsNotInited(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
-	s, err := NewService(context.Background(), &Config{StateNotifier: &mock.MockStateNotifier{}})
+	s, err := NewService(context.Background(), &Config{StateNotifier: &mock.MockStateNotifier{}, DataDir: t.TempDir()})
 	require.NoError(t, err)
 	assert.NoError(t, s.Stop())
 }
@@ -106,6 +106,7 @@ func TestService_Start_OnlyStartsOnce(t *testing.T) {
 		TCPPort:       2000,
 		UDPPort:       2000,
 		StateNotifier: &mock.MockStateNotifier{},
+		DataDir:       t.TempDir(),
 	}
 	s, err := NewService(context.Background(), cfg)
 	require.NoError(t, err)
@@ -222,6 +223,7 @@ func TestListenForNewNodes(t *testing.T) {
 
 	cfg.UDPPort = 14000
 	cfg.TCPPort = 14001
+	cfg.DataDir = t.TempDir()
 
 	s, err = NewService(context.Background(), cfg)
 	require.NoError(t, err)
@@ -282,7 +284,7 @@ func TestService_JoinLeaveTopic(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
-	s, err := NewService(ctx, &Config{StateNotifier: &mock.MockStateNotifier{}})
+	s, err := NewService(ctx, &Config{StateNotifier: &mock.MockStateNotifier{}, DataDir: t.TempDir()})
 	require.NoError(t, err)
 
 	go s.awaitStateInitialized()
@@ -2,6 +2,8 @@ package testing
 
 import (
 	"context"
+	"net"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -128,6 +130,29 @@ func (_ *FakeP2P) Disconnect(_ peer.ID) error {
 	return nil
 }
 
+// BanPeer -- fake.
+func (_ *FakeP2P) BanPeer(_ peer.ID, _ time.Duration) error {
+	return nil
+}
+
+// UnbanPeer -- fake.
+func (_ *FakeP2P) UnbanPeer(_ peer.ID) {}
+
+// BanIPRange -- fake.
+func (_ *FakeP2P) BanIPRange(_ net.IPNet) error {
+	return nil
+}
+
+// UnbanIPRange -- fake.
+func (_ *FakeP2P) UnbanIPRange(_ net.IPNet) bool {
+	return false
+}
+
+// BannedIPRanges -- fake.
+func (_ *FakeP2P) BannedIPRanges() []net.IPNet {
+	return nil
+}
+
 // Broadcast -- fake.
 func (_ *FakeP2P) Broadcast(_ context.Context, _ proto.Message) error {
 	return nil
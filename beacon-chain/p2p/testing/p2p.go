@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -240,6 +241,35 @@ func (p *TestP2P) Disconnect(pid peer.ID) error {
 	return p.BHost.Network().ClosePeer(pid)
 }
 
+// BanPeer bans the given peer in the peers status and disconnects it.
+func (p *TestP2P) BanPeer(pid peer.ID, duration time.Duration) error {
+	p.peers.Ban(pid, duration)
+	if p.BHost.Network().Connectedness(pid) == network.Connected {
+		return p.Disconnect(pid)
+	}
+	return nil
+}
+
+// UnbanPeer lifts the ban of the given peer in the peers status.
+func (p *TestP2P) UnbanPeer(pid peer.ID) {
+	p.peers.Unban(pid)
+}
+
+// BanIPRange -- fake.
+func (_ *TestP2P) BanIPRange(_ net.IPNet) error {
+	return nil
+}
+
+// UnbanIPRange -- fake.
+func (_ *TestP2P) UnbanIPRange(_ net.IPNet) bool {
+	return false
+}
+
+// BannedIPRanges -- fake.
+func (_ *TestP2P) BannedIPRanges() []net.IPNet {
+	return nil
+}
+
 // PeerID returns the Peer ID of the local peer.
 func (p *TestP2P) PeerID() peer.ID {
 	return p.BHost.ID()
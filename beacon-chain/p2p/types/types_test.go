@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
@@ -42,6 +43,7 @@ func TestErrorResponse_Limit(t *testing.T) {
 func TestRoundTripSerialization(t *testing.T) {
 	roundTripTestBlocksByRootReq(t)
 	roundTripTestErrorMessage(t)
+	roundTripTestLightClientBootstrapReq(t)
 }
 
 func roundTripTestBlocksByRootReq(t *testing.T) {
@@ -72,6 +74,19 @@ func roundTripTestErrorMessage(t *testing.T) {
 	assert.DeepEqual(t, []byte(newVal), errMsg)
 }
 
+func roundTripTestLightClientBootstrapReq(t *testing.T) {
+	req := LightClientBootstrapReq{'r', 'o', 'o', 't'}
+
+	marshalledObj, err := req.MarshalSSZ()
+	require.NoError(t, err)
+	assert.Equal(t, 32, len(marshalledObj))
+	newVal := LightClientBootstrapReq{}
+
+	require.NoError(t, newVal.UnmarshalSSZ(marshalledObj))
+	assert.Equal(t, req, newVal)
+	require.ErrorIs(t, newVal.UnmarshalSSZ(marshalledObj[1:]), ssz.ErrIncorrectByteSize)
+}
+
 func TestSSZBytes_HashTreeRoot(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -418,6 +418,10 @@ func receiveEvents(eventChan <-chan *sse.Event, w http.ResponseWriter, req *http
 				data = &SignedContributionAndProofJson{}
 			case events.BLSToExecutionChangeTopic:
 				data = &SignedBLSToExecutionChangeJson{}
+			case events.PayloadAttributesTopic:
+				data = &EventPayloadAttributeStreamJson{}
+			case events.BlockGossipTopic:
+				data = &EventBlockGossipJson{}
 			case "error":
 				data = &EventErrorJson{}
 			default:
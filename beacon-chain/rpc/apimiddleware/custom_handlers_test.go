@@ -288,6 +288,60 @@ data: {"block":"0x666f6f","state":"0x666f6f","epoch":"1","execution_optimistic":
 	assert.DeepEqual(t, expectedEvent, w.Body.String())
 }
 
+func TestReceiveEvents_PayloadAttributes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan *sse.Event)
+	w := httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	req := httptest.NewRequest("GET", "http://foo.example", &bytes.Buffer{})
+	req = req.WithContext(ctx)
+
+	go func() {
+		base64Val := "Zm9v"
+		data := &EventPayloadAttributeStreamJson{
+			Version: "capella",
+			Data: &EventPayloadAttributeJson{
+				ProposerIndex:     "1",
+				ProposalSlot:      "2",
+				ParentBlockNumber: "3",
+				ParentBlockRoot:   base64Val,
+				ParentBlockHash:   base64Val,
+				PayloadAttributes: &PayloadAttributesV2Json{
+					Timestamp:             "4",
+					PrevRandao:            base64Val,
+					SuggestedFeeRecipient: base64Val,
+					Withdrawals: []*WithdrawalJson{
+						{
+							WithdrawalIndex:  "5",
+							ValidatorIndex:   "6",
+							ExecutionAddress: base64Val,
+							Amount:           "7",
+						},
+					},
+				},
+			},
+		}
+		bData, err := json.Marshal(data)
+		require.NoError(t, err)
+		msg := &sse.Event{
+			Data:  bData,
+			Event: []byte(events.PayloadAttributesTopic),
+		}
+		ch <- msg
+		time.Sleep(time.Second)
+		cancel()
+	}()
+
+	errJson := receiveEvents(ch, w, req)
+	assert.Equal(t, true, errJson == nil)
+
+	expectedEvent := `event: payload_attributes
+data: {"version":"capella","data":{"proposer_index":"1","proposal_slot":"2","parent_block_number":"3","parent_block_root":"0x666f6f","parent_block_hash":"0x666f6f","payload_attributes":{"timestamp":"4","prev_randao":"0x666f6f","suggested_fee_recipient":"0x666f6f","withdrawals":[{"index":"5","validator_index":"6","address":"0x666f6f","amount":"7"}]}}}
+
+`
+	assert.DeepEqual(t, expectedEvent, w.Body.String())
+}
+
 func TestReceiveEvents_AggregatedAtt(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	ch := make(chan *sse.Event)
@@ -300,6 +300,14 @@ type SubmitContributionAndProofsRequestJson struct {
 	Data []*SignedContributionAndProofJson `json:"data"`
 }
 
+type AggregatedBeaconCommitteeSelectionsResponseJson struct {
+	Data []*BeaconCommitteeSelectionJson `json:"data"`
+}
+
+type AggregatedSyncCommitteeSelectionsResponseJson struct {
+	Data []*SyncCommitteeSelectionJson `json:"data"`
+}
+
 type ForkChoiceNodeResponseJson struct {
 	Slot               string                       `json:"slot"`
 	BlockRoot          string                       `json:"block_root" hex:"true"`
@@ -994,6 +1002,19 @@ type SyncCommitteeContributionJson struct {
 	Signature         string `json:"signature" hex:"true"`
 }
 
+type BeaconCommitteeSelectionJson struct {
+	ValidatorIndex string `json:"validator_index"`
+	Slot           string `json:"slot"`
+	SelectionProof string `json:"selection_proof" hex:"true"`
+}
+
+type SyncCommitteeSelectionJson struct {
+	ValidatorIndex    string `json:"validator_index"`
+	Slot              string `json:"slot"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+	SelectionProof    string `json:"selection_proof" hex:"true"`
+}
+
 type ValidatorRegistrationJson struct {
 	FeeRecipient string `json:"fee_recipient" hex:"true"`
 	GasLimit     string `json:"gas_limit"`
@@ -1150,6 +1171,32 @@ type EventChainReorgJson struct {
 	ExecutionOptimistic bool   `json:"execution_optimistic"`
 }
 
+type EventBlockGossipJson struct {
+	Slot  string `json:"slot"`
+	Block string `json:"block" hex:"true"`
+}
+
+type EventPayloadAttributeStreamJson struct {
+	Version string                     `json:"version"`
+	Data    *EventPayloadAttributeJson `json:"data"`
+}
+
+type EventPayloadAttributeJson struct {
+	ProposerIndex     string                   `json:"proposer_index"`
+	ProposalSlot      string                   `json:"proposal_slot"`
+	ParentBlockNumber string                   `json:"parent_block_number"`
+	ParentBlockRoot   string                   `json:"parent_block_root" hex:"true"`
+	ParentBlockHash   string                   `json:"parent_block_hash" hex:"true"`
+	PayloadAttributes *PayloadAttributesV2Json `json:"payload_attributes"`
+}
+
+type PayloadAttributesV2Json struct {
+	Timestamp             string            `json:"timestamp"`
+	PrevRandao            string            `json:"prev_randao" hex:"true"`
+	SuggestedFeeRecipient string            `json:"suggested_fee_recipient" hex:"true"`
+	Withdrawals           []*WithdrawalJson `json:"withdrawals"`
+}
+
 // ---------------
 // Error handling.
 // ---------------
@@ -0,0 +1,83 @@
+package blockfetcher
+
+import (
+	"context"
+	"testing"
+
+	chainMock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	testDB "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	genBlk := util.NewBeaconBlock()
+	genRoot, err := genBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, genBlk)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genRoot))
+
+	blks := make([]interfaces.SignedBeaconBlock, 0)
+	roots := make([][32]byte, 0)
+	for i := types.Slot(1); i <= 4; i++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = i
+		b.Block.ParentRoot = genRoot[:]
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		blks = append(blks, wsb)
+		roots = append(roots, r)
+	}
+	require.NoError(t, beaconDB.SaveBlocks(ctx, blks))
+
+	p := &BlockProvider{
+		BeaconDB: beaconDB,
+		ChainInfoFetcher: &chainMock.ChainService{
+			DB:                  beaconDB,
+			Block:               blks[3],
+			FinalizedCheckPoint: &ethpb.Checkpoint{Root: roots[1][:]},
+			CanonicalRoots:      map[[32]byte]bool{roots[2]: true},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		blockId []byte
+		want    types.Slot
+	}{
+		{name: "head", blockId: []byte("head"), want: 4},
+		{name: "finalized", blockId: []byte("finalized"), want: 2},
+		{name: "genesis", blockId: []byte("genesis"), want: 0},
+		{name: "root", blockId: roots[0][:], want: 1},
+		{name: "slot", blockId: []byte("3"), want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blk, err := p.Block(ctx, tt.blockId)
+			require.NoError(t, err)
+			require.NoError(t, blocks.BeaconBlockIsNil(blk))
+			assert.Equal(t, tt.want, blk.Block().Slot())
+		})
+	}
+
+	t.Run("no block at slot", func(t *testing.T) {
+		blk, err := p.Block(ctx, []byte("30"))
+		require.NoError(t, err)
+		assert.Equal(t, true, blk == nil)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := p.Block(ctx, []byte("foo"))
+		_, ok := err.(*BlockIdParseError)
+		assert.Equal(t, true, ok)
+	})
+}
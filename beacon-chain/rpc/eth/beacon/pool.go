@@ -88,6 +88,9 @@ func (bs *Server) SubmitAttestations(ctx context.Context, req *ethpbv1.SubmitAtt
 		})
 
 		validAttestations = append(validAttestations, att)
+		if bs.ActivityObserver != nil {
+			bs.ActivityObserver.ObserveAttestation(att)
+		}
 
 		go func() {
 			ctx = trace.NewContext(context.Background(), trace.FromContext(ctx))
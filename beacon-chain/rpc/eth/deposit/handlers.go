@@ -0,0 +1,46 @@
+package deposit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v3/network/httputil"
+	"go.opencensus.io/trace"
+)
+
+// DepositSnapshot is an HTTP handler for Beacon API getDepositSnapshot.
+// It returns the EIP-4881 snapshot of the finalized deposit tree.
+func (s *Server) DepositSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "deposit.DepositSnapshot")
+	defer span.End()
+
+	finalized := s.DepositFetcher.FinalizedDeposits(ctx)
+	if finalized == nil || finalized.Deposits == nil {
+		httputil.HandleError(w, "Could not find finalized deposits", http.StatusNotFound)
+		return
+	}
+	snapshot, err := finalized.Deposits.GetSnapshot()
+	if err != nil {
+		httputil.HandleError(w, "Could not get deposit snapshot: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if snapshot.DepositCount() == 0 {
+		httputil.HandleError(w, "No finalized deposits", http.StatusNotFound)
+		return
+	}
+	finalizedHashes := snapshot.Finalized()
+	depositRoot := snapshot.DepositRoot()
+	executionBlockHash := snapshot.ExecutionBlockHash()
+	resp := &DepositSnapshot{
+		Finalized:            make([]string, len(finalizedHashes)),
+		DepositRoot:          hexutil.Encode(depositRoot[:]),
+		DepositCount:         strconv.FormatUint(snapshot.DepositCount(), 10),
+		ExecutionBlockHash:   hexutil.Encode(executionBlockHash[:]),
+		ExecutionBlockHeight: strconv.FormatUint(snapshot.ExecutionBlockHeight(), 10),
+	}
+	for i := range finalizedHashes {
+		resp.Finalized[i] = hexutil.Encode(finalizedHashes[i][:])
+	}
+	httputil.WriteJson(w, &DepositSnapshotResponse{Data: resp})
+}
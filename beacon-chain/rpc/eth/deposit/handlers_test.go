@@ -0,0 +1,84 @@
+package deposit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositsnapshot"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	dc, err := depositcache.New()
+	require.NoError(t, err)
+	s := &Server{DepositFetcher: dc}
+
+	t.Run("no finalized deposits", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.DepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+
+	for i := 0; i < 5; i++ {
+		dep := &ethpb.Deposit{Data: &ethpb.Deposit_Data{
+			PublicKey:             bytesutil.PadTo([]byte(fmt.Sprintf("%d", i)), 48),
+			WithdrawalCredentials: make([]byte, 32),
+			Signature:             make([]byte, 96),
+		}}
+		require.NoError(t, dc.InsertDeposit(ctx, dep, uint64(i), int64(i), [32]byte{}))
+	}
+	executionHash := common.HexToHash("0x1234")
+	require.NoError(t, dc.InsertFinalizedDeposits(ctx, 3, executionHash, 100))
+	want, err := dc.FinalizedDeposits(ctx).Deposits.GetSnapshot()
+	require.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.DepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &DepositSnapshotResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, "4", resp.Data.DepositCount)
+		assert.Equal(t, "100", resp.Data.ExecutionBlockHeight)
+		assert.Equal(t, hexutil.Encode(executionHash[:]), resp.Data.ExecutionBlockHash)
+		root := want.DepositRoot()
+		assert.Equal(t, hexutil.Encode(root[:]), resp.Data.DepositRoot)
+		finalized := want.Finalized()
+		require.Equal(t, len(finalized), len(resp.Data.Finalized))
+		for i := range finalized {
+			assert.Equal(t, hexutil.Encode(finalized[i][:]), resp.Data.Finalized[i])
+		}
+
+		// The served snapshot must be usable to rebuild the deposit tree.
+		pb := &ethpb.DepositSnapshot{
+			DepositRoot:    hexutil.MustDecode(resp.Data.DepositRoot),
+			DepositCount:   4,
+			ExecutionHash:  hexutil.MustDecode(resp.Data.ExecutionBlockHash),
+			ExecutionDepth: 100,
+		}
+		for _, f := range resp.Data.Finalized {
+			pb.Finalized = append(pb.Finalized, hexutil.MustDecode(f))
+		}
+		_, err := depositsnapshot.DepositTreeFromProto(pb)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,13 @@
+// Package deposit defines the beacon API endpoint serving the EIP-4881 snapshot of the
+// finalized deposit tree, which lets new nodes initialize their deposit tree without
+// scanning the deposit contract logs from genesis.
+package deposit
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositcache"
+)
+
+// Server serves the deposit snapshot endpoint of the beacon API.
+type Server struct {
+	DepositFetcher depositcache.DepositFetcher
+}
@@ -0,0 +1,15 @@
+package deposit
+
+// DepositSnapshotResponse is the response of the deposit snapshot endpoint.
+type DepositSnapshotResponse struct {
+	Data *DepositSnapshot `json:"data"`
+}
+
+// DepositSnapshot is the EIP-4881 snapshot of the finalized deposit tree.
+type DepositSnapshot struct {
+	Finalized            []string `json:"finalized"`
+	DepositRoot          string   `json:"deposit_root"`
+	DepositCount         string   `json:"deposit_count"`
+	ExecutionBlockHash   string   `json:"execution_block_hash"`
+	ExecutionBlockHeight string   `json:"execution_block_height"`
+}
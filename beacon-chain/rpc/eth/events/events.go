@@ -35,6 +35,10 @@ const (
 	SyncCommitteeContributionTopic = "contribution_and_proof"
 	// BLSToExecutionChangeTopic represents a new received BLS to execution change event topic.
 	BLSToExecutionChangeTopic = "bls_to_execution_change"
+	// PayloadAttributesTopic represents a new payload attributes for execution payload building event topic.
+	PayloadAttributesTopic = "payload_attributes"
+	// BlockGossipTopic represents a new received gossip block, before it is imported, event topic.
+	BlockGossipTopic = "block_gossip"
 )
 
 var casesHandled = map[string]bool{
@@ -46,6 +50,8 @@ var casesHandled = map[string]bool{
 	ChainReorgTopic:                true,
 	SyncCommitteeContributionTopic: true,
 	BLSToExecutionChangeTopic:      true,
+	PayloadAttributesTopic:         true,
+	BlockGossipTopic:               true,
 }
 
 // StreamEvents allows requesting all events from a set of topics defined in the Ethereum consensus API standard.
@@ -191,6 +197,26 @@ func handleBlockOperationEvents(
 		}
 		v2Change := migration.V1Alpha1SignedBLSToExecChangeToV2(changeData.Change)
 		return streamData(stream, BLSToExecutionChangeTopic, v2Change)
+	case operation.BlockGossipReceived:
+		if _, ok := requestedTopics[BlockGossipTopic]; !ok {
+			return nil
+		}
+		blkData, ok := event.Data.(*operation.BlockGossipReceivedData)
+		if !ok {
+			return nil
+		}
+		if blkData.SignedBlock == nil || blkData.SignedBlock.IsNil() {
+			return nil
+		}
+		blkRoot, err := blkData.SignedBlock.Block().HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not hash tree root block")
+		}
+		blockGossip := &ethpb.EventBlockGossip{
+			Slot:  blkData.SignedBlock.Block().Slot(),
+			Block: blkRoot[:],
+		}
+		return streamData(stream, BlockGossipTopic, blockGossip)
 	default:
 		return nil
 	}
@@ -227,6 +253,15 @@ func handleStateEvents(
 			return nil
 		}
 		return streamData(stream, ChainReorgTopic, reorg)
+	case statefeed.PayloadAttributes:
+		if _, ok := requestedTopics[PayloadAttributesTopic]; !ok {
+			return nil
+		}
+		attributes, ok := event.Data.(*ethpb.EventPayloadAttribute)
+		if !ok {
+			return nil
+		}
+		return streamData(stream, PayloadAttributesTopic, attributes)
 	default:
 		return nil
 	}
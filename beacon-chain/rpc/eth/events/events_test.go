@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	enginev1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
 	"github.com/prysmaticlabs/prysm/v3/proto/migration"
 	eth "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
@@ -275,6 +276,45 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 			feed: srv.OperationNotifier.OperationFeed(),
 		})
 	})
+	t.Run(BlockGossipTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		blk := util.HydrateSignedBeaconBlock(&eth.SignedBeaconBlock{
+			Block: &eth.BeaconBlock{
+				Slot: 8,
+			},
+		})
+		wantedBlockRoot, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		genericResponse, err := anypb.New(&ethpb.EventBlockGossip{
+			Slot:  8,
+			Block: wantedBlockRoot[:],
+		})
+		require.NoError(t, err)
+		wantedMessage := &gateway.EventSource{
+			Event: BlockGossipTopic,
+			Data:  genericResponse,
+		}
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{BlockGossipTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: operation.BlockGossipReceived,
+				Data: &operation.BlockGossipReceivedData{
+					SignedBlock: wsb,
+				},
+			},
+			feed: srv.OperationNotifier.OperationFeed(),
+		})
+	})
 }
 
 func TestStreamEvents_StateEvents(t *testing.T) {
@@ -378,6 +418,54 @@ func TestStreamEvents_StateEvents(t *testing.T) {
 			feed: srv.StateNotifier.StateFeed(),
 		})
 	})
+	t.Run(PayloadAttributesTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		wantedAttributes := &ethpb.EventPayloadAttribute{
+			Version: "capella",
+			Data: &ethpb.EventPayloadAttribute_BasePayloadAttribute{
+				ProposerIndex:     2,
+				ProposalSlot:      9,
+				ParentBlockNumber: 7,
+				ParentBlockRoot:   make([]byte, 32),
+				ParentBlockHash:   make([]byte, 32),
+				PayloadAttributes: &enginev1.PayloadAttributesV2{
+					Timestamp:             12,
+					PrevRandao:            make([]byte, 32),
+					SuggestedFeeRecipient: make([]byte, 20),
+					Withdrawals: []*enginev1.Withdrawal{
+						{
+							Index:          1,
+							ValidatorIndex: 2,
+							Address:        make([]byte, 20),
+							Amount:         3,
+						},
+					},
+				},
+			},
+		}
+		genericResponse, err := anypb.New(wantedAttributes)
+		require.NoError(t, err)
+		wantedMessage := &gateway.EventSource{
+			Event: PayloadAttributesTopic,
+			Data:  genericResponse,
+		}
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{PayloadAttributesTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: statefeed.PayloadAttributes,
+				Data: wantedAttributes,
+			},
+			feed: srv.StateNotifier.StateFeed(),
+		})
+	})
 }
 
 func TestStreamEvents_CommaSeparatedTopics(t *testing.T) {
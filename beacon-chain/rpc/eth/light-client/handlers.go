@@ -0,0 +1,221 @@
+package lightclient
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	lightclient "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// maxRequestLightClientUpdates is the maximum number of updates returned by the updates endpoint,
+// MAX_REQUEST_LIGHT_CLIENT_UPDATES in the light client specification.
+const maxRequestLightClientUpdates = 128
+
+// Bootstrap is an HTTP handler for Beacon API getLightClientBootstrap.
+// It returns the light client bootstrap of the requested block root.
+func (s *Server) Bootstrap(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "lightclient.Bootstrap")
+	defer span.End()
+
+	root, err := hexutil.Decode(mux.Vars(r)["block_root"])
+	if err != nil || len(root) != 32 {
+		httputil.HandleError(w, "Invalid block root", http.StatusBadRequest)
+		return
+	}
+	blockRoot := bytesutil.ToBytes32(root)
+	blk, err := s.BeaconDB.Block(ctx, blockRoot)
+	if err != nil {
+		httputil.HandleError(w, "Could not get block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := blocks.BeaconBlockIsNil(blk); err != nil {
+		httputil.HandleError(w, "Block not found", http.StatusNotFound)
+		return
+	}
+	if blk.Version() == version.Phase0 {
+		httputil.HandleError(w, "Light client bootstrap is not available before Altair", http.StatusNotFound)
+		return
+	}
+	st, err := s.StateGen.StateByRoot(ctx, blockRoot)
+	if err != nil {
+		httputil.HandleError(w, "Could not get state: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, st, blk)
+	if err != nil {
+		httputil.HandleError(w, "Could not create light client bootstrap: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &BootstrapResponse{
+		Version: version.String(st.Version()),
+		Data: &Bootstrap{
+			Header:                     headerFromConsensus(bootstrap.Header),
+			CurrentSyncCommittee:       syncCommitteeFromConsensus(bootstrap.CurrentSyncCommittee),
+			CurrentSyncCommitteeBranch: branchFromConsensus(bootstrap.CurrentSyncCommitteeBranch),
+		},
+	})
+}
+
+// Updates is an HTTP handler for Beacon API getLightClientUpdatesByRange.
+// It returns the best light client update of each requested sync committee period, stopping at
+// the first period without an update.
+func (s *Server) Updates(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "lightclient.Updates")
+	defer span.End()
+
+	startPeriod, err := strconv.ParseUint(r.URL.Query().Get("start_period"), 10, 64)
+	if err != nil {
+		httputil.HandleError(w, "Could not parse start_period: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	count, err := strconv.ParseUint(r.URL.Query().Get("count"), 10, 64)
+	if err != nil {
+		httputil.HandleError(w, "Could not parse count: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if count == 0 {
+		httputil.HandleError(w, "Count must be greater than 0", http.StatusBadRequest)
+		return
+	}
+	if count > maxRequestLightClientUpdates {
+		count = maxRequestLightClientUpdates
+	}
+	if startPeriod+count < startPeriod {
+		httputil.HandleError(w, "Requested period range overflows", http.StatusBadRequest)
+		return
+	}
+
+	updates, err := s.BeaconDB.LightClientUpdates(ctx, startPeriod, startPeriod+count-1)
+	if err != nil {
+		httputil.HandleError(w, "Could not get light client updates: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := make([]*UpdateResponse, 0, len(updates))
+	for i, u := range updates {
+		if lightclient.SyncCommitteePeriodAtSlot(u.AttestedHeader.Beacon.Slot) != startPeriod+uint64(i) {
+			break
+		}
+		resp = append(resp, &UpdateResponse{
+			Version: version.String(versionAtSlot(u.AttestedHeader.Beacon.Slot)),
+			Data: &Update{
+				AttestedHeader:          headerFromConsensus(u.AttestedHeader),
+				NextSyncCommittee:       syncCommitteeFromConsensus(u.NextSyncCommittee),
+				NextSyncCommitteeBranch: branchFromConsensus(u.NextSyncCommitteeBranch),
+				FinalizedHeader:         headerFromConsensus(u.FinalizedHeader),
+				FinalityBranch:          branchFromConsensus(u.FinalityBranch),
+				SyncAggregate:           syncAggregateFromConsensus(u.SyncAggregate),
+				SignatureSlot:           strconv.FormatUint(uint64(u.SignatureSlot), 10),
+			},
+		})
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// FinalityUpdate is an HTTP handler for Beacon API getLightClientFinalityUpdate.
+// It returns the latest light client finality update known to the node.
+func (s *Server) FinalityUpdate(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "lightclient.FinalityUpdate")
+	defer span.End()
+
+	u := s.LightClientUpdateFetcher.LatestLightClientFinalityUpdate()
+	if u == nil {
+		httputil.HandleError(w, "No light client finality update available", http.StatusNotFound)
+		return
+	}
+	httputil.WriteJson(w, &FinalityUpdateResponse{
+		Version: version.String(versionAtSlot(u.AttestedHeader.Beacon.Slot)),
+		Data: &FinalityUpdate{
+			AttestedHeader:  headerFromConsensus(u.AttestedHeader),
+			FinalizedHeader: headerFromConsensus(u.FinalizedHeader),
+			FinalityBranch:  branchFromConsensus(u.FinalityBranch),
+			SyncAggregate:   syncAggregateFromConsensus(u.SyncAggregate),
+			SignatureSlot:   strconv.FormatUint(uint64(u.SignatureSlot), 10),
+		},
+	})
+}
+
+// OptimisticUpdate is an HTTP handler for Beacon API getLightClientOptimisticUpdate.
+// It returns the latest light client optimistic update known to the node.
+func (s *Server) OptimisticUpdate(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "lightclient.OptimisticUpdate")
+	defer span.End()
+
+	u := s.LightClientUpdateFetcher.LatestLightClientOptimisticUpdate()
+	if u == nil {
+		httputil.HandleError(w, "No light client optimistic update available", http.StatusNotFound)
+		return
+	}
+	httputil.WriteJson(w, &OptimisticUpdateResponse{
+		Version: version.String(versionAtSlot(u.AttestedHeader.Beacon.Slot)),
+		Data: &OptimisticUpdate{
+			AttestedHeader: headerFromConsensus(u.AttestedHeader),
+			SyncAggregate:  syncAggregateFromConsensus(u.SyncAggregate),
+			SignatureSlot:  strconv.FormatUint(uint64(u.SignatureSlot), 10),
+		},
+	})
+}
+
+// versionAtSlot returns the fork version active at the given slot.
+func versionAtSlot(slot types.Slot) int {
+	epoch := slots.ToEpoch(slot)
+	cfg := params.BeaconConfig()
+	switch {
+	case epoch >= cfg.CapellaForkEpoch:
+		return version.Capella
+	case epoch >= cfg.BellatrixForkEpoch:
+		return version.Bellatrix
+	case epoch >= cfg.AltairForkEpoch:
+		return version.Altair
+	default:
+		return version.Phase0
+	}
+}
+
+func headerFromConsensus(h *ethpb.LightClientHeader) *Header {
+	return &Header{
+		Beacon: &BeaconBlockHeader{
+			Slot:          strconv.FormatUint(uint64(h.Beacon.Slot), 10),
+			ProposerIndex: strconv.FormatUint(uint64(h.Beacon.ProposerIndex), 10),
+			ParentRoot:    hexutil.Encode(h.Beacon.ParentRoot),
+			StateRoot:     hexutil.Encode(h.Beacon.StateRoot),
+			BodyRoot:      hexutil.Encode(h.Beacon.BodyRoot),
+		},
+	}
+}
+
+func syncCommitteeFromConsensus(c *ethpb.SyncCommittee) *SyncCommittee {
+	pubkeys := make([]string, len(c.Pubkeys))
+	for i, pk := range c.Pubkeys {
+		pubkeys[i] = hexutil.Encode(pk)
+	}
+	return &SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: hexutil.Encode(c.AggregatePubkey),
+	}
+}
+
+func syncAggregateFromConsensus(a *ethpb.SyncAggregate) *SyncAggregate {
+	return &SyncAggregate{
+		SyncCommitteeBits:      hexutil.Encode(a.SyncCommitteeBits),
+		SyncCommitteeSignature: hexutil.Encode(a.SyncCommitteeSignature),
+	}
+}
+
+func branchFromConsensus(branch [][]byte) []string {
+	b := make([]string, len(branch))
+	for i, node := range branch {
+		b[i] = hexutil.Encode(node)
+	}
+	return b
+}
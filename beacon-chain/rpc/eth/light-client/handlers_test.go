@@ -0,0 +1,190 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
+	lightclient "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
+	dbtest "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	mockstategen "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen/mock"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func testUpdate(attestedSlot types.Slot) *ethpb.LightClientUpdate {
+	branch := func(depth int) [][]byte {
+		b := make([][]byte, depth)
+		for i := range b {
+			b[i] = make([]byte, 32)
+		}
+		return b
+	}
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpb.LightClientUpdate{
+		AttestedHeader: &ethpb.LightClientHeader{Beacon: util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: attestedSlot})},
+		NextSyncCommittee: &ethpb.SyncCommittee{
+			Pubkeys:         pubkeys,
+			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+		},
+		NextSyncCommitteeBranch: branch(lightclient.SyncCommitteeBranchLength),
+		FinalizedHeader:         &ethpb.LightClientHeader{Beacon: util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{})},
+		FinalityBranch:          branch(lightclient.FinalityBranchLength),
+		SyncAggregate: &ethpb.SyncAggregate{
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		SignatureSlot: attestedSlot + 1,
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	ctx := context.Background()
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	c, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(c))
+	require.NoError(t, genesis.SetNextSyncCommittee(c))
+	b, err := util.GenerateFullBlockAltair(genesis, keys, util.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	st, err := transition.ExecuteStateTransition(ctx, genesis.Copy(), blk)
+	require.NoError(t, err)
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	beaconDB := dbtest.SetupDB(t)
+	util.SaveBlock(t, ctx, beaconDB, b)
+	stateGen := mockstategen.NewMockService()
+	stateGen.AddStateForRoot(st, root)
+	s := &Server{BeaconDB: beaconDB, StateGen: stateGen}
+
+	t.Run("ok", func(t *testing.T) {
+		url := "http://www.example.com/eth/v1/beacon/light_client/bootstrap/" + hexutil.Encode(root[:])
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(root[:])})
+		writer := httptest.NewRecorder()
+		s.Bootstrap(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &BootstrapResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "altair", resp.Version)
+		assert.Equal(t, "1", resp.Data.Header.Beacon.Slot)
+		assert.Equal(t, hexutil.Encode(c.AggregatePubkey), resp.Data.CurrentSyncCommittee.AggregatePubkey)
+		assert.Equal(t, lightclient.SyncCommitteeBranchLength, len(resp.Data.CurrentSyncCommitteeBranch))
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		unknown := hexutil.Encode(make([]byte, 32))
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/light_client/bootstrap/"+unknown, nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": unknown})
+		writer := httptest.NewRecorder()
+		s.Bootstrap(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid block root", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/light_client/bootstrap/foo", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": "foo"})
+		writer := httptest.NewRecorder()
+		s.Bootstrap(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestUpdates(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	periodSlots := types.Slot(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * params.BeaconConfig().SlotsPerEpoch
+	for _, period := range []uint64{0, 1, 3} {
+		require.NoError(t, beaconDB.SaveLightClientUpdate(ctx, period, testUpdate(types.Slot(period)*periodSlots+1)))
+	}
+	s := &Server{BeaconDB: beaconDB}
+
+	t.Run("stops at first missing period", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/light_client/updates?start_period=0&count=5", nil)
+		writer := httptest.NewRecorder()
+		s.Updates(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		var resp []*UpdateResponse
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
+		require.Equal(t, 2, len(resp))
+		for i, u := range resp {
+			assert.Equal(t, strconv.FormatUint(uint64(types.Slot(i)*periodSlots+1), 10), u.Data.AttestedHeader.Beacon.Slot)
+		}
+	})
+	t.Run("count is zero", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/light_client/updates?start_period=0&count=0", nil)
+		writer := httptest.NewRecorder()
+		s.Updates(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("missing start period", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/light_client/updates?count=1", nil)
+		writer := httptest.NewRecorder()
+		s.Updates(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestFinalityUpdate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		update := lightclient.NewLightClientFinalityUpdateFromUpdate(testUpdate(10))
+		s := &Server{LightClientUpdateFetcher: &mock.ChainService{LightClientFinalityUpdate: update}}
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/light_client/finality_update", nil)
+		writer := httptest.NewRecorder()
+		s.FinalityUpdate(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &FinalityUpdateResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "10", resp.Data.AttestedHeader.Beacon.Slot)
+		assert.Equal(t, "11", resp.Data.SignatureSlot)
+		assert.Equal(t, lightclient.FinalityBranchLength, len(resp.Data.FinalityBranch))
+	})
+	t.Run("no update", func(t *testing.T) {
+		s := &Server{LightClientUpdateFetcher: &mock.ChainService{}}
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/light_client/finality_update", nil)
+		writer := httptest.NewRecorder()
+		s.FinalityUpdate(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
+func TestOptimisticUpdate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		update := lightclient.NewLightClientOptimisticUpdateFromUpdate(testUpdate(10))
+		s := &Server{LightClientUpdateFetcher: &mock.ChainService{LightClientOptimisticUpdate: update}}
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/light_client/optimistic_update", nil)
+		writer := httptest.NewRecorder()
+		s.OptimisticUpdate(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &OptimisticUpdateResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "10", resp.Data.AttestedHeader.Beacon.Slot)
+		assert.Equal(t, fmt.Sprintf("%#x", make([]byte, fieldparams.BLSSignatureLength)), resp.Data.SyncAggregate.SyncCommitteeSignature)
+	})
+	t.Run("no update", func(t *testing.T) {
+		s := &Server{LightClientUpdateFetcher: &mock.ChainService{}}
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/light_client/optimistic_update", nil)
+		writer := httptest.NewRecorder()
+		s.OptimisticUpdate(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
@@ -0,0 +1,17 @@
+// Package lightclient defines the beacon API endpoints serving the data light clients need
+// to follow the chain: bootstraps, the best update of each sync committee period and the
+// latest finality and optimistic updates.
+package lightclient
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
+)
+
+// Server serves the light client endpoints of the beacon API.
+type Server struct {
+	BeaconDB                 db.ReadOnlyDatabase
+	StateGen                 stategen.StateManager
+	LightClientUpdateFetcher blockchain.LightClientUpdateFetcher
+}
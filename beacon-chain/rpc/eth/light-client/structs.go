@@ -0,0 +1,85 @@
+package lightclient
+
+// BootstrapResponse is the response of the light client bootstrap endpoint.
+type BootstrapResponse struct {
+	Version string     `json:"version"`
+	Data    *Bootstrap `json:"data"`
+}
+
+// UpdateResponse is a single element of the response of the light client updates endpoint.
+type UpdateResponse struct {
+	Version string  `json:"version"`
+	Data    *Update `json:"data"`
+}
+
+// FinalityUpdateResponse is the response of the light client finality update endpoint.
+type FinalityUpdateResponse struct {
+	Version string          `json:"version"`
+	Data    *FinalityUpdate `json:"data"`
+}
+
+// OptimisticUpdateResponse is the response of the light client optimistic update endpoint.
+type OptimisticUpdateResponse struct {
+	Version string            `json:"version"`
+	Data    *OptimisticUpdate `json:"data"`
+}
+
+// Bootstrap is the data a light client needs to start syncing from a trusted block root.
+type Bootstrap struct {
+	Header                     *Header        `json:"header"`
+	CurrentSyncCommittee       *SyncCommittee `json:"current_sync_committee"`
+	CurrentSyncCommitteeBranch []string       `json:"current_sync_committee_branch"`
+}
+
+// Update is the best light client update of a sync committee period.
+type Update struct {
+	AttestedHeader          *Header        `json:"attested_header"`
+	NextSyncCommittee       *SyncCommittee `json:"next_sync_committee"`
+	NextSyncCommitteeBranch []string       `json:"next_sync_committee_branch"`
+	FinalizedHeader         *Header        `json:"finalized_header"`
+	FinalityBranch          []string       `json:"finality_branch"`
+	SyncAggregate           *SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot           string         `json:"signature_slot"`
+}
+
+// FinalityUpdate is the latest light client update carrying finality information.
+type FinalityUpdate struct {
+	AttestedHeader  *Header        `json:"attested_header"`
+	FinalizedHeader *Header        `json:"finalized_header"`
+	FinalityBranch  []string       `json:"finality_branch"`
+	SyncAggregate   *SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot   string         `json:"signature_slot"`
+}
+
+// OptimisticUpdate is the latest light client update carrying an attested header.
+type OptimisticUpdate struct {
+	AttestedHeader *Header        `json:"attested_header"`
+	SyncAggregate  *SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot  string         `json:"signature_slot"`
+}
+
+// Header is the beacon block header a light client follows.
+type Header struct {
+	Beacon *BeaconBlockHeader `json:"beacon"`
+}
+
+// BeaconBlockHeader is a beacon block header.
+type BeaconBlockHeader struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+// SyncCommittee is a sync committee.
+type SyncCommittee struct {
+	Pubkeys         []string `json:"pubkeys"`
+	AggregatePubkey string   `json:"aggregate_pubkey"`
+}
+
+// SyncAggregate is the sync committee aggregate signature of a block.
+type SyncAggregate struct {
+	SyncCommitteeBits      string `json:"sync_committee_bits"`
+	SyncCommitteeSignature string `json:"sync_committee_signature"`
+}
@@ -0,0 +1,454 @@
+package rewards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
+	coreblocks "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/blockfetcher"
+	rpchelpers "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/network/httputil"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// BlockRewards is an HTTP handler for Beacon API getBlockRewards.
+// It reports the rewards the proposer of the block earned from the operations included in it.
+func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "rewards.BlockRewards")
+	defer span.End()
+
+	blk, ok := s.blockForRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+	st, err := s.blockPreState(ctx, blk)
+	if err != nil {
+		httputil.HandleError(w, "Could not get block pre-state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := blockRewards(ctx, st, blk)
+	if err != nil {
+		httputil.HandleError(w, "Could not compute block rewards: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	optimistic, finalized, err := s.blockStatus(ctx, blk)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &BlockRewardsResponse{
+		Data:                data,
+		ExecutionOptimistic: optimistic,
+		Finalized:           finalized,
+	})
+}
+
+// AttestationRewards is an HTTP handler for Beacon API getAttestationsRewards.
+// It reports the attestation rewards and penalties of the requested validators for the given epoch.
+// The request body is an optional list of validator indices or public keys. All validators are
+// reported when it is empty.
+func (s *Server) AttestationRewards(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "rewards.AttestationRewards")
+	defer span.End()
+
+	e, err := strconv.ParseUint(mux.Vars(r)["epoch"], 10, 64)
+	if err != nil {
+		httputil.HandleError(w, "Could not parse epoch: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	epoch := types.Epoch(e)
+	if epoch < params.BeaconConfig().AltairForkEpoch {
+		httputil.HandleError(w, "Attestation rewards are not supported for Phase 0", http.StatusBadRequest)
+		return
+	}
+	// Rewards for an epoch are applied at the end of the following epoch.
+	currentEpoch := slots.ToEpoch(s.TimeFetcher.CurrentSlot())
+	if epoch+1 >= currentEpoch {
+		httputil.HandleError(w, fmt.Sprintf("Attestation rewards are available after two epoch transitions, current epoch is %d", currentEpoch), http.StatusNotFound)
+		return
+	}
+	endSlot, err := slots.EpochEnd(epoch + 1)
+	if err != nil {
+		httputil.HandleError(w, "Could not get end slot of epoch: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	st, err := s.ReplayerBuilder.ReplayerForSlot(endSlot).ReplayToSlot(ctx, endSlot)
+	if err != nil {
+		httputil.HandleError(w, "Could not get state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	indices, err := requestedValidators(r, st)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	optimistic, err := rpchelpers.IsOptimistic(ctx, st, s.OptimisticModeFetcher)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := attestationRewards(ctx, st, indices)
+	if err != nil {
+		httputil.HandleError(w, "Could not compute attestation rewards: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &AttestationRewardsResponse{
+		Data:                data,
+		ExecutionOptimistic: optimistic,
+		Finalized:           s.FinalizationFetcher.FinalizedCheckpt().Epoch > epoch+1,
+	})
+}
+
+// SyncCommitteeRewards is an HTTP handler for Beacon API getSyncCommitteeRewards.
+// It reports the rewards and penalties of sync committee members for the given block.
+// The request body is an optional list of validator indices or public keys. All committee
+// members are reported when it is empty.
+func (s *Server) SyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "rewards.SyncCommitteeRewards")
+	defer span.End()
+
+	blk, ok := s.blockForRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+	st, err := s.blockPreState(ctx, blk)
+	if err != nil {
+		httputil.HandleError(w, "Could not get block pre-state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	indices, err := requestedValidators(r, st)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := syncCommitteeRewards(st, blk, indices)
+	if err != nil {
+		httputil.HandleError(w, "Could not compute sync committee rewards: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	optimistic, finalized, err := s.blockStatus(ctx, blk)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &SyncCommitteeRewardsResponse{
+		Data:                data,
+		ExecutionOptimistic: optimistic,
+		Finalized:           finalized,
+	})
+}
+
+// blockForRequest fetches the block identified by the block_id path parameter. Errors are written
+// to the response, in which case false is returned.
+func (s *Server) blockForRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) (interfaces.SignedBeaconBlock, bool) {
+	blockId := []byte(mux.Vars(r)["block_id"])
+	if strings.HasPrefix(string(blockId), "0x") {
+		root, err := hexutil.Decode(string(blockId))
+		if err != nil {
+			httputil.HandleError(w, "Invalid block ID: "+err.Error(), http.StatusBadRequest)
+			return nil, false
+		}
+		blockId = root
+	}
+	blk, err := s.BlockFetcher.Block(ctx, blockId)
+	if invalidBlockIdErr, ok := err.(*blockfetcher.BlockIdParseError); ok {
+		httputil.HandleError(w, "Invalid block ID: "+invalidBlockIdErr.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	if err != nil {
+		httputil.HandleError(w, "Could not get block from block ID: "+err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	if err := blocks.BeaconBlockIsNil(blk); err != nil {
+		httputil.HandleError(w, "Could not find requested block: "+err.Error(), http.StatusNotFound)
+		return nil, false
+	}
+	if blk.Version() == version.Phase0 {
+		httputil.HandleError(w, "Rewards are not supported for Phase 0 blocks", http.StatusBadRequest)
+		return nil, false
+	}
+	return blk, true
+}
+
+// blockPreState returns the state the block was applied to, advanced to the slot of the block.
+func (s *Server) blockPreState(ctx context.Context, blk interfaces.SignedBeaconBlock) (state.BeaconState, error) {
+	st, err := s.StateGen.StateByRoot(ctx, blk.Block().ParentRoot())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent state")
+	}
+	return transition.ProcessSlots(ctx, st, blk.Block().Slot())
+}
+
+func (s *Server) blockStatus(ctx context.Context, blk interfaces.SignedBeaconBlock) (optimistic, finalized bool, err error) {
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return false, false, errors.Wrap(err, "could not get block root")
+	}
+	optimistic, err = s.OptimisticModeFetcher.IsOptimisticForRoot(ctx, root)
+	if err != nil {
+		return false, false, errors.Wrap(err, "could not check if block is optimistic")
+	}
+	return optimistic, s.FinalizationFetcher.IsFinalized(ctx, root), nil
+}
+
+// blockRewards applies the operations of the block to its pre-state in the same order as process_operations,
+// and records the proposer's balance change after each of them.
+func blockRewards(ctx context.Context, st state.BeaconState, blk interfaces.SignedBeaconBlock) (*BlockRewards, error) {
+	proposerIndex := blk.Block().ProposerIndex()
+	body := blk.Block().Body()
+	initBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get proposer balance")
+	}
+
+	st, err = coreblocks.ProcessProposerSlashings(ctx, st, body.ProposerSlashings(), validators.SlashValidator)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process proposer slashings")
+	}
+	proposerSlashingsBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get proposer balance")
+	}
+	st, err = coreblocks.ProcessAttesterSlashings(ctx, st, body.AttesterSlashings(), validators.SlashValidator)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process attester slashings")
+	}
+	attesterSlashingsBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get proposer balance")
+	}
+	st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, blk)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process attestations")
+	}
+	attestationsBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get proposer balance")
+	}
+
+	syncAggregate, err := body.SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate")
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	proposerReward, _, err := altair.SyncRewards(activeBalance)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync rewards")
+	}
+	syncAggregateReward := proposerReward * syncAggregate.SyncCommitteeBits.Count()
+
+	proposerSlashingsReward := balanceIncrease(initBalance, proposerSlashingsBalance)
+	attesterSlashingsReward := balanceIncrease(proposerSlashingsBalance, attesterSlashingsBalance)
+	attestationsReward := balanceIncrease(attesterSlashingsBalance, attestationsBalance)
+	total := proposerSlashingsReward + attesterSlashingsReward + attestationsReward + syncAggregateReward
+	return &BlockRewards{
+		ProposerIndex:     strconv.FormatUint(uint64(proposerIndex), 10),
+		Total:             strconv.FormatUint(total, 10),
+		Attestations:      strconv.FormatUint(attestationsReward, 10),
+		SyncAggregate:     strconv.FormatUint(syncAggregateReward, 10),
+		ProposerSlashings: strconv.FormatUint(proposerSlashingsReward, 10),
+		AttesterSlashings: strconv.FormatUint(attesterSlashingsReward, 10),
+	}, nil
+}
+
+// balanceIncrease returns the increase from before to after. A proposer that slashes itself
+// loses balance, which is not a reward.
+func balanceIncrease(before, after uint64) uint64 {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
+
+// attestationRewards computes the attestation rewards of the previous epoch of st, which must be
+// at the last slot of the epoch following the requested one. When indices is nil, every validator is reported.
+func attestationRewards(ctx context.Context, st state.BeaconState, indices []types.ValidatorIndex) (*AttestationRewards, error) {
+	vals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize precompute validators")
+	}
+	vals, bal, err = altair.ProcessEpochParticipation(ctx, st, bal, vals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process epoch participation")
+	}
+	st, err = precompute.ProcessJustificationAndFinalizationPreCompute(st, bal)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process justification and finalization")
+	}
+	st, vals, err = altair.ProcessInactivityScores(ctx, st, vals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process inactivity scores")
+	}
+	deltas, err := altair.AttestationDeltas(st, bal, vals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation deltas")
+	}
+
+	cfg := params.BeaconConfig()
+	idealVals := make([]*precompute.Validator, cfg.MaxEffectiveBalance/cfg.EffectiveBalanceIncrement)
+	for i := range idealVals {
+		idealVals[i] = &precompute.Validator{
+			IsActivePrevEpoch:            true,
+			IsPrevEpochSourceAttester:    true,
+			IsPrevEpochTargetAttester:    true,
+			IsPrevEpochHeadAttester:      true,
+			CurrentEpochEffectiveBalance: uint64(i+1) * cfg.EffectiveBalanceIncrement,
+		}
+	}
+	idealDeltas, err := altair.AttestationDeltas(st, bal, idealVals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get ideal attestation deltas")
+	}
+
+	rewards := &AttestationRewards{
+		IdealRewards: make([]*IdealAttestationReward, len(idealDeltas)),
+	}
+	for i, d := range idealDeltas {
+		rewards.IdealRewards[i] = &IdealAttestationReward{
+			EffectiveBalance: strconv.FormatUint(idealVals[i].CurrentEpochEffectiveBalance, 10),
+			Head:             strconv.FormatUint(d.HeadReward, 10),
+			Target:           strconv.FormatUint(d.TargetReward, 10),
+			Source:           strconv.FormatUint(d.SourceReward, 10),
+			Inactivity:       "0",
+		}
+	}
+	if indices == nil {
+		indices = make([]types.ValidatorIndex, len(deltas))
+		for i := range indices {
+			indices[i] = types.ValidatorIndex(i)
+		}
+	}
+	rewards.TotalRewards = make([]*TotalAttestationReward, len(indices))
+	for i, idx := range indices {
+		d := deltas[idx]
+		rewards.TotalRewards[i] = &TotalAttestationReward{
+			ValidatorIndex: strconv.FormatUint(uint64(idx), 10),
+			Head:           strconv.FormatUint(d.HeadReward, 10),
+			Target:         strconv.FormatInt(int64(d.TargetReward)-int64(d.TargetPenalty), 10),
+			Source:         strconv.FormatInt(int64(d.SourceReward)-int64(d.SourcePenalty), 10),
+			Inactivity:     strconv.FormatInt(-int64(d.InactivityPenalty), 10),
+		}
+	}
+	return rewards, nil
+}
+
+// syncCommitteeRewards computes the reward of every member of the current sync committee of st for
+// participating in the block's sync aggregate, or the penalty for not participating. Validators that
+// appear in the committee more than once receive the sum. When indices is nil, every member is reported.
+func syncCommitteeRewards(st state.BeaconState, blk interfaces.SignedBeaconBlock, indices []types.ValidatorIndex) ([]*SyncCommitteeReward, error) {
+	syncAggregate, err := blk.Block().Body().SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate")
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync committee")
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync rewards")
+	}
+
+	rewards := make(map[types.ValidatorIndex]int64)
+	members := make([]types.ValidatorIndex, 0, len(committee.Pubkeys))
+	for i, pk := range committee.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		if !ok {
+			return nil, fmt.Errorf("sync committee member %#x is not in the validator registry", pk)
+		}
+		if _, ok := rewards[idx]; !ok {
+			members = append(members, idx)
+		}
+		if syncAggregate.SyncCommitteeBits.BitAt(uint64(i)) {
+			rewards[idx] += int64(participantReward)
+		} else {
+			rewards[idx] -= int64(participantReward)
+		}
+	}
+	if indices != nil {
+		members = make([]types.ValidatorIndex, 0, len(indices))
+		for _, idx := range indices {
+			if _, ok := rewards[idx]; ok {
+				members = append(members, idx)
+			}
+		}
+	}
+	data := make([]*SyncCommitteeReward, len(members))
+	for i, idx := range members {
+		data[i] = &SyncCommitteeReward{
+			ValidatorIndex: strconv.FormatUint(uint64(idx), 10),
+			Reward:         strconv.FormatInt(rewards[idx], 10),
+		}
+	}
+	return data, nil
+}
+
+// requestedValidators decodes the list of validator indices or public keys in the request body.
+// It returns nil when the body is empty.
+func requestedValidators(r *http.Request, st state.ReadOnlyBeaconState) ([]types.ValidatorIndex, error) {
+	var ids []string
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil && err != io.EOF {
+			return nil, errors.Wrap(err, "could not decode request body")
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	indices := make([]types.ValidatorIndex, len(ids))
+	for i, id := range ids {
+		if strings.HasPrefix(id, "0x") {
+			pubkey, err := hexutil.Decode(id)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not decode validator public key %s", id)
+			}
+			if len(pubkey) != fieldparams.BLSPubkeyLength {
+				return nil, fmt.Errorf("invalid validator public key length %d", len(pubkey))
+			}
+			idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+			if !ok {
+				return nil, fmt.Errorf("unknown validator public key %s", id)
+			}
+			indices[i] = idx
+			continue
+		}
+		idx, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse validator index %s", id)
+		}
+		if idx >= uint64(st.NumValidators()) {
+			return nil, fmt.Errorf("unknown validator index %d", idx)
+		}
+		indices[i] = types.ValidatorIndex(idx)
+	}
+	return indices, nil
+}
@@ -0,0 +1,267 @@
+package rewards
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	mockstategen "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen/mock"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// altairBlock builds a block at slot 1 on top of an Altair genesis state, with a full sync aggregate,
+// a proposer slashing and an attestation for slot 0.
+func altairBlock(t *testing.T) (state.BeaconState, interfaces.SignedBeaconBlock) {
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	c, err := altair.NextSyncCommittee(context.Background(), genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(c))
+	conf := util.DefaultBlockGenConfig()
+	conf.FullSyncAggregate = true
+	conf.NumProposerSlashings = 1
+	conf.NumAttestations = 1
+	b, err := util.GenerateFullBlockAltair(genesis, keys, conf, 1)
+	require.NoError(t, err)
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return genesis, blk
+}
+
+// blockServer returns a server for the rewards of blk. The mock state manager hands out the
+// parent state without copying it, so every request needs a new server.
+func blockServer(parent state.BeaconState, blk interfaces.SignedBeaconBlock) *Server {
+	sg := mockstategen.NewMockService()
+	sg.AddStateForRoot(parent.Copy(), blk.Block().ParentRoot())
+	return &Server{
+		BlockFetcher:          &testutil.MockBlockFetcher{BlockToReturn: blk},
+		OptimisticModeFetcher: &mock.ChainService{},
+		FinalizationFetcher:   &mock.ChainService{},
+		StateGen:              sg,
+	}
+}
+
+func TestBlockRewards(t *testing.T) {
+	genesis, blk := altairBlock(t)
+	s := blockServer(genesis, blk)
+
+	request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/rewards/blocks/1", nil)
+	request = mux.SetURLVars(request, map[string]string{"block_id": "1"})
+	writer := httptest.NewRecorder()
+	s.BlockRewards(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &BlockRewardsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+
+	st, err := transition.ProcessSlots(context.Background(), genesis.Copy(), 1)
+	require.NoError(t, err)
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	require.NoError(t, err)
+	proposerReward, _, err := altair.SyncRewards(activeBalance)
+	require.NoError(t, err)
+	syncAggregateReward := proposerReward * params.BeaconConfig().SyncCommitteeSize
+
+	assert.Equal(t, strconv.FormatUint(uint64(blk.Block().ProposerIndex()), 10), resp.Data.ProposerIndex)
+	assert.Equal(t, strconv.FormatUint(syncAggregateReward, 10), resp.Data.SyncAggregate)
+	// The proposer reports the slashing itself, so it receives the whole whistleblower reward.
+	whistleblowerReward := params.BeaconConfig().MaxEffectiveBalance / params.BeaconConfig().WhistleBlowerRewardQuotient
+	assert.Equal(t, strconv.FormatUint(whistleblowerReward, 10), resp.Data.ProposerSlashings)
+	assert.Equal(t, "0", resp.Data.AttesterSlashings)
+	attestations, err := strconv.ParseUint(resp.Data.Attestations, 10, 64)
+	require.NoError(t, err)
+	assert.NotEqual(t, uint64(0), attestations)
+	assert.Equal(t, strconv.FormatUint(attestations+syncAggregateReward+whistleblowerReward, 10), resp.Data.Total)
+}
+
+func TestBlockRewards_Errors(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		s := &Server{BlockFetcher: &testutil.MockBlockFetcher{}}
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/rewards/blocks/10", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "10"})
+		writer := httptest.NewRecorder()
+		s.BlockRewards(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("phase 0", func(t *testing.T) {
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+		require.NoError(t, err)
+		s := &Server{BlockFetcher: &testutil.MockBlockFetcher{BlockToReturn: blk}}
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/beacon/rewards/blocks/0", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "0"})
+		writer := httptest.NewRecorder()
+		s.BlockRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "not supported for Phase 0", writer.Body.String())
+	})
+}
+
+func TestSyncCommitteeRewards(t *testing.T) {
+	genesis, blk := altairBlock(t)
+	st, err := transition.ProcessSlots(context.Background(), genesis.Copy(), 1)
+	require.NoError(t, err)
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	require.NoError(t, err)
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	require.NoError(t, err)
+	seats := make(map[types.ValidatorIndex]uint64)
+	for _, pk := range committee.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		require.Equal(t, true, ok)
+		seats[idx]++
+	}
+
+	t.Run("all members", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://www.example.com/eth/v1/beacon/rewards/sync_committee/1", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "1"})
+		writer := httptest.NewRecorder()
+		blockServer(genesis, blk).SyncCommitteeRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SyncCommitteeRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, len(seats), len(resp.Data))
+		for _, r := range resp.Data {
+			idx, err := strconv.ParseUint(r.ValidatorIndex, 10, 64)
+			require.NoError(t, err)
+			assert.Equal(t, strconv.FormatUint(participantReward*seats[types.ValidatorIndex(idx)], 10), r.Reward)
+		}
+	})
+	t.Run("requested validators", func(t *testing.T) {
+		pubkey := genesis.PubkeyAtIndex(1)
+		body, err := json.Marshal([]string{"0", fmt.Sprintf("%#x", pubkey)})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://www.example.com/eth/v1/beacon/rewards/sync_committee/1", bytes.NewReader(body))
+		request = mux.SetURLVars(request, map[string]string{"block_id": "1"})
+		writer := httptest.NewRecorder()
+		blockServer(genesis, blk).SyncCommitteeRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SyncCommitteeRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "0", resp.Data[0].ValidatorIndex)
+		assert.Equal(t, strconv.FormatUint(participantReward*seats[0], 10), resp.Data[0].Reward)
+		assert.Equal(t, "1", resp.Data[1].ValidatorIndex)
+		assert.Equal(t, strconv.FormatUint(participantReward*seats[1], 10), resp.Data[1].Reward)
+	})
+	t.Run("unknown validator", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://www.example.com/eth/v1/beacon/rewards/sync_committee/1", bytes.NewReader([]byte(`["1000"]`)))
+		request = mux.SetURLVars(request, map[string]string{"block_id": "1"})
+		writer := httptest.NewRecorder()
+		blockServer(genesis, blk).SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "unknown validator index 1000", writer.Body.String())
+	})
+}
+
+func TestAttestationRewards(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	endSlot, err := slots.EpochEnd(1)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(endSlot))
+	// Every validator but the first one attested perfectly in epoch 0.
+	participation := make([]byte, st.NumValidators())
+	for i := 1; i < len(participation); i++ {
+		participation[i] = 0b111
+	}
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+
+	currentSlot, err := slots.EpochStart(3)
+	require.NoError(t, err)
+	chain := &mock.ChainService{FinalizedCheckPoint: &ethpb.Checkpoint{Epoch: 0}, Slot: &currentSlot}
+	replayerBuilder := &mockstategen.MockReplayerBuilder{}
+	replayerBuilder.SetMockStateForSlot(st, endSlot)
+	s := &Server{
+		OptimisticModeFetcher: chain,
+		FinalizationFetcher:   chain,
+		TimeFetcher:           chain,
+		ReplayerBuilder:       replayerBuilder,
+	}
+
+	t.Run("requested validators", func(t *testing.T) {
+		pubkey := st.PubkeyAtIndex(1)
+		body, err := json.Marshal([]string{"0", fmt.Sprintf("%#x", pubkey)})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://www.example.com/eth/v1/beacon/rewards/attestations/0", bytes.NewReader(body))
+		request = mux.SetURLVars(request, map[string]string{"epoch": "0"})
+		writer := httptest.NewRecorder()
+		s.AttestationRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, false, resp.Finalized)
+
+		require.Equal(t, int(cfg.MaxEffectiveBalance/cfg.EffectiveBalanceIncrement), len(resp.Data.IdealRewards))
+		ideal := resp.Data.IdealRewards[len(resp.Data.IdealRewards)-1]
+		assert.Equal(t, strconv.FormatUint(cfg.MaxEffectiveBalance, 10), ideal.EffectiveBalance)
+
+		require.Equal(t, 2, len(resp.Data.TotalRewards))
+		missed := resp.Data.TotalRewards[0]
+		assert.Equal(t, "0", missed.ValidatorIndex)
+		assert.Equal(t, "0", missed.Head)
+		assert.Equal(t, "0", missed.Inactivity)
+		source, err := strconv.ParseInt(missed.Source, 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, true, source < 0)
+		target, err := strconv.ParseInt(missed.Target, 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, true, target < 0)
+
+		// A validator with the maximum effective balance that attested perfectly earns the ideal reward.
+		attested := resp.Data.TotalRewards[1]
+		assert.Equal(t, "1", attested.ValidatorIndex)
+		assert.Equal(t, ideal.Head, attested.Head)
+		assert.Equal(t, ideal.Source, attested.Source)
+		assert.Equal(t, ideal.Target, attested.Target)
+		assert.Equal(t, "0", attested.Inactivity)
+	})
+	t.Run("all validators", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://www.example.com/eth/v1/beacon/rewards/attestations/0", nil)
+		request = mux.SetURLVars(request, map[string]string{"epoch": "0"})
+		writer := httptest.NewRecorder()
+		s.AttestationRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, st.NumValidators(), len(resp.Data.TotalRewards))
+	})
+	t.Run("epoch too recent", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://www.example.com/eth/v1/beacon/rewards/attestations/2", nil)
+		request = mux.SetURLVars(request, map[string]string{"epoch": "2"})
+		writer := httptest.NewRecorder()
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid epoch", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://www.example.com/eth/v1/beacon/rewards/attestations/foo", nil)
+		request = mux.SetURLVars(request, map[string]string{"epoch": "foo"})
+		writer := httptest.NewRecorder()
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -0,0 +1,19 @@
+// Package rewards defines the beacon API endpoints reporting the rewards earned by validators
+// for proposing blocks, attesting and taking part in sync committees.
+package rewards
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/blockfetcher"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
+)
+
+// Server serves the rewards endpoints of the beacon API.
+type Server struct {
+	BlockFetcher          blockfetcher.Fetcher
+	OptimisticModeFetcher blockchain.OptimisticModeFetcher
+	FinalizationFetcher   blockchain.FinalizationFetcher
+	TimeFetcher           blockchain.TimeFetcher
+	StateGen              stategen.StateManager
+	ReplayerBuilder       stategen.ReplayerBuilder
+}
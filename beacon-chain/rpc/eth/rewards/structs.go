@@ -0,0 +1,63 @@
+package rewards
+
+// BlockRewardsResponse is the response of the block rewards endpoint.
+type BlockRewardsResponse struct {
+	Data                *BlockRewards `json:"data"`
+	ExecutionOptimistic bool          `json:"execution_optimistic"`
+	Finalized           bool          `json:"finalized"`
+}
+
+// BlockRewards breaks down the rewards earned by the proposer of a block, in Gwei.
+type BlockRewards struct {
+	ProposerIndex     string `json:"proposer_index"`
+	Total             string `json:"total"`
+	Attestations      string `json:"attestations"`
+	SyncAggregate     string `json:"sync_aggregate"`
+	ProposerSlashings string `json:"proposer_slashings"`
+	AttesterSlashings string `json:"attester_slashings"`
+}
+
+// AttestationRewardsResponse is the response of the attestation rewards endpoint.
+type AttestationRewardsResponse struct {
+	Data                *AttestationRewards `json:"data"`
+	ExecutionOptimistic bool                `json:"execution_optimistic"`
+	Finalized           bool                `json:"finalized"`
+}
+
+// AttestationRewards contains the attestation rewards a validator could have earned for each
+// effective balance, and the rewards that the requested validators actually earned.
+type AttestationRewards struct {
+	IdealRewards []*IdealAttestationReward `json:"ideal_rewards"`
+	TotalRewards []*TotalAttestationReward `json:"total_rewards"`
+}
+
+// IdealAttestationReward is the reward of a validator with the given effective balance that attested perfectly.
+type IdealAttestationReward struct {
+	EffectiveBalance string `json:"effective_balance"`
+	Head             string `json:"head"`
+	Target           string `json:"target"`
+	Source           string `json:"source"`
+	Inactivity       string `json:"inactivity"`
+}
+
+// TotalAttestationReward is the reward of a single validator. Penalties are reported as negative values.
+type TotalAttestationReward struct {
+	ValidatorIndex string `json:"validator_index"`
+	Head           string `json:"head"`
+	Target         string `json:"target"`
+	Source         string `json:"source"`
+	Inactivity     string `json:"inactivity"`
+}
+
+// SyncCommitteeRewardsResponse is the response of the sync committee rewards endpoint.
+type SyncCommitteeRewardsResponse struct {
+	Data                []*SyncCommitteeReward `json:"data"`
+	ExecutionOptimistic bool                   `json:"execution_optimistic"`
+	Finalized           bool                   `json:"finalized"`
+}
+
+// SyncCommitteeReward is the reward, or penalty when negative, of a sync committee member for a single block.
+type SyncCommitteeReward struct {
+	ValidatorIndex string `json:"validator_index"`
+	Reward         string `json:"reward"`
+}
@@ -0,0 +1,28 @@
+package validator
+
+import (
+	"net/http"
+
+	"github.com/prysmaticlabs/prysm/v3/network/httputil"
+	"go.opencensus.io/trace"
+)
+
+// BeaconCommitteeSelections is an HTTP handler for Beacon API submitBeaconCommitteeSelections.
+// Aggregating the partial selection proofs of a distributed validator cluster is done by the
+// distributed validator middleware sitting between the validator clients and the beacon node,
+// so the beacon node itself answers, as the spec allows, that the endpoint is not implemented.
+func (vs *Server) BeaconCommitteeSelections(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.BeaconCommitteeSelections")
+	defer span.End()
+
+	httputil.HandleError(w, "Endpoint not implemented, beacon committee selections are aggregated by distributed validator middleware", http.StatusNotImplemented)
+}
+
+// SyncCommitteeSelections is an HTTP handler for Beacon API submitSyncCommitteeSelections.
+// As with BeaconCommitteeSelections, it is only implemented by distributed validator middleware.
+func (vs *Server) SyncCommitteeSelections(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.SyncCommitteeSelections")
+	defer span.End()
+
+	httputil.HandleError(w, "Endpoint not implemented, sync committee selections are aggregated by distributed validator middleware", http.StatusNotImplemented)
+}
@@ -0,0 +1,30 @@
+package validator
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+)
+
+func TestBeaconCommitteeSelections(t *testing.T) {
+	s := &Server{}
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/beacon_committee_selections", bytes.NewBufferString("[]"))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.BeaconCommitteeSelections(writer, request)
+	assert.Equal(t, http.StatusNotImplemented, writer.Code)
+}
+
+func TestSyncCommitteeSelections(t *testing.T) {
+	s := &Server{}
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/sync_committee_selections", bytes.NewBufferString("[]"))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.SyncCommitteeSelections(writer, request)
+	assert.Equal(t, http.StatusNotImplemented, writer.Code)
+}
@@ -115,6 +115,9 @@ func (vs *Server) GetAttesterDuties(ctx context.Context, req *ethpbv1.AttesterDu
 			Slot:                    committee.AttesterSlot,
 		})
 	}
+	if vs.ActivityObserver != nil {
+		vs.ActivityObserver.ObserveValidators(req.Index)
+	}
 
 	root, err := attestationDependentRoot(s, req.Epoch)
 	if err != nil {
@@ -277,6 +280,9 @@ func (vs *Server) GetSyncCommitteeDuties(ctx context.Context, req *ethpbv2.SyncC
 	} else if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not get duties: %v", err)
 	}
+	if vs.ActivityObserver != nil {
+		vs.ActivityObserver.ObserveValidators(req.Index)
+	}
 
 	isOptimistic, err := rpchelpers.IsOptimistic(ctx, st, vs.OptimisticModeFetcher)
 	if err != nil {
@@ -597,6 +603,13 @@ func (vs *Server) PrepareBeaconProposer(
 	defer span.End()
 	var feeRecipients []common.Address
 	var validatorIndices []types.ValidatorIndex
+	if vs.ActivityObserver != nil {
+		indices := make([]types.ValidatorIndex, len(request.Recipients))
+		for i, r := range request.Recipients {
+			indices[i] = r.ValidatorIndex
+		}
+		vs.ActivityObserver.ObserveValidators(indices)
+	}
 	newRecipients := make([]*ethpbv1.PrepareBeaconProposerRequest_FeeRecipientContainer, 0, len(request.Recipients))
 	for _, r := range request.Recipients {
 		f, err := vs.V1Alpha1Server.BeaconDB.FeeRecipientByValidatorID(ctx, r.ValidatorIndex)
@@ -659,6 +672,13 @@ func (vs *Server) SubmitValidatorRegistration(ctx context.Context, reg *ethpbv1.
 	if err := vs.V1Alpha1Server.BlockBuilder.RegisterValidator(ctx, registrations); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Could not register block builder: %v", err)
 	}
+	if vs.ActivityObserver != nil {
+		pubkeys := make([][]byte, len(registrations))
+		for i, registration := range registrations {
+			pubkeys[i] = registration.Message.Pubkey
+		}
+		vs.ActivityObserver.ObserveValidatorPubkeys(pubkeys)
+	}
 
 	return &empty.Empty{}, nil
 }
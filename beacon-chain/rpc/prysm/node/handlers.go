@@ -0,0 +1,280 @@
+package node
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v3/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+)
+
+// ListTrustedPeers lists the trusted peers, which are never pruned nor deemed bad by peer scoring.
+func (s *Server) ListTrustedPeers(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListTrustedPeers")
+	defer span.End()
+
+	status := s.PeersFetcher.Peers()
+	trusted := status.GetTrustedPeers()
+	resp := &TrustedPeersResponse{Data: make([]*TrustedPeer, 0, len(trusted))}
+	for _, pid := range trusted {
+		trustedPeer := &TrustedPeer{PeerId: pid.String()}
+		if addr, err := status.Address(pid); err == nil && addr != nil {
+			trustedPeer.Address = addr.String()
+		}
+		if state, err := status.ConnectionState(pid); err == nil {
+			trustedPeer.State = strings.ToLower(ethpb.ConnectionState(state).String())
+		}
+		if dir, err := status.Direction(pid); err == nil {
+			trustedPeer.Direction = strings.ToLower(dir.String())
+		}
+		resp.Data = append(resp.Data, trustedPeer)
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// AddTrustedPeer adds the peer with the given multiaddress, which must include the peer ID, to the trusted peers.
+func (s *Server) AddTrustedPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.AddTrustedPeer")
+	defer span.End()
+
+	var req AddTrustedPeerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	addr, err := multiaddr.NewMultiaddr(req.Addr)
+	if err != nil {
+		httputil.HandleError(w, "Invalid multiaddress: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	info, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		httputil.HandleError(w, "Could not get peer info from multiaddress: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	status := s.PeersFetcher.Peers()
+	if _, err := status.Address(info.ID); err != nil {
+		var transportAddr multiaddr.Multiaddr
+		if len(info.Addrs) > 0 {
+			transportAddr = info.Addrs[0]
+		}
+		status.Add(nil, info.ID, transportAddr, network.DirUnknown)
+	}
+	status.SetTrustedPeers([]peer.ID{info.ID})
+	w.WriteHeader(http.StatusOK)
+}
+
+// RemoveTrustedPeer removes the given peer from the trusted peers.
+func (s *Server) RemoveTrustedPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.RemoveTrustedPeer")
+	defer span.End()
+
+	pid, err := peer.Decode(mux.Vars(r)["peer_id"])
+	if err != nil {
+		httputil.HandleError(w, "Invalid peer ID: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	status := s.PeersFetcher.Peers()
+	if !status.IsTrustedPeer(pid) {
+		httputil.HandleError(w, "Peer is not trusted", http.StatusNotFound)
+		return
+	}
+	status.DeleteTrustedPeers([]peer.ID{pid})
+	w.WriteHeader(http.StatusOK)
+}
+
+// ListBans lists the banned peers and ip ranges.
+func (s *Server) ListBans(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListBans")
+	defer span.End()
+
+	banned := s.PeersFetcher.Peers().Banned()
+	ipRanges := s.PeerBanner.BannedIPRanges()
+	bans := &Bans{
+		Peers:    make([]*BannedPeer, 0, len(banned)),
+		IPRanges: make([]string, len(ipRanges)),
+	}
+	for pid, bannedUntil := range banned {
+		bans.Peers = append(bans.Peers, &BannedPeer{
+			PeerId:      pid.String(),
+			BannedUntil: strconv.FormatInt(bannedUntil.Unix(), 10),
+		})
+	}
+	for i, ipRange := range ipRanges {
+		bans.IPRanges[i] = ipRange.String()
+	}
+	httputil.WriteJson(w, &BansResponse{Data: bans})
+}
+
+// BanPeer bans the given peer for the given duration, or indefinitely if no duration is provided,
+// and disconnects it.
+func (s *Server) BanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.BanPeer")
+	defer span.End()
+
+	var req BanPeerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		httputil.HandleError(w, "Invalid peer ID: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var duration time.Duration
+	if req.Duration != "" {
+		duration, err = time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			httputil.HandleError(w, "Invalid ban duration: "+req.Duration, http.StatusBadRequest)
+			return
+		}
+	}
+	if err := s.PeerBanner.BanPeer(pid, duration); err != nil {
+		httputil.HandleError(w, "Could not disconnect banned peer: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnbanPeer lifts the ban of the given peer.
+func (s *Server) UnbanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.UnbanPeer")
+	defer span.End()
+
+	pid, err := peer.Decode(mux.Vars(r)["peer_id"])
+	if err != nil {
+		httputil.HandleError(w, "Invalid peer ID: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, ok := s.PeersFetcher.Peers().Banned()[pid]; !ok {
+		httputil.HandleError(w, "Peer is not banned", http.StatusNotFound)
+		return
+	}
+	s.PeerBanner.UnbanPeer(pid)
+	w.WriteHeader(http.StatusOK)
+}
+
+// BanIPRange denies all connections within the given ip range and disconnects the peers within it.
+func (s *Server) BanIPRange(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.BanIPRange")
+	defer span.End()
+
+	var req BanIPRangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, ipnet, err := net.ParseCIDR(req.Cidr)
+	if err != nil {
+		httputil.HandleError(w, "Invalid ip range: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.PeerBanner.BanIPRange(*ipnet); err != nil {
+		httputil.HandleError(w, "Could not disconnect banned peers: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnbanIPRange lifts the ban of the ip range given by the cidr query parameter.
+func (s *Server) UnbanIPRange(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.UnbanIPRange")
+	defer span.End()
+
+	_, ipnet, err := net.ParseCIDR(r.URL.Query().Get("cidr"))
+	if err != nil {
+		httputil.HandleError(w, "Invalid ip range: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !s.PeerBanner.UnbanIPRange(*ipnet) {
+		httputil.HandleError(w, "Ip range is not banned", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// PeerScores returns the score breakdown of every known peer, or of the peer given by the
+// peer_id query parameter.
+func (s *Server) PeerScores(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.PeerScores")
+	defer span.End()
+
+	status := s.PeersFetcher.Peers()
+	pids := status.All()
+	if rawId := r.URL.Query().Get("peer_id"); rawId != "" {
+		pid, err := peer.Decode(rawId)
+		if err != nil {
+			httputil.HandleError(w, "Invalid peer ID: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, err := status.ConnectionState(pid); err != nil {
+			httputil.HandleError(w, "Peer not found", http.StatusNotFound)
+			return
+		}
+		pids = []peer.ID{pid}
+	}
+	resp := &PeerScoresResponse{Data: make([]*PeerScore, 0, len(pids))}
+	for _, pid := range pids {
+		resp.Data = append(resp.Data, peerScore(status, pid))
+	}
+	httputil.WriteJson(w, resp)
+}
+
+func peerScore(status *peers.Status, pid peer.ID) *PeerScore {
+	scorers := status.Scorers()
+	score := &PeerScore{
+		PeerId:       pid.String(),
+		OverallScore: scorers.Score(pid),
+		Trusted:      status.IsTrustedPeer(pid),
+		Bad:          status.IsBad(pid),
+		BlockProvider: &BlockProviderScore{
+			ProcessedBlocks: strconv.FormatUint(scorers.BlockProviderScorer().ProcessedBlocks(pid), 10),
+			Score:           scorers.BlockProviderScorer().Score(pid),
+		},
+		PeerStatus: &PeerStatusScore{
+			Score: scorers.PeerStatusScorer().Score(pid),
+		},
+	}
+	if state, err := status.ConnectionState(pid); err == nil {
+		score.State = strings.ToLower(ethpb.ConnectionState(state).String())
+	}
+	score.BadResponses = &BadResponsesScore{
+		Count: "0",
+		Score: scorers.BadResponsesScorer().Score(pid),
+	}
+	if count, err := scorers.BadResponsesScorer().Count(pid); err == nil {
+		score.BadResponses.Count = strconv.Itoa(count)
+	}
+	if err := scorers.ValidationError(pid); err != nil {
+		score.PeerStatus.ValidationError = err.Error()
+	}
+	score.Gossip = &GossipScore{
+		Score:       scorers.GossipScorer().Score(pid),
+		TopicScores: make(map[string]*TopicScore),
+	}
+	gossipScore, behaviourPenalty, topicScores, err := scorers.GossipScorer().GossipData(pid)
+	if err == nil {
+		score.Gossip.GossipScore = gossipScore
+		score.Gossip.BehaviourPenalty = behaviourPenalty
+		for topic, snapshot := range topicScores {
+			score.Gossip.TopicScores[topic] = &TopicScore{
+				TimeInMesh:               strconv.FormatUint(snapshot.TimeInMesh, 10),
+				FirstMessageDeliveries:   snapshot.FirstMessageDeliveries,
+				MeshMessageDeliveries:    snapshot.MeshMessageDeliveries,
+				InvalidMessageDeliveries: snapshot.InvalidMessageDeliveries,
+			}
+		}
+	}
+	return score
+}
@@ -0,0 +1,171 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+const testPeerId = "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR"
+
+type peersProvider struct {
+	status *peers.Status
+}
+
+func (p *peersProvider) Peers() *peers.Status {
+	return p.status
+}
+
+type peerBanner struct {
+	status   *peers.Status
+	ipRanges []net.IPNet
+}
+
+func (b *peerBanner) BanPeer(pid peer.ID, duration time.Duration) error {
+	b.status.Ban(pid, duration)
+	return nil
+}
+
+func (b *peerBanner) UnbanPeer(pid peer.ID) {
+	b.status.Unban(pid)
+}
+
+func (b *peerBanner) BanIPRange(ipnet net.IPNet) error {
+	b.ipRanges = append(b.ipRanges, ipnet)
+	return nil
+}
+
+func (b *peerBanner) UnbanIPRange(ipnet net.IPNet) bool {
+	for i, r := range b.ipRanges {
+		if r.String() == ipnet.String() {
+			b.ipRanges = append(b.ipRanges[:i], b.ipRanges[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (b *peerBanner) BannedIPRanges() []net.IPNet {
+	return b.ipRanges
+}
+
+func setupServer() *Server {
+	status := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 2,
+			},
+		},
+	})
+	return &Server{
+		PeersFetcher: &peersProvider{status: status},
+		PeerBanner:   &peerBanner{status: status},
+	}
+}
+
+func TestTrustedPeers(t *testing.T) {
+	s := setupServer()
+	url := "http://example.com/prysm/node/trusted_peers"
+
+	writer := testutil.DoRequest(t, s.AddTrustedPeer, http.MethodPost, url, &AddTrustedPeerRequest{Addr: "/ip4/127.0.0.1/tcp/30303"}, nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+	addr := "/ip4/127.0.0.1/tcp/30303/p2p/" + testPeerId
+	writer = testutil.DoRequest(t, s.AddTrustedPeer, http.MethodPost, url, &AddTrustedPeerRequest{Addr: addr}, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	writer = testutil.DoRequest(t, s.ListTrustedPeers, http.MethodGet, url, nil, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &TrustedPeersResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, testPeerId, resp.Data[0].PeerId)
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/30303", resp.Data[0].Address)
+	assert.Equal(t, "disconnected", resp.Data[0].State)
+
+	writer = testutil.DoRequest(t, s.RemoveTrustedPeer, http.MethodDelete, url+"/"+testPeerId, nil, map[string]string{"peer_id": testPeerId})
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, 0, len(s.PeersFetcher.Peers().GetTrustedPeers()))
+
+	writer = testutil.DoRequest(t, s.RemoveTrustedPeer, http.MethodDelete, url+"/"+testPeerId, nil, map[string]string{"peer_id": testPeerId})
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+}
+
+func TestBans(t *testing.T) {
+	s := setupServer()
+	url := "http://example.com/prysm/node/bans"
+
+	writer := testutil.DoRequest(t, s.BanPeer, http.MethodPost, url+"/peers", &BanPeerRequest{PeerId: testPeerId, Duration: "soon"}, nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = testutil.DoRequest(t, s.BanPeer, http.MethodPost, url+"/peers", &BanPeerRequest{PeerId: testPeerId, Duration: "1h"}, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	writer = testutil.DoRequest(t, s.BanIPRange, http.MethodPost, url+"/ip_ranges", &BanIPRangeRequest{Cidr: "10.0.0.0"}, nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = testutil.DoRequest(t, s.BanIPRange, http.MethodPost, url+"/ip_ranges", &BanIPRangeRequest{Cidr: "10.0.0.0/8"}, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	writer = testutil.DoRequest(t, s.ListBans, http.MethodGet, url, nil, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &BansResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data.Peers))
+	assert.Equal(t, testPeerId, resp.Data.Peers[0].PeerId)
+	assert.DeepEqual(t, []string{"10.0.0.0/8"}, resp.Data.IPRanges)
+
+	vars := map[string]string{"peer_id": testPeerId}
+	writer = testutil.DoRequest(t, s.UnbanPeer, http.MethodDelete, url+"/peers/"+testPeerId, nil, vars)
+	require.Equal(t, http.StatusOK, writer.Code)
+	writer = testutil.DoRequest(t, s.UnbanPeer, http.MethodDelete, url+"/peers/"+testPeerId, nil, vars)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+
+	writer = testutil.DoRequest(t, s.UnbanIPRange, http.MethodDelete, url+"/ip_ranges?cidr=10.0.0.0/8", nil, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	writer = testutil.DoRequest(t, s.UnbanIPRange, http.MethodDelete, url+"/ip_ranges?cidr=10.0.0.0/8", nil, nil)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+}
+
+func TestPeerScores(t *testing.T) {
+	s := setupServer()
+	status := s.PeersFetcher.Peers()
+	pid, err := peer.Decode(testPeerId)
+	require.NoError(t, err)
+	status.Add(nil, pid, nil, network.DirInbound)
+	status.SetConnectionState(pid, peers.PeerConnected)
+	status.Scorers().BadResponsesScorer().Increment(pid)
+	status.Scorers().BlockProviderScorer().IncrementProcessedBlocks(pid, 64)
+
+	url := "http://example.com/prysm/node/peers/scores"
+	writer := testutil.DoRequest(t, s.PeerScores, http.MethodGet, url+"?peer_id=foo", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+	writer = testutil.DoRequest(t, s.PeerScores, http.MethodGet, url, nil, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &PeerScoresResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	score := resp.Data[0]
+	assert.Equal(t, testPeerId, score.PeerId)
+	assert.Equal(t, "connected", score.State)
+	assert.Equal(t, "1", score.BadResponses.Count)
+	assert.Equal(t, true, score.BadResponses.Score < 0)
+	assert.Equal(t, "64", score.BlockProvider.ProcessedBlocks)
+	assert.Equal(t, false, score.Bad)
+	assert.Equal(t, true, strings.Contains(writer.Body.String(), "gossip"))
+
+	writer = testutil.DoRequest(t, s.PeerScores, http.MethodGet, url+"?peer_id="+testPeerId, nil, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+}
@@ -0,0 +1,13 @@
+// Package node defines the Prysm API endpoints for managing the peers of the beacon node
+// at runtime: trusted peers, peer and ip range bans, and peer score introspection.
+package node
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+)
+
+// Server serves the Prysm peer management endpoints.
+type Server struct {
+	PeersFetcher p2p.PeersProvider
+	PeerBanner   p2p.PeerBanner
+}
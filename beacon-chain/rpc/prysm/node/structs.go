@@ -0,0 +1,100 @@
+package node
+
+// AddTrustedPeerRequest is the request body of the add trusted peer endpoint.
+type AddTrustedPeerRequest struct {
+	Addr string `json:"addr"`
+}
+
+// TrustedPeersResponse is the response of the list trusted peers endpoint.
+type TrustedPeersResponse struct {
+	Data []*TrustedPeer `json:"data"`
+}
+
+// TrustedPeer describes a trusted peer and its connection.
+type TrustedPeer struct {
+	PeerId    string `json:"peer_id"`
+	Address   string `json:"address"`
+	State     string `json:"state"`
+	Direction string `json:"direction"`
+}
+
+// BanPeerRequest is the request body of the ban peer endpoint. An empty duration
+// bans the peer indefinitely.
+type BanPeerRequest struct {
+	PeerId   string `json:"peer_id"`
+	Duration string `json:"duration"`
+}
+
+// BanIPRangeRequest is the request body of the ban ip range endpoint.
+type BanIPRangeRequest struct {
+	Cidr string `json:"cidr"`
+}
+
+// BansResponse is the response of the list bans endpoint.
+type BansResponse struct {
+	Data *Bans `json:"data"`
+}
+
+// Bans lists the banned peers and ip ranges.
+type Bans struct {
+	Peers    []*BannedPeer `json:"peers"`
+	IPRanges []string      `json:"ip_ranges"`
+}
+
+// BannedPeer describes a banned peer, along with the unix time at which its ban expires.
+type BannedPeer struct {
+	PeerId      string `json:"peer_id"`
+	BannedUntil string `json:"banned_until"`
+}
+
+// PeerScoresResponse is the response of the peer scores endpoint.
+type PeerScoresResponse struct {
+	Data []*PeerScore `json:"data"`
+}
+
+// PeerScore is the score breakdown of a peer, per scorer.
+type PeerScore struct {
+	PeerId        string              `json:"peer_id"`
+	State         string              `json:"state"`
+	OverallScore  float64             `json:"overall_score"`
+	Trusted       bool                `json:"trusted"`
+	Bad           bool                `json:"bad"`
+	BadResponses  *BadResponsesScore  `json:"bad_responses"`
+	BlockProvider *BlockProviderScore `json:"block_provider"`
+	PeerStatus    *PeerStatusScore    `json:"peer_status"`
+	Gossip        *GossipScore        `json:"gossip"`
+}
+
+// BadResponsesScore is the score given by the bad responses scorer.
+type BadResponsesScore struct {
+	Count string  `json:"count"`
+	Score float64 `json:"score"`
+}
+
+// BlockProviderScore is the score given by the block provider scorer.
+type BlockProviderScore struct {
+	ProcessedBlocks string  `json:"processed_blocks"`
+	Score           float64 `json:"score"`
+}
+
+// PeerStatusScore is the score given by the peer status scorer.
+type PeerStatusScore struct {
+	Score           float64 `json:"score"`
+	ValidationError string  `json:"validation_error"`
+}
+
+// GossipScore is the score given by the gossip scorer.
+type GossipScore struct {
+	Score            float64                `json:"score"`
+	GossipScore      float64                `json:"gossip_score"`
+	BehaviourPenalty float64                `json:"behaviour_penalty"`
+	TopicScores      map[string]*TopicScore `json:"topic_scores"`
+}
+
+// TopicScore is the gossip score snapshot of a peer for a single topic.
+type TopicScore struct {
+	TimeInMesh               string  `json:"time_in_mesh"`
+	FirstMessageDeliveries   float32 `json:"first_message_deliveries"`
+	MeshMessageDeliveries    float32 `json:"mesh_message_deliveries"`
+	InvalidMessageDeliveries float32 `json:"invalid_message_deliveries"`
+}
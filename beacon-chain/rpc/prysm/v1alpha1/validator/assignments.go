@@ -145,6 +145,7 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 
 	validatorAssignments := make([]*ethpb.DutiesResponse_Duty, 0, len(req.PublicKeys))
 	nextValidatorAssignments := make([]*ethpb.DutiesResponse_Duty, 0, len(req.PublicKeys))
+	observedIndices := make([]types.ValidatorIndex, 0, len(req.PublicKeys))
 	for _, pubKey := range req.PublicKeys {
 		if ctx.Err() != nil {
 			return nil, status.Errorf(codes.Aborted, "Could not continue fetching assignments: %v", ctx.Err())
@@ -157,6 +158,7 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 		}
 		idx, ok := s.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubKey))
 		if ok {
+			observedIndices = append(observedIndices, idx)
 			s := assignmentStatus(s, idx)
 
 			assignment.ValidatorIndex = idx
@@ -234,6 +236,9 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 		vs.AssignValidatorToSubnet(pubKey, assignment.Status)
 		vs.AssignValidatorToSubnet(pubKey, nextAssignment.Status)
 	}
+	if vs.ActivityObserver != nil {
+		vs.ActivityObserver.ObserveValidators(observedIndices)
+	}
 
 	return &ethpb.DutiesResponse{
 		Duties:             validatorAssignments,
@@ -17,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
@@ -67,6 +68,22 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
 	}
+	if features.Get().EnableReorgLateBlocks {
+		// Build on the parent of the head if the head is a late and weak block that can be orphaned.
+		proposerHead := vs.ForkFetcher.ForkChoicer().GetProposerHead()
+		if proposerHead != [32]byte{} && proposerHead != bytesutil.ToBytes32(parentRoot) {
+			head, err = vs.StateGen.StateByRoot(ctx, proposerHead)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Could not get proposer head state: %v", err)
+			}
+			log.WithFields(logrus.Fields{
+				"slot":       req.Slot,
+				"headRoot":   fmt.Sprintf("%#x", bytesutil.Trunc(parentRoot)),
+				"parentRoot": fmt.Sprintf("%#x", bytesutil.Trunc(proposerHead[:])),
+			}).Info("Attempting to orphan a late and weak head block")
+			parentRoot = proposerHead[:]
+		}
+	}
 	head, err = transition.ProcessSlotsUsingNextSlotCache(ctx, head, parentRoot, req.Slot)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not process slots up to %d: %v", req.Slot, err)
@@ -133,9 +150,12 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not convert block to proto: %v", err)
 	}
-	if slots.ToEpoch(req.Slot) >= params.BeaconConfig().CapellaForkEpoch {
+	if slots.ToEpoch(req.Slot) >= params.BeaconConfig().CapellaForkEpoch && !blk.IsBlinded() {
 		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Capella{Capella: pb.(*ethpb.BeaconBlockCapella)}}, nil
 	}
+	if slots.ToEpoch(req.Slot) >= params.BeaconConfig().CapellaForkEpoch && blk.IsBlinded() {
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_BlindedCapella{BlindedCapella: pb.(*ethpb.BlindedBeaconBlockCapella)}}, nil
+	}
 	if slots.ToEpoch(req.Slot) >= params.BeaconConfig().BellatrixForkEpoch && !blk.IsBlinded() {
 		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Bellatrix{Bellatrix: pb.(*ethpb.BeaconBlockBellatrix)}}, nil
 	}
@@ -169,6 +189,14 @@ func (vs *Server) PrepareBeaconProposer(
 	var feeRecipients []common.Address
 	var validatorIndices []types.ValidatorIndex
 
+	if vs.ActivityObserver != nil {
+		indices := make([]types.ValidatorIndex, len(request.Recipients))
+		for i, r := range request.Recipients {
+			indices[i] = r.ValidatorIndex
+		}
+		vs.ActivityObserver.ObserveValidators(indices)
+	}
+
 	newRecipients := make([]*ethpb.PrepareBeaconProposerRequest_FeeRecipientContainer, 0, len(request.Recipients))
 	for _, r := range request.Recipients {
 		f, err := vs.BeaconDB.FeeRecipientByValidatorID(ctx, r.ValidatorIndex)
@@ -313,6 +341,13 @@ func (vs *Server) SubmitValidatorRegistrations(ctx context.Context, reg *ethpb.S
 	if err := vs.BlockBuilder.RegisterValidator(ctx, reg.Messages); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Could not register block builder: %v", err)
 	}
+	if vs.ActivityObserver != nil {
+		pubkeys := make([][]byte, len(reg.Messages))
+		for i, m := range reg.Messages {
+			pubkeys[i] = m.Message.Pubkey
+		}
+		vs.ActivityObserver.ObserveValidatorPubkeys(pubkeys)
+	}
 
 	return &emptypb.Empty{}, nil
 }
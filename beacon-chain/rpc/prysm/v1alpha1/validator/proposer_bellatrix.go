@@ -4,38 +4,43 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"time"
+	"math/big"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v3/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	consensusblocks "github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/encoding/ssz"
-	enginev1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
-	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/runtime/version"
 	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	"github.com/sirupsen/logrus"
 )
 
-// builderGetPayloadMissCount tracks the number of misses when validator tries to get a payload from builder
-var builderGetPayloadMissCount = promauto.NewCounter(prometheus.CounterOpts{
-	Name: "builder_get_payload_miss_count",
-	Help: "The number of get payload misses for validator requests to builder",
-})
-
-// blockBuilderTimeout is the maximum amount of time allowed for a block builder to respond to a
-// block request. This value is known as `BUILDER_PROPOSAL_DELAY_TOLERANCE` in builder spec.
-const blockBuilderTimeout = 1 * time.Second
+var (
+	// builderGetPayloadMissCount tracks the number of misses when validator tries to get a payload from builder
+	builderGetPayloadMissCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "builder_get_payload_miss_count",
+		Help: "The number of get payload misses for validator requests to builder",
+	})
+	// executionPayloadSourceCount tracks whether proposed execution payloads came from the builder or the local execution client.
+	executionPayloadSourceCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "proposer_execution_payload_source_count",
+		Help: "The number of execution payloads used in block proposals, by source (builder or local)",
+	}, []string{"source"})
+)
 
 // Sets the execution data for the block. Execution data can come from local EL client or remote builder depends on validator registration and circuit breaker conditions.
+// When both are available, the builder header is only used if its bid beats the local block value boosted by the configured percentage.
 func (vs *Server) setExecutionData(ctx context.Context, blk interfaces.BeaconBlock, headState state.BeaconState) error {
 	idx := blk.ProposerIndex()
 	slot := blk.Slot()
@@ -43,109 +48,211 @@ func (vs *Server) setExecutionData(ctx context.Context, blk interfaces.BeaconBlo
 		return nil
 	}
 
+	var builderHeader interfaces.ExecutionData
+	var builderValue *big.Int
 	canUseBuilder, err := vs.canUseBuilder(ctx, slot, idx)
 	if err != nil {
 		log.WithError(err).Warn("Proposer: failed to check if builder can be used")
 	} else if canUseBuilder {
-		h, err := vs.getPayloadHeaderFromBuilder(ctx, slot, idx)
+		builderHeader, builderValue, err = vs.getPayloadHeaderFromBuilder(ctx, slot, idx, headState)
 		if err != nil {
 			builderGetPayloadMissCount.Inc()
 			log.WithError(err).Warn("Proposer: failed to get payload header from builder")
-		} else {
+		}
+	}
+
+	localPayload, localValue, err := vs.getExecutionPayload(ctx, slot, idx, blk.ParentRoot(), headState)
+	if err != nil {
+		if builderHeader == nil {
+			return errors.Wrap(err, "failed to get execution payload")
+		}
+		log.WithError(err).Warn("Proposer: failed to get local execution payload, falling back to builder")
+		localPayload = nil
+	}
+
+	if builderHeader != nil {
+		useBuilder := localPayload == nil || builderBidWins(builderValue, localValue, params.BeaconConfig().LocalBlockValueBoost)
+		logFields := logrus.Fields{
+			"slot":         slot,
+			"builderValue": builderValue.String(),
+			"localValue":   "unknown",
+			"localBoost":   params.BeaconConfig().LocalBlockValueBoost,
+		}
+		if localValue != nil {
+			logFields["localValue"] = localValue.String()
+		}
+		if useBuilder {
 			blk.SetBlinded(true)
-			if err := blk.Body().SetExecution(h); err != nil {
-				log.WithError(err).Warn("Proposer: failed to set execution payload")
-			} else {
+			if err := blk.Body().SetExecution(builderHeader); err == nil {
+				executionPayloadSourceCount.WithLabelValues("builder").Inc()
+				if localPayload != nil && localValue == nil {
+					log.WithFields(logFields).Info("Proposer: using builder header, local block value is unknown so the local boost does not apply")
+				} else {
+					log.WithFields(logFields).Info("Proposer: using builder header, bid is higher than local block value")
+				}
 				return nil
 			}
+			log.WithError(err).Warn("Proposer: failed to set execution payload")
+			blk.SetBlinded(false)
+			if localPayload == nil {
+				return errors.Wrap(err, "failed to set builder execution header")
+			}
+		} else {
+			log.WithFields(logFields).Info("Proposer: using local execution payload, bid is not higher than boosted local block value")
 		}
 	}
-	executionData, err := vs.getExecutionPayload(ctx, slot, idx, blk.ParentRoot(), headState)
-	if err != nil {
-		return errors.Wrap(err, "failed to get execution payload")
+	executionPayloadSourceCount.WithLabelValues("local").Inc()
+	return blk.Body().SetExecution(localPayload)
+}
+
+// builderBidWins returns whether the builder bid value is strictly higher than the local block value
+// increased by boost percent. The local block value is unknown (nil) when the execution client does not
+// report it, which is the case before Capella as engine_getPayloadV1 has no block value. The boost cannot
+// be applied to an unknown value, so that the builder bid wins as it did before block values were compared.
+func builderBidWins(builderValue, localValue *big.Int, boost uint64) bool {
+	if localValue == nil {
+		return true
+	}
+	if builderValue == nil {
+		return false
 	}
-	return blk.Body().SetExecution(executionData)
+	b := new(big.Int).Mul(builderValue, big.NewInt(100))
+	l := new(big.Int).Mul(localValue, new(big.Int).Add(big.NewInt(100), new(big.Int).SetUint64(boost)))
+	return b.Cmp(l) > 0
 }
 
 // This function retrieves the payload header given the slot number and the validator index.
-// It's a no-op if the latest head block is not versioned bellatrix.
-func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot types.Slot, idx types.ValidatorIndex) (interfaces.ExecutionData, error) {
-	b, err := vs.HeadFetcher.HeadBlock(ctx)
-	if err != nil {
-		return nil, err
-	}
-	if blocks.IsPreBellatrixVersion(b.Version()) {
-		return nil, nil
+// It's a no-op if the given head state is not versioned bellatrix. The header builds on the latest
+// execution payload of the head state, which is the state of the parent of the proposed block, even
+// when the proposer orphans the head block. The bids of all relays are validated and the header of
+// the highest valid bid is returned along with its value.
+func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot types.Slot, idx types.ValidatorIndex, headState state.BeaconState) (interfaces.ExecutionData, *big.Int, error) {
+	if blocks.IsPreBellatrixVersion(headState.Version()) {
+		return nil, nil, nil
 	}
 
-	h, err := b.Block().Body().Execution()
+	h, err := headState.LatestExecutionPayloadHeader()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	pk, err := vs.HeadFetcher.HeadValidatorIndexToPublicKey(ctx, idx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	signedBids, err := vs.BlockBuilder.GetHeaders(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(signedBids) == 0 {
+		return nil, nil, errors.New("builder returned no bid")
+	}
+	var bestHeader interfaces.ExecutionData
+	var bestValue *big.Int
+	var bestPubkey []byte
+	var lastErr error
+	for _, signedBid := range signedBids {
+		header, value, pubkey, err := vs.validateBuilderBid(signedBid, slot, h.BlockHash(), headState)
+		if err != nil {
+			log.WithError(err).Debug("Proposer: ignoring invalid builder bid")
+			lastErr = err
+			continue
+		}
+		if bestValue == nil || value.Cmp(bestValue) > 0 {
+			bestHeader, bestValue, bestPubkey = header, value, pubkey
+		}
+	}
+	if bestHeader == nil {
+		return nil, nil, errors.Wrap(lastErr, "no valid builder bid")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, blockBuilderTimeout)
-	defer cancel()
+	log.WithFields(logrus.Fields{
+		"value":         bestValue.String(),
+		"builderPubKey": fmt.Sprintf("%#x", bestPubkey),
+		"blockHash":     fmt.Sprintf("%#x", bestHeader.BlockHash()),
+		"bids":          len(signedBids),
+	}).Info("Received header with bid")
+	return bestHeader, bestValue, nil
+}
 
-	bid, err := vs.BlockBuilder.GetHeader(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk)
+// Validates a builder bid for the given slot and parent execution block hash. The bid must be of the
+// fork of the slot, and a capella bid must commit to the withdrawals expected from the head state.
+// It returns the header of the bid along with its value and the builder public key.
+func (vs *Server) validateBuilderBid(signedBid builder.SignedBid, slot types.Slot, parentHash []byte, headState state.BeaconState) (interfaces.ExecutionData, *big.Int, []byte, error) {
+	if signedBid == nil || signedBid.IsNil() {
+		return nil, nil, nil, errors.New("builder returned nil bid")
+	}
+	wantVersion := version.Bellatrix
+	if slots.ToEpoch(slot) >= params.BeaconConfig().CapellaForkEpoch {
+		wantVersion = version.Capella
+	}
+	if signedBid.Version() != wantVersion {
+		return nil, nil, nil, fmt.Errorf("builder bid version %s does not match fork version %s",
+			version.String(signedBid.Version()), version.String(wantVersion))
+	}
+	bid, err := signedBid.Message()
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, errors.Wrap(err, "could not get bid")
 	}
-	if bid == nil || bid.Message == nil {
-		return nil, errors.New("builder returned nil bid")
+	if bid == nil || bid.IsNil() {
+		return nil, nil, nil, errors.New("builder returned nil bid")
 	}
 
-	v := bytesutil.LittleEndianBytesToBigInt(bid.Message.Value)
+	v := bytesutil.LittleEndianBytesToBigInt(bid.Value())
 	if v.String() == "0" {
-		return nil, errors.New("builder returned header with 0 bid amount")
+		return nil, nil, nil, errors.New("builder returned header with 0 bid amount")
 	}
 
+	header, err := bid.Header()
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not get bid header")
+	}
+	txRoot, err := header.TransactionsRoot()
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not get transaction root")
+	}
 	emptyRoot, err := ssz.TransactionsRoot([][]byte{})
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-
-	if bytesutil.ToBytes32(bid.Message.Header.TransactionsRoot) == emptyRoot {
-		return nil, errors.New("builder returned header with an empty tx root")
+	if bytesutil.ToBytes32(txRoot) == emptyRoot {
+		return nil, nil, nil, errors.New("builder returned header with an empty tx root")
 	}
 
-	if !bytes.Equal(bid.Message.Header.ParentHash, h.BlockHash()) {
-		return nil, fmt.Errorf("incorrect parent hash %#x != %#x", bid.Message.Header.ParentHash, h.BlockHash())
+	if !bytes.Equal(header.ParentHash(), parentHash) {
+		return nil, nil, nil, fmt.Errorf("incorrect parent hash %#x != %#x", header.ParentHash(), parentHash)
 	}
 
 	t, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), slot)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	if bid.Message.Header.Timestamp != uint64(t.Unix()) {
-		return nil, fmt.Errorf("incorrect timestamp %d != %d", bid.Message.Header.Timestamp, uint64(t.Unix()))
+	if header.Timestamp() != uint64(t.Unix()) {
+		return nil, nil, nil, fmt.Errorf("incorrect timestamp %d != %d", header.Timestamp(), uint64(t.Unix()))
 	}
 
-	if err := validateBuilderSignature(bid); err != nil {
-		return nil, errors.Wrap(err, "could not validate builder signature")
+	if bid.Version() >= version.Capella {
+		if err := validateBuilderWithdrawalsRoot(header, headState); err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
-	log.WithFields(logrus.Fields{
-		"value":         v.String(),
-		"builderPubKey": fmt.Sprintf("%#x", bid.Message.Pubkey),
-		"blockHash":     fmt.Sprintf("%#x", bid.Message.Header.BlockHash),
-	}).Info("Received header with bid")
-	return consensusblocks.WrappedExecutionPayloadHeader(bid.Message.Header)
+	if err := validateBuilderSignature(signedBid); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not validate builder signature")
+	}
+	return header, v, bid.Pubkey(), nil
 }
 
 // This function retrieves the full payload block using the input blind block. This input must be versioned as
-// bellatrix blind block. The output block will contain the full payload. The original header block
+// bellatrix or capella blind block. The output block will contain the full payload. The original header block
 // will be returned the block builder is not configured.
 func (vs *Server) unblindBuilderBlock(ctx context.Context, b interfaces.SignedBeaconBlock) (interfaces.SignedBeaconBlock, error) {
 	if err := consensusblocks.BeaconBlockIsNil(b); err != nil {
 		return nil, err
 	}
 
-	// No-op if the input block is not version blind and bellatrix.
-	if b.Version() != version.Bellatrix || !b.IsBlinded() {
+	// No-op if the input block is not version blind and bellatrix or later.
+	if b.Version() < version.Bellatrix || !b.IsBlinded() {
 		return b, nil
 	}
 	// No-op nothing if the builder has not been configured.
@@ -153,54 +260,21 @@ func (vs *Server) unblindBuilderBlock(ctx context.Context, b interfaces.SignedBe
 		return b, nil
 	}
 
-	agg, err := b.Block().Body().SyncAggregate()
-	if err != nil {
-		return nil, err
-	}
 	h, err := b.Block().Body().Execution()
 	if err != nil {
 		return nil, err
 	}
-	header, ok := h.Proto().(*enginev1.ExecutionPayloadHeader)
-	if !ok {
-		return nil, errors.New("execution data must be execution payload header")
-	}
-	parentRoot := b.Block().ParentRoot()
-	stateRoot := b.Block().StateRoot()
-	randaoReveal := b.Block().Body().RandaoReveal()
-	graffiti := b.Block().Body().Graffiti()
-	sig := b.Signature()
-	sb := &ethpb.SignedBlindedBeaconBlockBellatrix{
-		Block: &ethpb.BlindedBeaconBlockBellatrix{
-			Slot:          b.Block().Slot(),
-			ProposerIndex: b.Block().ProposerIndex(),
-			ParentRoot:    parentRoot[:],
-			StateRoot:     stateRoot[:],
-			Body: &ethpb.BlindedBeaconBlockBodyBellatrix{
-				RandaoReveal:           randaoReveal[:],
-				Eth1Data:               b.Block().Body().Eth1Data(),
-				Graffiti:               graffiti[:],
-				ProposerSlashings:      b.Block().Body().ProposerSlashings(),
-				AttesterSlashings:      b.Block().Body().AttesterSlashings(),
-				Attestations:           b.Block().Body().Attestations(),
-				Deposits:               b.Block().Body().Deposits(),
-				VoluntaryExits:         b.Block().Body().VoluntaryExits(),
-				SyncAggregate:          agg,
-				ExecutionPayloadHeader: header,
-			},
-		},
-		Signature: sig[:],
-	}
-
-	payload, err := vs.BlockBuilder.SubmitBlindedBlock(ctx, sb)
+	payload, err := vs.BlockBuilder.SubmitBlindedBlock(ctx, b)
 	if err != nil {
 		return nil, err
 	}
-	headerRoot, err := header.HashTreeRoot()
+	if payload == nil || payload.IsNil() {
+		return nil, errors.New("builder returned nil payload")
+	}
+	headerRoot, err := h.HashTreeRoot()
 	if err != nil {
 		return nil, err
 	}
-
 	payloadRoot, err := payload.HashTreeRoot()
 	if err != nil {
 		return nil, err
@@ -210,53 +284,61 @@ func (vs *Server) unblindBuilderBlock(ctx context.Context, b interfaces.SignedBe
 			"%#x != %#x", headerRoot, payloadRoot)
 	}
 
-	bb := &ethpb.SignedBeaconBlockBellatrix{
-		Block: &ethpb.BeaconBlockBellatrix{
-			Slot:          sb.Block.Slot,
-			ProposerIndex: sb.Block.ProposerIndex,
-			ParentRoot:    sb.Block.ParentRoot,
-			StateRoot:     sb.Block.StateRoot,
-			Body: &ethpb.BeaconBlockBodyBellatrix{
-				RandaoReveal:      sb.Block.Body.RandaoReveal,
-				Eth1Data:          sb.Block.Body.Eth1Data,
-				Graffiti:          sb.Block.Body.Graffiti,
-				ProposerSlashings: sb.Block.Body.ProposerSlashings,
-				AttesterSlashings: sb.Block.Body.AttesterSlashings,
-				Attestations:      sb.Block.Body.Attestations,
-				Deposits:          sb.Block.Body.Deposits,
-				VoluntaryExits:    sb.Block.Body.VoluntaryExits,
-				SyncAggregate:     agg,
-				ExecutionPayload:  payload,
-			},
-		},
-		Signature: sb.Signature,
-	}
-	wb, err := consensusblocks.NewSignedBeaconBlock(bb)
+	wb, err := consensusblocks.BuildSignedBeaconBlockFromExecutionPayload(b, payload.Proto())
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "could not build full block from payload")
+	}
+	txs, err := payload.Transactions()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get transactions from payload")
 	}
 
 	log.WithFields(logrus.Fields{
 		"blockHash":    fmt.Sprintf("%#x", h.BlockHash()),
 		"feeRecipient": fmt.Sprintf("%#x", h.FeeRecipient()),
-		"gasUsed":      h.GasUsed,
+		"gasUsed":      h.GasUsed(),
 		"slot":         b.Block().Slot(),
-		"txs":          len(payload.Transactions),
+		"txs":          len(txs),
 	}).Info("Retrieved full payload from builder")
 
 	return wb, nil
 }
 
+// Validates that the withdrawals root of a capella builder header commits to the withdrawals
+// expected from the state the block is proposed on top of.
+func validateBuilderWithdrawalsRoot(header interfaces.ExecutionData, st state.BeaconState) error {
+	withdrawals, err := st.ExpectedWithdrawals()
+	if err != nil {
+		return errors.Wrap(err, "could not get expected withdrawals")
+	}
+	wantRoot, err := ssz.WithdrawalSliceRoot(hash.CustomSHA256Hasher(), withdrawals, fieldparams.MaxWithdrawalsPerPayload)
+	if err != nil {
+		return errors.Wrap(err, "could not compute withdrawals root")
+	}
+	gotRoot, err := header.WithdrawalsRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not get withdrawals root")
+	}
+	if !bytes.Equal(gotRoot, wantRoot[:]) {
+		return fmt.Errorf("incorrect withdrawals root %#x != %#x", gotRoot, wantRoot)
+	}
+	return nil
+}
+
 // Validates builder signature and returns an error if the signature is invalid.
-func validateBuilderSignature(bid *ethpb.SignedBuilderBid) error {
+func validateBuilderSignature(signedBid builder.SignedBid) error {
 	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder,
 		nil, /* fork version */
 		nil /* genesis val root */)
 	if err != nil {
 		return err
 	}
-	if bid == nil || bid.Message == nil {
+	if signedBid == nil || signedBid.IsNil() {
 		return errors.New("nil builder bid")
 	}
-	return signing.VerifySigningRoot(bid.Message, bid.Message.Pubkey, bid.Signature, d)
+	bid, err := signedBid.Message()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid")
+	}
+	return signing.VerifySigningRoot(bid, bid.Pubkey(), signedBid.Signature(), d)
 }
@@ -2,18 +2,22 @@ package validator
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/api/client/builder"
 	blockchainTest "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
 	builderTest "github.com/prysmaticlabs/prysm/v3/beacon-chain/builder/testing"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/encoding/ssz"
 	v1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
@@ -56,40 +60,77 @@ func TestServer_getPayloadHeader(t *testing.T) {
 		Message:   bid,
 		Signature: sk.Sign(sr[:]).Marshal(),
 	}
+	newSignedBid := func(value uint64, blockHash byte, timestamp uint64) *ethpb.SignedBuilderBid {
+		b := &ethpb.BuilderBid{
+			Header: &v1.ExecutionPayloadHeader{
+				FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+				StateRoot:        make([]byte, fieldparams.RootLength),
+				ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+				LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+				PrevRandao:       make([]byte, fieldparams.RootLength),
+				BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+				BlockHash:        bytesutil.PadTo([]byte{blockHash}, fieldparams.RootLength),
+				TransactionsRoot: bytesutil.PadTo([]byte{1}, fieldparams.RootLength),
+				ParentHash:       params.BeaconConfig().ZeroHash[:],
+				Timestamp:        timestamp,
+			},
+			Pubkey: sk.PublicKey().Marshal(),
+			Value:  bytesutil.PadTo(bytesutil.Uint64ToBytesLittleEndian(value), 32),
+		}
+		sr, err := signing.ComputeSigningRoot(b, domain)
+		require.NoError(t, err)
+		return &ethpb.SignedBuilderBid{Message: b, Signature: sk.Sign(sr[:]).Marshal()}
+	}
+	wrapBids := func(bids ...*ethpb.SignedBuilderBid) []builder.SignedBid {
+		wrapped := make([]builder.SignedBid, len(bids))
+		for i, b := range bids {
+			w, err := builder.WrappedSignedBuilderBid(b)
+			require.NoError(t, err)
+			wrapped[i] = w
+		}
+		return wrapped
+	}
+	highestBid := newSignedBid(1<<20, 'b', uint64(ti.Unix()))
 
 	require.NoError(t, err)
+	phase0State, err := util.NewBeaconState()
+	require.NoError(t, err)
+	bellatrixState, err := util.NewBeaconStateBellatrix()
+	require.NoError(t, err)
+	// When the proposer orphans a late head block, the head state is the state of the parent of the
+	// proposed block, whose execution payload differs from the one of the head block.
+	reorgHead, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
+	require.NoError(t, err)
+	proposerHeadState, err := util.NewBeaconStateBellatrix(func(st *ethpb.BeaconStateBellatrix) error {
+		st.LatestExecutionPayloadHeader.BlockHash = bytesutil.PadTo([]byte{'p'}, fieldparams.RootLength)
+		return nil
+	})
+	require.NoError(t, err)
+	proposerHeadBid := newSignedBid(1, 'e', uint64(ti.Unix()))
+	proposerHeadBid.Message.Header.ParentHash = bytesutil.PadTo([]byte{'p'}, fieldparams.RootLength)
+	sr, err = signing.ComputeSigningRoot(proposerHeadBid.Message, domain)
+	require.NoError(t, err)
+	proposerHeadBid.Signature = sk.Sign(sr[:]).Marshal()
+
 	tests := []struct {
 		name           string
-		head           interfaces.SignedBeaconBlock
 		mock           *builderTest.MockBuilderService
-		fetcher        *blockchainTest.ChainService
+		headState      state.BeaconState
 		err            string
 		returnedHeader *v1.ExecutionPayloadHeader
 	}{
 		{
-			name: "head is not bellatrix ready",
-			mock: &builderTest.MockBuilderService{},
-			fetcher: &blockchainTest.ChainService{
-				Block: func() interfaces.SignedBeaconBlock {
-					wb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
-					require.NoError(t, err)
-					return wb
-				}(),
-			},
+			name:      "head is not bellatrix ready",
+			mock:      &builderTest.MockBuilderService{},
+			headState: phase0State,
 		},
 		{
 			name: "get header failed",
 			mock: &builderTest.MockBuilderService{
 				ErrGetHeader: errors.New("can't get header"),
 			},
-			fetcher: &blockchainTest.ChainService{
-				Block: func() interfaces.SignedBeaconBlock {
-					wb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
-					require.NoError(t, err)
-					return wb
-				}(),
-			},
-			err: "can't get header",
+			headState: bellatrixState,
+			err:       "can't get header",
 		},
 		{
 			name: "0 bid",
@@ -102,14 +143,8 @@ func TestServer_getPayloadHeader(t *testing.T) {
 					},
 				},
 			},
-			fetcher: &blockchainTest.ChainService{
-				Block: func() interfaces.SignedBeaconBlock {
-					wb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
-					require.NoError(t, err)
-					return wb
-				}(),
-			},
-			err: "builder returned header with 0 bid amount",
+			headState: bellatrixState,
+			err:       "builder returned header with 0 bid amount",
 		},
 		{
 			name: "invalid tx root",
@@ -124,36 +159,62 @@ func TestServer_getPayloadHeader(t *testing.T) {
 					},
 				},
 			},
-			fetcher: &blockchainTest.ChainService{
-				Block: func() interfaces.SignedBeaconBlock {
-					wb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
-					require.NoError(t, err)
-					return wb
-				}(),
+			headState: bellatrixState,
+			err:       "builder returned header with an empty tx root",
+		},
+		{
+			name: "bid version does not match fork",
+			mock: &builderTest.MockBuilderService{
+				BidCapella: &ethpb.SignedBuilderBidCapella{
+					Message: &ethpb.BuilderBidCapella{
+						Value:  []byte{1},
+						Header: &v1.ExecutionPayloadHeaderCapella{BlockNumber: 123},
+					},
+				},
 			},
-			err: "builder returned header with an empty tx root",
+			headState: bellatrixState,
+			err:       "builder bid version capella does not match fork version bellatrix",
 		},
 		{
 			name: "can get header",
 			mock: &builderTest.MockBuilderService{
 				Bid: sBid,
 			},
-			fetcher: &blockchainTest.ChainService{
-				Block: func() interfaces.SignedBeaconBlock {
-					wb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
-					require.NoError(t, err)
-					return wb
-				}(),
-			},
+			headState:      bellatrixState,
 			returnedHeader: bid.Header,
 		},
+		{
+			name: "header builds on the proposer head",
+			mock: &builderTest.MockBuilderService{
+				ExtraBids: wrapBids(proposerHeadBid),
+			},
+			headState:      proposerHeadState,
+			returnedHeader: proposerHeadBid.Message.Header,
+		},
+		{
+			name: "highest valid bid is selected",
+			mock: &builderTest.MockBuilderService{
+				Bid:       sBid,
+				ExtraBids: wrapBids(highestBid, newSignedBid(5, 'c', uint64(ti.Unix())), newSignedBid(1<<21, 'd', uint64(ti.Unix())+1)),
+			},
+			headState:      bellatrixState,
+			returnedHeader: highestBid.Message.Header,
+		},
+		{
+			name: "no valid bid",
+			mock: &builderTest.MockBuilderService{
+				ExtraBids: wrapBids(newSignedBid(1<<21, 'd', uint64(ti.Unix())+1)),
+			},
+			headState: bellatrixState,
+			err:       "no valid builder bid: incorrect timestamp",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			vs := &Server{BlockBuilder: tc.mock, HeadFetcher: tc.fetcher, TimeFetcher: &blockchainTest.ChainService{
+			vs := &Server{BlockBuilder: tc.mock, HeadFetcher: &blockchainTest.ChainService{Block: reorgHead}, TimeFetcher: &blockchainTest.ChainService{
 				Genesis: time.Now(),
 			}}
-			h, err := vs.getPayloadHeaderFromBuilder(context.Background(), 0, 0)
+			h, _, err := vs.getPayloadHeaderFromBuilder(context.Background(), 0, 0, tc.headState)
 			if tc.err != "" {
 				require.ErrorContains(t, tc.err, err)
 			} else {
@@ -168,9 +229,118 @@ func TestServer_getPayloadHeader(t *testing.T) {
 	}
 }
 
+func TestServer_getPayloadHeaderCapella(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	slot := params.BeaconConfig().SlotsPerEpoch
+	genesis := time.Now()
+	ti, err := slots.ToTime(uint64(genesis.Unix()), slot)
+	require.NoError(t, err)
+	st, _ := util.DeterministicGenesisStateCapella(t, 1)
+	withdrawals, err := st.ExpectedWithdrawals()
+	require.NoError(t, err)
+	withdrawalsRoot, err := ssz.WithdrawalSliceRoot(hash.CustomSHA256Hasher(), withdrawals, fieldparams.MaxWithdrawalsPerPayload)
+	require.NoError(t, err)
+
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	signBid := func(bid *ethpb.BuilderBidCapella) *ethpb.SignedBuilderBidCapella {
+		domain, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+		require.NoError(t, err)
+		sr, err := signing.ComputeSigningRoot(bid, domain)
+		require.NoError(t, err)
+		return &ethpb.SignedBuilderBidCapella{Message: bid, Signature: sk.Sign(sr[:]).Marshal()}
+	}
+	newBid := func(withdrawalsRoot []byte) *ethpb.BuilderBidCapella {
+		return &ethpb.BuilderBidCapella{
+			Header: &v1.ExecutionPayloadHeaderCapella{
+				FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+				StateRoot:        make([]byte, fieldparams.RootLength),
+				ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+				LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+				PrevRandao:       make([]byte, fieldparams.RootLength),
+				BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+				BlockHash:        make([]byte, fieldparams.RootLength),
+				TransactionsRoot: bytesutil.PadTo([]byte{1}, fieldparams.RootLength),
+				ParentHash:       params.BeaconConfig().ZeroHash[:],
+				Timestamp:        uint64(ti.Unix()),
+				WithdrawalsRoot:  withdrawalsRoot,
+			},
+			Pubkey: sk.PublicKey().Marshal(),
+			Value:  bytesutil.PadTo([]byte{1, 2, 3}, 32),
+		}
+	}
+	head, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		mock           *builderTest.MockBuilderService
+		err            string
+		returnedHeader *v1.ExecutionPayloadHeaderCapella
+	}{
+		{
+			name: "bellatrix bid after capella",
+			mock: &builderTest.MockBuilderService{
+				Bid: &ethpb.SignedBuilderBid{Message: &ethpb.BuilderBid{Value: []byte{1}, Header: &v1.ExecutionPayloadHeader{}}},
+			},
+			err: "builder bid version bellatrix does not match fork version capella",
+		},
+		{
+			name: "incorrect withdrawals root",
+			mock: &builderTest.MockBuilderService{
+				BidCapella: signBid(newBid(make([]byte, fieldparams.RootLength))),
+			},
+			err: "incorrect withdrawals root",
+		},
+		{
+			name: "can get header",
+			mock: &builderTest.MockBuilderService{
+				BidCapella: signBid(newBid(withdrawalsRoot[:])),
+			},
+			returnedHeader: newBid(withdrawalsRoot[:]).Header,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			vs := &Server{BlockBuilder: tc.mock, HeadFetcher: &blockchainTest.ChainService{Block: head}, TimeFetcher: &blockchainTest.ChainService{
+				Genesis: genesis,
+			}}
+			h, _, err := vs.getPayloadHeaderFromBuilder(context.Background(), slot, 0, st)
+			if tc.err != "" {
+				require.ErrorContains(t, tc.err, err)
+			} else {
+				require.NoError(t, err)
+				want, err := blocks.WrappedExecutionPayloadHeaderCapella(tc.returnedHeader)
+				require.NoError(t, err)
+				require.DeepEqual(t, want, h)
+			}
+		})
+	}
+}
+
 func TestServer_getBuilderBlock(t *testing.T) {
 	p := emptyPayload()
 	p.GasLimit = 123
+	pCapella := &v1.ExecutionPayloadCapella{
+		ParentHash:    make([]byte, fieldparams.RootLength),
+		FeeRecipient:  make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:     make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+		LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:    make([]byte, fieldparams.RootLength),
+		BaseFeePerGas: make([]byte, fieldparams.RootLength),
+		BlockHash:     make([]byte, fieldparams.RootLength),
+		Transactions:  [][]byte{{1}},
+		Withdrawals:   []*v1.Withdrawal{{Index: 1, ValidatorIndex: 2, Address: make([]byte, fieldparams.FeeRecipientLength), Amount: 3}},
+		GasLimit:      123,
+	}
+	wrappedPayloadCapella, err := blocks.WrappedExecutionPayloadCapella(pCapella)
+	require.NoError(t, err)
 
 	tests := []struct {
 		name        string
@@ -292,6 +462,33 @@ func TestServer_getBuilderBlock(t *testing.T) {
 				return wb
 			}(),
 		},
+		{
+			name: "can get capella payload",
+			blk: func() interfaces.SignedBeaconBlock {
+				b := util.NewBlindedBeaconBlockCapella()
+				b.Block.Slot = 1
+				b.Block.ProposerIndex = 2
+				header, err := blocks.PayloadToHeaderCapella(wrappedPayloadCapella)
+				require.NoError(t, err)
+				b.Block.Body.ExecutionPayloadHeader = header
+				wb, err := blocks.NewSignedBeaconBlock(b)
+				require.NoError(t, err)
+				return wb
+			}(),
+			mock: &builderTest.MockBuilderService{
+				HasConfigured:  true,
+				PayloadCapella: pCapella,
+			},
+			returnedBlk: func() interfaces.SignedBeaconBlock {
+				b := util.NewBeaconBlockCapella()
+				b.Block.Slot = 1
+				b.Block.ProposerIndex = 2
+				b.Block.Body.ExecutionPayload = pCapella
+				wb, err := blocks.NewSignedBeaconBlock(b)
+				require.NoError(t, err)
+				return wb
+			}(),
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -335,8 +532,34 @@ func TestServer_validateBuilderSignature(t *testing.T) {
 		Message:   bid,
 		Signature: sk.Sign(sr[:]).Marshal(),
 	}
-	require.NoError(t, validateBuilderSignature(sBid))
+	wBid, err := builder.WrappedSignedBuilderBid(sBid)
+	require.NoError(t, err)
+	require.NoError(t, validateBuilderSignature(wBid))
 
 	sBid.Message.Value = make([]byte, 32)
-	require.ErrorIs(t, validateBuilderSignature(sBid), signing.ErrSigFailedToVerify)
+	require.ErrorIs(t, validateBuilderSignature(wBid), signing.ErrSigFailedToVerify)
+}
+
+func TestBuilderBidWins(t *testing.T) {
+	tests := []struct {
+		name         string
+		builderValue *big.Int
+		localValue   *big.Int
+		boost        uint64
+		want         bool
+	}{
+		{name: "unknown local value", builderValue: big.NewInt(1), want: true},
+		{name: "unknown local value with boost", builderValue: big.NewInt(1), boost: 100, want: true},
+		{name: "higher bid", builderValue: big.NewInt(101), localValue: big.NewInt(100), want: true},
+		{name: "equal bid", builderValue: big.NewInt(100), localValue: big.NewInt(100), want: false},
+		{name: "lower bid", builderValue: big.NewInt(99), localValue: big.NewInt(100), want: false},
+		{name: "higher bid within boost", builderValue: big.NewInt(110), localValue: big.NewInt(100), boost: 10, want: false},
+		{name: "higher bid above boost", builderValue: big.NewInt(111), localValue: big.NewInt(100), boost: 10, want: true},
+		{name: "zero local value", builderValue: big.NewInt(1), localValue: big.NewInt(0), boost: 100, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, builderBidWins(tt.builderValue, tt.localValue, tt.boost))
+		})
+	}
 }
@@ -6,11 +6,10 @@ import (
 	"math/big"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositsnapshot"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
-	"github.com/prysmaticlabs/prysm/v3/container/trie"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
-	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
@@ -129,24 +128,15 @@ func (vs *Server) deposits(
 	return pendingDeposits, nil
 }
 
-func (vs *Server) depositTrie(ctx context.Context, canonicalEth1Data *ethpb.Eth1Data, canonicalEth1DataHeight *big.Int) (*trie.SparseMerkleTrie, error) {
+func (vs *Server) depositTrie(ctx context.Context, canonicalEth1Data *ethpb.Eth1Data, canonicalEth1DataHeight *big.Int) (*depositsnapshot.DepositTree, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.depositTrie")
 	defer span.End()
 
-	var depositTrie *trie.SparseMerkleTrie
-
 	finalizedDeposits := vs.DepositFetcher.FinalizedDeposits(ctx)
-	depositTrie = finalizedDeposits.Deposits
+	depositTrie := finalizedDeposits.Deposits
 	upToEth1DataDeposits := vs.DepositFetcher.NonFinalizedDeposits(ctx, finalizedDeposits.MerkleTrieIndex, canonicalEth1DataHeight)
 	insertIndex := finalizedDeposits.MerkleTrieIndex + 1
 
-	if shouldRebuildTrie(canonicalEth1Data.DepositCount, uint64(len(upToEth1DataDeposits))) {
-		log.WithFields(logrus.Fields{
-			"unfinalized deposits": len(upToEth1DataDeposits),
-			"total deposit count":  canonicalEth1Data.DepositCount,
-		}).Warn("Too many unfinalized deposits, building a deposit trie from scratch.")
-		return vs.rebuildDepositTrie(ctx, canonicalEth1Data, canonicalEth1DataHeight)
-	}
 	for _, dep := range upToEth1DataDeposits {
 		depHash, err := dep.Data.HashTreeRoot()
 		if err != nil {
@@ -157,46 +147,17 @@ func (vs *Server) depositTrie(ctx context.Context, canonicalEth1Data *ethpb.Eth1
 		}
 		insertIndex++
 	}
-	valid, err := validateDepositTrie(depositTrie, canonicalEth1Data)
-	// Log a warning here, as the cached trie is invalid.
-	if !valid {
-		log.WithError(err).Warn("Cached deposit trie is invalid, rebuilding it now")
-		return vs.rebuildDepositTrie(ctx, canonicalEth1Data, canonicalEth1DataHeight)
+	// Finalized deposits are pruned from the cache, so the trie can no longer be rebuilt
+	// from scratch. Proofs from an invalid trie would make the block invalid.
+	if valid, err := validateDepositTrie(depositTrie, canonicalEth1Data); !valid {
+		return nil, errors.Wrap(err, "deposit trie is invalid")
 	}
 
 	return depositTrie, nil
 }
 
-// rebuilds our deposit trie by recreating it from all processed deposits till
-// specified eth1 block height.
-func (vs *Server) rebuildDepositTrie(ctx context.Context, canonicalEth1Data *ethpb.Eth1Data, canonicalEth1DataHeight *big.Int) (*trie.SparseMerkleTrie, error) {
-	ctx, span := trace.StartSpan(ctx, "ProposerServer.rebuildDepositTrie")
-	defer span.End()
-
-	deposits := vs.DepositFetcher.AllDeposits(ctx, canonicalEth1DataHeight)
-	trieItems := make([][]byte, 0, len(deposits))
-	for _, dep := range deposits {
-		depHash, err := dep.Data.HashTreeRoot()
-		if err != nil {
-			return nil, errors.Wrap(err, "could not hash deposit data")
-		}
-		trieItems = append(trieItems, depHash[:])
-	}
-	depositTrie, err := trie.GenerateTrieFromItems(trieItems, params.BeaconConfig().DepositContractTreeDepth)
-	if err != nil {
-		return nil, err
-	}
-
-	valid, err := validateDepositTrie(depositTrie, canonicalEth1Data)
-	// Log an error here, as even with rebuilding the trie, it is still invalid.
-	if !valid {
-		log.WithError(err).Error("Rebuilt deposit trie is invalid")
-	}
-	return depositTrie, nil
-}
-
 // validate that the provided deposit trie matches up with the canonical eth1 data provided.
-func validateDepositTrie(trie *trie.SparseMerkleTrie, canonicalEth1Data *ethpb.Eth1Data) (bool, error) {
+func validateDepositTrie(trie *depositsnapshot.DepositTree, canonicalEth1Data *ethpb.Eth1Data) (bool, error) {
 	if trie == nil || canonicalEth1Data == nil {
 		return false, errors.New("nil trie or eth1data provided")
 	}
@@ -213,7 +174,7 @@ func validateDepositTrie(trie *trie.SparseMerkleTrie, canonicalEth1Data *ethpb.E
 	return true, nil
 }
 
-func constructMerkleProof(trie *trie.SparseMerkleTrie, index int, deposit *ethpb.Deposit) (*ethpb.Deposit, error) {
+func constructMerkleProof(trie *depositsnapshot.DepositTree, index int, deposit *ethpb.Deposit) (*ethpb.Deposit, error) {
 	proof, err := trie.MerkleProof(index)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not generate merkle proof for deposit at index %d", index)
@@ -224,21 +185,3 @@ func constructMerkleProof(trie *trie.SparseMerkleTrie, index int, deposit *ethpb
 	deposit.Proof = proof
 	return deposit, nil
 }
-
-// This checks whether we should fallback to rebuild the whole deposit trie.
-func shouldRebuildTrie(totalDepCount, unFinalizedDeps uint64) bool {
-	if totalDepCount == 0 || unFinalizedDeps == 0 {
-		return false
-	}
-	// The total number interior nodes hashed in a binary trie would be
-	// x - 1, where x is the total number of leaves of the trie. For simplicity's
-	// sake we assume it as x here as this function is meant as a heuristic rather than
-	// and exact calculation.
-	//
-	// Since the effective_depth = log(x) , the total depth can be represented as
-	// depth = log(x) + k. We can then find the total number of nodes to be hashed by
-	// calculating  y (log(x) + k) , where y is the number of unfinalized deposits. For
-	// the deposit trie, the value of log(x) + k is fixed at 32.
-	unFinalizedCompute := unFinalizedDeps * params.BeaconConfig().DepositContractTreeDepth
-	return unFinalizedCompute > totalDepCount
-}
@@ -15,6 +15,7 @@ import (
 	builderTest "github.com/prysmaticlabs/prysm/v3/beacon-chain/builder/testing"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositsnapshot"
 	b "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
@@ -1348,7 +1349,7 @@ func TestProposer_DepositTrie_UtilizesCachedFinalizedDeposits(t *testing.T) {
 	assert.Equal(t, expectedRoot, actualRoot, "Incorrect deposit trie root")
 }
 
-func TestProposer_DepositTrie_RebuildTrie(t *testing.T) {
+func TestProposer_DepositTrie_FinalizedDepositsPruned(t *testing.T) {
 	ctx := context.Background()
 
 	height := big.NewInt(int64(params.BeaconConfig().Eth1FollowDistance))
@@ -1443,18 +1444,9 @@ func TestProposer_DepositTrie_RebuildTrie(t *testing.T) {
 		require.NoError(t, err)
 		depositCache.InsertPendingDeposit(ctx, dp.Deposit, dp.Eth1BlockHeight, dp.Index, root)
 	}
-	d := depositCache.AllDepositContainers(ctx)
-	origDeposit, ok := proto.Clone(d[0].Deposit).(*ethpb.Deposit)
-	assert.Equal(t, true, ok)
-	junkCreds := mockCreds
-	copy(junkCreds[:1], []byte{'A'})
-	// Mutate it since its a pointer
-	d[0].Deposit.Data.WithdrawalCredentials = junkCreds[:]
-	// Insert junk to corrupt trie.
-	depositCache.InsertFinalizedDeposits(ctx, 2)
-
-	// Add original back
-	d[0].Deposit = origDeposit
+	// Finalize the first two deposits, which prunes them from the cache.
+	require.NoError(t, depositCache.InsertFinalizedDeposits(ctx, 1, [32]byte{}, 0))
+	assert.Equal(t, 2, len(depositCache.AllDepositContainers(ctx)))
 
 	bs := &Server{
 		ChainStartFetcher:      p,
@@ -1475,13 +1467,20 @@ func TestProposer_DepositTrie_RebuildTrie(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expectedRoot, actualRoot, "Incorrect deposit trie root")
 
+	wantProof, err := depositTrie.MerkleProof(2)
+	require.NoError(t, err)
+	proof, err := dt.MerkleProof(2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, wantProof, proof)
+	_, err = dt.MerkleProof(1)
+	assert.ErrorContains(t, "is finalized", err)
 }
 
 func TestProposer_ValidateDepositTrie(t *testing.T) {
 	tt := []struct {
 		name            string
 		eth1dataCreator func() *ethpb.Eth1Data
-		trieCreator     func() *trie.SparseMerkleTrie
+		trieCreator     func() *depositsnapshot.DepositTree
 		success         bool
 	}{
 		{
@@ -1489,29 +1488,25 @@ func TestProposer_ValidateDepositTrie(t *testing.T) {
 			eth1dataCreator: func() *ethpb.Eth1Data {
 				return &ethpb.Eth1Data{DepositRoot: []byte{}, DepositCount: 10, BlockHash: []byte{}}
 			},
-			trieCreator: func() *trie.SparseMerkleTrie {
-				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-				assert.NoError(t, err)
-				return newTrie
+			trieCreator: func() *depositsnapshot.DepositTree {
+				return depositsnapshot.NewDepositTree()
 			},
 			success: false,
 		},
 		{
 			name: "invalid deposit root",
 			eth1dataCreator: func() *ethpb.Eth1Data {
-				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-				assert.NoError(t, err)
-				assert.NoError(t, newTrie.Insert([]byte{'a'}, 0))
-				assert.NoError(t, newTrie.Insert([]byte{'b'}, 1))
-				assert.NoError(t, newTrie.Insert([]byte{'c'}, 2))
+				newTrie := depositsnapshot.NewDepositTree()
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'a'}, 32), 0))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'b'}, 32), 1))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'c'}, 32), 2))
 				return &ethpb.Eth1Data{DepositRoot: []byte{'B'}, DepositCount: 3, BlockHash: []byte{}}
 			},
-			trieCreator: func() *trie.SparseMerkleTrie {
-				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-				assert.NoError(t, err)
-				assert.NoError(t, newTrie.Insert([]byte{'a'}, 0))
-				assert.NoError(t, newTrie.Insert([]byte{'b'}, 1))
-				assert.NoError(t, newTrie.Insert([]byte{'c'}, 2))
+			trieCreator: func() *depositsnapshot.DepositTree {
+				newTrie := depositsnapshot.NewDepositTree()
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'a'}, 32), 0))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'b'}, 32), 1))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'c'}, 32), 2))
 				return newTrie
 			},
 			success: false,
@@ -1519,21 +1514,19 @@ func TestProposer_ValidateDepositTrie(t *testing.T) {
 		{
 			name: "valid deposit trie",
 			eth1dataCreator: func() *ethpb.Eth1Data {
-				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-				assert.NoError(t, err)
-				assert.NoError(t, newTrie.Insert([]byte{'a'}, 0))
-				assert.NoError(t, newTrie.Insert([]byte{'b'}, 1))
-				assert.NoError(t, newTrie.Insert([]byte{'c'}, 2))
+				newTrie := depositsnapshot.NewDepositTree()
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'a'}, 32), 0))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'b'}, 32), 1))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'c'}, 32), 2))
 				rt, err := newTrie.HashTreeRoot()
 				require.NoError(t, err)
 				return &ethpb.Eth1Data{DepositRoot: rt[:], DepositCount: 3, BlockHash: []byte{}}
 			},
-			trieCreator: func() *trie.SparseMerkleTrie {
-				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-				assert.NoError(t, err)
-				assert.NoError(t, newTrie.Insert([]byte{'a'}, 0))
-				assert.NoError(t, newTrie.Insert([]byte{'b'}, 1))
-				assert.NoError(t, newTrie.Insert([]byte{'c'}, 2))
+			trieCreator: func() *depositsnapshot.DepositTree {
+				newTrie := depositsnapshot.NewDepositTree()
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'a'}, 32), 0))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'b'}, 32), 1))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'c'}, 32), 2))
 				return newTrie
 			},
 			success: true,
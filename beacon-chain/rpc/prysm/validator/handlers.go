@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/network/httputil"
+	"go.opencensus.io/trace"
+)
+
+// Performance returns the per-epoch performance recorded for the tracked validators, sorted by epoch and
+// validator index. It can be restricted to the validators given by repeated index query parameters, and
+// to the epoch given by the epoch query parameter.
+func (s *Server) Performance(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.Performance")
+	defer span.End()
+
+	if !s.monitorEnabled(w) {
+		return
+	}
+	query := r.URL.Query()
+	indices, err := parseIndices(query["index"])
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter := make(map[types.ValidatorIndex]bool, len(indices))
+	for _, idx := range indices {
+		filter[idx] = true
+	}
+	var epoch *types.Epoch
+	if rawEpoch := query.Get("epoch"); rawEpoch != "" {
+		e, err := strconv.ParseUint(rawEpoch, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, "Invalid epoch: "+rawEpoch, http.StatusBadRequest)
+			return
+		}
+		epoch = (*types.Epoch)(&e)
+	}
+
+	resp := &PerformanceResponse{Data: make([]*EpochPerformance, 0)}
+	for _, p := range s.ValidatorMonitor.EpochPerformanceHistory() {
+		if len(filter) > 0 && !filter[p.ValidatorIndex] {
+			continue
+		}
+		if epoch != nil && p.Epoch != *epoch {
+			continue
+		}
+		resp.Data = append(resp.Data, epochPerformance(p))
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// TrackedIndices lists the indices of the tracked validators.
+func (s *Server) TrackedIndices(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.TrackedIndices")
+	defer span.End()
+
+	if !s.monitorEnabled(w) {
+		return
+	}
+	tracked := s.ValidatorMonitor.TrackedValidatorIndices()
+	resp := &TrackedIndicesResponse{Data: make([]string, len(tracked))}
+	for i, idx := range tracked {
+		resp.Data[i] = strconv.FormatUint(uint64(idx), 10)
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// TrackIndices starts tracking the given validator indices. Validators can only be added at runtime if
+// the validator monitor was enabled at startup with --monitor-indices, --monitor-export-file or
+// --monitor-auto-track, otherwise the request fails with 503 Service Unavailable.
+func (s *Server) TrackIndices(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.TrackIndices")
+	defer span.End()
+
+	if !s.monitorEnabled(w) {
+		return
+	}
+	var req TrackIndicesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	indices, err := parseIndices(req.Indices)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.ValidatorMonitor.AddTrackedValidators(ctx, indices); err != nil {
+		httputil.HandleError(w, "Could not track validators: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UntrackIndex stops tracking the given validator index. Like TrackIndices, it requires the validator
+// monitor to be enabled at startup.
+func (s *Server) UntrackIndex(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.UntrackIndex")
+	defer span.End()
+
+	if !s.monitorEnabled(w) {
+		return
+	}
+	indices, err := parseIndices([]string{mux.Vars(r)["index"]})
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tracked := false
+	for _, idx := range s.ValidatorMonitor.TrackedValidatorIndices() {
+		if idx == indices[0] {
+			tracked = true
+			break
+		}
+	}
+	if !tracked {
+		httputil.HandleError(w, "Validator is not tracked", http.StatusNotFound)
+		return
+	}
+	s.ValidatorMonitor.RemoveTrackedValidators(indices)
+	w.WriteHeader(http.StatusOK)
+}
+
+// monitorEnabled writes an error response if the validator monitor was not enabled when the node started.
+func (s *Server) monitorEnabled(w http.ResponseWriter) bool {
+	if s.ValidatorMonitor == nil {
+		httputil.HandleError(w, "Validator monitor is not enabled, restart the node with --monitor-indices, "+
+			"--monitor-export-file or --monitor-auto-track to track validators", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+func parseIndices(rawIndices []string) ([]types.ValidatorIndex, error) {
+	indices := make([]types.ValidatorIndex, len(rawIndices))
+	for i, rawIdx := range rawIndices {
+		idx, err := strconv.ParseUint(rawIdx, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("Invalid validator index: %s", rawIdx)
+		}
+		indices[i] = types.ValidatorIndex(idx)
+	}
+	return indices, nil
+}
+
+func epochPerformance(p monitor.EpochPerformance) *EpochPerformance {
+	return &EpochPerformance{
+		ValidatorIndex:             strconv.FormatUint(uint64(p.ValidatorIndex), 10),
+		Epoch:                      strconv.FormatUint(uint64(p.Epoch), 10),
+		AttestationIncluded:        p.AttestationIncluded,
+		AttestedSlot:               strconv.FormatUint(uint64(p.AttestedSlot), 10),
+		InclusionSlot:              strconv.FormatUint(uint64(p.InclusionSlot), 10),
+		InclusionDistance:          strconv.FormatUint(uint64(p.InclusionDistance), 10),
+		CorrectSource:              p.CorrectSource,
+		CorrectTarget:              p.CorrectTarget,
+		CorrectHead:                p.CorrectHead,
+		ProposedBlocks:             strconv.FormatUint(p.ProposedBlocks, 10),
+		SyncCommitteeExpected:      strconv.FormatUint(p.SyncCommitteeExpected, 10),
+		SyncCommitteeContributions: strconv.FormatUint(p.SyncCommitteeContributions, 10),
+		Balance:                    strconv.FormatUint(p.Balance, 10),
+		BalanceChange:              strconv.FormatInt(p.BalanceChange, 10),
+	}
+}
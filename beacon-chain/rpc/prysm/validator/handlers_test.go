@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/testutil"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+type mockTracker struct {
+	tracked map[types.ValidatorIndex]bool
+	history []monitor.EpochPerformance
+}
+
+func (m *mockTracker) TrackedValidatorIndices() []types.ValidatorIndex {
+	indices := make([]types.ValidatorIndex, 0, len(m.tracked))
+	for idx := range m.tracked {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+func (m *mockTracker) AddTrackedValidators(_ context.Context, indices []types.ValidatorIndex) error {
+	for _, idx := range indices {
+		if idx >= 64 {
+			return errors.Errorf("validator index %d does not exist in head state", idx)
+		}
+	}
+	for _, idx := range indices {
+		m.tracked[idx] = true
+	}
+	return nil
+}
+
+func (m *mockTracker) RemoveTrackedValidators(indices []types.ValidatorIndex) {
+	for _, idx := range indices {
+		delete(m.tracked, idx)
+	}
+}
+
+func (m *mockTracker) EpochPerformanceHistory() []monitor.EpochPerformance {
+	return m.history
+}
+
+func TestPerformance(t *testing.T) {
+	s := &Server{ValidatorMonitor: &mockTracker{
+		history: []monitor.EpochPerformance{
+			{ValidatorIndex: 1, Epoch: 10, AttestationIncluded: true, AttestedSlot: 320, InclusionSlot: 321, InclusionDistance: 1, CorrectHead: true, BalanceChange: -5},
+			{ValidatorIndex: 2, Epoch: 10, ProposedBlocks: 1, Balance: 32000000000},
+			{ValidatorIndex: 1, Epoch: 11, SyncCommitteeExpected: 32, SyncCommitteeContributions: 31},
+		},
+	}}
+	url := "http://example.com/prysm/validator/monitor/performance"
+
+	writer := testutil.DoRequest(t, s.Performance, http.MethodGet, url+"?index=foo", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = testutil.DoRequest(t, s.Performance, http.MethodGet, url+"?epoch=foo", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+	writer = testutil.DoRequest(t, s.Performance, http.MethodGet, url, nil, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &PerformanceResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 3, len(resp.Data))
+	assert.DeepEqual(t, &EpochPerformance{
+		ValidatorIndex:             "1",
+		Epoch:                      "10",
+		AttestationIncluded:        true,
+		AttestedSlot:               "320",
+		InclusionSlot:              "321",
+		InclusionDistance:          "1",
+		CorrectHead:                true,
+		ProposedBlocks:             "0",
+		SyncCommitteeExpected:      "0",
+		SyncCommitteeContributions: "0",
+		Balance:                    "0",
+		BalanceChange:              "-5",
+	}, resp.Data[0])
+
+	writer = testutil.DoRequest(t, s.Performance, http.MethodGet, url+"?index=1&epoch=11", nil, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp = &PerformanceResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, "31", resp.Data[0].SyncCommitteeContributions)
+
+	writer = testutil.DoRequest(t, s.Performance, http.MethodGet, url+"?index=2&index=3", nil, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp = &PerformanceResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, "32000000000", resp.Data[0].Balance)
+}
+
+func TestTrackedIndices(t *testing.T) {
+	s := &Server{ValidatorMonitor: &mockTracker{tracked: map[types.ValidatorIndex]bool{5: true}}}
+	url := "http://example.com/prysm/validator/monitor/indices"
+
+	writer := testutil.DoRequest(t, s.TrackIndices, http.MethodPost, url, &TrackIndicesRequest{Indices: []string{"1", "foo"}}, nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = testutil.DoRequest(t, s.TrackIndices, http.MethodPost, url, &TrackIndicesRequest{Indices: []string{"1", "100"}}, nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = testutil.DoRequest(t, s.TrackIndices, http.MethodPost, url, &TrackIndicesRequest{Indices: []string{"10", "1"}}, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	writer = testutil.DoRequest(t, s.TrackedIndices, http.MethodGet, url, nil, nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &TrackedIndicesResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.DeepEqual(t, []string{"1", "5", "10"}, resp.Data)
+
+	writer = testutil.DoRequest(t, s.UntrackIndex, http.MethodDelete, url+"/5", nil, map[string]string{"index": "5"})
+	require.Equal(t, http.StatusOK, writer.Code)
+	writer = testutil.DoRequest(t, s.UntrackIndex, http.MethodDelete, url+"/5", nil, map[string]string{"index": "5"})
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+	assert.DeepEqual(t, []types.ValidatorIndex{1, 10}, s.ValidatorMonitor.TrackedValidatorIndices())
+}
+
+func TestMonitorNotEnabled(t *testing.T) {
+	s := &Server{}
+	writer := testutil.DoRequest(t, s.TrackedIndices, http.MethodGet, "http://example.com/prysm/validator/monitor/indices", nil, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	writer = testutil.DoRequest(t, s.TrackIndices, http.MethodPost, "http://example.com/prysm/validator/monitor/indices", &TrackIndicesRequest{Indices: []string{"1"}}, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	assert.StringContains(t, "--monitor-indices", writer.Body.String())
+}
@@ -0,0 +1,12 @@
+// Package validator defines the Prysm API endpoints for inspecting the per-epoch performance
+// of the validators tracked by the validator monitor, and changing them at runtime.
+package validator
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor"
+)
+
+// Server serves the Prysm validator monitor endpoints.
+type Server struct {
+	ValidatorMonitor monitor.Tracker
+}
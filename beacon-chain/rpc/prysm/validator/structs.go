@@ -0,0 +1,34 @@
+package validator
+
+// PerformanceResponse is the response of the validator performance endpoint.
+type PerformanceResponse struct {
+	Data []*EpochPerformance `json:"data"`
+}
+
+// EpochPerformance is the performance of a tracked validator during an epoch.
+type EpochPerformance struct {
+	ValidatorIndex             string `json:"validator_index"`
+	Epoch                      string `json:"epoch"`
+	AttestationIncluded        bool   `json:"attestation_included"`
+	AttestedSlot               string `json:"attested_slot"`
+	InclusionSlot              string `json:"inclusion_slot"`
+	InclusionDistance          string `json:"inclusion_distance"`
+	CorrectSource              bool   `json:"correct_source"`
+	CorrectTarget              bool   `json:"correct_target"`
+	CorrectHead                bool   `json:"correct_head"`
+	ProposedBlocks             string `json:"proposed_blocks"`
+	SyncCommitteeExpected      string `json:"sync_committee_expected"`
+	SyncCommitteeContributions string `json:"sync_committee_contributions"`
+	Balance                    string `json:"balance"`
+	BalanceChange              string `json:"balance_change"`
+}
+
+// TrackedIndicesResponse is the response of the list tracked validators endpoint.
+type TrackedIndicesResponse struct {
+	Data []string `json:"data"`
+}
+
+// TrackIndicesRequest is the request body of the track validators endpoint.
+type TrackIndicesRequest struct {
+	Indices []string `json:"indices"`
+}
@@ -6,8 +6,10 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"sync"
 
+	"github.com/gorilla/mux"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpcopentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
@@ -22,21 +24,28 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/blockfetcher"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/beacon"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/debug"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/deposit"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/events"
+	lightclient "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/light-client"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/node"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/validator"
+	prysmnode "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/node"
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/v1alpha1/beacon"
 	debugv1alpha1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/v1alpha1/debug"
 	nodev1alpha1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/v1alpha1/node"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/v1alpha1/validator"
+	prysmvalidator "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/validator"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/statefetcher"
 	slasherservice "github.com/prysmaticlabs/prysm/v3/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
@@ -105,6 +114,7 @@ type Config struct {
 	Broadcaster                   p2p.Broadcaster
 	PeersFetcher                  p2p.PeersProvider
 	PeerManager                   p2p.PeerManager
+	PeerBanner                    p2p.PeerBanner
 	MetadataProvider              p2p.MetadataProvider
 	DepositFetcher                depositcache.DepositFetcher
 	PendingDepositFetcher         depositcache.PendingDepositsFetcher
@@ -116,7 +126,11 @@ type Config struct {
 	ExecutionEngineCaller         execution.EngineCaller
 	ProposerIdsCache              *cache.ProposerPayloadIDsCache
 	OptimisticModeFetcher         blockchain.OptimisticModeFetcher
+	LightClientUpdateFetcher      blockchain.LightClientUpdateFetcher
 	BlockBuilder                  builder.BlockBuilder
+	Router                        *mux.Router
+	ValidatorMonitor              monitor.Tracker
+	ValidatorActivityObserver     monitor.ActivityObserver
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -172,9 +186,100 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 	}
 	s.grpcServer = grpc.NewServer(opts...)
 
+	if s.cfg.Router != nil {
+		s.initializeRewardServerRoutes()
+		s.initializeDepositServerRoutes()
+		s.initializePrysmNodeServerRoutes()
+		s.initializePrysmValidatorServerRoutes()
+		s.initializeValidatorServerRoutes()
+		if features.Get().EnableLightClient {
+			s.initializeLightClientServerRoutes()
+		}
+	}
+
 	return s
 }
 
+// initializeRewardServerRoutes registers the rewards endpoints of the beacon API on the HTTP router.
+// These endpoints are served directly over HTTP rather than through the gRPC gateway.
+func (s *Service) initializeRewardServerRoutes() {
+	var stateCache stategen.CachedGetter
+	if s.cfg.StateGen != nil {
+		stateCache = s.cfg.StateGen.CombinedCache()
+	}
+	rewardsServer := &rewards.Server{
+		BlockFetcher: &blockfetcher.BlockProvider{
+			BeaconDB:         s.cfg.BeaconDB,
+			ChainInfoFetcher: s.cfg.ChainInfoFetcher,
+		},
+		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
+		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		TimeFetcher:           s.cfg.GenesisTimeFetcher,
+		StateGen:              s.cfg.StateGen,
+		ReplayerBuilder:       stategen.NewCanonicalHistory(s.cfg.BeaconDB, s.cfg.ChainInfoFetcher, s.cfg.ChainInfoFetcher, stategen.WithCache(stateCache)),
+	}
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/rewards/blocks/{block_id}", rewardsServer.BlockRewards).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/rewards/attestations/{epoch}", rewardsServer.AttestationRewards).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/rewards/sync_committee/{block_id}", rewardsServer.SyncCommitteeRewards).Methods(http.MethodPost)
+}
+
+// initializeDepositServerRoutes registers the deposit snapshot endpoint of the beacon API on the HTTP router.
+func (s *Service) initializeDepositServerRoutes() {
+	depositServer := &deposit.Server{
+		DepositFetcher: s.cfg.DepositFetcher,
+	}
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/deposit_snapshot", depositServer.DepositSnapshot).Methods(http.MethodGet)
+}
+
+// initializeValidatorServerRoutes registers the validator endpoints of the beacon API which are served
+// directly over HTTP rather than through the gRPC gateway.
+func (s *Service) initializeValidatorServerRoutes() {
+	validatorServer := &validator.Server{}
+	s.cfg.Router.HandleFunc("/eth/v1/validator/beacon_committee_selections", validatorServer.BeaconCommitteeSelections).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/eth/v1/validator/sync_committee_selections", validatorServer.SyncCommitteeSelections).Methods(http.MethodPost)
+}
+
+// initializePrysmNodeServerRoutes registers the Prysm peer management endpoints on the HTTP router.
+func (s *Service) initializePrysmNodeServerRoutes() {
+	nodeServer := &prysmnode.Server{
+		PeersFetcher: s.cfg.PeersFetcher,
+		PeerBanner:   s.cfg.PeerBanner,
+	}
+	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers", nodeServer.ListTrustedPeers).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers", nodeServer.AddTrustedPeer).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers/{peer_id}", nodeServer.RemoveTrustedPeer).Methods(http.MethodDelete)
+	s.cfg.Router.HandleFunc("/prysm/node/bans", nodeServer.ListBans).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/node/bans/peers", nodeServer.BanPeer).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/node/bans/peers/{peer_id}", nodeServer.UnbanPeer).Methods(http.MethodDelete)
+	s.cfg.Router.HandleFunc("/prysm/node/bans/ip_ranges", nodeServer.BanIPRange).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/node/bans/ip_ranges", nodeServer.UnbanIPRange).Methods(http.MethodDelete)
+	s.cfg.Router.HandleFunc("/prysm/node/peers/scores", nodeServer.PeerScores).Methods(http.MethodGet)
+}
+
+// initializePrysmValidatorServerRoutes registers the Prysm validator monitor endpoints on the HTTP router.
+func (s *Service) initializePrysmValidatorServerRoutes() {
+	validatorServer := &prysmvalidator.Server{
+		ValidatorMonitor: s.cfg.ValidatorMonitor,
+	}
+	s.cfg.Router.HandleFunc("/prysm/validator/monitor/performance", validatorServer.Performance).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/validator/monitor/indices", validatorServer.TrackedIndices).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/validator/monitor/indices", validatorServer.TrackIndices).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/validator/monitor/indices/{index}", validatorServer.UntrackIndex).Methods(http.MethodDelete)
+}
+
+// initializeLightClientServerRoutes registers the light client endpoints of the beacon API on the HTTP router.
+func (s *Service) initializeLightClientServerRoutes() {
+	lightClientServer := &lightclient.Server{
+		BeaconDB:                 s.cfg.BeaconDB,
+		StateGen:                 s.cfg.StateGen,
+		LightClientUpdateFetcher: s.cfg.LightClientUpdateFetcher,
+	}
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/bootstrap/{block_root}", lightClientServer.Bootstrap).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/updates", lightClientServer.Updates).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/finality_update", lightClientServer.FinalityUpdate).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/optimistic_update", lightClientServer.OptimisticUpdate).Methods(http.MethodGet)
+}
+
 // paranoid build time check to ensure ChainInfoFetcher implements required interfaces
 var _ stategen.CanonicalChecker = blockchain.ChainInfoFetcher(nil)
 var _ stategen.CurrentSlotter = blockchain.ChainInfoFetcher(nil)
@@ -224,6 +329,7 @@ func (s *Service) Start() {
 		ProposerSlotIndexCache: s.cfg.ProposerIdsCache,
 		BlockBuilder:           s.cfg.BlockBuilder,
 		BLSChangesPool:         s.cfg.BLSChangesPool,
+		ActivityObserver:       s.cfg.ValidatorActivityObserver,
 	}
 	validatorServerV1 := &validator.Server{
 		HeadFetcher:           s.cfg.HeadFetcher,
@@ -244,6 +350,7 @@ func (s *Service) Start() {
 		},
 		SyncCommitteePool:      s.cfg.SyncCommitteeObjectPool,
 		ProposerSlotIndexCache: s.cfg.ProposerIdsCache,
+		ActivityObserver:       s.cfg.ValidatorActivityObserver,
 	}
 
 	nodeServer := &nodev1alpha1.Server{
@@ -323,6 +430,7 @@ func (s *Service) Start() {
 		ExecutionPayloadReconstructor: s.cfg.ExecutionPayloadReconstructor,
 		BLSChangesPool:                s.cfg.BLSChangesPool,
 		FinalizationFetcher:           s.cfg.FinalizationFetcher,
+		ActivityObserver:              s.cfg.ValidatorActivityObserver,
 	}
 	ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpbservice.RegisterBeaconNodeServer(s.grpcServer, nodeServerV1)
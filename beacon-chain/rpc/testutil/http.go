@@ -0,0 +1,29 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+// DoRequest calls the HTTP handler with a request built from the method, url, JSON encoded
+// body and path variables, and returns the recorded response.
+func DoRequest(t *testing.T, handler http.HandlerFunc, method, url string, body interface{}, vars map[string]string) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	request := httptest.NewRequest(method, url, &buf)
+	if vars != nil {
+		request = mux.SetURLVars(request, vars)
+	}
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	handler(writer, request)
+	return writer
+}
@@ -0,0 +1,21 @@
+package testutil
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+)
+
+// MockBlockFetcher is a fake implementation of blockfetcher.Fetcher.
+type MockBlockFetcher struct {
+	BlockToReturn interfaces.SignedBeaconBlock
+	ErrorToReturn error
+}
+
+// Block --
+func (m *MockBlockFetcher) Block(context.Context, []byte) (interfaces.SignedBeaconBlock, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	return m.BlockToReturn, nil
+}
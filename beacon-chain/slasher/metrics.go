@@ -53,4 +53,8 @@ var (
 		Name: "slasher_surrounded_votes_total",
 		Help: "Total slashable surrounded votes successfully detected by slasher",
 	})
+	rescannedEpochsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_rescanned_epochs_total",
+		Help: "Total number of epochs of the beacon database rescanned by slasher",
+	})
 )
@@ -38,6 +38,11 @@ func DefaultParams() *Parameters {
 	}
 }
 
+// HistoryLength returns the number of epochs of min and max spans kept by slasher.
+func (p *Parameters) HistoryLength() types.Epoch {
+	return p.historyLength
+}
+
 // Validator min and max spans are split into chunks of length C = chunkSize.
 // That is, if we are keeping N epochs worth of attesting history, finding what
 // chunk a certain epoch, e, falls into can be computed as (e % N) / C. For example,
@@ -77,11 +77,20 @@ func (s *Service) receiveBlocks(ctx context.Context, beaconBlockHeadersChan chan
 // This grouping will allow us to perform detection on batches of attestations
 // per validator chunk index which can be done concurrently.
 func (s *Service) processQueuedAttestations(ctx context.Context, slotTicker <-chan types.Slot) {
+	var lastEpoch types.Epoch
 	for {
 		select {
 		case currentSlot := <-slotTicker:
 			attestations := s.attsQueue.dequeue()
 			currentEpoch := slots.ToEpoch(currentSlot)
+
+			// Write the chunks updated during the previous epoch back to disk.
+			if currentEpoch > lastEpoch {
+				if err := s.serviceCfg.Database.FlushSlasherChunks(ctx); err != nil {
+					log.WithError(err).Error("Could not flush slasher chunks to DB")
+				}
+				lastEpoch = currentEpoch
+			}
 			// We take all the attestations in the queue and filter out
 			// those which are valid now and valid in the future.
 			validAtts, validInFuture, numDropped := s.filterAttestations(attestations, currentEpoch)
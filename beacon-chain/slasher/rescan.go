@@ -0,0 +1,238 @@
+package slasher
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/filters"
+	slashertypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// rescanProgressInterval is the minimum interval between two logs of the progress of a rescan.
+const rescanProgressInterval = 10 * time.Second
+
+// RescanResult summarizes the data ingested by a rescan of the beacon database, and the slashable
+// offenses detected in it.
+type RescanResult struct {
+	NumBlocks         int
+	NumAttestations   int
+	AttesterSlashings []*ethpb.AttesterSlashing
+	ProposerSlashings []*ethpb.ProposerSlashing
+}
+
+type rescanCheckpoint struct {
+	epoch types.Epoch
+	root  [32]byte
+}
+
+// Rescan ingests the block headers stored in the beacon database for the given range of epochs, and the
+// attestations included in these blocks, into the slasher database as if they were received live, one
+// epoch after the other. Slashable offenses found along the way are returned, but neither verified nor
+// submitted to the slashing operations pool. Attestations which were never included in a block cannot
+// be recovered from the beacon database.
+func (s *Service) Rescan(ctx context.Context, startEpoch, endEpoch types.Epoch) (*RescanResult, error) {
+	ctx, span := trace.StartSpan(ctx, "slasher.Rescan")
+	defer span.End()
+	if s.serviceCfg.BeaconDB == nil || s.serviceCfg.StateGen == nil {
+		return nil, errors.New("rescan requires a beacon database and a state generator")
+	}
+	if startEpoch > endEpoch {
+		return nil, errors.Errorf("start epoch %d is after end epoch %d", startEpoch, endEpoch)
+	}
+
+	log.WithFields(logrus.Fields{
+		"startEpoch": startEpoch,
+		"endEpoch":   endEpoch,
+	}).Info("Rescanning beacon database for slashing detection")
+	result := &RescanResult{}
+	targetStates := make(map[rescanCheckpoint]state.BeaconState)
+	start := time.Now()
+	lastLog := start
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Blocks include attestations targeting their epoch or the previous one, so older target states
+		// are no longer needed.
+		for cp := range targetStates {
+			if cp.epoch+1 < epoch {
+				delete(targetStates, cp)
+			}
+		}
+
+		atts, headers, err := s.historicalData(ctx, epoch, targetStates)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read historical data for epoch %d", epoch)
+		}
+		if err := s.serviceCfg.Database.SaveAttestationRecordsForValidators(ctx, atts); err != nil {
+			return nil, errors.Wrap(err, "could not save attestation records to DB")
+		}
+		attSlashings, err := s.checkSlashableAttestations(ctx, epoch, atts)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not check slashable attestations")
+		}
+		propSlashings, err := s.detectProposerSlashings(ctx, headers)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not detect proposer slashings")
+		}
+		result.NumBlocks += len(headers)
+		result.NumAttestations += len(atts)
+		result.AttesterSlashings = append(result.AttesterSlashings, attSlashings...)
+		result.ProposerSlashings = append(result.ProposerSlashings, propSlashings...)
+		rescannedEpochsTotal.Inc()
+
+		if time.Since(lastLog) >= rescanProgressInterval || epoch == endEpoch {
+			lastLog = time.Now()
+			done := uint64(epoch-startEpoch) + 1
+			log.WithFields(logrus.Fields{
+				"epoch":           epoch,
+				"endEpoch":        endEpoch,
+				"percent":         done * 100 / (uint64(endEpoch-startEpoch) + 1),
+				"numBlocks":       result.NumBlocks,
+				"numAttestations": result.NumAttestations,
+				"elapsed":         time.Since(start),
+			}).Info("Rescan progress")
+		}
+		if epoch == endEpoch {
+			// Prevents an overflow of the loop counter when rescanning up to the maximum epoch.
+			break
+		}
+	}
+	if err := s.serviceCfg.Database.FlushSlasherChunks(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not flush slasher chunks")
+	}
+	log.WithFields(logrus.Fields{
+		"numBlocks":            result.NumBlocks,
+		"numAttestations":      result.NumAttestations,
+		"numAttesterSlashings": len(result.AttesterSlashings),
+		"numProposerSlashings": len(result.ProposerSlashings),
+		"elapsed":              time.Since(start),
+	}).Info("Done rescanning beacon database")
+	return result, nil
+}
+
+// catchUp rescans the configured number of epochs of the beacon database up to the given epoch, bounded by
+// the history length of slasher, and processes the slashable offenses found.
+func (s *Service) catchUp(ctx context.Context, currentEpoch types.Epoch) {
+	numEpochs := s.serviceCfg.CatchUpEpochs
+	if numEpochs > s.params.historyLength {
+		numEpochs = s.params.historyLength
+	}
+	var startEpoch types.Epoch
+	if currentEpoch > numEpochs {
+		startEpoch = currentEpoch - numEpochs
+	}
+	result, err := s.Rescan(ctx, startEpoch, currentEpoch)
+	if err != nil {
+		log.WithError(err).Error("Could not catch up on historical data")
+		return
+	}
+	if err := s.processAttesterSlashings(ctx, result.AttesterSlashings); err != nil {
+		log.WithError(err).Error("Could not process attester slashings")
+	}
+	if err := s.processProposerSlashings(ctx, result.ProposerSlashings); err != nil {
+		log.WithError(err).Error("Could not process proposer slashings")
+	}
+}
+
+// historicalData reads the blocks of the given epoch from the beacon database, and returns the indexed
+// attestations they include along with their headers.
+func (s *Service) historicalData(
+	ctx context.Context, epoch types.Epoch, targetStates map[rescanCheckpoint]state.BeaconState,
+) ([]*slashertypes.IndexedAttestationWrapper, []*slashertypes.SignedBlockHeaderWrapper, error) {
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	endSlot := startSlot + params.BeaconConfig().SlotsPerEpoch - 1
+	blks, _, err := s.serviceCfg.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get blocks")
+	}
+
+	atts := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	headers := make([]*slashertypes.SignedBlockHeaderWrapper, 0, len(blks))
+	for _, blk := range blks {
+		header, err := interfaces.SignedBeaconBlockHeaderFromBlockInterface(blk)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not get block header")
+		}
+		if validateBlockHeaderIntegrity(header) {
+			signingRoot, err := header.Header.HashTreeRoot()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "could not get hash tree root of block header")
+			}
+			headers = append(headers, &slashertypes.SignedBlockHeaderWrapper{
+				SignedBeaconBlockHeader: header,
+				SigningRoot:             signingRoot,
+			})
+		}
+
+		for _, att := range blk.Block().Body().Attestations() {
+			targetState, err := s.rescanTargetState(ctx, att.Data.Target, targetStates)
+			if err != nil {
+				return nil, nil, err
+			}
+			committee, err := helpers.BeaconCommitteeFromState(ctx, targetState, att.Data.Slot, att.Data.CommitteeIndex)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "could not get attestation committee")
+			}
+			indexedAtt, err := attestation.ConvertToIndexed(ctx, att, committee)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "could not convert to indexed attestation")
+			}
+			if !validateAttestationIntegrity(indexedAtt) {
+				continue
+			}
+			signingRoot, err := indexedAtt.Data.HashTreeRoot()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "could not get hash tree root of attestation")
+			}
+			atts = append(atts, &slashertypes.IndexedAttestationWrapper{
+				IndexedAttestation: indexedAtt,
+				SigningRoot:        signingRoot,
+			})
+		}
+	}
+	return atts, headers, nil
+}
+
+// rescanTargetState returns the state at the start of the epoch of the given attestation target, from which
+// the committees of the attestation can be computed.
+func (s *Service) rescanTargetState(
+	ctx context.Context, target *ethpb.Checkpoint, targetStates map[rescanCheckpoint]state.BeaconState,
+) (state.BeaconState, error) {
+	cp := rescanCheckpoint{epoch: target.Epoch, root: bytesutil.ToBytes32(target.Root)}
+	if st, ok := targetStates[cp]; ok {
+		return st, nil
+	}
+	st, err := s.serviceCfg.StateGen.StateByRoot(ctx, cp.root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get target state for epoch %d", target.Epoch)
+	}
+	epochStartSlot, err := slots.EpochStart(target.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() < epochStartSlot {
+		st, err = transition.ProcessSlots(ctx, st.Copy(), epochStartSlot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to epoch %d", target.Epoch)
+		}
+	}
+	targetStates[cp] = st
+	return st, nil
+}
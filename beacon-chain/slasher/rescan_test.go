@@ -0,0 +1,75 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	dbtest "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestService_Rescan(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	beaconDB := dbtest.SetupDB(t)
+
+	genesisState, _ := util.DeterministicGenesisState(t, 64)
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	require.NoError(t, beaconDB.SaveState(ctx, genesisState, genesisRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	// Two conflicting blocks of the same proposer, each including a conflicting vote of the same validator.
+	for i := byte(1); i <= 2; i++ {
+		bits := bitfield.NewBitlist(2)
+		bits.SetBitAt(0, true)
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 33
+		b.Block.ProposerIndex = 1
+		b.Block.ParentRoot = genesisRoot[:]
+		b.Block.Body.Graffiti = bytesutil.PadTo([]byte{i}, 32)
+		b.Block.Body.Attestations = []*ethpb.Attestation{util.HydrateAttestation(&ethpb.Attestation{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				Slot:            32,
+				BeaconBlockRoot: bytesutil.PadTo([]byte{i}, 32),
+				Target:          &ethpb.Checkpoint{Epoch: 1, Root: genesisRoot[:]},
+			},
+		})}
+		b.Signature = bytesutil.PadTo([]byte{i}, 96)
+		util.SaveBlock(t, ctx, beaconDB, b)
+	}
+
+	srv, err := New(ctx, &ServiceConfig{
+		Database: slasherDB,
+		BeaconDB: beaconDB,
+		StateGen: stategen.New(beaconDB, doublylinkedtree.New()),
+	})
+	require.NoError(t, err)
+	_, err = srv.Rescan(ctx, 2, 1)
+	require.ErrorContains(t, "start epoch 2 is after end epoch 1", err)
+
+	result, err := srv.Rescan(ctx, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.NumBlocks)
+	assert.Equal(t, 2, result.NumAttestations)
+	require.Equal(t, 1, len(result.ProposerSlashings))
+	assert.Equal(t, uint64(33), uint64(result.ProposerSlashings[0].Header_1.Header.Slot))
+	require.NotEqual(t, 0, len(result.AttesterSlashings))
+	assert.DeepEqual(t, result.AttesterSlashings[0].Attestation_1.AttestingIndices, result.AttesterSlashings[0].Attestation_2.AttestingIndices)
+
+	// Attestations are recorded in the slasher database.
+	records, err := slasherDB.AttestationRecordForValidator(ctx, types.ValidatorIndex(result.AttesterSlashings[0].Attestation_1.AttestingIndices[0]), 1)
+	require.NoError(t, err)
+	require.NotNil(t, records)
+}
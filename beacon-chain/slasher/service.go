@@ -39,6 +39,11 @@ type ServiceConfig struct {
 	SlashingPoolInserter    slashings.PoolInserter
 	HeadStateFetcher        blockchain.HeadFetcher
 	SyncChecker             sync.Checker
+
+	// BeaconDB and CatchUpEpochs allow slasher to catch up on the given number of epochs of historical
+	// data from the beacon database when it starts, before processing data received live.
+	BeaconDB      db.ReadOnlyDatabase
+	CatchUpEpochs types.Epoch
 }
 
 // SlashingChecker is an interface for defining services that the beacon node may interact with to provide slashing data.
@@ -128,6 +133,11 @@ func (s *Service) run() {
 	go s.receiveAttestations(s.ctx, indexedAttsChan)
 	go s.receiveBlocks(s.ctx, beaconBlockHeadersChan)
 
+	// Data received while catching up is queued, and processed once slasher is done catching up.
+	if s.serviceCfg.CatchUpEpochs > 0 {
+		s.catchUp(s.ctx, slots.ToEpoch(headState.Slot()))
+	}
+
 	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
 	s.attsSlotTicker = slots.NewSlotTicker(s.genesisTime, secondsPerSlot)
 	s.blocksSlotTicker = slots.NewSlotTicker(s.genesisTime, secondsPerSlot)
@@ -154,6 +164,9 @@ func (s *Service) Stop() error {
 	// New context as the service context has already been canceled.
 	ctx, innerCancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer innerCancel()
+	if err := s.serviceCfg.Database.FlushSlasherChunks(ctx); err != nil {
+		log.WithError(err).Error("Could not flush slasher chunks to DB")
+	}
 	log.Info("Flushing last epoch written for each validator to disk, please wait")
 	if err := s.serviceCfg.Database.SaveLastEpochsWrittenForValidators(
 		ctx, s.latestEpochWrittenForValidator,
@@ -205,6 +205,13 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 	if s.beaconDB.HasState(ctx, blockRoot) {
 		return s.beaconDB.State(ctx, blockRoot)
 	}
+	archived, err := s.beaconDB.ArchivedState(ctx, blockRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get archived state")
+	}
+	if archived != nil {
+		return archived, nil
+	}
 
 	summary, err := s.stateSummary(ctx, blockRoot)
 	if err != nil {
@@ -302,6 +309,15 @@ func (s *State) latestAncestor(ctx context.Context, blockRoot [32]byte) (state.B
 			return s, errors.Wrap(err, "failed to retrieve state from db")
 		}
 
+		// Does the state exist in the historical state archive.
+		archived, err := s.beaconDB.ArchivedState(ctx, parentRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to retrieve archived state from db")
+		}
+		if archived != nil {
+			return archived, nil
+		}
+
 		b, err = s.beaconDB.Block(ctx, parentRoot)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to retrieve block from db")
@@ -132,7 +132,18 @@ func (c *CanonicalHistory) getState(ctx context.Context, blockRoot [32]byte) (st
 			return nil, errors.Wrap(err, "error reading from state cache during state replay")
 		}
 	}
-	return c.h.StateOrError(ctx, blockRoot)
+	st, err := c.h.StateOrError(ctx, blockRoot)
+	if !errors.Is(err, db.ErrNotFoundState) {
+		return st, err
+	}
+	archived, aerr := c.h.ArchivedState(ctx, blockRoot)
+	if aerr != nil {
+		return nil, errors.Wrap(aerr, "error reading from historical state archive during state replay")
+	}
+	if archived == nil {
+		return nil, err
+	}
+	return archived, nil
 }
 
 // ancestorChain works backwards through the chain lineage, accumulating blocks and checking for a saved state.
@@ -377,6 +377,31 @@ func TestAncestorChainOK(t *testing.T) {
 	require.Equal(t, expectedHTR, actualHTR)
 }
 
+func TestAncestorChainArchivedState(t *testing.T) {
+	ctx := context.Background()
+	var begin, middle, end types.Slot = 100, 150, 155
+	specs := []mockHistorySpec{
+		{slot: begin},
+		{slot: middle},
+		{slot: end, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, end+1)
+	ch := &CanonicalHistory{h: hist, cc: hist, cs: hist}
+	middleRoot := hist.slotMap[middle]
+	hist.archived[middleRoot] = hist.hiddenStates[middleRoot]
+
+	endBlock := hist.blocks[hist.slotMap[end]]
+	st, bs, err := ch.ancestorChain(ctx, endBlock)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(bs))
+	require.DeepEqual(t, endBlock, bs[0])
+	expectedHTR, err := hist.archived[middleRoot].HashTreeRoot(ctx)
+	require.NoError(t, err)
+	actualHTR, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expectedHTR, actualHTR)
+}
+
 func TestChainForSlot(t *testing.T) {
 	ctx := context.Background()
 	var zero, one, two, three types.Slot = 50, 51, 150, 151
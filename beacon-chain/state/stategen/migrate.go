@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -44,6 +45,15 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 			return ctx.Err()
 		}
 
+		if s.archive != nil {
+			if slot%s.archive.diffInterval == 0 && slot != 0 {
+				if err := s.archiveState(ctx, slot); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
 		if slot%s.slotsPerArchivedPoint == 0 && slot != 0 {
 			cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
 			if err != nil {
@@ -120,3 +130,44 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// archiveState saves the state of the given slot to the historical state archive, as a full snapshot
+// if the slot is on a snapshot interval, or as a diff against the latest snapshot otherwise.
+func (s *State) archiveState(ctx context.Context, slot types.Slot) error {
+	cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
+	if err != nil {
+		return fmt.Errorf("could not get epoch boundary state for slot %d", slot)
+	}
+
+	var aRoot [32]byte
+	var aState state.BeaconState
+	if exists {
+		aRoot = cached.root
+		aState = cached.state
+	} else {
+		_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slot)
+		if err != nil {
+			return err
+		}
+		if len(roots) != 1 {
+			return errUnknownBlock
+		}
+		aRoot = roots[0]
+		aState, err = s.StateByRoot(ctx, aRoot)
+		if err != nil {
+			return err
+		}
+	}
+
+	snapshot := slot%s.archive.snapshotInterval == 0
+	if err := s.beaconDB.SaveArchivedState(ctx, aState, aRoot, snapshot); err != nil {
+		return err
+	}
+	log.WithFields(
+		logrus.Fields{
+			"slot":     aState.Slot(),
+			"root":     hex.EncodeToString(bytesutil.Trunc(aRoot[:])),
+			"snapshot": snapshot,
+		}).Debug("Archived state in DB")
+	return nil
+}
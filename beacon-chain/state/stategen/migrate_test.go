@@ -7,6 +7,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
 	testDB "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	consensusblocks "github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
@@ -63,6 +64,44 @@ func TestMigrateToCold_HappyPath(t *testing.T) {
 	require.LogsContain(t, hook, "Saved state in DB")
 }
 
+func TestMigrateToCold_StateArchive(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB, doublylinkedtree.New(), WithStateArchive(2, 1))
+	beaconState, _ := util.DeterministicGenesisState(t, 32)
+	var roots [][32]byte
+	var states []state.BeaconState
+	for slot := types.Slot(1); slot <= 3; slot++ {
+		st := beaconState.Copy()
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, st.UpdateBalancesAtIndex(0, uint64(slot)))
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, service.beaconDB, b)
+		require.NoError(t, service.epochBoundaryStateCache.put(r, st))
+		roots = append(roots, r)
+		states = append(states, st)
+	}
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 4
+	fRoot, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, service.beaconDB, b)
+	require.NoError(t, service.MigrateToCold(ctx, fRoot))
+
+	// Archived states are not saved in full, but can be loaded by a fresh service.
+	service = New(beaconDB, doublylinkedtree.New(), WithStateArchive(2, 1))
+	for i, r := range roots {
+		assert.Equal(t, false, beaconDB.HasState(ctx, r))
+		got, err := service.StateByRoot(ctx, r)
+		require.NoError(t, err)
+		assert.DeepSSZEqual(t, states[i].ToProtoUnsafe(), got.ToProtoUnsafe())
+	}
+}
+
 func TestMigrateToCold_RegeneratePath(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
@@ -80,6 +80,7 @@ type mockHistory struct {
 	canonical                      map[[32]byte]bool
 	states                         map[[32]byte]state.BeaconState
 	hiddenStates                   map[[32]byte]state.BeaconState
+	archived                       map[[32]byte]state.BeaconState
 	current                        types.Slot
 	overrideHighestSlotBlocksBelow func(context.Context, types.Slot) (types.Slot, [][32]byte, error)
 }
@@ -145,6 +146,13 @@ func (m *mockHistory) StateOrError(_ context.Context, blockRoot [32]byte) (state
 	return nil, db.ErrNotFoundState
 }
 
+func (m *mockHistory) ArchivedState(_ context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	if s, ok := m.archived[blockRoot]; ok {
+		return s.Copy(), nil
+	}
+	return nil, nil
+}
+
 func (m *mockHistory) IsCanonical(_ context.Context, blockRoot [32]byte) (bool, error) {
 	canon, ok := m.canonical[blockRoot]
 	return ok && canon, nil
@@ -194,6 +202,7 @@ func newMockHistory(t *testing.T, hist []mockHistorySpec, current types.Slot) *m
 		canonical:    map[[32]byte]bool{},
 		states:       map[[32]byte]state.BeaconState{},
 		hiddenStates: map[[32]byte]state.BeaconState{},
+		archived:     map[[32]byte]state.BeaconState{},
 		slotMap:      map[types.Slot][32]byte{},
 		slotIndex:    slotList{},
 		current:      current,
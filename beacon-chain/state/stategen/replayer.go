@@ -30,6 +30,7 @@ type HistoryAccessor interface {
 	GenesisBlockRoot(ctx context.Context) ([32]byte, error)
 	Block(ctx context.Context, blockRoot [32]byte) (interfaces.SignedBeaconBlock, error)
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+	ArchivedState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 }
 
 // CanonicalChecker determines whether the given block root is canonical.
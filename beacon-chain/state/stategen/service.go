@@ -46,6 +46,7 @@ type State struct {
 	finalizedInfo           *finalizedInfo
 	epochBoundaryStateCache *epochBoundaryState
 	saveHotStateDB          *saveHotStateDbConfig
+	archive                 *stateArchiveConfig
 	backfillStatus          *backfill.Status
 	migrationLock           *sync.Mutex
 	fc                      forkchoice.ForkChoicer
@@ -61,6 +62,14 @@ type saveHotStateDbConfig struct {
 	blockRootsOfSavedStates [][32]byte
 }
 
+// This tracks the config of the historical state archive. When enabled, finalized states are
+// archived as full snapshots every snapshot interval, and as diffs against the latest snapshot
+// every diff interval, instead of as full states every archived point.
+type stateArchiveConfig struct {
+	snapshotInterval types.Slot
+	diffInterval     types.Slot
+}
+
 // This tracks the finalized point. It's also the point where slot and the block root of
 // cold and hot sections of the DB splits.
 type finalizedInfo struct {
@@ -79,6 +88,17 @@ func WithBackfillStatus(bfs *backfill.Status) StateGenOption {
 	}
 }
 
+// WithStateArchive enables the historical state archive, with the given intervals between full
+// snapshots and between diffs. The snapshot interval must be a multiple of the diff interval.
+func WithStateArchive(snapshotInterval, diffInterval types.Slot) StateGenOption {
+	return func(sg *State) {
+		sg.archive = &stateArchiveConfig{
+			snapshotInterval: snapshotInterval,
+			diffInterval:     diffInterval,
+		}
+	}
+}
+
 // New returns a new state management object.
 func New(beaconDB db.NoHeadAccessDatabase, fc forkchoice.ForkChoicer, opts ...StateGenOption) *State {
 	s := &State{
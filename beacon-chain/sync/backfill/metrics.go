@@ -0,0 +1,21 @@
+package backfill
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	backfillBatchesImported = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_batches_imported",
+		Help: "Number of verified batches of blocks saved to the database by the backfill service",
+	})
+	backfillBlocksImported = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_blocks_imported",
+		Help: "Number of verified blocks saved to the database by the backfill service",
+	})
+	backfillRemainingSlots = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "backfill_remaining_slots",
+		Help: "Number of slots between the backfill start and the lowest block imported by the backfill service",
+	})
+)
@@ -0,0 +1,369 @@
+package backfill
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/crypto/rand"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+const (
+	// DefaultBatchSize is the number of slots requested from a peer in a single BeaconBlocksByRange request.
+	DefaultBatchSize = 64
+	// pollInterval is the time to wait between checks for initial sync completion or for suitable peers.
+	pollInterval = 5 * time.Second
+	// defaultRetryDelay is the time to wait before requesting blocks again after a failed request.
+	defaultRetryDelay = time.Second
+)
+
+var (
+	errNoBatchVerifier = errors.New("backfill service cannot verify blocks without the origin checkpoint state")
+	errChainUnlinked   = errors.New("backfilled blocks do not link to the backfill block root")
+)
+
+// BlocksByRangeRequester sends a BeaconBlocksByRange request to the given peer and returns the
+// blocks in the response. It is provided by the beacon node, so that the backfill package does not depend
+// on the sync package.
+type BlocksByRangeRequester func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error)
+
+// SyncChecker reports whether initial sync has completed.
+type SyncChecker interface {
+	Synced() bool
+}
+
+// ServiceDB describes the set of DB methods that the backfill Service needs to function.
+type ServiceDB interface {
+	BackfillDB
+	HasBlock(ctx context.Context, blockRoot [32]byte) bool
+	SaveBlocks(ctx context.Context, blks []interfaces.SignedBeaconBlock) error
+	State(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+}
+
+// Service fills the gap in block history left by checkpoint sync. Starting from the origin checkpoint block,
+// it walks backwards towards Status.StartGap(), requesting batches of blocks from peers with BeaconBlocksByRange.
+// Each batch must link into the parent root of the lowest block imported so far and carry valid proposer
+// signatures before it is written to the database, after which the end of the gap is lowered with
+// Status.AdvanceEnd(). Once the chain links to the backfill block root, the gap is closed with Status.Advance().
+type Service struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	status      *Status
+	db          ServiceDB
+	p2p         p2p.P2P
+	request     BlocksByRangeRequester
+	syncChecker SyncChecker
+	batchSize   uint64
+	verifier    *verifier
+	rand        *rand.Rand
+	retryDelay  time.Duration
+}
+
+// position is the lowest point of the verified chain, built downwards from the origin checkpoint block.
+// parent is the root of the next block that needs to be imported, which must be at a slot below slot.
+type position struct {
+	parent [32]byte
+	slot   types.Slot
+}
+
+// Option is a functional option for the backfill Service.
+type Option func(s *Service) error
+
+// WithDatabase sets the database used to read the backfill range and to save backfilled blocks.
+func WithDatabase(db ServiceDB) Option {
+	return func(s *Service) error {
+		s.db = db
+		return nil
+	}
+}
+
+// WithP2P sets the p2p service used to find peers to backfill from.
+func WithP2P(p p2p.P2P) Option {
+	return func(s *Service) error {
+		s.p2p = p
+		return nil
+	}
+}
+
+// WithBlocksByRangeRequester sets the function used to request blocks from peers.
+func WithBlocksByRangeRequester(r BlocksByRangeRequester) Option {
+	return func(s *Service) error {
+		s.request = r
+		return nil
+	}
+}
+
+// WithSyncChecker sets the initial sync checker. Backfill waits for initial sync to complete before starting.
+func WithSyncChecker(c SyncChecker) Option {
+	return func(s *Service) error {
+		s.syncChecker = c
+		return nil
+	}
+}
+
+// WithBatchSize sets the number of slots requested in a single BeaconBlocksByRange request.
+func WithBatchSize(n uint64) Option {
+	return func(s *Service) error {
+		if n == 0 || n > params.BeaconNetworkConfig().MaxRequestBlocks {
+			return errors.Errorf("backfill batch size must be between 1 and %d, got %d", params.BeaconNetworkConfig().MaxRequestBlocks, n)
+		}
+		s.batchSize = n
+		return nil
+	}
+}
+
+// NewService initializes the backfill Service with the given Status, which must already be loaded via Reload().
+func NewService(ctx context.Context, su *Status, opts ...Option) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:        ctx,
+		cancel:     cancel,
+		status:     su,
+		batchSize:  DefaultBatchSize,
+		rand:       rand.NewGenerator(),
+		retryDelay: defaultRetryDelay,
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if s.db == nil || s.p2p == nil || s.request == nil {
+		cancel()
+		return nil, errors.New("backfill service requires a database, p2p service and blocks by range requester")
+	}
+	return s, nil
+}
+
+// Start the backfill service.
+func (s *Service) Start() {
+	if s.status.Complete() {
+		log.Debug("Node history is complete, exiting backfill service")
+		return
+	}
+	if err := s.waitForInitialSync(); err != nil {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"startGap": s.status.StartGap(),
+		"endGap":   s.status.EndGap(),
+	}).Info("Starting backfill of block history before the checkpoint sync origin")
+	if err := s.run(); err != nil {
+		if errors.Is(s.ctx.Err(), context.Canceled) {
+			return
+		}
+		log.WithError(err).Error("Backfill service exited with an error")
+	}
+}
+
+// Stop the backfill service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the backfill service.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) waitForInitialSync() error {
+	if s.syncChecker == nil {
+		return nil
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for !s.syncChecker.Synced() {
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func (s *Service) run() error {
+	originRoot, err := s.db.OriginCheckpointBlockRoot(s.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve origin checkpoint block root")
+	}
+	origin, err := s.db.Block(s.ctx, originRoot)
+	if err != nil {
+		return errors.Wrapf(err, "could not retrieve origin checkpoint block root=%#x", originRoot)
+	}
+	if err := blocks.BeaconBlockIsNil(origin); err != nil {
+		return err
+	}
+	st, err := s.db.State(s.ctx, originRoot)
+	if err != nil {
+		return errors.Wrapf(err, "could not retrieve origin checkpoint state root=%#x", originRoot)
+	}
+	if st == nil || st.IsNil() {
+		return errNoBatchVerifier
+	}
+	s.verifier = newVerifier(st)
+	bfRoot, err := s.db.BackfillBlockRoot(s.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve backfill block root")
+	}
+
+	low := s.status.StartGap()
+	pos := position{parent: origin.Block().ParentRoot(), slot: origin.Block().Slot()}
+	cursor := pos.slot
+	for {
+		if s.ctx.Err() != nil {
+			return s.ctx.Err()
+		}
+		pos, err = s.skipStored(pos, bfRoot)
+		if err != nil {
+			return err
+		}
+		// Blocks from pos.slot up to the origin checkpoint block are available, whether they were
+		// imported by the last batch or by an earlier run of the service.
+		s.status.AdvanceEnd(pos.slot)
+		if pos.parent == bfRoot {
+			break
+		}
+		if cursor > pos.slot {
+			cursor = pos.slot
+		}
+		backfillRemainingSlots.Set(float64(pos.slot - low))
+		if cursor <= low+1 {
+			// Every slot in the gap was requested, but the chain does not yet link to the backfill root.
+			// The verified chain is linked to the origin checkpoint block, so if there are no slots left
+			// below it, the backfill root can never be reached.
+			if pos.slot <= low+1 {
+				return errors.Wrapf(errChainUnlinked, "lowest backfilled block at slot %d has parent root %#x", pos.slot, pos.parent)
+			}
+			// Otherwise a peer must have withheld blocks, so start over from the lowest verified block.
+			log.WithField("slot", pos.slot).Warn("Backfilled blocks do not link to the backfill block root, retrying")
+			select {
+			case <-s.ctx.Done():
+				return s.ctx.Err()
+			case <-time.After(s.retryDelay):
+			}
+			cursor = pos.slot
+			continue
+		}
+		start := low + 1
+		if cursor-start > types.Slot(s.batchSize) {
+			start = cursor - types.Slot(s.batchSize)
+		}
+		pid, err := s.pickPeer()
+		if err != nil {
+			return err
+		}
+		req := &ethpb.BeaconBlocksByRangeRequest{
+			StartSlot: start,
+			Count:     uint64(cursor - start),
+			Step:      1,
+		}
+		blks, err := s.request(s.ctx, pid, req)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not request backfill blocks from peer")
+			s.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+			select {
+			case <-s.ctx.Done():
+				return s.ctx.Err()
+			case <-time.After(s.retryDelay):
+			}
+			continue
+		}
+		next, err := s.importBatch(blks, pos)
+		if err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"peer":  pid,
+				"start": req.StartSlot,
+				"count": req.Count,
+			}).Debug("Rejected backfill batch")
+			s.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+			cursor = pos.slot
+			continue
+		}
+		pos = next
+		cursor = start
+	}
+
+	if err := s.status.Advance(s.ctx, origin.Block().Slot(), originRoot); err != nil {
+		return errors.Wrap(err, "could not advance backfill status")
+	}
+	backfillRemainingSlots.Set(0)
+	log.WithField("originSlot", origin.Block().Slot()).Info("Backfill complete, block history is available from genesis")
+	return nil
+}
+
+// skipStored follows the chain through blocks that are already in the database, for instance
+// because they were imported by an earlier run of the service that was interrupted.
+func (s *Service) skipStored(pos position, bfRoot [32]byte) (position, error) {
+	for pos.parent != bfRoot && s.db.HasBlock(s.ctx, pos.parent) {
+		b, err := s.db.Block(s.ctx, pos.parent)
+		if err != nil {
+			return pos, err
+		}
+		if err := blocks.BeaconBlockIsNil(b); err != nil {
+			return pos, err
+		}
+		pos = position{parent: b.Block().ParentRoot(), slot: b.Block().Slot()}
+	}
+	return pos, nil
+}
+
+// importBatch verifies that the given blocks extend the chain below pos and carry valid proposer signatures,
+// then saves them to the database. It returns the new lowest position of the verified chain.
+func (s *Service) importBatch(blks []interfaces.SignedBeaconBlock, pos position) (position, error) {
+	if len(blks) == 0 {
+		return pos, nil
+	}
+	parent, err := verifyChain(blks, pos.parent)
+	if err != nil {
+		return pos, err
+	}
+	if err := s.verifier.verify(blks); err != nil {
+		return pos, err
+	}
+	if err := s.db.SaveBlocks(s.ctx, blks); err != nil {
+		return pos, errors.Wrap(err, "could not save backfilled blocks")
+	}
+	backfillBatchesImported.Inc()
+	backfillBlocksImported.Add(float64(len(blks)))
+	return position{parent: parent, slot: blks[0].Block().Slot()}, nil
+}
+
+// pickPeer selects a random peer that has finalized the origin checkpoint, waiting until one is available.
+func (s *Service) pickPeer() (peer.ID, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		_, best := s.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, slots.ToEpoch(s.status.EndGap()))
+		pids := make([]peer.ID, 0, len(best))
+		for _, pid := range best {
+			if !s.p2p.Peers().IsBad(pid) {
+				pids = append(pids, pid)
+			}
+		}
+		if len(pids) > 0 {
+			return pids[s.rand.Intn(len(pids))], nil
+		}
+		log.Debug("Waiting for suitable peers to backfill from")
+		select {
+		case <-s.ctx.Done():
+			return "", s.ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
+	dbtest "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+// testChain generates a chain of signed blocks on top of a deterministic genesis state,
+// leaving the slots in skip empty. It returns the genesis state, the blocks and the post-state of the last block.
+func testChain(t *testing.T, upTo types.Slot, skip map[types.Slot]bool) (state.BeaconState, []interfaces.SignedBeaconBlock, state.BeaconState) {
+	ctx := context.Background()
+	genesis, keys := util.DeterministicGenesisState(t, 64)
+	st := genesis.Copy()
+	blks := make([]interfaces.SignedBeaconBlock, 0, upTo)
+	for slot := types.Slot(1); slot <= upTo; slot++ {
+		if skip[slot] {
+			continue
+		}
+		b, err := util.GenerateFullBlock(st, keys, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		blks = append(blks, wsb)
+	}
+	return genesis, blks, st
+}
+
+// setupCheckpointDB prepares a database as checkpoint sync would, using the last block as the origin.
+func setupCheckpointDB(t *testing.T, genesis state.BeaconState, blks []interfaces.SignedBeaconBlock, originState state.BeaconState) *Status {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	require.NoError(t, db.SaveGenesisData(ctx, genesis))
+	serState, err := originState.MarshalSSZ()
+	require.NoError(t, err)
+	serBlock, err := blks[len(blks)-1].MarshalSSZ()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveOrigin(ctx, serState, serBlock))
+	su := NewStatus(db)
+	require.NoError(t, su.Reload(ctx))
+	return su
+}
+
+func addTestPeer(t *testing.T, p *p2ptest.TestP2P, pid peer.ID, finalized types.Epoch) {
+	p.Peers().Add(new(enr.Record), pid, nil, network.DirOutbound)
+	p.Peers().SetConnectionState(pid, peers.PeerConnected)
+	p.Peers().SetChainState(pid, &ethpb.Status{
+		FinalizedEpoch: finalized,
+	})
+}
+
+// rangeRequester serves blocks from the given chain, passing each response through the tamper function if set.
+func rangeRequester(chain []interfaces.SignedBeaconBlock, tamper func(pid peer.ID, blks []interfaces.SignedBeaconBlock) []interfaces.SignedBeaconBlock) BlocksByRangeRequester {
+	return func(_ context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error) {
+		resp := make([]interfaces.SignedBeaconBlock, 0)
+		for _, b := range chain {
+			if b.Block().Slot() >= req.StartSlot && b.Block().Slot() < req.StartSlot.Add(req.Count) {
+				resp = append(resp, b)
+			}
+		}
+		if tamper != nil {
+			return tamper(pid, resp), nil
+		}
+		return resp, nil
+	}
+}
+
+func TestService_Backfill(t *testing.T) {
+	ctx := context.Background()
+	genesis, blks, originState := testChain(t, 12, map[types.Slot]bool{4: true, 5: true, 9: true})
+	su := setupCheckpointDB(t, genesis, blks, originState)
+	require.Equal(t, false, su.Complete())
+	require.Equal(t, false, su.SlotCovered(3))
+
+	p := p2ptest.NewTestP2P(t)
+	addTestPeer(t, p, "good", 0)
+	s, err := NewService(ctx, su,
+		WithDatabase(su.store.(ServiceDB)),
+		WithP2P(p),
+		WithBlocksByRangeRequester(rangeRequester(blks, nil)),
+		WithBatchSize(3),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.run())
+
+	require.Equal(t, true, su.Complete())
+	db := su.store.(ServiceDB)
+	for _, b := range blks {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, true, db.HasBlock(ctx, r))
+		require.Equal(t, true, su.SlotCovered(b.Block().Slot()))
+	}
+}
+
+func TestService_BackfillRejectsBadBatches(t *testing.T) {
+	ctx := context.Background()
+	genesis, blks, originState := testChain(t, 10, map[types.Slot]bool{6: true})
+	su := setupCheckpointDB(t, genesis, blks, originState)
+
+	p := p2ptest.NewTestP2P(t)
+	addTestPeer(t, p, "withholding", 0)
+	withheld := 0
+	// The withholding peer drops the highest block of its first few responses, breaking the parent chain.
+	tamper := func(pid peer.ID, resp []interfaces.SignedBeaconBlock) []interfaces.SignedBeaconBlock {
+		if withheld < 2 && len(resp) > 1 {
+			withheld++
+			return resp[:len(resp)-1]
+		}
+		return resp
+	}
+	s, err := NewService(ctx, su,
+		WithDatabase(su.store.(ServiceDB)),
+		WithP2P(p),
+		WithBlocksByRangeRequester(rangeRequester(blks, tamper)),
+		WithBatchSize(4),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.run())
+	require.Equal(t, true, su.Complete())
+	bad, err := p.Peers().Scorers().BadResponsesScorer().Count("withholding")
+	require.NoError(t, err)
+	require.Equal(t, 2, bad)
+}
+
+func TestService_BackfillAdvancesPerBatch(t *testing.T) {
+	ctx := context.Background()
+	genesis, blks, originState := testChain(t, 9, nil)
+	su := setupCheckpointDB(t, genesis, blks, originState)
+
+	p := p2ptest.NewTestP2P(t)
+	addTestPeer(t, p, "good", 0)
+	// Blocks imported by earlier batches are available before the gap is closed.
+	var ends []types.Slot
+	requester := func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error) {
+		ends = append(ends, su.EndGap())
+		require.Equal(t, true, su.SlotCovered(su.EndGap()))
+		require.Equal(t, false, su.Complete())
+		return rangeRequester(blks, nil)(ctx, pid, req)
+	}
+	s, err := NewService(ctx, su,
+		WithDatabase(su.store.(ServiceDB)),
+		WithP2P(p),
+		WithBlocksByRangeRequester(requester),
+		WithBatchSize(3),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.run())
+	require.Equal(t, true, su.Complete())
+	require.DeepEqual(t, []types.Slot{9, 6, 3}, ends)
+}
+
+func TestService_BackfillRetriesFailedRequests(t *testing.T) {
+	ctx := context.Background()
+	genesis, blks, originState := testChain(t, 6, nil)
+	su := setupCheckpointDB(t, genesis, blks, originState)
+
+	p := p2ptest.NewTestP2P(t)
+	addTestPeer(t, p, "flaky", 0)
+	failures := 0
+	requester := func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error) {
+		if failures < 2 {
+			failures++
+			return nil, errors.New("stream reset")
+		}
+		return rangeRequester(blks, nil)(ctx, pid, req)
+	}
+	s, err := NewService(ctx, su,
+		WithDatabase(su.store.(ServiceDB)),
+		WithP2P(p),
+		WithBlocksByRangeRequester(requester),
+		WithBatchSize(10),
+	)
+	require.NoError(t, err)
+	s.retryDelay = 0
+	require.NoError(t, s.run())
+	require.Equal(t, true, su.Complete())
+	bad, err := p.Peers().Scorers().BadResponsesScorer().Count("flaky")
+	require.NoError(t, err)
+	require.Equal(t, 2, bad)
+}
+
+func TestService_BackfillResumesFromStoredBlocks(t *testing.T) {
+	ctx := context.Background()
+	genesis, blks, originState := testChain(t, 8, nil)
+	su := setupCheckpointDB(t, genesis, blks, originState)
+	db := su.store.(ServiceDB)
+	// Blocks imported by an earlier run are not requested again.
+	require.NoError(t, db.SaveBlocks(ctx, blks[4:]))
+
+	p := p2ptest.NewTestP2P(t)
+	addTestPeer(t, p, "good", 0)
+	var requested []types.Slot
+	requester := func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error) {
+		requested = append(requested, req.StartSlot.Add(req.Count-1))
+		return rangeRequester(blks, nil)(ctx, pid, req)
+	}
+	s, err := NewService(ctx, su,
+		WithDatabase(db),
+		WithP2P(p),
+		WithBlocksByRangeRequester(requester),
+		WithBatchSize(10),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.run())
+	require.Equal(t, true, su.Complete())
+	require.DeepEqual(t, []types.Slot{4}, requested)
+}
+
+func TestService_BackfillChainNeverLinks(t *testing.T) {
+	ctx := context.Background()
+	genesis, blks, originState := testChain(t, 6, nil)
+	su := setupCheckpointDB(t, genesis, blks, originState)
+	// The served chain is linked to the origin, but not to the backfill block root.
+	require.NoError(t, su.store.SaveBackfillBlockRoot(ctx, [32]byte{'u'}))
+
+	p := p2ptest.NewTestP2P(t)
+	addTestPeer(t, p, "good", 0)
+	requests := 0
+	requester := func(ctx context.Context, pid peer.ID, req *ethpb.BeaconBlocksByRangeRequest) ([]interfaces.SignedBeaconBlock, error) {
+		requests++
+		return rangeRequester(blks, nil)(ctx, pid, req)
+	}
+	s, err := NewService(ctx, su,
+		WithDatabase(su.store.(ServiceDB)),
+		WithP2P(p),
+		WithBlocksByRangeRequester(requester),
+		WithBatchSize(10),
+	)
+	require.NoError(t, err)
+	s.retryDelay = 0
+	err = s.run()
+	require.ErrorIs(t, err, errChainUnlinked)
+	require.Equal(t, 1, requests)
+}
+
+func TestNewService_Errors(t *testing.T) {
+	_, err := NewService(context.Background(), &Status{})
+	require.ErrorContains(t, "requires a database", err)
+	_, err = NewService(context.Background(), &Status{}, WithBatchSize(0))
+	require.ErrorContains(t, "batch size must be between", err)
+}
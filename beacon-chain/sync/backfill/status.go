@@ -2,6 +2,7 @@ package backfill
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
@@ -23,8 +24,10 @@ func NewStatus(store BackfillDB) *Status {
 // end of the missing block range via the Advance() method, to check whether a Slot is missing from the database
 // via the SlotCovered() method, and to see the current StartGap() and EndGap().
 type Status struct {
+	mu          sync.RWMutex
 	start       types.Slot
 	end         types.Slot
+	origin      types.Slot
 	store       BackfillDB
 	genesisSync bool
 }
@@ -33,11 +36,13 @@ type Status struct {
 // If the slot is <= StartGap(), or >= EndGap(), the result is true.
 // If the slot is between StartGap() and EndGap(), the result is false.
 func (s *Status) SlotCovered(sl types.Slot) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	// short circuit if the node was synced from genesis
 	if s.genesisSync {
 		return true
 	}
-	if s.StartGap() < sl && sl < s.EndGap() {
+	if s.start < sl && sl < s.end {
 		return false
 	}
 	return true
@@ -45,11 +50,15 @@ func (s *Status) SlotCovered(sl types.Slot) bool {
 
 // StartGap returns the slot at the beginning of the range that needs to be backfilled.
 func (s *Status) StartGap() types.Slot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.start
 }
 
 // EndGap returns the slot at the end of the range that needs to be backfilled.
 func (s *Status) EndGap() types.Slot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.end
 }
 
@@ -59,15 +68,31 @@ var ErrAdvancePastOrigin = errors.New("cannot advance backfill Status beyond the
 // It updates the backfill block root entry in the database,
 // and also updates the Status value's copy of the backfill position slot.
 func (s *Status) Advance(ctx context.Context, upTo types.Slot, root [32]byte) error {
-	if upTo > s.end {
-		return errors.Wrapf(ErrAdvancePastOrigin, "advance slot=%d, origin slot=%d", upTo, s.end)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if upTo > s.origin {
+		return errors.Wrapf(ErrAdvancePastOrigin, "advance slot=%d, origin slot=%d", upTo, s.origin)
 	}
 	s.start = upTo
 	return s.store.SaveBackfillBlockRoot(ctx, root)
 }
 
+// AdvanceEnd lowers the end of the gap to the given slot, once the blocks from that slot up to the
+// origin checkpoint block have been backfilled. Since blocks are backfilled from the origin checkpoint
+// block downwards, this makes them available before the whole gap is filled. The end of the gap is
+// not persisted, as the backfill service finds the stored blocks again when it restarts.
+func (s *Status) AdvanceEnd(slot types.Slot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if slot < s.end {
+		s.end = slot
+	}
+}
+
 // Reload queries the database for backfill status, initializing the internal data and validating the database state.
 func (s *Status) Reload(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	cpRoot, err := s.store.OriginCheckpointBlockRoot(ctx)
 	if err != nil {
 		// mark genesis sync and short circuit further lookups
@@ -84,7 +109,8 @@ func (s *Status) Reload(ctx context.Context) error {
 	if err := blocks.BeaconBlockIsNil(cpBlock); err != nil {
 		return err
 	}
-	s.end = cpBlock.Block().Slot()
+	s.origin = cpBlock.Block().Slot()
+	s.end = s.origin
 
 	_, err = s.store.GenesisBlockRoot(ctx)
 	if err != nil {
@@ -112,6 +138,14 @@ func (s *Status) Reload(ctx context.Context) error {
 	return nil
 }
 
+// Complete returns true if the node was synced from genesis, or if the backfill process has
+// filled the entire gap between genesis and the origin checkpoint block.
+func (s *Status) Complete() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.genesisSync || s.start >= s.end
+}
+
 // BackfillDB describes the set of DB methods that the Status type needs to function.
 type BackfillDB interface {
 	SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error
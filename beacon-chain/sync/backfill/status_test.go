@@ -121,7 +121,7 @@ func TestAdvance(t *testing.T) {
 			return nil
 		},
 	}
-	s := &Status{end: 100, store: mdb}
+	s := &Status{end: 100, origin: 100, store: mdb}
 	var root [32]byte
 	copy(root[:], []byte{0x23, 0x23})
 	require.NoError(t, s.Advance(ctx, 90, root))
@@ -136,6 +136,27 @@ func TestAdvance(t *testing.T) {
 	require.Equal(t, 1, len(saveBackfillBuf))
 }
 
+func TestAdvanceEnd(t *testing.T) {
+	ctx := context.Background()
+	s := &Status{start: 10, end: 100, origin: 100, store: &mockBackfillDB{
+		saveBackfillBlockRoot: func(ctx context.Context, root [32]byte) error {
+			return nil
+		},
+	}}
+	s.AdvanceEnd(50)
+	require.Equal(t, types.Slot(50), s.EndGap())
+	require.Equal(t, true, s.SlotCovered(50))
+	require.Equal(t, false, s.SlotCovered(49))
+	// the end of the gap is never raised again
+	s.AdvanceEnd(60)
+	require.Equal(t, types.Slot(50), s.EndGap())
+	require.Equal(t, false, s.Complete())
+
+	// the gap can still be closed up to the origin checkpoint slot
+	require.NoError(t, s.Advance(ctx, 100, [32]byte{}))
+	require.Equal(t, true, s.Complete())
+}
+
 func goodBlockRoot(root [32]byte) func(ctx context.Context) ([32]byte, error) {
 	return func(ctx context.Context) ([32]byte, error) {
 		return root, nil
@@ -334,7 +355,7 @@ func TestReload(t *testing.T) {
 				backfillBlockRoot: goodBlockRoot(backfillRoot),
 			},
 			err:      derp,
-			expected: &Status{genesisSync: false, start: backfillSlot, end: originSlot},
+			expected: &Status{genesisSync: false, start: backfillSlot, end: originSlot, origin: originSlot},
 		},
 	}
 
@@ -0,0 +1,105 @@
+package backfill
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/network/forks"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+var (
+	errUnknownProposer   = errors.New("block proposer index is not in the origin state validator registry")
+	errInvalidSignatures = errors.New("batch contains one or more invalid proposer signatures")
+	errChainBroken       = errors.New("block root does not match the parent root of the next block in the chain")
+)
+
+// verifier checks the proposer signatures of backfilled blocks. The validator registry is append-only, so the
+// public keys of every proposer before the origin checkpoint can be found in the origin state.
+type verifier struct {
+	keys    [][fieldparams.BLSPubkeyLength]byte
+	gvr     []byte
+	domains map[[fieldparams.VersionLength]byte][]byte
+}
+
+func newVerifier(st state.ReadOnlyBeaconState) *verifier {
+	keys := make([][fieldparams.BLSPubkeyLength]byte, st.NumValidators())
+	for i := 0; i < len(keys); i++ {
+		keys[i] = st.PubkeyAtIndex(types.ValidatorIndex(i))
+	}
+	return &verifier{
+		keys:    keys,
+		gvr:     st.GenesisValidatorsRoot(),
+		domains: make(map[[fieldparams.VersionLength]byte][]byte),
+	}
+}
+
+// domain returns the proposer signing domain at the given epoch, caching the result for each fork version.
+func (v *verifier) domain(epoch types.Epoch) ([]byte, error) {
+	fork, err := forks.Fork(epoch)
+	if err != nil {
+		return nil, err
+	}
+	var version [fieldparams.VersionLength]byte
+	copy(version[:], fork.CurrentVersion)
+	if d, ok := v.domains[version]; ok {
+		return d, nil
+	}
+	d, err := signing.Domain(fork, epoch, params.BeaconConfig().DomainBeaconProposer, v.gvr)
+	if err != nil {
+		return nil, err
+	}
+	v.domains[version] = d
+	return d, nil
+}
+
+// verify checks the proposer signatures of all given blocks as a single signature batch.
+func (v *verifier) verify(blks []interfaces.SignedBeaconBlock) error {
+	set := bls.NewSet()
+	for _, b := range blks {
+		idx := b.Block().ProposerIndex()
+		if uint64(idx) >= uint64(len(v.keys)) {
+			return errors.Wrapf(errUnknownProposer, "proposer index=%d, slot=%d", idx, b.Block().Slot())
+		}
+		d, err := v.domain(slots.ToEpoch(b.Block().Slot()))
+		if err != nil {
+			return err
+		}
+		sig := b.Signature()
+		bs, err := signing.BlockSignatureBatch(v.keys[idx][:], sig[:], d, b.Block().HashTreeRoot)
+		if err != nil {
+			return err
+		}
+		set.Join(bs)
+	}
+	ok, err := set.Verify()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidSignatures
+	}
+	return nil
+}
+
+// verifyChain checks that the given blocks, sorted by ascending slot, form a chain which terminates in
+// the block with root `parent`. It returns the parent root of the lowest block in the chain, which the
+// next, lower batch of blocks must terminate in.
+func verifyChain(blks []interfaces.SignedBeaconBlock, parent [32]byte) ([32]byte, error) {
+	for i := len(blks) - 1; i >= 0; i-- {
+		r, err := blks[i].Block().HashTreeRoot()
+		if err != nil {
+			return [32]byte{}, err
+		}
+		if r != parent {
+			return [32]byte{}, errors.Wrapf(errChainBroken, "slot=%d, root=%#x, expected=%#x", blks[i].Block().Slot(), r, parent)
+		}
+		parent = blks[i].Block().ParentRoot()
+	}
+	return parent, nil
+}
@@ -0,0 +1,51 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+
+	coreblocks "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestVerifyChain(t *testing.T) {
+	genesis, blks, _ := testChain(t, 5, map[types.Slot]bool{3: true})
+	top, err := blks[len(blks)-1].Block().HashTreeRoot()
+	require.NoError(t, err)
+	genesisBlock, err := coreblocks.NewGenesisBlockForState(context.Background(), genesis)
+	require.NoError(t, err)
+	genesisRoot, err := genesisBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	parent, err := verifyChain(blks, top)
+	require.NoError(t, err)
+	require.Equal(t, genesisRoot, parent)
+
+	// Removing a block from the middle of the batch breaks the chain.
+	broken := []interfaces.SignedBeaconBlock{blks[0], blks[2], blks[3]}
+	_, err = verifyChain(broken, top)
+	require.ErrorIs(t, err, errChainBroken)
+
+	// A batch that does not terminate in the expected root is rejected.
+	_, err = verifyChain(blks[:2], top)
+	require.ErrorIs(t, err, errChainBroken)
+}
+
+func TestVerifier(t *testing.T) {
+	genesis, blks, _ := testChain(t, 4, nil)
+	v := newVerifier(genesis)
+	require.NoError(t, v.verify(blks))
+
+	// Swapping in a signature from another block invalidates the batch.
+	tampered, err := blks[1].Copy()
+	require.NoError(t, err)
+	sig := blks[0].Signature()
+	tampered.SetSignature(sig[:])
+	require.ErrorIs(t, v.verify([]interfaces.SignedBeaconBlock{blks[0], tampered}), errInvalidSignatures)
+
+	// Proposers must be part of the validator registry of the origin state.
+	v.keys = v.keys[:0]
+	require.ErrorIs(t, v.verify(blks), errUnknownProposer)
+}
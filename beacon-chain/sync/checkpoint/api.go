@@ -5,8 +5,11 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache/depositsnapshot"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -42,5 +45,59 @@ func (dl *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
 	if err != nil {
 		return errors.Wrap(err, "Error retrieving checkpoint origin state and block")
 	}
-	return d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes())
+	if err := d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes()); err != nil {
+		return err
+	}
+	if err := dl.initializeDepositSnapshot(ctx, d, od.State()); err != nil {
+		log.WithError(err).Warn("Could not initialize deposits from the remote deposit snapshot, deposit logs will be scanned from the deposit contract deployment block")
+	}
+	return nil
+}
+
+// initializeDepositSnapshot downloads the EIP-4881 deposit snapshot of the remote beacon node and saves it as
+// the execution chain data of the node, so that the execution service resumes processing deposit logs from the
+// execution block of the snapshot instead of scanning them from genesis.
+func (dl *APIInitializer) initializeDepositSnapshot(ctx context.Context, d db.Database, origin state.BeaconState) error {
+	existing, err := d.ExecutionChainData(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not check database for execution chain data")
+	}
+	if existing != nil && existing.ChainstartData.GetChainstarted() {
+		log.Info("Execution chain data found in db, not initializing it from the remote deposit snapshot")
+		return nil
+	}
+	pb, err := dl.c.GetDepositSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot, err := depositsnapshot.SnapshotFromProto(pb)
+	if err != nil {
+		return errors.Wrap(err, "invalid deposit snapshot")
+	}
+	if snapshot.ExecutionBlockHeight() == 0 {
+		return errors.New("deposit snapshot does not specify its execution block height")
+	}
+	if snapshot.DepositCount() > origin.Eth1Data().DepositCount {
+		return errors.Errorf("deposit snapshot contains %d deposits, more than the %d deposits of the checkpoint state",
+			snapshot.DepositCount(), origin.Eth1Data().DepositCount)
+	}
+	executionHash := snapshot.ExecutionBlockHash()
+	log.WithFields(log.Fields{
+		"depositCount":         snapshot.DepositCount(),
+		"executionBlockHeight": snapshot.ExecutionBlockHeight(),
+	}).Info("Initializing deposits from the remote deposit snapshot")
+	return d.SaveExecutionChainData(ctx, &ethpb.ETH1ChainData{
+		CurrentEth1Data: &ethpb.LatestETH1Data{
+			BlockHeight:        snapshot.ExecutionBlockHeight(),
+			BlockHash:          executionHash[:],
+			LastRequestedBlock: snapshot.ExecutionBlockHeight(),
+		},
+		ChainstartData: &ethpb.ChainStartData{
+			Chainstarted:       true,
+			GenesisTime:        origin.GenesisTime(),
+			Eth1Data:           &ethpb.Eth1Data{},
+			ChainstartDeposits: make([]*ethpb.Deposit, 0),
+		},
+		DepositSnapshot: snapshot.ToProto(),
+	})
 }
@@ -0,0 +1,176 @@
+package sync
+
+import (
+	"context"
+
+	libp2pcore "github.com/libp2p/go-libp2p/core"
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	lightclient "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/light-client"
+	p2ptypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/network/forks"
+	pb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// maxRequestLightClientUpdates is the maximum number of light client updates in a single request,
+// MAX_REQUEST_LIGHT_CLIENT_UPDATES in the altair light client networking specification.
+const maxRequestLightClientUpdates = 128
+
+// lightClientBootstrapRPCHandler responds with the light client bootstrap of the requested block root.
+func (s *Service) lightClientBootstrapRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	ctx, cancel := context.WithTimeout(ctx, ttfbTimeout)
+	defer cancel()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "light_client_bootstrap")
+
+	rawMsg, ok := msg.(*p2ptypes.LightClientBootstrapReq)
+	if !ok {
+		return errors.New("message is not type LightClientBootstrapReq")
+	}
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	blockRoot := [32]byte(*rawMsg)
+	blk, err := s.cfg.beaconDB.Block(ctx, blockRoot)
+	if err != nil {
+		log.WithError(err).Debug("Could not fetch block")
+		s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+		return err
+	}
+	if err := blocks.BeaconBlockIsNil(blk); err != nil {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+		return err
+	}
+	st, err := s.cfg.stateGen.StateByRoot(ctx, blockRoot)
+	if err != nil {
+		log.WithError(err).Debug("Could not fetch state")
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+		return err
+	}
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, st, blk)
+	if err != nil {
+		log.WithError(err).Debug("Could not create light client bootstrap")
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+		return err
+	}
+
+	if err := s.writeLightClientChunk(stream, bootstrap.Header.Beacon.Slot, bootstrap); err != nil {
+		return err
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// lightClientUpdatesByRangeRPCHandler responds with the best light client updates of the requested
+// sync committee periods, stopping at the first period without an update.
+func (s *Service) lightClientUpdatesByRangeRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	ctx, cancel := context.WithTimeout(ctx, respTimeout)
+	defer cancel()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "light_client_updates_by_range")
+
+	m, ok := msg.(*pb.LightClientUpdatesByRangeRequest)
+	if !ok {
+		return errors.New("message is not type LightClientUpdatesByRangeRequest")
+	}
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	if m.Count == 0 || m.StartPeriod+m.Count < m.StartPeriod {
+		s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
+		s.writeErrorResponseToStream(responseCodeInvalidRequest, p2ptypes.ErrInvalidRequest.Error(), stream)
+		return p2ptypes.ErrInvalidRequest
+	}
+	count := m.Count
+	if count > maxRequestLightClientUpdates {
+		count = maxRequestLightClientUpdates
+	}
+
+	updates, err := s.cfg.beaconDB.LightClientUpdates(ctx, m.StartPeriod, m.StartPeriod+count-1)
+	if err != nil {
+		log.WithError(err).Debug("Could not fetch light client updates")
+		s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+		return err
+	}
+	for i, update := range updates {
+		if lightclient.SyncCommitteePeriodAtSlot(update.AttestedHeader.Beacon.Slot) != m.StartPeriod+uint64(i) {
+			break
+		}
+		if err := s.writeLightClientChunk(stream, update.AttestedHeader.Beacon.Slot, update); err != nil {
+			return err
+		}
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// lightClientFinalityUpdateRPCHandler responds with the latest light client finality update.
+func (s *Service) lightClientFinalityUpdateRPCHandler(_ context.Context, _ interface{}, stream libp2pcore.Stream) error {
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "light_client_finality_update")
+
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	update := s.cfg.chain.LatestLightClientFinalityUpdate()
+	if update == nil {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+		return p2ptypes.ErrResourceUnavailable
+	}
+	if err := s.writeLightClientChunk(stream, update.AttestedHeader.Beacon.Slot, update); err != nil {
+		return err
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// lightClientOptimisticUpdateRPCHandler responds with the latest light client optimistic update.
+func (s *Service) lightClientOptimisticUpdateRPCHandler(_ context.Context, _ interface{}, stream libp2pcore.Stream) error {
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "light_client_optimistic_update")
+
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	update := s.cfg.chain.LatestLightClientOptimisticUpdate()
+	if update == nil {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+		return p2ptypes.ErrResourceUnavailable
+	}
+	if err := s.writeLightClientChunk(stream, update.AttestedHeader.Beacon.Slot, update); err != nil {
+		return err
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// writeLightClientChunk writes a light client object as a chunked response to the given network stream.
+// The context bytes are the fork digest of the epoch of the object's attested header.
+// response_chunk  ::= <result> | <context-bytes> | <encoding-dependent-header> | <encoded-payload>
+func (s *Service) writeLightClientChunk(stream libp2pcore.Stream, attestedSlot types.Slot, msg ssz.Marshaler) error {
+	SetStreamWriteDeadline(stream, defaultWriteDuration)
+	valRoot := s.cfg.chain.GenesisValidatorsRoot()
+	digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(attestedSlot), valRoot[:])
+	if err != nil {
+		return err
+	}
+	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
+		return err
+	}
+	if _, err := stream.Write(digest[:]); err != nil {
+		return err
+	}
+	_, err = s.cfg.p2p.Encoding().EncodeWithMaxLength(stream, msg)
+	return err
+}
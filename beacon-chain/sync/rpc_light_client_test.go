@@ -0,0 +1,185 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	lightclient "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/light-client"
+	db "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/testing"
+	p2ptypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	leakybucket "github.com/prysmaticlabs/prysm/v3/container/leaky-bucket"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func testLightClientUpdate(attestedSlot types.Slot) *ethpb.LightClientUpdate {
+	branch := func(depth int) [][]byte {
+		b := make([][]byte, depth)
+		for i := range b {
+			b[i] = make([]byte, 32)
+		}
+		return b
+	}
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpb.LightClientUpdate{
+		AttestedHeader: &ethpb.LightClientHeader{Beacon: util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: attestedSlot})},
+		NextSyncCommittee: &ethpb.SyncCommittee{
+			Pubkeys:         pubkeys,
+			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+		},
+		NextSyncCommitteeBranch: branch(lightclient.SyncCommitteeBranchLength),
+		FinalizedHeader:         &ethpb.LightClientHeader{Beacon: util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{})},
+		FinalityBranch:          branch(lightclient.FinalityBranchLength),
+		SyncAggregate: &ethpb.SyncAggregate{
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		SignatureSlot: attestedSlot + 1,
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler_StopsAtGap(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	d := db.SetupDB(t)
+	ctx := context.Background()
+
+	periodSlots := types.Slot(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * params.BeaconConfig().SlotsPerEpoch
+	for _, period := range []uint64{0, 1, 3} {
+		update := testLightClientUpdate(types.Slot(period)*periodSlots + 1)
+		require.NoError(t, d.SaveLightClientUpdate(ctx, period, update))
+	}
+
+	r := &Service{cfg: &config{p2p: p1, beaconDB: d, chain: &mock.ChainService{}}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1)
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(10000, 10000, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		for i := uint64(0); i < 2; i++ {
+			expectSuccess(t, stream)
+			digest := make([]byte, 4)
+			_, err := stream.Read(digest)
+			require.NoError(t, err)
+			res := &ethpb.LightClientUpdate{}
+			require.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, res))
+			assert.Equal(t, i, lightclient.SyncCommitteePeriodAtSlot(res.AttestedHeader.Beacon.Slot))
+		}
+		_, _, err := ReadStatusCode(stream, r.cfg.p2p.Encoding())
+		require.ErrorContains(t, "EOF", err, "Expected no update after the missing period")
+	})
+
+	stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &ethpb.LightClientUpdatesByRangeRequest{StartPeriod: 0, Count: 4}
+	require.NoError(t, r.lightClientUpdatesByRangeRPCHandler(ctx, req, stream))
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler_ZeroCount(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	d := db.SetupDB(t)
+
+	r := &Service{cfg: &config{p2p: p1, beaconDB: d, chain: &mock.ChainService{}}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1)
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(10000, 10000, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeInvalidRequest, p2ptypes.ErrInvalidRequest.Error(), stream)
+	})
+
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	err = r.lightClientUpdatesByRangeRPCHandler(context.Background(), &ethpb.LightClientUpdatesByRangeRequest{}, stream)
+	require.ErrorIs(t, err, p2ptypes.ErrInvalidRequest)
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientOptimisticUpdateRPCHandler(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	update := lightclient.NewLightClientOptimisticUpdateFromUpdate(testLightClientUpdate(10))
+	chain := &mock.ChainService{LightClientOptimisticUpdate: update}
+	r := &Service{cfg: &config{p2p: p1, chain: chain}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID(p2p.RPCLightClientOptimisticUpdateTopicV1)
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(10000, 10000, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectSuccess(t, stream)
+		digest := make([]byte, 4)
+		_, err := stream.Read(digest)
+		require.NoError(t, err)
+		res := &ethpb.LightClientOptimisticUpdate{}
+		require.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, res))
+		assert.DeepEqual(t, update, res)
+	})
+
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, r.lightClientOptimisticUpdateRPCHandler(context.Background(), nil, stream))
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientFinalityUpdateRPCHandler_NoUpdate(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	r := &Service{cfg: &config{p2p: p1, chain: &mock.ChainService{}}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID(p2p.RPCLightClientFinalityUpdateTopicV1)
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(10000, 10000, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+	})
+
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	err = r.lightClientFinalityUpdateRPCHandler(context.Background(), nil, stream)
+	require.ErrorIs(t, err, p2ptypes.ErrResourceUnavailable)
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
@@ -119,6 +119,20 @@ func (s *Service) registerSubscribers(epoch types.Epoch, digest [4]byte) {
 				digest,
 			)
 		}
+		if features.Get().EnableLightClient {
+			s.subscribe(
+				p2p.LightClientFinalityUpdateTopicFormat,
+				s.validateLightClientFinalityUpdate,
+				s.lightClientFinalityUpdateSubscriber,
+				digest,
+			)
+			s.subscribe(
+				p2p.LightClientOptimisticUpdateTopicFormat,
+				s.validateLightClientOptimisticUpdate,
+				s.lightClientOptimisticUpdateSubscriber,
+				digest,
+			)
+		}
 	}
 
 	// New Gossip Topic in Capella
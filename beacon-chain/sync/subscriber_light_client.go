@@ -0,0 +1,19 @@
+package sync
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientFinalityUpdateSubscriber is a no-op, light client updates are only relayed
+// and the node computes its own from the blocks it imports.
+func (_ *Service) lightClientFinalityUpdateSubscriber(_ context.Context, _ proto.Message) error {
+	return nil
+}
+
+// lightClientOptimisticUpdateSubscriber is a no-op, light client updates are only relayed
+// and the node computes its own from the blocks it imports.
+func (_ *Service) lightClientOptimisticUpdateSubscriber(_ context.Context, _ proto.Message) error {
+	return nil
+}
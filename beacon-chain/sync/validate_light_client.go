@@ -0,0 +1,72 @@
+package sync
+
+import (
+	"context"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// validateLightClientFinalityUpdate forwards a light client finality update only if it matches
+// the latest finality update computed locally, as required by the light client networking specification.
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientFinalityUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	update, ok := m.(*ethpb.LightClientFinalityUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+
+	local := s.cfg.chain.LatestLightClientFinalityUpdate()
+	if local == nil || !proto.Equal(local, update) {
+		return pubsub.ValidationIgnore, nil
+	}
+	msg.ValidatorData = update // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientOptimisticUpdate forwards a light client optimistic update only if it matches
+// the latest optimistic update computed locally, as required by the light client networking specification.
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientOptimisticUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	update, ok := m.(*ethpb.LightClientOptimisticUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+
+	local := s.cfg.chain.LatestLightClientOptimisticUpdate()
+	if local == nil || !proto.Equal(local, update) {
+		return pubsub.ValidationIgnore, nil
+	}
+	msg.ValidatorData = update // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
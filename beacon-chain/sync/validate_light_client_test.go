@@ -0,0 +1,113 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	lightclient "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/testing"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestValidateLightClientOptimisticUpdate(t *testing.T) {
+	p := p2ptest.NewTestP2P(t)
+	ctx := context.Background()
+	local := lightclient.NewLightClientOptimisticUpdateFromUpdate(testLightClientUpdate(10))
+	other := lightclient.NewLightClientOptimisticUpdateFromUpdate(testLightClientUpdate(11))
+
+	tests := []struct {
+		name   string
+		local  *ethpb.LightClientOptimisticUpdate
+		update *ethpb.LightClientOptimisticUpdate
+		want   pubsub.ValidationResult
+	}{
+		{name: "no local update", update: local, want: pubsub.ValidationIgnore},
+		{name: "different from local update", local: local, update: other, want: pubsub.ValidationIgnore},
+		{name: "matches local update", local: local, update: local, want: pubsub.ValidationAccept},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Service{
+				cfg: &config{
+					p2p: p,
+					chain: &mock.ChainService{
+						Genesis:                     time.Now(),
+						LightClientOptimisticUpdate: tt.local,
+					},
+				},
+			}
+			buf := new(bytes.Buffer)
+			_, err := p.Encoding().EncodeGossip(buf, tt.update)
+			require.NoError(t, err)
+			topic := p2p.GossipTypeMapping[reflect.TypeOf(tt.update)]
+			d, err := r.currentForkDigest()
+			require.NoError(t, err)
+			topic = r.addDigestToTopic(topic, d)
+			m := &pubsub.Message{
+				Message: &pubsubpb.Message{
+					Data:  buf.Bytes(),
+					Topic: &topic,
+				},
+			}
+			res, err := r.validateLightClientOptimisticUpdate(ctx, "", m)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestValidateLightClientFinalityUpdate(t *testing.T) {
+	p := p2ptest.NewTestP2P(t)
+	ctx := context.Background()
+	local := lightclient.NewLightClientFinalityUpdateFromUpdate(testLightClientUpdate(10))
+	other := lightclient.NewLightClientFinalityUpdateFromUpdate(testLightClientUpdate(11))
+
+	tests := []struct {
+		name   string
+		local  *ethpb.LightClientFinalityUpdate
+		update *ethpb.LightClientFinalityUpdate
+		want   pubsub.ValidationResult
+	}{
+		{name: "no local update", update: local, want: pubsub.ValidationIgnore},
+		{name: "different from local update", local: local, update: other, want: pubsub.ValidationIgnore},
+		{name: "matches local update", local: local, update: local, want: pubsub.ValidationAccept},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Service{
+				cfg: &config{
+					p2p: p,
+					chain: &mock.ChainService{
+						Genesis:                   time.Now(),
+						LightClientFinalityUpdate: tt.local,
+					},
+				},
+			}
+			buf := new(bytes.Buffer)
+			_, err := p.Encoding().EncodeGossip(buf, tt.update)
+			require.NoError(t, err)
+			topic := p2p.GossipTypeMapping[reflect.TypeOf(tt.update)]
+			d, err := r.currentForkDigest()
+			require.NoError(t, err)
+			topic = r.addDigestToTopic(topic, d)
+			m := &pubsub.Message{
+				Message: &pubsubpb.Message{
+					Data:  buf.Bytes(),
+					Topic: &topic,
+				},
+			}
+			res, err := r.validateLightClientFinalityUpdate(ctx, "", m)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
@@ -4,6 +4,7 @@ package flags
 
 import (
 	"strings"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v3/cmd"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
@@ -11,11 +12,25 @@ import (
 )
 
 var (
-	// MevRelayEndpoint provides an HTTP access endpoint to a MEV builder network.
-	MevRelayEndpoint = &cli.StringFlag{
-		Name:  "http-mev-relay",
-		Usage: "A MEV builder relay string http endpoint, this wil be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder",
-		Value: "",
+	// MevRelayEndpoint provides HTTP access endpoints to MEV builder networks.
+	MevRelayEndpoint = &cli.StringSliceFlag{
+		Name: "http-mev-relay",
+		Usage: "A MEV builder relay string http endpoint, this wil be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder. " +
+			"Multiple relays can be specified by repeating the flag or as a comma separated list, they are queried in parallel and the highest valid bid is used",
+	}
+	// BuilderRelayTimeout defines the maximum amount of time each relay is given to respond to a request.
+	BuilderRelayTimeout = &cli.DurationFlag{
+		Name:  "builder-relay-timeout",
+		Usage: "The maximum amount of time to wait for each MEV builder relay to respond, relays that do not respond in time are ignored",
+		Value: time.Second,
+	}
+	// LocalBlockValueBoost defines the percentage by which the local block value is boosted when compared to builder bids.
+	LocalBlockValueBoost = &cli.Uint64Flag{
+		Name: "local-block-value-boost",
+		Usage: "A percentage boost applied to the value of the locally built execution payload when comparing it with the best builder bid. " +
+			"The builder block is only used if its value is higher than the boosted local block value. " +
+			"The boost has no effect before Capella, as the execution client does not report the local block value",
+		Value: 0,
 	}
 	MaxBuilderConsecutiveMissedSlots = &cli.IntFlag{
 		Name:  "max-builder-consecutive-missed-slots",
@@ -156,6 +171,20 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// StateArchiveSnapshotSlots enables the historical state archive, and specifies the number of slots between the full
+	// state snapshots saved in the cold section of beaconDB.
+	StateArchiveSnapshotSlots = &cli.IntFlag{
+		Name: "state-archive-snapshot-slots",
+		Usage: "Enables the historical state archive, which saves finalized states as full snapshots every given number of slots, " +
+			"and as compact diffs against these snapshots every --state-archive-diff-slots slots, instead of saving full states " +
+			"every --slots-per-archive-point slots. Must be a multiple of --state-archive-diff-slots.",
+	}
+	// StateArchiveDiffSlots specifies the number of slots between the state diffs saved by the historical state archive.
+	StateArchiveDiffSlots = &cli.IntFlag{
+		Name:  "state-archive-diff-slots",
+		Usage: "The slot durations of when a state diff gets saved by the historical state archive. Must be a multiple of the slots per epoch.",
+		Value: 32,
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
@@ -168,6 +197,12 @@ var (
 		Usage: "The factor by which block batch limit may increase on burst.",
 		Value: 2,
 	}
+	// BackfillBatchSize specifies the number of slots requested in each batch by the backfill service.
+	BackfillBatchSize = &cli.Uint64Flag{
+		Name:  "backfill-batch-size",
+		Usage: "Number of slots requested from a peer in each batch when backfilling block history (requires --enable-experimental-backfill)",
+		Value: 64,
+	}
 	// EnableDebugRPCEndpoints as /v1/beacon/state.
 	EnableDebugRPCEndpoints = &cli.BoolFlag{
 		Name:  "enable-debug-rpc-endpoints",
@@ -253,4 +288,17 @@ var (
 		Usage: "Directory for the slasher database",
 		Value: cmd.DefaultDataDir(),
 	}
+	// SlasherCatchUpEpochs defines the number of epochs of historical data the slasher ingests from the beacon database on start.
+	SlasherCatchUpEpochs = &cli.Uint64Flag{
+		Name: "slasher-catch-up-epochs",
+		Usage: "Number of epochs before the head whose blocks and included attestations the slasher ingests from the beacon " +
+			"database when it starts, to detect slashable offenses committed before it was enabled. Bounded by the slasher history length",
+	}
+	// SlasherChunkCacheMB defines the memory budget of the slasher chunk cache.
+	SlasherChunkCacheMB = &cli.Uint64Flag{
+		Name: "slasher-chunk-cache-mb",
+		Usage: "Size in megabytes of the in-memory cache of recently used slasher min and max span chunks, which are written " +
+			"back to disk at epoch boundaries. Set to 0 to read and write chunks directly from disk",
+		Value: 256,
+	}
 )
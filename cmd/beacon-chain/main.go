@@ -55,11 +55,14 @@ var appFlags = []cli.Flag{
 	flags.SetGCPercent,
 	flags.BlockBatchLimit,
 	flags.BlockBatchLimitBurstFactor,
+	flags.BackfillBatchSize,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropGenesisStateFlag,
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.StateArchiveSnapshotSlots,
+	flags.StateArchiveDiffSlots,
 	flags.EnableDebugRPCEndpoints,
 	flags.SubscribeToAllSubnets,
 	flags.HistoricalSlasherNode,
@@ -73,6 +76,8 @@ var appFlags = []cli.Flag{
 	flags.TerminalBlockHashOverride,
 	flags.TerminalBlockHashActivationEpochOverride,
 	flags.MevRelayEndpoint,
+	flags.BuilderRelayTimeout,
+	flags.LocalBlockValueBoost,
 	flags.MaxBuilderEpochMissedSlots,
 	flags.MaxBuilderConsecutiveMissedSlots,
 	flags.EngineEndpointTimeoutSeconds,
@@ -124,6 +129,10 @@ var appFlags = []cli.Flag{
 	cmd.RestoreSourceFileFlag,
 	cmd.RestoreTargetDirFlag,
 	cmd.ValidatorMonitorIndicesFlag,
+	cmd.ValidatorMonitorExportFileFlag,
+	cmd.ValidatorMonitorAutoTrackFlag,
+	cmd.ValidatorMonitorAutoTrackLimitFlag,
+	cmd.ValidatorMonitorAutoTrackInactiveEpochsFlag,
 	cmd.ApiTimeoutFlag,
 	checkpoint.BlockPath,
 	checkpoint.StatePath,
@@ -131,6 +140,8 @@ var appFlags = []cli.Flag{
 	genesis.StatePath,
 	genesis.BeaconAPIURL,
 	flags.SlasherDirFlag,
+	flags.SlasherCatchUpEpochs,
+	flags.SlasherChunkCacheMB,
 }
 
 func init() {
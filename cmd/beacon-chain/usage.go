@@ -74,6 +74,10 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RestoreSourceFileFlag,
 			cmd.RestoreTargetDirFlag,
 			cmd.ValidatorMonitorIndicesFlag,
+			cmd.ValidatorMonitorExportFileFlag,
+			cmd.ValidatorMonitorAutoTrackFlag,
+			cmd.ValidatorMonitorAutoTrackLimitFlag,
+			cmd.ValidatorMonitorAutoTrackInactiveEpochsFlag,
 			cmd.ApiTimeoutFlag,
 		},
 	},
@@ -112,8 +116,11 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ExecutionJWTSecretFlag,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
+			flags.StateArchiveSnapshotSlots,
+			flags.StateArchiveDiffSlots,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.BackfillBatchSize,
 			flags.EnableDebugRPCEndpoints,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
@@ -123,10 +130,14 @@ var appHelpFlagGroups = []flagGroup{
 			flags.Eth1HeaderReqLimit,
 			flags.MinPeersPerSubnet,
 			flags.MevRelayEndpoint,
+			flags.BuilderRelayTimeout,
+			flags.LocalBlockValueBoost,
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
 			flags.EngineEndpointTimeoutSeconds,
 			flags.SlasherDirFlag,
+			flags.SlasherCatchUpEpochs,
+			flags.SlasherChunkCacheMB,
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
 			checkpoint.RemoteURL,
@@ -227,8 +227,36 @@ var (
 	// ValidatorMonitorIndicesFlag specifies a list of validator indices to
 	// track for performance updates
 	ValidatorMonitorIndicesFlag = &cli.IntSliceFlag{
-		Name:  "monitor-indices",
-		Usage: "List of validator indices to track performance",
+		Name: "monitor-indices",
+		Usage: "List of validator indices to track performance. The validator monitor, and with it the runtime addition " +
+			"and removal of tracked indices through the /prysm/validator/monitor/indices endpoint, is only enabled if " +
+			"this flag, --monitor-export-file or --monitor-auto-track is set",
+	}
+	// ValidatorMonitorExportFileFlag specifies the file the per-epoch performance of the
+	// tracked validators is appended to.
+	ValidatorMonitorExportFileFlag = &cli.StringFlag{
+		Name: "monitor-export-file",
+		Usage: "File the per-epoch performance of the validators tracked by the validator monitor is appended to, " +
+			"as JSON lines, once each epoch is complete. Enables the validator monitor even if no --monitor-indices are given",
+	}
+	// ValidatorMonitorAutoTrackFlag enables tracking the validators served by the validator RPCs of the node.
+	ValidatorMonitorAutoTrackFlag = &cli.BoolFlag{
+		Name: "monitor-auto-track",
+		Usage: "Automatically track the validators which request duties, register or publish attestations through this node, " +
+			"and stop tracking them once they go quiet. Enables the validator monitor even if no --monitor-indices are given",
+	}
+	// ValidatorMonitorAutoTrackLimitFlag bounds the number of automatically tracked validators.
+	ValidatorMonitorAutoTrackLimitFlag = &cli.IntFlag{
+		Name:  "monitor-auto-track-limit",
+		Usage: "Maximum number of validators tracked automatically by the validator monitor",
+		Value: 2048,
+	}
+	// ValidatorMonitorAutoTrackInactiveEpochsFlag specifies after how many epochs without activity an
+	// automatically tracked validator is no longer tracked.
+	ValidatorMonitorAutoTrackInactiveEpochsFlag = &cli.Uint64Flag{
+		Name:  "monitor-auto-track-inactive-epochs",
+		Usage: "Number of epochs without activity after which an automatically tracked validator is no longer tracked",
+		Value: 4,
 	}
 
 	// RestoreSourceFileFlag specifies the filepath to the backed-up database file
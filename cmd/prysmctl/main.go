@@ -8,6 +8,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/cmd/prysmctl/deprecated"
 	"github.com/prysmaticlabs/prysm/v3/cmd/prysmctl/p2p"
 	"github.com/prysmaticlabs/prysm/v3/cmd/prysmctl/signing"
+	"github.com/prysmaticlabs/prysm/v3/cmd/prysmctl/slasher"
 	"github.com/prysmaticlabs/prysm/v3/cmd/prysmctl/testnet"
 	"github.com/prysmaticlabs/prysm/v3/cmd/prysmctl/weaksubjectivity"
 	log "github.com/sirupsen/logrus"
@@ -37,4 +38,5 @@ func init() {
 	prysmctlCommands = append(prysmctlCommands, testnet.Commands...)
 	prysmctlCommands = append(prysmctlCommands, weaksubjectivity.Commands...)
 	prysmctlCommands = append(prysmctlCommands, signing.Commands...)
+	prysmctlCommands = append(prysmctlCommands, slasher.Commands...)
 }
@@ -0,0 +1,223 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/api/client/beacon"
+	"github.com/urfave/cli/v2"
+)
+
+var managePeersFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+	Addr           string
+	PeerID         string
+	CIDR           string
+	Duration       string
+}{}
+
+var (
+	beaconNodeHostFlag = &cli.StringFlag{
+		Name:        "beacon-node-host",
+		Usage:       "host:port for beacon node API connection",
+		Destination: &managePeersFlags.BeaconNodeHost,
+		Value:       "localhost:3500",
+	}
+	httpTimeoutFlag = &cli.DurationFlag{
+		Name:        "http-timeout",
+		Usage:       "timeout for http requests made to the beacon node (uses duration format, ex: 2m31s). default: 30s",
+		Destination: &managePeersFlags.Timeout,
+		Value:       30 * time.Second,
+	}
+	peerIDFlag = &cli.StringFlag{
+		Name:        "peer-id",
+		Usage:       "ID of the peer",
+		Destination: &managePeersFlags.PeerID,
+	}
+	cidrFlag = &cli.StringFlag{
+		Name:        "cidr",
+		Usage:       "ip range in CIDR notation, ex: 10.0.0.0/8",
+		Destination: &managePeersFlags.CIDR,
+	}
+)
+
+var peersCmd = &cli.Command{
+	Name:  "peers",
+	Usage: "commands for managing the peers of a running beacon node via its API",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "trusted",
+			Usage: "commands for managing trusted peers, which are never pruned nor deemed bad by peer scoring",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "list",
+					Usage:  "List the trusted peers",
+					Action: managePeersAction(listTrustedPeers),
+					Flags:  []cli.Flag{beaconNodeHostFlag, httpTimeoutFlag},
+				},
+				{
+					Name:   "add",
+					Usage:  "Add a trusted peer",
+					Action: managePeersAction(addTrustedPeer),
+					Flags: []cli.Flag{
+						beaconNodeHostFlag,
+						httpTimeoutFlag,
+						&cli.StringFlag{
+							Name:        "addr",
+							Usage:       "multiaddr of the peer, including its peer ID, ex: /ip4/1.2.3.4/tcp/13000/p2p/16Uiu2...",
+							Destination: &managePeersFlags.Addr,
+							Required:    true,
+						},
+					},
+				},
+				{
+					Name:   "remove",
+					Usage:  "Remove a trusted peer",
+					Action: managePeersAction(removeTrustedPeer),
+					Flags:  []cli.Flag{beaconNodeHostFlag, httpTimeoutFlag, peerIDFlag},
+				},
+			},
+		},
+		{
+			Name:   "bans",
+			Usage:  "List the banned peers and ip ranges",
+			Action: managePeersAction(listBans),
+			Flags:  []cli.Flag{beaconNodeHostFlag, httpTimeoutFlag},
+		},
+		{
+			Name:   "ban",
+			Usage:  "Ban a peer, given by --peer-id, or an ip range, given by --cidr",
+			Action: managePeersAction(ban),
+			Flags: []cli.Flag{
+				beaconNodeHostFlag,
+				httpTimeoutFlag,
+				peerIDFlag,
+				cidrFlag,
+				&cli.StringFlag{
+					Name:        "duration",
+					Usage:       "duration of a peer ban (uses duration format, ex: 1h). Peers are banned indefinitely if unset",
+					Destination: &managePeersFlags.Duration,
+				},
+			},
+		},
+		{
+			Name:   "unban",
+			Usage:  "Unban a peer, given by --peer-id, or an ip range, given by --cidr",
+			Action: managePeersAction(unban),
+			Flags:  []cli.Flag{beaconNodeHostFlag, httpTimeoutFlag, peerIDFlag, cidrFlag},
+		},
+		{
+			Name:   "scores",
+			Usage:  "Dump the score breakdown of every known peer, or of the peer given by --peer-id",
+			Action: managePeersAction(peerScores),
+			Flags:  []cli.Flag{beaconNodeHostFlag, httpTimeoutFlag, peerIDFlag},
+		},
+	},
+}
+
+func managePeersAction(action func(ctx context.Context, client *beacon.Client) error) cli.ActionFunc {
+	return func(_ *cli.Context) error {
+		client, err := beacon.NewClient(managePeersFlags.BeaconNodeHost, beacon.WithTimeout(managePeersFlags.Timeout))
+		if err != nil {
+			return err
+		}
+		if err := action(context.Background(), client); err != nil {
+			log.WithError(err).Fatal("Could not manage beacon node peers")
+		}
+		return nil
+	}
+}
+
+func listTrustedPeers(ctx context.Context, client *beacon.Client) error {
+	trustedPeers, err := client.GetTrustedPeers(ctx)
+	if err != nil {
+		return err
+	}
+	return printJson(trustedPeers)
+}
+
+func addTrustedPeer(ctx context.Context, client *beacon.Client) error {
+	if err := client.AddTrustedPeer(ctx, managePeersFlags.Addr); err != nil {
+		return err
+	}
+	log.WithField("addr", managePeersFlags.Addr).Info("Added trusted peer")
+	return nil
+}
+
+func removeTrustedPeer(ctx context.Context, client *beacon.Client) error {
+	if managePeersFlags.PeerID == "" {
+		return errors.New("--peer-id is required")
+	}
+	if err := client.RemoveTrustedPeer(ctx, managePeersFlags.PeerID); err != nil {
+		return err
+	}
+	log.WithField("peerID", managePeersFlags.PeerID).Info("Removed trusted peer")
+	return nil
+}
+
+func listBans(ctx context.Context, client *beacon.Client) error {
+	bans, err := client.GetBans(ctx)
+	if err != nil {
+		return err
+	}
+	return printJson(bans)
+}
+
+func ban(ctx context.Context, client *beacon.Client) error {
+	f := managePeersFlags
+	switch {
+	case f.PeerID != "" && f.CIDR == "":
+		if err := client.BanPeer(ctx, f.PeerID, f.Duration); err != nil {
+			return err
+		}
+		log.WithField("peerID", f.PeerID).Info("Banned peer")
+	case f.CIDR != "" && f.PeerID == "":
+		if err := client.BanIPRange(ctx, f.CIDR); err != nil {
+			return err
+		}
+		log.WithField("cidr", f.CIDR).Info("Banned ip range")
+	default:
+		return errors.New("exactly one of --peer-id or --cidr is required")
+	}
+	return nil
+}
+
+func unban(ctx context.Context, client *beacon.Client) error {
+	f := managePeersFlags
+	switch {
+	case f.PeerID != "" && f.CIDR == "":
+		if err := client.UnbanPeer(ctx, f.PeerID); err != nil {
+			return err
+		}
+		log.WithField("peerID", f.PeerID).Info("Unbanned peer")
+	case f.CIDR != "" && f.PeerID == "":
+		if err := client.UnbanIPRange(ctx, f.CIDR); err != nil {
+			return err
+		}
+		log.WithField("cidr", f.CIDR).Info("Unbanned ip range")
+	default:
+		return errors.New("exactly one of --peer-id or --cidr is required")
+	}
+	return nil
+}
+
+func peerScores(ctx context.Context, client *beacon.Client) error {
+	scores, err := client.GetPeerScores(ctx, managePeersFlags.PeerID)
+	if err != nil {
+		return err
+	}
+	return printJson(scores)
+}
+
+func printJson(v interface{}) error {
+	enc, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(enc))
+	return nil
+}
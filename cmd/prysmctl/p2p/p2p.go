@@ -12,6 +12,7 @@ var Commands = []*cli.Command{
 				Usage:       "commands for sending p2p rpc requests to beacon nodes",
 				Subcommands: []*cli.Command{requestBlocksCmd},
 			},
+			peersCmd,
 		},
 	},
 }
@@ -0,0 +1,377 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/prysmaticlabs/prysm/v3/io/prompt"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/validator/accounts"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager/derived"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// #nosec G101 -- Not sensitive data
+const mnemonicPromptText = "Enter the mnemonic phrase the withdrawal keys of your validators were derived from"
+
+var blsToExecutionChangeFlags = struct {
+	MnemonicFile          string
+	MnemonicLanguage      string
+	Mnemonic25thWordFile  string
+	ValidatorIndices      cli.StringSlice
+	ValidatorStartIndex   int
+	ExecutionAddress      string
+	GenesisValidatorsRoot string
+	OutputFile            string
+	SignedChangesFile     string
+	BeaconNodeHost        string
+	Timeout               time.Duration
+}{}
+
+var blsToExecutionChangeCmd = &cli.Command{
+	Name: "bls-to-execution-change",
+	Usage: "Signs BLS to execution changes, which set the withdrawal credentials of validators to an execution address, " +
+		"and optionally broadcasts them to the network through a beacon node",
+	Description: "Derives the BLS withdrawal keys of the given validators from a mnemonic and signs, offline, messages " +
+		"changing their withdrawal credentials to the given execution address. The signed changes are saved to a file " +
+		"in the format of the staking-deposit-cli. If a beacon node host is provided, the signed changes are also " +
+		"submitted to it. Changes signed beforehand, by this command or the staking-deposit-cli, can be submitted " +
+		"with --signed-changes-file.",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "mnemonic-file",
+			Usage:       "Path to a file containing the mnemonic phrase the withdrawal keys were derived from. Prompted for if not set",
+			Destination: &blsToExecutionChangeFlags.MnemonicFile,
+		},
+		&cli.StringFlag{
+			Name:        "mnemonic-language",
+			Usage:       "Language of the mnemonic. Supported languages are: english|chinese_traditional|chinese_simplified|czech|french|japanese|korean|italian|spanish",
+			Destination: &blsToExecutionChangeFlags.MnemonicLanguage,
+			Value:       "english",
+		},
+		&cli.StringFlag{
+			Name:        "mnemonic-25th-word-file",
+			Usage:       "(Advanced) Path to a plain-text, .txt file containing a 25th word passphrase for the mnemonic",
+			Destination: &blsToExecutionChangeFlags.Mnemonic25thWordFile,
+		},
+		&cli.StringSliceFlag{
+			Name:        "validator-indices",
+			Usage:       "Comma-separated list of the beacon chain indices of the validators, in the order of their key derivation account indices",
+			Destination: &blsToExecutionChangeFlags.ValidatorIndices,
+		},
+		&cli.IntFlag{
+			Name:        "validator-start-index",
+			Usage:       "Key derivation account index of the first validator in --validator-indices, as used when generating the deposits",
+			Destination: &blsToExecutionChangeFlags.ValidatorStartIndex,
+			Value:       0,
+		},
+		&cli.StringFlag{
+			Name:        "execution-address",
+			Usage:       "Hex-encoded execution address the withdrawals of the validators will be sent to. This cannot be changed afterwards",
+			Destination: &blsToExecutionChangeFlags.ExecutionAddress,
+		},
+		&cli.StringFlag{
+			Name:        "genesis-validators-root",
+			Usage:       "Hex-encoded genesis validators root of the network, needed to sign offline. Retrieved from the beacon node if not set",
+			Destination: &blsToExecutionChangeFlags.GenesisValidatorsRoot,
+		},
+		&cli.StringFlag{
+			Name:        "output-file",
+			Usage:       "Path of the file the signed BLS to execution changes are written to",
+			Destination: &blsToExecutionChangeFlags.OutputFile,
+			Value:       "bls_to_execution_changes.json",
+		},
+		&cli.StringFlag{
+			Name:        "signed-changes-file",
+			Usage:       "Path to a file of signed BLS to execution changes, as written by this command or the staking-deposit-cli, to submit to the beacon node",
+			Destination: &blsToExecutionChangeFlags.SignedChangesFile,
+		},
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port of the beacon node API the signed changes are submitted to. Changes are only saved to a file if not set",
+			Destination: &blsToExecutionChangeFlags.BeaconNodeHost,
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-host (uses duration format, ex: 2m31s). default: 2m",
+			Destination: &blsToExecutionChangeFlags.Timeout,
+			Value:       time.Minute * 2,
+		},
+		features.Mainnet,
+		features.PraterTestnet,
+		features.SepoliaTestnet,
+	},
+	Before: func(cliCtx *cli.Context) error {
+		return features.ConfigureValidator(cliCtx)
+	},
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionBLSToExecutionChange(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not sign BLS to execution changes")
+		}
+		return nil
+	},
+}
+
+// blsToExecutionChangeJson is a signed BLS to execution change in the format written by the staking-deposit-cli.
+type blsToExecutionChangeJson struct {
+	*apimiddleware.SignedBLSToExecutionChangeJson
+	Metadata *blsToExecutionChangeMetadataJson `json:"metadata,omitempty"`
+}
+
+type blsToExecutionChangeMetadataJson struct {
+	NetworkName           string `json:"network_name"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+}
+
+func cliActionBLSToExecutionChange(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	f := blsToExecutionChangeFlags
+
+	var client *beacon.Client
+	if f.BeaconNodeHost != "" {
+		var err error
+		client, err = beacon.NewClient(f.BeaconNodeHost, beacon.WithTimeout(f.Timeout))
+		if err != nil {
+			return err
+		}
+	}
+
+	if f.SignedChangesFile != "" {
+		if client == nil {
+			return errors.New("a beacon node host is required to submit signed changes")
+		}
+		changes, err := readSignedChanges(f.SignedChangesFile)
+		if err != nil {
+			return err
+		}
+		return submitSignedChanges(ctx, client, changes)
+	}
+
+	genesisValidatorsRoot, err := genesisValidatorsRoot(ctx, client, f.GenesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+	if !common.IsHexAddress(f.ExecutionAddress) {
+		return errors.Errorf("%s is not a valid execution address", f.ExecutionAddress)
+	}
+	address := common.HexToAddress(f.ExecutionAddress)
+	indices, err := parseValidatorIndices(f.ValidatorIndices.Value())
+	if err != nil {
+		return err
+	}
+	mnemonic, err := inputMnemonic(f.MnemonicFile)
+	if err != nil {
+		return errors.Wrap(err, "could not get mnemonic phrase")
+	}
+	var mnemonicPassphrase string
+	if f.Mnemonic25thWordFile != "" {
+		passphrase, err := os.ReadFile(f.Mnemonic25thWordFile) // #nosec G304 -- ReadFile is safe
+		if err != nil {
+			return errors.Wrap(err, "could not read mnemonic passphrase file")
+		}
+		mnemonicPassphrase = strings.TrimRight(string(passphrase), "\r\n")
+	}
+	keys, err := derived.WithdrawalKeysFromMnemonic(mnemonic, f.MnemonicLanguage, mnemonicPassphrase, f.ValidatorStartIndex, len(indices))
+	if err != nil {
+		return err
+	}
+
+	changes, err := signBLSToExecutionChanges(keys, indices, address, genesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+	if err := writeSignedChanges(f.OutputFile, changes, genesisValidatorsRoot); err != nil {
+		return err
+	}
+	log.WithField("path", f.OutputFile).Infof("Saved %d signed BLS to execution changes", len(changes))
+
+	if client == nil {
+		return nil
+	}
+	return submitSignedChanges(ctx, client, changesToJson(changes, nil))
+}
+
+// signBLSToExecutionChanges signs a change of the withdrawal credentials to the given execution address for each
+// validator, using the withdrawal key at the same position. The signing domain is computed with the genesis fork
+// version, as required by the specification, so the changes remain valid across forks.
+func signBLSToExecutionChanges(
+	keys []bls.SecretKey,
+	indices []types.ValidatorIndex,
+	address common.Address,
+	genesisValidatorsRoot []byte,
+) ([]*ethpb.SignedBLSToExecutionChange, error) {
+	if len(keys) != len(indices) {
+		return nil, errors.Errorf("got %d withdrawal keys for %d validators", len(keys), len(indices))
+	}
+	cfg := params.BeaconConfig()
+	domain, err := signing.ComputeDomain(cfg.DomainBLSToExecutionChange, cfg.GenesisForkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute signing domain")
+	}
+	changes := make([]*ethpb.SignedBLSToExecutionChange, len(indices))
+	for i, index := range indices {
+		message := &ethpb.BLSToExecutionChange{
+			ValidatorIndex:     index,
+			FromBlsPubkey:      keys[i].PublicKey().Marshal(),
+			ToExecutionAddress: address.Bytes(),
+		}
+		root, err := signing.ComputeSigningRoot(message, domain)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not compute signing root for validator %d", index)
+		}
+		changes[i] = &ethpb.SignedBLSToExecutionChange{
+			Message:   message,
+			Signature: keys[i].Sign(root[:]).Marshal(),
+		}
+	}
+	return changes, nil
+}
+
+func changesToJson(changes []*ethpb.SignedBLSToExecutionChange, metadata *blsToExecutionChangeMetadataJson) []*blsToExecutionChangeJson {
+	result := make([]*blsToExecutionChangeJson, len(changes))
+	for i, change := range changes {
+		result[i] = &blsToExecutionChangeJson{
+			SignedBLSToExecutionChangeJson: &apimiddleware.SignedBLSToExecutionChangeJson{
+				Message: &apimiddleware.BLSToExecutionChangeJson{
+					ValidatorIndex:     strconv.FormatUint(uint64(change.Message.ValidatorIndex), 10),
+					FromBLSPubkey:      hexutil.Encode(change.Message.FromBlsPubkey),
+					ToExecutionAddress: hexutil.Encode(change.Message.ToExecutionAddress),
+				},
+				Signature: hexutil.Encode(change.Signature),
+			},
+			Metadata: metadata,
+		}
+	}
+	return result
+}
+
+func writeSignedChanges(path string, changes []*ethpb.SignedBLSToExecutionChange, genesisValidatorsRoot []byte) error {
+	metadata := &blsToExecutionChangeMetadataJson{
+		NetworkName:           params.BeaconConfig().ConfigName,
+		GenesisValidatorsRoot: hexutil.Encode(genesisValidatorsRoot),
+	}
+	enc, err := json.MarshalIndent(changesToJson(changes, metadata), "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "could not encode signed changes")
+	}
+	return file.WriteFile(path, enc)
+}
+
+func readSignedChanges(path string) ([]*blsToExecutionChangeJson, error) {
+	enc, err := os.ReadFile(path) // #nosec G304 -- ReadFile is safe
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read signed changes file")
+	}
+	var changes []*blsToExecutionChangeJson
+	if err := json.Unmarshal(enc, &changes); err != nil {
+		return nil, errors.Wrap(err, "could not decode signed changes file")
+	}
+	for i, change := range changes {
+		if change == nil || change.SignedBLSToExecutionChangeJson == nil || change.Message == nil {
+			return nil, errors.Errorf("signed change at position %d is empty", i)
+		}
+	}
+	return changes, nil
+}
+
+// submitSignedChanges submits the signed changes to the beacon node, after checking that they were signed for the
+// network of the node when the signed changes specify their network.
+func submitSignedChanges(ctx context.Context, client *beacon.Client, changes []*blsToExecutionChangeJson) error {
+	genesis, err := client.GetGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	request := make([]*apimiddleware.SignedBLSToExecutionChangeJson, len(changes))
+	for i, change := range changes {
+		if change.Metadata != nil && change.Metadata.GenesisValidatorsRoot != "" &&
+			!strings.EqualFold(change.Metadata.GenesisValidatorsRoot, genesis.GenesisValidatorsRoot) {
+			return errors.Errorf(
+				"change of validator %s was signed for genesis validators root %s, but the beacon node has genesis validators root %s",
+				change.Message.ValidatorIndex, change.Metadata.GenesisValidatorsRoot, genesis.GenesisValidatorsRoot,
+			)
+		}
+		request[i] = change.SignedBLSToExecutionChangeJson
+	}
+	if err := client.SubmitChangeBLStoExecution(ctx, request); err != nil {
+		return err
+	}
+	log.WithField("beaconNode", client.NodeURL()).Infof("Submitted %d BLS to execution changes", len(request))
+	return nil
+}
+
+func genesisValidatorsRoot(ctx context.Context, client *beacon.Client, flagValue string) ([]byte, error) {
+	if flagValue != "" {
+		root, err := hexutil.Decode(flagValue)
+		if err != nil || len(root) != 32 {
+			return nil, errors.Errorf("%s is not a valid genesis validators root", flagValue)
+		}
+		return root, nil
+	}
+	if client == nil {
+		return nil, errors.New("a genesis validators root or a beacon node host is required to sign changes")
+	}
+	genesis, err := client.GetGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root, err := hexutil.Decode(genesis.GenesisValidatorsRoot)
+	if err != nil || len(root) != 32 {
+		return nil, errors.Errorf("beacon node returned an invalid genesis validators root %s", genesis.GenesisValidatorsRoot)
+	}
+	return root, nil
+}
+
+func parseValidatorIndices(values []string) ([]types.ValidatorIndex, error) {
+	if len(values) == 0 {
+		return nil, errors.New("no validator indices provided")
+	}
+	indices := make([]types.ValidatorIndex, 0, len(values))
+	seen := make(map[types.ValidatorIndex]bool, len(values))
+	for _, v := range values {
+		index, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse validator index %s", v)
+		}
+		if seen[types.ValidatorIndex(index)] {
+			return nil, errors.Errorf("validator index %d provided more than once", index)
+		}
+		seen[types.ValidatorIndex(index)] = true
+		indices = append(indices, types.ValidatorIndex(index))
+	}
+	return indices, nil
+}
+
+func inputMnemonic(mnemonicFile string) (string, error) {
+	if mnemonicFile == "" {
+		return prompt.PasswordPrompt(mnemonicPromptText, accounts.ValidateMnemonic)
+	}
+	data, err := os.ReadFile(mnemonicFile) // #nosec G304 -- ReadFile is safe
+	if err != nil {
+		return "", err
+	}
+	mnemonic := string(bytes.TrimSpace(data))
+	if err := accounts.ValidateMnemonic(mnemonic); err != nil {
+		return "", errors.Wrap(err, "mnemonic phrase did not pass validation")
+	}
+	return mnemonic, nil
+}
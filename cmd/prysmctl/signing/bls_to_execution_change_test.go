@@ -0,0 +1,74 @@
+package signing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestSignBLSToExecutionChanges(t *testing.T) {
+	keys := make([]bls.SecretKey, 3)
+	for i := range keys {
+		var err error
+		keys[i], err = bls.RandKey()
+		require.NoError(t, err)
+	}
+	indices := []types.ValidatorIndex{5, 100, 7}
+	address := common.HexToAddress("0x5d6a9cfb5b0f8c0e2b5a4c6d0a0b8a4c3f2e1d0c")
+	gvr := make([]byte, 32)
+	gvr[0] = 'a'
+
+	_, err := signBLSToExecutionChanges(keys[:2], indices, address, gvr)
+	require.ErrorContains(t, "got 2 withdrawal keys for 3 validators", err)
+
+	changes, err := signBLSToExecutionChanges(keys, indices, address, gvr)
+	require.NoError(t, err)
+	require.Equal(t, len(indices), len(changes))
+	cfg := params.BeaconConfig()
+	domain, err := signing.ComputeDomain(cfg.DomainBLSToExecutionChange, cfg.GenesisForkVersion, gvr)
+	require.NoError(t, err)
+	for i, change := range changes {
+		assert.Equal(t, indices[i], change.Message.ValidatorIndex)
+		assert.DeepEqual(t, keys[i].PublicKey().Marshal(), change.Message.FromBlsPubkey)
+		assert.DeepEqual(t, address.Bytes(), change.Message.ToExecutionAddress)
+		root, err := signing.ComputeSigningRoot(change.Message, domain)
+		require.NoError(t, err)
+		sig, err := bls.SignatureFromBytes(change.Signature)
+		require.NoError(t, err)
+		assert.Equal(t, true, sig.Verify(keys[i].PublicKey(), root[:]))
+	}
+
+	path := filepath.Join(t.TempDir(), "changes.json")
+	require.NoError(t, writeSignedChanges(path, changes, gvr))
+	read, err := readSignedChanges(path)
+	require.NoError(t, err)
+	require.Equal(t, len(changes), len(read))
+	for i, change := range read {
+		assert.Equal(t, "5d6a9cfb5b0f8c0e2b5a4c6d0a0b8a4c3f2e1d0c", change.Message.ToExecutionAddress[2:])
+		assert.Equal(t, hexutil.Encode(changes[i].Signature), change.Signature)
+		require.NotNil(t, change.Metadata)
+		assert.Equal(t, hexutil.Encode(gvr), change.Metadata.GenesisValidatorsRoot)
+		assert.Equal(t, cfg.ConfigName, change.Metadata.NetworkName)
+	}
+}
+
+func TestParseValidatorIndices(t *testing.T) {
+	indices, err := parseValidatorIndices([]string{"1", " 20", "3"})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []types.ValidatorIndex{1, 20, 3}, indices)
+
+	_, err = parseValidatorIndices(nil)
+	require.ErrorContains(t, "no validator indices provided", err)
+	_, err = parseValidatorIndices([]string{"1", "a"})
+	require.ErrorContains(t, "could not parse validator index a", err)
+	_, err = parseValidatorIndices([]string{"1", "1"})
+	require.ErrorContains(t, "validator index 1 provided more than once", err)
+}
@@ -59,6 +59,7 @@ var Commands = []*cli.Command{
 					return nil
 				},
 			},
+			blsToExecutionChangeCmd,
 		},
 	},
 }
@@ -0,0 +1,5 @@
+package slasher
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "prysmctl-slasher")
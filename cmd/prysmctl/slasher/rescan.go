@@ -0,0 +1,157 @@
+package slasher
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/slasherkv"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var rescanFlags = struct {
+	BeaconDataDir  string
+	SlasherDataDir string
+	StartEpoch     uint64
+	EndEpoch       uint64
+	ClearDB        bool
+	ChunkCacheMB   uint64
+}{}
+
+var rescanCmd = &cli.Command{
+	Name: "rescan",
+	Usage: "Rebuild a slasher database offline from the blocks stored in a beacon database, and the attestations they include. " +
+		"The beacon node must be stopped",
+	Action: func(cliCtx *cli.Context) error {
+		if err := rescanAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not rescan beacon database")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		cmd.ChainConfigFileFlag,
+		&cli.StringFlag{
+			Name:        "beacon-datadir",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &rescanFlags.BeaconDataDir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "slasher-datadir",
+			Usage:       "path to directory containing slasher.db",
+			Destination: &rescanFlags.SlasherDataDir,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-epoch",
+			Usage:       "first epoch to rescan. If unset, rescans the slasher history length before the end epoch",
+			Destination: &rescanFlags.StartEpoch,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-epoch",
+			Usage:       "last epoch to rescan. If unset, uses the epoch of the head block of the beacon database",
+			Destination: &rescanFlags.EndEpoch,
+		},
+		&cli.BoolFlag{
+			Name:        "clear-db",
+			Usage:       "delete an existing slasher database before rebuilding it",
+			Destination: &rescanFlags.ClearDB,
+		},
+		&cli.Uint64Flag{
+			Name:        "chunk-cache-mb",
+			Usage:       "size in megabytes of the in-memory cache of slasher min and max span chunks",
+			Destination: &rescanFlags.ChunkCacheMB,
+			Value:       256,
+		},
+	},
+}
+
+func rescanAction(cliCtx *cli.Context) error {
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		chainConfigFileName := cliCtx.String(cmd.ChainConfigFileFlag.Name)
+		if err := params.LoadChainConfigFile(chainConfigFileName, nil); err != nil {
+			return err
+		}
+	}
+	ctx := context.Background()
+	f := rescanFlags
+
+	slasherDBPath := path.Join(f.SlasherDataDir, slasherkv.DatabaseFileName)
+	if file.FileExists(slasherDBPath) {
+		if !f.ClearDB {
+			return errors.Errorf("slasher database %s already exists, use --clear-db to rebuild it", slasherDBPath)
+		}
+		if err := os.Remove(slasherDBPath); err != nil {
+			return errors.Wrap(err, "could not remove slasher database")
+		}
+		log.WithField("path", slasherDBPath).Info("Removed existing slasher database")
+	}
+
+	beaconDB, err := kv.NewKVStore(ctx, f.BeaconDataDir)
+	if err != nil {
+		return errors.Wrap(err, "could not open beacon database")
+	}
+	defer func() {
+		if err := beaconDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon database")
+		}
+	}()
+	slasherDB, err := slasherkv.NewKVStore(ctx, f.SlasherDataDir, slasherkv.WithChunkCacheSize(f.ChunkCacheMB*1024*1024))
+	if err != nil {
+		return errors.Wrap(err, "could not open slasher database")
+	}
+	defer func() {
+		if err := slasherDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher database")
+		}
+	}()
+
+	endEpoch := types.Epoch(f.EndEpoch)
+	if !cliCtx.IsSet("end-epoch") {
+		head, err := beaconDB.HeadBlock(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not get head block")
+		}
+		endEpoch = slots.ToEpoch(head.Block().Slot())
+	}
+	startEpoch := types.Epoch(f.StartEpoch)
+	historyLength := slasher.DefaultParams().HistoryLength()
+	if !cliCtx.IsSet("start-epoch") && endEpoch > historyLength {
+		startEpoch = endEpoch - historyLength
+	}
+
+	srv, err := slasher.New(ctx, &slasher.ServiceConfig{
+		Database: slasherDB,
+		BeaconDB: beaconDB,
+		StateGen: stategen.New(beaconDB, doublylinkedtree.New()),
+	})
+	if err != nil {
+		return err
+	}
+	result, err := srv.Rescan(ctx, startEpoch, endEpoch)
+	if err != nil {
+		return err
+	}
+	// Stopping the service flushes the last epoch written for each validator to the slasher database.
+	if err := srv.Stop(); err != nil {
+		return err
+	}
+	if len(result.AttesterSlashings) > 0 || len(result.ProposerSlashings) > 0 {
+		log.WithFields(logrus.Fields{
+			"numAttesterSlashings": len(result.AttesterSlashings),
+			"numProposerSlashings": len(result.ProposerSlashings),
+		}).Warn("Found slashable offenses, which are not submitted to any beacon node")
+	}
+	return nil
+}
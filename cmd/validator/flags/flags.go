@@ -31,7 +31,7 @@ var (
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
 		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Usage: "Beacon node RPC provider endpoint. Several comma-separated endpoints can be given, in which case duties are routed to the healthiest beacon node",
 		Value: "127.0.0.1:4000",
 	}
 	// BeaconRPCGatewayProviderFlag defines a beacon node JSON-RPC endpoint.
@@ -43,7 +43,7 @@ var (
 	// BeaconRESTApiProviderFlag defines a beacon node REST API endpoint.
 	BeaconRESTApiProviderFlag = &cli.StringFlag{
 		Name:  "beacon-rest-api-provider",
-		Usage: "Beacon node REST API provider endpoint",
+		Usage: "Beacon node REST API provider endpoint. Several comma-separated endpoints can be given, in which case duties are routed to the healthiest beacon node",
 		Value: "http://127.0.0.1:3500",
 	}
 	// CertFlag defines a flag for the node's TLS certificate.
@@ -321,6 +321,26 @@ var (
 		Usage: "Enables the web portal for the validator client (work in progress)",
 		Value: false,
 	}
+	// RecordBeaconNodeSessionFlag records the requests made to the beacon node to a file.
+	RecordBeaconNodeSessionFlag = &cli.StringFlag{
+		Name: "record-beacon-node-session",
+		Usage: "Records every request made to the beacon node, along with its response and slot timing, " +
+			"to the given file so that the session can be replayed to investigate missed duties",
+	}
+	// DistributedValidatorFlag enables the usage of aggregated selection proofs for distributed validator clusters.
+	DistributedValidatorFlag = &cli.BoolFlag{
+		Name: "distributed",
+		Usage: "Runs the validator as part of a distributed validator cluster, fetching the aggregated selection proofs " +
+			"of the cluster from the beacon node API in order to determine aggregation duties. Requires --enable-beacon-rest-api " +
+			"and a distributed validator middleware in front of the beacon node",
+	}
+	// DoppelGangerEpochsFlag sets the number of epochs during which validator keys are checked for doppelgangers.
+	DoppelGangerEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-epochs",
+		Usage: "Number of epochs during which the liveness of a validator key is monitored, without performing its duties, " +
+			"before the key is considered free of doppelgangers. Used with --enable-doppelganger",
+		Value: 2,
+	}
 	// SlashingProtectionExportDirFlag allows specifying the outpt directory
 	// for a validator's slashing protection history.
 	SlashingProtectionExportDirFlag = &cli.StringFlag{
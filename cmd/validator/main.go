@@ -71,6 +71,9 @@ var appFlags = []cli.Flag{
 	flags.WalletPasswordFileFlag,
 	flags.WalletDirFlag,
 	flags.EnableWebFlag,
+	flags.RecordBeaconNodeSessionFlag,
+	flags.DistributedValidatorFlag,
+	flags.DoppelGangerEpochsFlag,
 	flags.GraffitiFileFlag,
 	// Consensys' Web3Signer flags
 	flags.Web3SignerURLFlag,
@@ -89,6 +89,9 @@ var appHelpFlagGroups = []flagGroup{
 			flags.BeaconRESTApiProviderFlag,
 			flags.CertFlag,
 			flags.EnableWebFlag,
+			flags.RecordBeaconNodeSessionFlag,
+			flags.DistributedValidatorFlag,
+			flags.DoppelGangerEpochsFlag,
 			flags.DisablePenaltyRewardLogFlag,
 			flags.GraffitiFlag,
 			flags.EnableRPCFlag,
@@ -61,6 +61,8 @@ type Flags struct {
 	EnableSlasher bool // Enable slasher in the beacon node runtime.
 	// EnableSlashingProtectionPruning for the validator client.
 	EnableSlashingProtectionPruning bool
+	// EnableMinimalSlashingProtection keeps only the EIP-3076 minimal watermarks in the validator client's slashing protection database.
+	EnableMinimalSlashingProtection bool
 
 	DisablePullTips                   bool // DisablePullTips disables experimental disabling of boundary checks.
 	EnableDefensivePull               bool // EnableDefensivePull enables exerimental back boundary checks.
@@ -69,6 +71,9 @@ type Flags struct {
 	EnableBatchGossipAggregation      bool // EnableBatchGossipAggregation specifies whether to further aggregate our gossip batches before verifying them.
 	EnableOnlyBlindedBeaconBlocks     bool // EnableOnlyBlindedBeaconBlocks enables only storing blinded beacon blocks in the DB post-Bellatrix fork.
 	EnableStartOptimistic             bool // EnableStartOptimistic treats every block as optimistic at startup.
+	EnableExperimentalBackfill        bool // EnableExperimentalBackfill enables backfilling of block history for checkpoint synced nodes.
+	EnableLightClient                 bool // EnableLightClient enables the light client server.
+	EnableReorgLateBlocks             bool // EnableReorgLateBlocks allows the proposer to orphan late and weak head blocks.
 
 	DisableStakinContractCheck bool // Disables check for deposit contract when proposing blocks
 
@@ -77,6 +82,10 @@ type Flags struct {
 	// KeystoreImportDebounceInterval specifies the time duration the validator waits to reload new keys if they have
 	// changed on disk. This feature is for advanced use cases only.
 	KeystoreImportDebounceInterval time.Duration
+
+	// LocalPayloadStoreEpochs is the number of recent epochs whose full execution payloads are kept in the database
+	// alongside blinded blocks.
+	LocalPayloadStoreEpochs uint64
 }
 
 var featureConfig *Flags
@@ -251,10 +260,22 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(EnableOnlyBlindedBeaconBlocks)
 		cfg.EnableOnlyBlindedBeaconBlocks = true
 	}
+	if ctx.IsSet(localPayloadStoreEpochs.Name) {
+		if cfg.EnableOnlyBlindedBeaconBlocks {
+			log.WithField(localPayloadStoreEpochs.Name, ctx.Uint64(localPayloadStoreEpochs.Name)).Warn(enabledFeatureFlag)
+			cfg.LocalPayloadStoreEpochs = ctx.Uint64(localPayloadStoreEpochs.Name)
+		} else {
+			log.Warnf("--%s has no effect without --%s", localPayloadStoreEpochs.Name, EnableOnlyBlindedBeaconBlocks.Name)
+		}
+	}
 	if ctx.Bool(enableStartupOptimistic.Name) {
 		logEnabled(enableStartupOptimistic)
 		cfg.EnableStartOptimistic = true
 	}
+	if ctx.Bool(enableExperimentalBackfill.Name) {
+		logEnabled(enableExperimentalBackfill)
+		cfg.EnableExperimentalBackfill = true
+	}
 	if ctx.IsSet(enableFullSSZDataLogging.Name) {
 		logEnabled(enableFullSSZDataLogging)
 		cfg.EnableFullSSZDataLogging = true
@@ -263,6 +284,14 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(enableVerboseSigVerification)
 		cfg.EnableVerboseSigVerification = true
 	}
+	if ctx.Bool(enableLightClient.Name) {
+		logEnabled(enableLightClient)
+		cfg.EnableLightClient = true
+	}
+	if ctx.Bool(enableReorgLateBlocks.Name) {
+		logEnabled(enableReorgLateBlocks)
+		cfg.EnableReorgLateBlocks = true
+	}
 	Init(cfg)
 	return nil
 }
@@ -293,6 +322,10 @@ func ConfigureValidator(ctx *cli.Context) error {
 		logEnabled(enableSlashingProtectionPruning)
 		cfg.EnableSlashingProtectionPruning = true
 	}
+	if ctx.Bool(EnableMinimalSlashingProtection.Name) {
+		logEnabled(EnableMinimalSlashingProtection)
+		cfg.EnableMinimalSlashingProtection = true
+	}
 	if ctx.Bool(enableDoppelGangerProtection.Name) {
 		logEnabled(enableDoppelGangerProtection)
 		cfg.EnableDoppelGanger = true
@@ -77,6 +77,13 @@ var (
 		Name:  "enable-slashing-protection-history-pruning",
 		Usage: "Enables the pruning of the validator client's slashing protection database",
 	}
+	// EnableMinimalSlashingProtection is exported for the slashing protection import and export commands.
+	EnableMinimalSlashingProtection = &cli.BoolFlag{
+		Name: "enable-minimal-slashing-protection",
+		Usage: "Keeps only the highest signed source and target epochs and proposal slot of each validator key in the " +
+			"slashing protection database, as in the EIP-3076 minimal format, instead of the complete signing history. " +
+			"The database is converted, without losing protection, whenever this flag is toggled",
+	}
 	enableDoppelGangerProtection = &cli.BoolFlag{
 		Name: "enable-doppelganger",
 		Usage: "Enables the validator to perform a doppelganger check. (Warning): This is not " +
@@ -118,12 +125,22 @@ var (
 		Name:  "enable-only-blinded-beacon-blocks",
 		Usage: "Enables storing only blinded beacon blocks in the database without full execution layer transactions",
 	}
+	localPayloadStoreEpochs = &cli.Uint64Flag{
+		Name: "local-payload-store-epochs",
+		Usage: "Keeps the full execution payloads of the blocks of the given number of recent epochs in the database, " +
+			"when --enable-only-blinded-beacon-blocks is set, so that they are served to peers and over the API without " +
+			"querying the execution client. Disabled when 0",
+	}
 	enableStartupOptimistic = &cli.BoolFlag{
 		Name:   "startup-optimistic",
 		Usage:  "Treats every block as optimistically synced at launch. Use with caution",
 		Value:  false,
 		Hidden: true,
 	}
+	enableExperimentalBackfill = &cli.BoolFlag{
+		Name:  "enable-experimental-backfill",
+		Usage: "Backfills the block history between genesis and the origin checkpoint block of a checkpoint synced node",
+	}
 	enableFullSSZDataLogging = &cli.BoolFlag{
 		Name:  "enable-full-ssz-data-logging",
 		Usage: "Enables displaying logs for full ssz data on rejected gossip messages",
@@ -136,6 +153,14 @@ var (
 		Name:  "enable-verbose-sig-verification",
 		Usage: "Enables identifying invalid signatures if batch verification fails when processing block",
 	}
+	enableLightClient = &cli.BoolFlag{
+		Name:  "enable-lightclient",
+		Usage: "Enables the light client server: light client updates are computed, stored and served over p2p and the beacon API",
+	}
+	enableReorgLateBlocks = &cli.BoolFlag{
+		Name:  "enable-reorg-late-blocks",
+		Usage: "Enables the proposer to orphan a late and weakly attested head block by building on its parent",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -152,6 +177,7 @@ var ValidatorFlags = append(deprecatedFlags, []cli.Flag{
 	dynamicKeyReloadDebounceInterval,
 	attestTimely,
 	enableSlashingProtectionPruning,
+	EnableMinimalSlashingProtection,
 	enableDoppelGangerProtection,
 	EnableBeaconRESTApi,
 }...)
@@ -180,10 +206,14 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	disableForkChoiceDoublyLinkedTree,
 	disableGossipBatchAggregation,
 	EnableOnlyBlindedBeaconBlocks,
+	localPayloadStoreEpochs,
 	enableStartupOptimistic,
+	enableExperimentalBackfill,
 	disableDefensivePull,
 	enableFullSSZDataLogging,
 	enableVerboseSigVerification,
+	enableLightClient,
+	enableReorgLateBlocks,
 }...)...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
@@ -67,8 +67,11 @@ type BeaconChainConfig struct {
 	SecondsPerETH1Block                       uint64      `yaml:"SECONDS_PER_ETH1_BLOCK" spec:"true"`              // SecondsPerETH1Block is the approximate time for a single eth1 block to be produced.
 
 	// Fork choice algorithm constants.
-	ProposerScoreBoost uint64 `yaml:"PROPOSER_SCORE_BOOST" spec:"true"` // ProposerScoreBoost defines a value that is a % of the committee weight for fork-choice boosting.
-	IntervalsPerSlot   uint64 `yaml:"INTERVALS_PER_SLOT" spec:"true"`   // IntervalsPerSlot defines the number of fork choice intervals in a slot defined in the fork choice spec.
+	ProposerScoreBoost              uint64      `yaml:"PROPOSER_SCORE_BOOST" spec:"true"`    // ProposerScoreBoost defines a value that is a % of the committee weight for fork-choice boosting.
+	IntervalsPerSlot                uint64      `yaml:"INTERVALS_PER_SLOT" spec:"true"`      // IntervalsPerSlot defines the number of fork choice intervals in a slot defined in the fork choice spec.
+	ReorgWeightThreshold            uint64      `yaml:"REORG_WEIGHT_THRESHOLD"`              // ReorgWeightThreshold defines a value that is a % of the committee weight below which a late head block is considered weak and may be orphaned by the proposer.
+	ReorgParentWeightThreshold      uint64      `yaml:"REORG_PARENT_WEIGHT_THRESHOLD"`       // ReorgParentWeightThreshold defines a value that is a % of the committee weight above which the parent of a weak head block is considered strong enough to build on.
+	ReorgMaxEpochsSinceFinalization types.Epoch `yaml:"REORG_MAX_EPOCHS_SINCE_FINALIZATION"` // ReorgMaxEpochsSinceFinalization defines the maximum number of epochs since finalization during which the proposer may orphan a late head block.
 
 	// Ethereum PoW parameters.
 	DepositChainID         uint64 `yaml:"DEPOSIT_CHAIN_ID" spec:"true"`         // DepositChainID of the eth1 network. This used for replay protection.
@@ -205,6 +208,7 @@ type BeaconChainConfig struct {
 	// Mev-boost circuit breaker
 	MaxBuilderConsecutiveMissedSlots types.Slot // MaxBuilderConsecutiveMissedSlots defines the number of consecutive skip slot to fallback from using relay/builder to local execution engine for block construction.
 	MaxBuilderEpochMissedSlots       types.Slot // MaxBuilderEpochMissedSlots is defines the number of total skip slot (per epoch rolling windows) to fallback from using relay/builder to local execution engine for block construction.
+	LocalBlockValueBoost             uint64     // LocalBlockValueBoost defines the percentage by which the local execution payload value is boosted when compared against builder bids. It has no effect when the execution client does not report the local payload value.
 
 	// Execution engine timeout value
 	ExecutionEngineTimeoutValue uint64 // ExecutionEngineTimeoutValue defines the seconds to wait before timing out engine endpoints with execution payload execution semantics (newPayload, forkchoiceUpdated).
@@ -21,6 +21,7 @@ type ProposerSettingsPayload struct {
 type ProposerOptionPayload struct {
 	FeeRecipient  string         `json:"fee_recipient" yaml:"fee_recipient"`
 	BuilderConfig *BuilderConfig `json:"builder" yaml:"builder"`
+	Graffiti      string         `json:"graffiti,omitempty" yaml:"graffiti,omitempty"`
 }
 
 // BuilderConfig is the struct representation of the JSON config file set in the validator through the CLI.
@@ -73,6 +74,7 @@ type ProposerSettings struct {
 type ProposerOption struct {
 	FeeRecipient  common.Address
 	BuilderConfig *BuilderConfig
+	Graffiti      string
 }
 
 // DefaultProposerOption returns a Proposer Option with defaults filled
@@ -0,0 +1,29 @@
+// Package httputil contains helpers shared by HTTP handlers that are served
+// directly, without going through the gRPC gateway.
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/prysmaticlabs/prysm/v3/api/gateway/apimiddleware"
+	log "github.com/sirupsen/logrus"
+)
+
+// WriteJson writes the response message in JSON format.
+func WriteJson(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Error("Could not write response message")
+	}
+}
+
+// HandleError writes an error message in the standard JSON error format, using the given HTTP status code.
+func HandleError(w http.ResponseWriter, message string, code int) {
+	errJson := &apimiddleware.DefaultErrorJson{
+		Message: message,
+		Code:    code,
+	}
+	apimiddleware.WriteError(w, errJson, nil)
+}
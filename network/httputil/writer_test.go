@@ -0,0 +1,30 @@
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestWriteJson(t *testing.T) {
+	writer := httptest.NewRecorder()
+	WriteJson(writer, map[string]string{"foo": "bar"})
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "application/json", writer.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"foo\":\"bar\"}\n", writer.Body.String())
+}
+
+func TestHandleError(t *testing.T) {
+	writer := httptest.NewRecorder()
+	HandleError(writer, "something went wrong", http.StatusBadRequest)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	e := &apimiddleware.DefaultErrorJson{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+	assert.Equal(t, "something went wrong", e.Message)
+	assert.Equal(t, http.StatusBadRequest, e.Code)
+}
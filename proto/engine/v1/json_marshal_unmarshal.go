@@ -465,6 +465,34 @@ func (e *ExecutionPayloadCapella) UnmarshalJSON(enc []byte) error {
 	return nil
 }
 
+// ExecutionPayloadCapellaWithValue is the response of engine_getPayloadV2, that is
+// a capella execution payload along with the value of the block in wei.
+type ExecutionPayloadCapellaWithValue struct {
+	Payload *ExecutionPayloadCapella
+	Value   *big.Int
+}
+
+// UnmarshalJSON --
+func (e *ExecutionPayloadCapellaWithValue) UnmarshalJSON(enc []byte) error {
+	dec := GetPayloadV2ResponseJson{}
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		return err
+	}
+	if dec.ExecutionPayload == nil {
+		return errors.New("missing required field 'executionPayload' for GetPayloadV2Response")
+	}
+	value, err := hexutil.DecodeBig(dec.BlockValue)
+	if err != nil {
+		return errors.Wrap(err, "could not decode block value")
+	}
+	payload := &ExecutionPayloadCapella{}
+	if err := payload.UnmarshalJSON(enc); err != nil {
+		return err
+	}
+	*e = ExecutionPayloadCapellaWithValue{Payload: payload, Value: value}
+	return nil
+}
+
 type payloadAttributesJSON struct {
 	Timestamp             hexutil.Uint64 `json:"timestamp"`
 	PrevRandao            hexutil.Bytes  `json:"prevRandao"`
@@ -631,3 +659,11 @@ func (f *ForkchoiceState) UnmarshalJSON(enc []byte) error {
 	f.FinalizedBlockHash = dec.FinalizedBlockHash
 	return nil
 }
+
+// ExecutionPayloadBodyV1 is the response kind received by the engine_getPayloadBodiesByHashV1
+// and engine_getPayloadBodiesByRangeV1 endpoints via JSON-RPC. Withdrawals are nil for
+// payloads from before the Capella fork.
+type ExecutionPayloadBodyV1 struct {
+	Transactions []hexutil.Bytes `json:"transactions"`
+	Withdrawals  []*Withdrawal   `json:"withdrawals"`
+}
@@ -205,6 +205,42 @@ func TestJsonMarshalUnmarshal(t *testing.T) {
 		require.DeepEqual(t, bytesutil.PadTo([]byte("address"), 20), withdrawal.Address)
 		require.Equal(t, uint64(1), withdrawal.Amount)
 	})
+	t.Run("execution payload capella with value", func(t *testing.T) {
+		hash := common.BytesToHash([]byte("hash"))
+		logsBloom := hexutil.Bytes(bytesutil.PadTo([]byte("logs"), fieldparams.LogsBloomLength))
+		bn := hexutil.Uint64(1)
+		resp := &enginev1.GetPayloadV2ResponseJson{
+			BlockValue: "0x7b",
+			ExecutionPayload: &enginev1.ExecutionPayloadCapellaJSON{
+				ParentHash:    &hash,
+				FeeRecipient:  &common.Address{},
+				StateRoot:     &hash,
+				ReceiptsRoot:  &hash,
+				LogsBloom:     &logsBloom,
+				PrevRandao:    &hash,
+				BlockNumber:   &bn,
+				GasLimit:      &bn,
+				GasUsed:       &bn,
+				Timestamp:     &bn,
+				ExtraData:     hexutil.Bytes{},
+				BaseFeePerGas: "0x1",
+				BlockHash:     &hash,
+				Transactions:  []hexutil.Bytes{},
+			},
+		}
+		enc, err := json.Marshal(resp)
+		require.NoError(t, err)
+		withValue := &enginev1.ExecutionPayloadCapellaWithValue{}
+		require.NoError(t, json.Unmarshal(enc, withValue))
+		require.Equal(t, "123", withValue.Value.String())
+		require.DeepEqual(t, hash.Bytes(), withValue.Payload.BlockHash)
+		require.Equal(t, uint64(1), withValue.Payload.BlockNumber)
+
+		resp.BlockValue = "123"
+		enc, err = json.Marshal(resp)
+		require.NoError(t, err)
+		require.ErrorContains(t, "could not decode block value", json.Unmarshal(enc, withValue))
+	})
 	t.Run("execution block", func(t *testing.T) {
 		baseFeePerGas := big.NewInt(1770307273)
 		want := &gethtypes.Header{
@@ -474,6 +510,27 @@ func TestJsonMarshalUnmarshal(t *testing.T) {
 		require.DeepEqual(t, bytesutil.PadTo([]byte("address2"), 20), payloadPb.Withdrawals[1].Address)
 		require.Equal(t, uint64(200), payloadPb.Withdrawals[1].Amount)
 	})
+	t.Run("execution payload body", func(t *testing.T) {
+		enc := []byte(`{
+			"transactions": ["0x01", "0x0203"],
+			"withdrawals": [{"index": "0x1", "validatorIndex": "0x2", "address": "0x0000000000000000000000000000000000000003", "amount": "0x4"}]
+		}`)
+		body := &enginev1.ExecutionPayloadBodyV1{}
+		require.NoError(t, json.Unmarshal(enc, body))
+		require.DeepEqual(t, []hexutil.Bytes{{0x01}, {0x02, 0x03}}, body.Transactions)
+		require.Equal(t, 1, len(body.Withdrawals))
+		require.Equal(t, uint64(1), body.Withdrawals[0].Index)
+		require.Equal(t, types.ValidatorIndex(2), body.Withdrawals[0].ValidatorIndex)
+		require.DeepEqual(t, common.HexToAddress("0x03").Bytes(), body.Withdrawals[0].Address)
+		require.Equal(t, uint64(4), body.Withdrawals[0].Amount)
+	})
+	t.Run("execution payload body pre-capella", func(t *testing.T) {
+		enc := []byte(`{"transactions": ["0x01"], "withdrawals": null}`)
+		body := &enginev1.ExecutionPayloadBodyV1{}
+		require.NoError(t, json.Unmarshal(enc, body))
+		require.Equal(t, 1, len(body.Transactions))
+		require.Equal(t, true, body.Withdrawals == nil)
+	})
 }
 
 func TestPayloadIDBytes_MarshalUnmarshalJSON(t *testing.T) {
@@ -10,7 +10,9 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	v2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
 	eth "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	iface "github.com/prysmaticlabs/prysm/v3/validator/client/iface"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -67,6 +69,36 @@ func (mr *MockValidatorClientMockRecorder) DomainData(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainData", reflect.TypeOf((*MockValidatorClient)(nil).DomainData), arg0, arg1)
 }
 
+// GetAggregatedSelections mocks base method.
+func (m *MockValidatorClient) GetAggregatedSelections(arg0 context.Context, arg1 []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregatedSelections", arg0, arg1)
+	ret0, _ := ret[0].([]iface.BeaconCommitteeSelection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregatedSelections indicates an expected call of GetAggregatedSelections.
+func (mr *MockValidatorClientMockRecorder) GetAggregatedSelections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedSelections", reflect.TypeOf((*MockValidatorClient)(nil).GetAggregatedSelections), arg0, arg1)
+}
+
+// GetAggregatedSyncSelections mocks base method.
+func (m *MockValidatorClient) GetAggregatedSyncSelections(arg0 context.Context, arg1 []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregatedSyncSelections", arg0, arg1)
+	ret0, _ := ret[0].([]iface.SyncCommitteeSelection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregatedSyncSelections indicates an expected call of GetAggregatedSyncSelections.
+func (mr *MockValidatorClientMockRecorder) GetAggregatedSyncSelections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedSyncSelections", reflect.TypeOf((*MockValidatorClient)(nil).GetAggregatedSyncSelections), arg0, arg1)
+}
+
 // GetAttestationData mocks base method.
 func (m *MockValidatorClient) GetAttestationData(arg0 context.Context, arg1 *eth.AttestationDataRequest) (*eth.AttestationData, error) {
 	m.ctrl.T.Helper()
@@ -127,6 +159,21 @@ func (mr *MockValidatorClientMockRecorder) GetFeeRecipientByPubKey(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeRecipientByPubKey", reflect.TypeOf((*MockValidatorClient)(nil).GetFeeRecipientByPubKey), arg0, arg1)
 }
 
+// GetLiveness mocks base method.
+func (m *MockValidatorClient) GetLiveness(arg0 context.Context, arg1 *v2.GetLivenessRequest) (*v2.GetLivenessResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiveness", arg0, arg1)
+	ret0, _ := ret[0].(*v2.GetLivenessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiveness indicates an expected call of GetLiveness.
+func (mr *MockValidatorClientMockRecorder) GetLiveness(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveness", reflect.TypeOf((*MockValidatorClient)(nil).GetLiveness), arg0, arg1)
+}
+
 // GetSyncCommitteeContribution mocks base method.
 func (m *MockValidatorClient) GetSyncCommitteeContribution(arg0 context.Context, arg1 *eth.SyncCommitteeContributionRequest) (*eth.SyncCommitteeContribution, error) {
 	m.ctrl.T.Helper()
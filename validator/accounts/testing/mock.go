@@ -116,6 +116,10 @@ func (_ MockValidator) SlotDeadline(_ types.Slot) time.Time {
 	panic("implement me")
 }
 
+func (_ MockValidator) WithDeadline(_ context.Context, _ time.Time) (context.Context, context.CancelFunc) {
+	panic("implement me")
+}
+
 func (_ MockValidator) LogValidatorGainsAndLosses(_ context.Context, _ types.Slot) error {
 	panic("implement me")
 }
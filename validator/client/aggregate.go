@@ -3,18 +3,19 @@ package client
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1/validator-client"
-	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
 	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -50,13 +51,26 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 	v.aggregatedSlotCommitteeIDCache.Add(k, true)
 	v.aggregatedSlotCommitteeIDCacheLock.Unlock()
 
-	slotSig, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
-	if err != nil {
-		log.WithError(err).Error("Could not sign slot")
-		if v.emitAccountMetrics {
-			ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
+	var slotSig []byte
+	if v.distributed {
+		var ok bool
+		slotSig, ok = v.attSelection(slot, duty.ValidatorIndex)
+		if !ok {
+			log.WithField("slot", slot).Error("No aggregated selection proof for validator")
+			if v.emitAccountMetrics {
+				ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return
+		}
+	} else {
+		slotSig, err = v.signSlotWithSelectionProof(ctx, pubKey, slot)
+		if err != nil {
+			log.WithError(err).Error("Could not sign slot")
+			if v.emitAccountMetrics {
+				ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return
 		}
-		return
 	}
 
 	// As specified in spec, an aggregator should wait until two thirds of the way through slot
@@ -143,6 +157,68 @@ func (v *validator) signSlotWithSelectionProof(ctx context.Context, pubKey [fiel
 	return sig.Marshal(), nil
 }
 
+type attSelectionKey struct {
+	slot  types.Slot
+	index types.ValidatorIndex
+}
+
+// aggregatedSelectionProofs signs the partial selection proofs of the given duties and exchanges them,
+// through the beacon node API, for the selection proofs aggregated over the distributed validator
+// cluster. The aggregated proofs are cached until their slot has passed.
+func (v *validator) aggregatedSelectionProofs(ctx context.Context, slot types.Slot, duties *ethpb.DutiesResponse) error {
+	ctx, span := trace.StartSpan(ctx, "validator.aggregatedSelectionProofs")
+	defer span.End()
+
+	var selections []iface.BeaconCommitteeSelection
+	for _, epochDuties := range [][]*ethpb.DutiesResponse_Duty{duties.CurrentEpochDuties, duties.NextEpochDuties} {
+		for _, duty := range epochDuties {
+			if duty.Status != ethpb.ValidatorStatus_ACTIVE && duty.Status != ethpb.ValidatorStatus_EXITING {
+				continue
+			}
+			sig, err := v.signSlotWithSelectionProof(ctx, bytesutil.ToBytes48(duty.PublicKey), duty.AttesterSlot)
+			if err != nil {
+				return errors.Wrapf(err, "could not sign selection proof for slot %d", duty.AttesterSlot)
+			}
+			selections = append(selections, iface.BeaconCommitteeSelection{
+				SelectionProof: sig,
+				Slot:           duty.AttesterSlot,
+				ValidatorIndex: duty.ValidatorIndex,
+			})
+		}
+	}
+	if len(selections) == 0 {
+		return nil
+	}
+
+	aggregated, err := v.validatorClient.GetAggregatedSelections(ctx, selections)
+	if err != nil {
+		return err
+	}
+
+	v.attSelectionLock.Lock()
+	defer v.attSelectionLock.Unlock()
+	if v.attSelections == nil {
+		v.attSelections = make(map[attSelectionKey]iface.BeaconCommitteeSelection)
+	}
+	for k := range v.attSelections {
+		if k.slot < slot {
+			delete(v.attSelections, k)
+		}
+	}
+	for _, s := range aggregated {
+		v.attSelections[attSelectionKey{slot: s.Slot, index: s.ValidatorIndex}] = s
+	}
+	return nil
+}
+
+// attSelection returns the aggregated selection proof of the validator for the given slot.
+func (v *validator) attSelection(slot types.Slot, index types.ValidatorIndex) ([]byte, bool) {
+	v.attSelectionLock.Lock()
+	defer v.attSelectionLock.Unlock()
+	s, ok := v.attSelections[attSelectionKey{slot: slot, index: index}]
+	return s.SelectionProof, ok
+}
+
 // waitToSlotTwoThirds waits until two third through the current slot period
 // such that any attestations from this slot have time to reach the beacon node
 // before creating the aggregated attestation.
@@ -156,17 +232,16 @@ func (v *validator) waitToSlotTwoThirds(ctx context.Context, slot types.Slot) {
 
 	startTime := slots.StartTime(v.genesisTime, slot)
 	finalTime := startTime.Add(delay)
-	wait := prysmTime.Until(finalTime)
+	wait := finalTime.Sub(v.timeSource().Now())
 	if wait <= 0 {
 		return
 	}
-	t := time.NewTimer(wait)
-	defer t.Stop()
+	t := v.timeSource().After(wait)
 	select {
 	case <-ctx.Done():
 		tracing.AnnotateError(span, ctx.Err())
 		return
-	case <-t.C:
+	case <-t:
 		return
 	}
 }
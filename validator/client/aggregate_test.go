@@ -11,12 +11,14 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 	"github.com/prysmaticlabs/prysm/v3/testing/util"
 	"github.com/prysmaticlabs/prysm/v3/time"
 	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -169,3 +171,109 @@ func TestAggregateAndProofSignature_CanSignValidSignature(t *testing.T) {
 	_, err = bls.SignatureFromBytes(sig)
 	require.NoError(t, err)
 }
+
+func TestSubmitAggregateAndProof_Distributed(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.distributed = true
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 7,
+			},
+		},
+	}
+	aggregatedProof := bytesutil.PadTo([]byte{0x01}, 96)
+	validator.attSelections = map[attSelectionKey]iface.BeaconCommitteeSelection{
+		{slot: 0, index: 7}: {SelectionProof: aggregatedProof, Slot: 0, ValidatorIndex: 7},
+	}
+
+	m.validatorClient.EXPECT().SubmitAggregateSelectionProof(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AggregateSelectionRequest{}),
+	).DoAndReturn(func(_ context.Context, req *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+		assert.DeepEqual(t, aggregatedProof, req.SlotSignature)
+		return &ethpb.AggregateSelectionResponse{
+			AggregateAndProof: &ethpb.AggregateAttestationAndProof{
+				AggregatorIndex: 7,
+				Aggregate: util.HydrateAttestation(&ethpb.Attestation{
+					AggregationBits: make([]byte, 1),
+				}),
+				SelectionProof: aggregatedProof,
+			},
+		}, nil
+	})
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().SubmitSignedAggregateSelectionProof(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.SignedAggregateSubmitRequest{}),
+	).Return(&ethpb.SignedAggregateSubmitResponse{AttestationDataRoot: make([]byte, 32)}, nil)
+
+	validator.SubmitAggregateAndProof(context.Background(), 0, pubKey)
+}
+
+func TestAggregatedSelectionProofs(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.distributed = true
+	validator.attSelections = map[attSelectionKey]iface.BeaconCommitteeSelection{
+		{slot: 1, index: 7}: {Slot: 1, ValidatorIndex: 7},
+	}
+	duties := &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 7,
+				AttesterSlot:   40,
+				Status:         ethpb.ValidatorStatus_ACTIVE,
+			},
+		},
+		NextEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+				ValidatorIndex: 7,
+				AttesterSlot:   70,
+				Status:         ethpb.ValidatorStatus_PENDING,
+			},
+		},
+	}
+	aggregatedProof := bytesutil.PadTo([]byte{0x01}, 96)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().GetAggregatedSelections(
+		gomock.Any(), // ctx
+		gomock.Any(), // selections
+	).DoAndReturn(func(_ context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+		require.Equal(t, 1, len(selections))
+		assert.Equal(t, types.Slot(40), selections[0].Slot)
+		assert.Equal(t, types.ValidatorIndex(7), selections[0].ValidatorIndex)
+		return []iface.BeaconCommitteeSelection{{SelectionProof: aggregatedProof, Slot: 40, ValidatorIndex: 7}}, nil
+	})
+
+	require.NoError(t, validator.aggregatedSelectionProofs(context.Background(), 32, duties))
+
+	proof, ok := validator.attSelection(40, 7)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, aggregatedProof, proof)
+	_, ok = validator.attSelection(1, 7)
+	assert.Equal(t, false, ok, "Expected selections of past slots to be pruned")
+
+	aggregator, err := validator.isAggregator(context.Background(), []types.ValidatorIndex{7}, 40, bytesutil.ToBytes48(validatorKey.PublicKey().Marshal()), 7)
+	require.NoError(t, err)
+	assert.Equal(t, true, aggregator)
+	aggregator, err = validator.isAggregator(context.Background(), []types.ValidatorIndex{7}, 41, bytesutil.ToBytes48(validatorKey.PublicKey().Marshal()), 7)
+	require.NoError(t, err)
+	assert.Equal(t, false, aggregator, "Expected no aggregation without an aggregated selection proof")
+}
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v3/async"
@@ -21,7 +20,6 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1/validator-client"
-	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
 	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
 	"github.com/sirupsen/logrus"
@@ -265,12 +263,11 @@ func (v *validator) waitOneThirdOrValidBlock(ctx context.Context, slot types.Slo
 	delay := slots.DivideSlotBy(3 /* a third of the slot duration */)
 	startTime := slots.StartTime(v.genesisTime, slot)
 	finalTime := startTime.Add(delay)
-	wait := prysmTime.Until(finalTime)
+	wait := finalTime.Sub(v.timeSource().Now())
 	if wait <= 0 {
 		return
 	}
-	t := time.NewTimer(wait)
-	defer t.Stop()
+	t := v.timeSource().After(wait)
 
 	bChannel := make(chan interfaces.SignedBeaconBlock, 1)
 	sub := v.blockFeed.Subscribe(bChannel)
@@ -290,7 +287,7 @@ func (v *validator) waitOneThirdOrValidBlock(ctx context.Context, slot types.Slo
 		case <-sub.Err():
 			log.Error("Subscriber closed, exiting goroutine")
 			return
-		case <-t.C:
+		case <-t:
 			return
 		}
 	}
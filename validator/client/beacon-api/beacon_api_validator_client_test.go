@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
 	"github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/mock"
 )
 
@@ -205,3 +206,10 @@ func TestBeaconApiValidatorClient_ProposeBeaconBlockError(t *testing.T) {
 	assert.ErrorContains(t, expectedErr.Error(), err)
 	assert.DeepEqual(t, expectedResp, resp)
 }
+
+func TestBeaconApiValidatorClient_GetFeeRecipientByPubKey(t *testing.T) {
+	validatorClient := beaconApiValidatorClient{}
+	resp, err := validatorClient.GetFeeRecipientByPubKey(context.Background(), &ethpb.FeeRecipientByPubKeyRequest{PublicKey: []byte{1}})
+	require.ErrorContains(t, "is not supported by the beacon API", err)
+	assert.Equal(t, (*ethpb.FeeRecipientByPubKeyResponse)(nil), resp)
+}
@@ -5,21 +5,219 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	neturl "net/url"
 	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 )
 
 type dutiesProvider interface {
 	GetAttesterDuties(ctx context.Context, epoch types.Epoch, validatorIndices []types.ValidatorIndex) ([]*apimiddleware.AttesterDutyJson, error)
+	GetProposerDuties(ctx context.Context, epoch types.Epoch) ([]*apimiddleware.ProposerDutyJson, error)
+	GetSyncDuties(ctx context.Context, epoch types.Epoch, validatorIndices []types.ValidatorIndex) ([]*apimiddleware.SyncCommitteeDuty, error)
+	GetCommittees(ctx context.Context, epoch types.Epoch) ([]*apimiddleware.CommitteeJson, error)
 }
 
 type beaconApiDutiesProvider struct {
 	jsonRestHandler jsonRestHandler
 }
 
+type committeeKey struct {
+	slot           types.Slot
+	committeeIndex types.CommitteeIndex
+}
+
+func (c *beaconApiValidatorClient) getDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	multipleValidatorStatus, err := c.multipleValidatorStatus(ctx, &ethpb.MultipleValidatorStatusRequest{PublicKeys: in.PublicKeys})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get validators status")
+	}
+
+	currentEpochDuties, err := c.getDutiesForEpoch(ctx, in.Epoch, multipleValidatorStatus, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get duties for current epoch `%d`", in.Epoch)
+	}
+
+	// Like the gRPC API, the next epoch duties don't include proposer duties since they can't be known in advance.
+	nextEpochDuties, err := c.getDutiesForEpoch(ctx, in.Epoch+1, multipleValidatorStatus, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get duties for next epoch `%d`", in.Epoch+1)
+	}
+
+	return &ethpb.DutiesResponse{
+		Duties:             currentEpochDuties,
+		CurrentEpochDuties: currentEpochDuties,
+		NextEpochDuties:    nextEpochDuties,
+	}, nil
+}
+
+func (c *beaconApiValidatorClient) getDutiesForEpoch(
+	ctx context.Context,
+	epoch types.Epoch,
+	multipleValidatorStatus *ethpb.MultipleValidatorStatusResponse,
+	fetchProposerDuties bool,
+) ([]*ethpb.DutiesResponse_Duty, error) {
+	if len(multipleValidatorStatus.PublicKeys) != len(multipleValidatorStatus.Indices) ||
+		len(multipleValidatorStatus.PublicKeys) != len(multipleValidatorStatus.Statuses) {
+		return nil, errors.New("validators status response lengths do not match")
+	}
+
+	// Only the validators known by the beacon node can have duties
+	validatorIndices := make([]types.ValidatorIndex, 0, len(multipleValidatorStatus.Indices))
+	for index, validatorIndex := range multipleValidatorStatus.Indices {
+		if multipleValidatorStatus.Statuses[index].Status != ethpb.ValidatorStatus_UNKNOWN_STATUS {
+			validatorIndices = append(validatorIndices, validatorIndex)
+		}
+	}
+
+	attesterDutiesMap := make(map[types.ValidatorIndex]*apimiddleware.AttesterDutyJson)
+	proposerSlotsMap := make(map[types.ValidatorIndex][]types.Slot)
+	syncDutiesMap := make(map[types.ValidatorIndex]struct{})
+	committeesMap := make(map[committeeKey][]types.ValidatorIndex)
+
+	if len(validatorIndices) > 0 {
+		attesterDuties, err := c.dutiesProvider.GetAttesterDuties(ctx, epoch, validatorIndices)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get attester duties for epoch `%d`", epoch)
+		}
+
+		for _, attesterDuty := range attesterDuties {
+			validatorIndex, err := strconv.ParseUint(attesterDuty.ValidatorIndex, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse attester validator index `%s`", attesterDuty.ValidatorIndex)
+			}
+			attesterDutiesMap[types.ValidatorIndex(validatorIndex)] = attesterDuty
+		}
+
+		if len(attesterDuties) > 0 {
+			committees, err := c.dutiesProvider.GetCommittees(ctx, epoch)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get committees for epoch `%d`", epoch)
+			}
+
+			for _, committee := range committees {
+				key, validators, err := convertCommitteeJsonToProto(committee)
+				if err != nil {
+					return nil, err
+				}
+				committeesMap[key] = validators
+			}
+		}
+
+		if epoch >= params.BeaconConfig().AltairForkEpoch {
+			syncDuties, err := c.dutiesProvider.GetSyncDuties(ctx, epoch, validatorIndices)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get sync duties for epoch `%d`", epoch)
+			}
+
+			for _, syncDuty := range syncDuties {
+				validatorIndex, err := strconv.ParseUint(syncDuty.ValidatorIndex, 10, 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to parse sync validator index `%s`", syncDuty.ValidatorIndex)
+				}
+				syncDutiesMap[types.ValidatorIndex(validatorIndex)] = struct{}{}
+			}
+		}
+	}
+
+	if fetchProposerDuties {
+		proposerDuties, err := c.dutiesProvider.GetProposerDuties(ctx, epoch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get proposer duties for epoch `%d`", epoch)
+		}
+
+		for _, proposerDuty := range proposerDuties {
+			validatorIndex, err := strconv.ParseUint(proposerDuty.ValidatorIndex, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse proposer validator index `%s`", proposerDuty.ValidatorIndex)
+			}
+
+			slot, err := strconv.ParseUint(proposerDuty.Slot, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse proposer slot `%s`", proposerDuty.Slot)
+			}
+
+			proposerSlotsMap[types.ValidatorIndex(validatorIndex)] = append(proposerSlotsMap[types.ValidatorIndex(validatorIndex)], types.Slot(slot))
+		}
+	}
+
+	duties := make([]*ethpb.DutiesResponse_Duty, len(multipleValidatorStatus.PublicKeys))
+	for index, publicKey := range multipleValidatorStatus.PublicKeys {
+		duty := &ethpb.DutiesResponse_Duty{
+			PublicKey: publicKey,
+			Status:    multipleValidatorStatus.Statuses[index].Status,
+		}
+		duties[index] = duty
+
+		if duty.Status == ethpb.ValidatorStatus_UNKNOWN_STATUS {
+			continue
+		}
+
+		validatorIndex := multipleValidatorStatus.Indices[index]
+		duty.ValidatorIndex = validatorIndex
+		duty.ProposerSlots = proposerSlotsMap[validatorIndex]
+		_, duty.IsSyncCommittee = syncDutiesMap[validatorIndex]
+
+		attesterDuty, ok := attesterDutiesMap[validatorIndex]
+		if !ok {
+			continue
+		}
+
+		attesterSlot, err := strconv.ParseUint(attesterDuty.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse attester slot `%s`", attesterDuty.Slot)
+		}
+
+		committeeIndex, err := strconv.ParseUint(attesterDuty.CommitteeIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse attester committee index `%s`", attesterDuty.CommitteeIndex)
+		}
+
+		key := committeeKey{slot: types.Slot(attesterSlot), committeeIndex: types.CommitteeIndex(committeeIndex)}
+		committee, ok := committeesMap[key]
+		if !ok {
+			return nil, errors.Errorf("failed to find committee for slot `%d` and committee index `%d`", attesterSlot, committeeIndex)
+		}
+
+		duty.AttesterSlot = key.slot
+		duty.CommitteeIndex = key.committeeIndex
+		duty.Committee = committee
+	}
+
+	return duties, nil
+}
+
+func convertCommitteeJsonToProto(committee *apimiddleware.CommitteeJson) (committeeKey, []types.ValidatorIndex, error) {
+	if committee == nil {
+		return committeeKey{}, nil, errors.New("committee is nil")
+	}
+
+	slot, err := strconv.ParseUint(committee.Slot, 10, 64)
+	if err != nil {
+		return committeeKey{}, nil, errors.Wrapf(err, "failed to parse committee slot `%s`", committee.Slot)
+	}
+
+	committeeIndex, err := strconv.ParseUint(committee.Index, 10, 64)
+	if err != nil {
+		return committeeKey{}, nil, errors.Wrapf(err, "failed to parse committee index `%s`", committee.Index)
+	}
+
+	validators := make([]types.ValidatorIndex, len(committee.Validators))
+	for index, stringValidatorIndex := range committee.Validators {
+		validatorIndex, err := strconv.ParseUint(stringValidatorIndex, 10, 64)
+		if err != nil {
+			return committeeKey{}, nil, errors.Wrapf(err, "failed to parse committee validator index `%s`", stringValidatorIndex)
+		}
+		validators[index] = types.ValidatorIndex(validatorIndex)
+	}
+
+	return committeeKey{slot: types.Slot(slot), committeeIndex: types.CommitteeIndex(committeeIndex)}, validators, nil
+}
+
 func (c beaconApiDutiesProvider) GetAttesterDuties(ctx context.Context, epoch types.Epoch, validatorIndices []types.ValidatorIndex) ([]*apimiddleware.AttesterDutyJson, error) {
 
 	jsonValidatorIndices := make([]string, len(validatorIndices))
@@ -45,3 +243,77 @@ func (c beaconApiDutiesProvider) GetAttesterDuties(ctx context.Context, epoch ty
 
 	return attesterDuties.Data, nil
 }
+
+// GetProposerDuties retrieves the proposer duties for the given epoch
+func (c beaconApiDutiesProvider) GetProposerDuties(ctx context.Context, epoch types.Epoch) ([]*apimiddleware.ProposerDutyJson, error) {
+	proposerDuties := &apimiddleware.ProposerDutiesResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), proposerDuties); err != nil {
+		return nil, errors.Wrap(err, "failed to query proposer duties for epoch")
+	}
+
+	if proposerDuties.Data == nil {
+		return nil, errors.New("proposer duties data is nil")
+	}
+
+	for index, proposerDuty := range proposerDuties.Data {
+		if proposerDuty == nil {
+			return nil, errors.Errorf("proposer duty at index `%d` is nil", index)
+		}
+	}
+
+	return proposerDuties.Data, nil
+}
+
+// GetSyncDuties retrieves the sync committee duties for the given epoch and validator indices
+func (c beaconApiDutiesProvider) GetSyncDuties(ctx context.Context, epoch types.Epoch, validatorIndices []types.ValidatorIndex) ([]*apimiddleware.SyncCommitteeDuty, error) {
+	jsonValidatorIndices := make([]string, len(validatorIndices))
+	for index, validatorIndex := range validatorIndices {
+		jsonValidatorIndices[index] = strconv.FormatUint(uint64(validatorIndex), 10)
+	}
+
+	validatorIndicesBytes, err := json.Marshal(jsonValidatorIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal validator indices")
+	}
+
+	syncDuties := &apimiddleware.SyncCommitteeDutiesResponseJson{}
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, fmt.Sprintf("/eth/v1/validator/duties/sync/%d", epoch), nil, bytes.NewBuffer(validatorIndicesBytes), syncDuties); err != nil {
+		return nil, errors.Wrap(err, "failed to send POST data to REST endpoint")
+	}
+
+	if syncDuties.Data == nil {
+		return nil, errors.New("sync duties data is nil")
+	}
+
+	for index, syncDuty := range syncDuties.Data {
+		if syncDuty == nil {
+			return nil, errors.Errorf("sync duty at index `%d` is nil", index)
+		}
+	}
+
+	return syncDuties.Data, nil
+}
+
+// GetCommittees retrieves the committees of the head state for the given epoch
+func (c beaconApiDutiesProvider) GetCommittees(ctx context.Context, epoch types.Epoch) ([]*apimiddleware.CommitteeJson, error) {
+	queryParams := neturl.Values{}
+	queryParams.Add("epoch", uint64ToString(epoch))
+	url := buildURL("/eth/v1/beacon/states/head/committees", queryParams)
+
+	stateCommittees := &apimiddleware.StateCommitteesResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, url, stateCommittees); err != nil {
+		return nil, errors.Wrap(err, "failed to query committees for epoch")
+	}
+
+	if stateCommittees.Data == nil {
+		return nil, errors.New("state committees data is nil")
+	}
+
+	for index, committee := range stateCommittees.Data {
+		if committee == nil {
+			return nil, errors.Errorf("committee at index `%d` is nil", index)
+		}
+	}
+
+	return stateCommittees.Data, nil
+}
@@ -11,13 +11,18 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 	"github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/mock"
 )
 
 const getAttesterDutiesTestEndpoint = "/eth/v1/validator/duties/attester"
+const getProposerDutiesTestEndpoint = "/eth/v1/validator/duties/proposer"
+const getSyncDutiesTestEndpoint = "/eth/v1/validator/duties/sync"
+const getCommitteesTestEndpoint = "/eth/v1/beacon/states/head/committees"
 
 func TestGetAttesterDuties_Valid(t *testing.T) {
 	stringValidatorIndices := []string{"2", "9"}
@@ -131,3 +136,483 @@ func TestGetAttesterDuties_NilAttesterDuty(t *testing.T) {
 	_, err := dutiesProvider.GetAttesterDuties(ctx, epoch, nil)
 	assert.ErrorContains(t, "attester duty at index `0` is nil", err)
 }
+
+func TestGetProposerDuties_Valid(t *testing.T) {
+	const epoch = types.Epoch(1)
+
+	expectedProposerDuties := apimiddleware.ProposerDutiesResponseJson{
+		Data: []*apimiddleware.ProposerDutyJson{
+			{
+				Pubkey:         hexutil.Encode([]byte{1}),
+				ValidatorIndex: "2",
+				Slot:           "3",
+			},
+			{
+				Pubkey:         hexutil.Encode([]byte{4}),
+				ValidatorIndex: "5",
+				Slot:           "6",
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("%s/%d", getProposerDutiesTestEndpoint, epoch),
+		&apimiddleware.ProposerDutiesResponseJson{},
+	).Return(
+		nil,
+		nil,
+	).SetArg(
+		2,
+		expectedProposerDuties,
+	).Times(1)
+
+	dutiesProvider := &beaconApiDutiesProvider{jsonRestHandler: jsonRestHandler}
+	proposerDuties, err := dutiesProvider.GetProposerDuties(ctx, epoch)
+	require.NoError(t, err)
+	assert.DeepEqual(t, expectedProposerDuties.Data, proposerDuties)
+}
+
+func TestGetProposerDuties_Invalid(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		data                 []*apimiddleware.ProposerDutyJson
+		httpError            error
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "http error",
+			httpError:            errors.New("foo error"),
+			expectedErrorMessage: "foo error",
+		},
+		{
+			name:                 "nil data",
+			expectedErrorMessage: "proposer duties data is nil",
+		},
+		{
+			name:                 "nil proposer duty",
+			data:                 []*apimiddleware.ProposerDutyJson{nil},
+			expectedErrorMessage: "proposer duty at index `0` is nil",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			const epoch = types.Epoch(1)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().GetRestJsonResponse(
+				ctx,
+				fmt.Sprintf("%s/%d", getProposerDutiesTestEndpoint, epoch),
+				&apimiddleware.ProposerDutiesResponseJson{},
+			).Return(
+				nil,
+				testCase.httpError,
+			).SetArg(
+				2,
+				apimiddleware.ProposerDutiesResponseJson{Data: testCase.data},
+			).Times(1)
+
+			dutiesProvider := &beaconApiDutiesProvider{jsonRestHandler: jsonRestHandler}
+			_, err := dutiesProvider.GetProposerDuties(ctx, epoch)
+			assert.ErrorContains(t, testCase.expectedErrorMessage, err)
+		})
+	}
+}
+
+func TestGetSyncDuties_Valid(t *testing.T) {
+	stringValidatorIndices := []string{"2", "6"}
+	const epoch = types.Epoch(1)
+
+	validatorIndicesBytes, err := json.Marshal(stringValidatorIndices)
+	require.NoError(t, err)
+
+	expectedSyncDuties := apimiddleware.SyncCommitteeDutiesResponseJson{
+		Data: []*apimiddleware.SyncCommitteeDuty{
+			{
+				Pubkey:                        hexutil.Encode([]byte{1}),
+				ValidatorIndex:                "2",
+				ValidatorSyncCommitteeIndices: []string{"3", "4"},
+			},
+			{
+				Pubkey:                        hexutil.Encode([]byte{5}),
+				ValidatorIndex:                "6",
+				ValidatorSyncCommitteeIndices: []string{"7"},
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		fmt.Sprintf("%s/%d", getSyncDutiesTestEndpoint, epoch),
+		nil,
+		bytes.NewBuffer(validatorIndicesBytes),
+		&apimiddleware.SyncCommitteeDutiesResponseJson{},
+	).Return(
+		nil,
+		nil,
+	).SetArg(
+		4,
+		expectedSyncDuties,
+	).Times(1)
+
+	dutiesProvider := &beaconApiDutiesProvider{jsonRestHandler: jsonRestHandler}
+	syncDuties, err := dutiesProvider.GetSyncDuties(ctx, epoch, []types.ValidatorIndex{2, 6})
+	require.NoError(t, err)
+	assert.DeepEqual(t, expectedSyncDuties.Data, syncDuties)
+}
+
+func TestGetSyncDuties_NilSyncDuty(t *testing.T) {
+	const epoch = types.Epoch(1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		fmt.Sprintf("%s/%d", getSyncDutiesTestEndpoint, epoch),
+		nil,
+		gomock.Any(),
+		&apimiddleware.SyncCommitteeDutiesResponseJson{},
+	).Return(
+		nil,
+		nil,
+	).SetArg(
+		4,
+		apimiddleware.SyncCommitteeDutiesResponseJson{Data: []*apimiddleware.SyncCommitteeDuty{nil}},
+	).Times(1)
+
+	dutiesProvider := &beaconApiDutiesProvider{jsonRestHandler: jsonRestHandler}
+	_, err := dutiesProvider.GetSyncDuties(ctx, epoch, []types.ValidatorIndex{2})
+	assert.ErrorContains(t, "sync duty at index `0` is nil", err)
+}
+
+func TestGetCommittees_Valid(t *testing.T) {
+	const epoch = types.Epoch(1)
+
+	expectedCommittees := apimiddleware.StateCommitteesResponseJson{
+		Data: []*apimiddleware.CommitteeJson{
+			{
+				Index:      "2",
+				Slot:       "3",
+				Validators: []string{"4", "5"},
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("%s?epoch=%d", getCommitteesTestEndpoint, epoch),
+		&apimiddleware.StateCommitteesResponseJson{},
+	).Return(
+		nil,
+		nil,
+	).SetArg(
+		2,
+		expectedCommittees,
+	).Times(1)
+
+	dutiesProvider := &beaconApiDutiesProvider{jsonRestHandler: jsonRestHandler}
+	committees, err := dutiesProvider.GetCommittees(ctx, epoch)
+	require.NoError(t, err)
+	assert.DeepEqual(t, expectedCommittees.Data, committees)
+}
+
+func TestGetCommittees_NilData(t *testing.T) {
+	const epoch = types.Epoch(1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("%s?epoch=%d", getCommitteesTestEndpoint, epoch),
+		&apimiddleware.StateCommitteesResponseJson{},
+	).Return(
+		nil,
+		nil,
+	).Times(1)
+
+	dutiesProvider := &beaconApiDutiesProvider{jsonRestHandler: jsonRestHandler}
+	_, err := dutiesProvider.GetCommittees(ctx, epoch)
+	assert.ErrorContains(t, "state committees data is nil", err)
+}
+
+// expectDutiesValidatorsStatus sets up the validators status query done by getDuties: validators 1 and 2 are active
+// and the third public key is unknown to the beacon node.
+func expectDutiesValidatorsStatus(ctx context.Context, ctrl *gomock.Controller, pubkeys [][]byte) *mock.MockstateValidatorsProvider {
+	stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+	stateValidatorsProvider.EXPECT().GetStateValidators(
+		ctx,
+		[]string{hexutil.Encode(pubkeys[0]), hexutil.Encode(pubkeys[1]), hexutil.Encode(pubkeys[2])},
+		nil,
+		nil,
+	).Return(
+		&apimiddleware.StateValidatorsResponseJson{
+			Data: []*apimiddleware.ValidatorContainerJson{
+				{
+					Index:     "1",
+					Status:    "active_ongoing",
+					Validator: &apimiddleware.ValidatorJson{PublicKey: hexutil.Encode(pubkeys[0]), ActivationEpoch: "0"},
+				},
+				{
+					Index:     "2",
+					Status:    "active_ongoing",
+					Validator: &apimiddleware.ValidatorJson{PublicKey: hexutil.Encode(pubkeys[1]), ActivationEpoch: "0"},
+				},
+			},
+		},
+		nil,
+	).Times(1)
+	return stateValidatorsProvider
+}
+
+func TestGetDuties_Valid(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	const epoch = types.Epoch(1)
+	pubkeys := [][]byte{{1}, {2}, {3}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	stateValidatorsProvider := expectDutiesValidatorsStatus(ctx, ctrl, pubkeys)
+
+	dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+	dutiesProvider.EXPECT().GetAttesterDuties(ctx, epoch, []types.ValidatorIndex{1, 2}).Return(
+		[]*apimiddleware.AttesterDutyJson{{ValidatorIndex: "1", Slot: "33", CommitteeIndex: "4"}},
+		nil,
+	).Times(1)
+	dutiesProvider.EXPECT().GetCommittees(ctx, epoch).Return(
+		[]*apimiddleware.CommitteeJson{
+			{Index: "3", Slot: "33", Validators: []string{"8"}},
+			{Index: "4", Slot: "33", Validators: []string{"1", "5"}},
+		},
+		nil,
+	).Times(1)
+	dutiesProvider.EXPECT().GetSyncDuties(ctx, epoch, []types.ValidatorIndex{1, 2}).Return(
+		[]*apimiddleware.SyncCommitteeDuty{{ValidatorIndex: "2", ValidatorSyncCommitteeIndices: []string{"6"}}},
+		nil,
+	).Times(1)
+	dutiesProvider.EXPECT().GetProposerDuties(ctx, epoch).Return(
+		[]*apimiddleware.ProposerDutyJson{
+			{ValidatorIndex: "2", Slot: "34"},
+			{ValidatorIndex: "7", Slot: "35"},
+			{ValidatorIndex: "2", Slot: "36"},
+		},
+		nil,
+	).Times(1)
+
+	dutiesProvider.EXPECT().GetAttesterDuties(ctx, epoch+1, []types.ValidatorIndex{1, 2}).Return(
+		[]*apimiddleware.AttesterDutyJson{{ValidatorIndex: "2", Slot: "70", CommitteeIndex: "0"}},
+		nil,
+	).Times(1)
+	dutiesProvider.EXPECT().GetCommittees(ctx, epoch+1).Return(
+		[]*apimiddleware.CommitteeJson{{Index: "0", Slot: "70", Validators: []string{"9", "2"}}},
+		nil,
+	).Times(1)
+	dutiesProvider.EXPECT().GetSyncDuties(ctx, epoch+1, []types.ValidatorIndex{1, 2}).Return(
+		[]*apimiddleware.SyncCommitteeDuty{},
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{
+		stateValidatorsProvider: stateValidatorsProvider,
+		dutiesProvider:          dutiesProvider,
+	}
+
+	duties, err := validatorClient.GetDuties(ctx, &ethpb.DutiesRequest{Epoch: epoch, PublicKeys: pubkeys})
+	require.NoError(t, err)
+
+	expectedCurrentEpochDuties := []*ethpb.DutiesResponse_Duty{
+		{
+			Committee:      []types.ValidatorIndex{1, 5},
+			CommitteeIndex: 4,
+			AttesterSlot:   33,
+			PublicKey:      pubkeys[0],
+			Status:         ethpb.ValidatorStatus_ACTIVE,
+			ValidatorIndex: 1,
+		},
+		{
+			ProposerSlots:   []types.Slot{34, 36},
+			PublicKey:       pubkeys[1],
+			Status:          ethpb.ValidatorStatus_ACTIVE,
+			ValidatorIndex:  2,
+			IsSyncCommittee: true,
+		},
+		{
+			PublicKey: pubkeys[2],
+			Status:    ethpb.ValidatorStatus_UNKNOWN_STATUS,
+		},
+	}
+	expectedNextEpochDuties := []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:      pubkeys[0],
+			Status:         ethpb.ValidatorStatus_ACTIVE,
+			ValidatorIndex: 1,
+		},
+		{
+			Committee:      []types.ValidatorIndex{9, 2},
+			CommitteeIndex: 0,
+			AttesterSlot:   70,
+			PublicKey:      pubkeys[1],
+			Status:         ethpb.ValidatorStatus_ACTIVE,
+			ValidatorIndex: 2,
+		},
+		{
+			PublicKey: pubkeys[2],
+			Status:    ethpb.ValidatorStatus_UNKNOWN_STATUS,
+		},
+	}
+
+	assert.DeepEqual(t, &ethpb.DutiesResponse{
+		Duties:             expectedCurrentEpochDuties,
+		CurrentEpochDuties: expectedCurrentEpochDuties,
+		NextEpochDuties:    expectedNextEpochDuties,
+	}, duties)
+}
+
+func TestGetDuties_PreAltairSkipsSyncDuties(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+
+	const epoch = types.Epoch(1)
+	pubkeys := [][]byte{{1}, {2}, {3}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	stateValidatorsProvider := expectDutiesValidatorsStatus(ctx, ctrl, pubkeys)
+
+	// GetSyncDuties must not be called before the Altair fork
+	dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+	dutiesProvider.EXPECT().GetAttesterDuties(ctx, gomock.Any(), []types.ValidatorIndex{1, 2}).Return(
+		[]*apimiddleware.AttesterDutyJson{},
+		nil,
+	).Times(2)
+	dutiesProvider.EXPECT().GetProposerDuties(ctx, epoch).Return(
+		[]*apimiddleware.ProposerDutyJson{},
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{
+		stateValidatorsProvider: stateValidatorsProvider,
+		dutiesProvider:          dutiesProvider,
+	}
+
+	duties, err := validatorClient.GetDuties(ctx, &ethpb.DutiesRequest{Epoch: epoch, PublicKeys: pubkeys})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(duties.CurrentEpochDuties))
+	require.Equal(t, 3, len(duties.NextEpochDuties))
+	assert.Equal(t, false, duties.CurrentEpochDuties[1].IsSyncCommittee)
+}
+
+func TestGetDuties_Invalid(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		attesterDuties       []*apimiddleware.AttesterDutyJson
+		committees           []*apimiddleware.CommitteeJson
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "bad attester validator index",
+			attesterDuties:       []*apimiddleware.AttesterDutyJson{{ValidatorIndex: "foo"}},
+			expectedErrorMessage: "failed to parse attester validator index `foo`",
+		},
+		{
+			name:                 "bad committee slot",
+			attesterDuties:       []*apimiddleware.AttesterDutyJson{{ValidatorIndex: "1", Slot: "33", CommitteeIndex: "4"}},
+			committees:           []*apimiddleware.CommitteeJson{{Index: "4", Slot: "foo"}},
+			expectedErrorMessage: "failed to parse committee slot `foo`",
+		},
+		{
+			name:                 "missing committee",
+			attesterDuties:       []*apimiddleware.AttesterDutyJson{{ValidatorIndex: "1", Slot: "33", CommitteeIndex: "4"}},
+			committees:           []*apimiddleware.CommitteeJson{{Index: "3", Slot: "33"}},
+			expectedErrorMessage: "failed to find committee for slot `33` and committee index `4`",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			params.SetupTestConfigCleanup(t)
+			cfg := params.BeaconConfig().Copy()
+			cfg.AltairForkEpoch = 0
+			params.OverrideBeaconConfig(cfg)
+
+			const epoch = types.Epoch(1)
+			pubkeys := [][]byte{{1}, {2}, {3}}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			stateValidatorsProvider := expectDutiesValidatorsStatus(ctx, ctrl, pubkeys)
+
+			dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+			dutiesProvider.EXPECT().GetAttesterDuties(ctx, epoch, []types.ValidatorIndex{1, 2}).Return(
+				testCase.attesterDuties,
+				nil,
+			).Times(1)
+			dutiesProvider.EXPECT().GetCommittees(ctx, epoch).Return(
+				testCase.committees,
+				nil,
+			).AnyTimes()
+			dutiesProvider.EXPECT().GetSyncDuties(ctx, epoch, []types.ValidatorIndex{1, 2}).Return(
+				[]*apimiddleware.SyncCommitteeDuty{},
+				nil,
+			).AnyTimes()
+			dutiesProvider.EXPECT().GetProposerDuties(ctx, epoch).Return(
+				[]*apimiddleware.ProposerDutyJson{},
+				nil,
+			).AnyTimes()
+
+			validatorClient := &beaconApiValidatorClient{
+				stateValidatorsProvider: stateValidatorsProvider,
+				dutiesProvider:          dutiesProvider,
+			}
+
+			_, err := validatorClient.GetDuties(ctx, &ethpb.DutiesRequest{Epoch: epoch, PublicKeys: pubkeys})
+			assert.ErrorContains(t, testCase.expectedErrorMessage, err)
+		})
+	}
+}
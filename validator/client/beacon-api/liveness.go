@@ -0,0 +1,47 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
+)
+
+func (c *beaconApiValidatorClient) liveness(ctx context.Context, in *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	if in == nil {
+		return nil, errors.New("liveness request is nil")
+	}
+
+	indexes := make([]string, len(in.Index))
+	for i, index := range in.Index {
+		indexes[i] = uint64ToString(index)
+	}
+
+	livenessResponse, err := c.getLiveness(ctx, in.Epoch, indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ethpbv2.GetLivenessResponse{
+		Data: make([]*ethpbv2.GetLivenessResponse_Liveness, len(livenessResponse.Data)),
+	}
+	for i, liveness := range livenessResponse.Data {
+		if liveness == nil {
+			return nil, errors.New("liveness is nil")
+		}
+
+		index, err := strconv.ParseUint(liveness.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index `%s`", liveness.Index)
+		}
+
+		response.Data[i] = &ethpbv2.GetLivenessResponse_Liveness{
+			Index:  types.ValidatorIndex(index),
+			IsLive: liveness.IsLive,
+		}
+	}
+
+	return response, nil
+}
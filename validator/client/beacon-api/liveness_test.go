@@ -0,0 +1,96 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/mock"
+)
+
+type livenessJson = struct {
+	Index  string `json:"index"`
+	IsLive bool   `json:"is_live"`
+}
+
+func TestGetLiveness(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		responseJson         *apimiddleware.LivenessResponseJson
+		endpointError        error
+		expectedErrorMessage string
+	}{
+		{
+			name: "valid",
+			responseJson: &apimiddleware.LivenessResponseJson{
+				Data: []*livenessJson{{Index: "1", IsLive: true}, {Index: "2", IsLive: false}},
+			},
+		},
+		{
+			name:                 "endpoint error",
+			responseJson:         &apimiddleware.LivenessResponseJson{},
+			endpointError:        errors.New("foo error"),
+			expectedErrorMessage: "failed to send POST data to `/eth/v1/validator/liveness/3` REST URL: foo error",
+		},
+		{
+			name: "nil liveness",
+			responseJson: &apimiddleware.LivenessResponseJson{
+				Data: []*livenessJson{nil},
+			},
+			expectedErrorMessage: "liveness is nil",
+		},
+		{
+			name: "bad validator index",
+			responseJson: &apimiddleware.LivenessResponseJson{
+				Data: []*livenessJson{{Index: "foo"}},
+			},
+			expectedErrorMessage: "failed to parse validator index `foo`",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			marshalledIndexes, err := json.Marshal([]string{"1", "2"})
+			require.NoError(t, err)
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().PostRestJson(
+				ctx,
+				"/eth/v1/validator/liveness/3",
+				nil,
+				bytes.NewBuffer(marshalledIndexes),
+				&apimiddleware.LivenessResponseJson{},
+			).SetArg(
+				4,
+				*testCase.responseJson,
+			).Return(
+				nil,
+				testCase.endpointError,
+			).Times(1)
+
+			validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+			liveness, err := validatorClient.GetLiveness(ctx, &ethpbv2.GetLivenessRequest{Epoch: 3, Index: []types.ValidatorIndex{1, 2}})
+			if testCase.expectedErrorMessage != "" {
+				assert.ErrorContains(t, testCase.expectedErrorMessage, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.DeepEqual(t, &ethpbv2.GetLivenessResponse{
+				Data: []*ethpbv2.GetLivenessResponse_Liveness{{Index: 1, IsLive: true}, {Index: 2, IsLive: false}},
+			}, liveness)
+		})
+	}
+}
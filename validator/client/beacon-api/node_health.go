@@ -0,0 +1,55 @@
+package beacon_api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+)
+
+type beaconApiNodeHealthClient struct {
+	validatorClient *beaconApiValidatorClient
+}
+
+func NewBeaconApiNodeHealthClient(host string, timeout time.Duration) iface.NodeHealthClient {
+	jsonRestHandler := beaconApiJsonRestHandler{
+		httpClient: http.Client{Timeout: timeout},
+		host:       host,
+	}
+
+	return &beaconApiNodeHealthClient{
+		validatorClient: &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler},
+	}
+}
+
+func (c *beaconApiNodeHealthClient) SyncStatus(ctx context.Context) (*ethpbv1.SyncInfo, error) {
+	response, err := c.validatorClient.getSyncing(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil || response.Data == nil {
+		return nil, errors.New("sync status is nil")
+	}
+
+	headSlot, err := strconv.ParseUint(response.Data.HeadSlot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse head slot `%s`", response.Data.HeadSlot)
+	}
+
+	syncDistance, err := strconv.ParseUint(response.Data.SyncDistance, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse sync distance `%s`", response.Data.SyncDistance)
+	}
+
+	return &ethpbv1.SyncInfo{
+		HeadSlot:     types.Slot(headSlot),
+		SyncDistance: types.Slot(syncDistance),
+		IsSyncing:    response.Data.IsSyncing,
+		IsOptimistic: response.Data.IsOptimistic,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package beacon_api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/helpers"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/mock"
+)
+
+func TestSyncStatus(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		responseJson         *apimiddleware.SyncingResponseJson
+		endpointError        error
+		expectedErrorMessage string
+	}{
+		{
+			name: "valid",
+			responseJson: &apimiddleware.SyncingResponseJson{
+				Data: &helpers.SyncDetailsJson{HeadSlot: "123", SyncDistance: "4", IsSyncing: true, IsOptimistic: true},
+			},
+		},
+		{
+			name:                 "endpoint error",
+			responseJson:         &apimiddleware.SyncingResponseJson{},
+			endpointError:        errors.New("foo error"),
+			expectedErrorMessage: "failed to get json response from `/eth/v1/node/syncing` REST endpoint: foo error",
+		},
+		{
+			name:                 "nil data",
+			responseJson:         &apimiddleware.SyncingResponseJson{},
+			expectedErrorMessage: "sync status is nil",
+		},
+		{
+			name: "bad head slot",
+			responseJson: &apimiddleware.SyncingResponseJson{
+				Data: &helpers.SyncDetailsJson{HeadSlot: "foo", SyncDistance: "4"},
+			},
+			expectedErrorMessage: "failed to parse head slot `foo`",
+		},
+		{
+			name: "bad sync distance",
+			responseJson: &apimiddleware.SyncingResponseJson{
+				Data: &helpers.SyncDetailsJson{HeadSlot: "123", SyncDistance: "bar"},
+			},
+			expectedErrorMessage: "failed to parse sync distance `bar`",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().GetRestJsonResponse(
+				ctx,
+				syncingEnpoint,
+				&apimiddleware.SyncingResponseJson{},
+			).SetArg(
+				2,
+				*testCase.responseJson,
+			).Return(
+				nil,
+				testCase.endpointError,
+			).Times(1)
+
+			healthClient := &beaconApiNodeHealthClient{
+				validatorClient: &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler},
+			}
+
+			syncInfo, err := healthClient.SyncStatus(ctx)
+			if testCase.expectedErrorMessage != "" {
+				require.ErrorContains(t, testCase.expectedErrorMessage, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, types.Slot(123), syncInfo.HeadSlot)
+			assert.Equal(t, types.Slot(4), syncInfo.SyncDistance)
+			assert.Equal(t, true, syncInfo.IsSyncing)
+			assert.Equal(t, true, syncInfo.IsOptimistic)
+		})
+	}
+}
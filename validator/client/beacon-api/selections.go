@@ -0,0 +1,124 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+)
+
+func (c *beaconApiValidatorClient) getAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	const endpoint = "/eth/v1/validator/beacon_committee_selections"
+
+	jsonSelections := make([]*apimiddleware.BeaconCommitteeSelectionJson, len(selections))
+	for i, selection := range selections {
+		jsonSelections[i] = &apimiddleware.BeaconCommitteeSelectionJson{
+			ValidatorIndex: uint64ToString(selection.ValidatorIndex),
+			Slot:           uint64ToString(selection.Slot),
+			SelectionProof: hexutil.Encode(selection.SelectionProof),
+		}
+	}
+
+	marshalledSelections, err := json.Marshal(jsonSelections)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal beacon committee selections")
+	}
+
+	responseJson := &apimiddleware.AggregatedBeaconCommitteeSelectionsResponseJson{}
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, endpoint, nil, bytes.NewBuffer(marshalledSelections), responseJson); err != nil {
+		return nil, errors.Wrapf(err, "failed to send POST data to `%s` REST endpoint", endpoint)
+	}
+	if len(responseJson.Data) == 0 {
+		return nil, errors.New("no aggregated beacon committee selections returned")
+	}
+
+	aggregatedSelections := make([]iface.BeaconCommitteeSelection, len(responseJson.Data))
+	for i, jsonSelection := range responseJson.Data {
+		if jsonSelection == nil {
+			return nil, errors.Errorf("aggregated beacon committee selection at index `%d` is nil", i)
+		}
+		validatorIndex, err := strconv.ParseUint(jsonSelection.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index `%s`", jsonSelection.ValidatorIndex)
+		}
+		slot, err := strconv.ParseUint(jsonSelection.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse slot `%s`", jsonSelection.Slot)
+		}
+		selectionProof, err := hexutil.Decode(jsonSelection.SelectionProof)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode selection proof `%s`", jsonSelection.SelectionProof)
+		}
+		aggregatedSelections[i] = iface.BeaconCommitteeSelection{
+			SelectionProof: selectionProof,
+			Slot:           types.Slot(slot),
+			ValidatorIndex: types.ValidatorIndex(validatorIndex),
+		}
+	}
+
+	return aggregatedSelections, nil
+}
+
+func (c *beaconApiValidatorClient) getAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	const endpoint = "/eth/v1/validator/sync_committee_selections"
+
+	jsonSelections := make([]*apimiddleware.SyncCommitteeSelectionJson, len(selections))
+	for i, selection := range selections {
+		jsonSelections[i] = &apimiddleware.SyncCommitteeSelectionJson{
+			ValidatorIndex:    uint64ToString(selection.ValidatorIndex),
+			Slot:              uint64ToString(selection.Slot),
+			SubcommitteeIndex: uint64ToString(selection.SubcommitteeIndex),
+			SelectionProof:    hexutil.Encode(selection.SelectionProof),
+		}
+	}
+
+	marshalledSelections, err := json.Marshal(jsonSelections)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal sync committee selections")
+	}
+
+	responseJson := &apimiddleware.AggregatedSyncCommitteeSelectionsResponseJson{}
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, endpoint, nil, bytes.NewBuffer(marshalledSelections), responseJson); err != nil {
+		return nil, errors.Wrapf(err, "failed to send POST data to `%s` REST endpoint", endpoint)
+	}
+	if len(responseJson.Data) == 0 {
+		return nil, errors.New("no aggregated sync committee selections returned")
+	}
+
+	aggregatedSelections := make([]iface.SyncCommitteeSelection, len(responseJson.Data))
+	for i, jsonSelection := range responseJson.Data {
+		if jsonSelection == nil {
+			return nil, errors.Errorf("aggregated sync committee selection at index `%d` is nil", i)
+		}
+		validatorIndex, err := strconv.ParseUint(jsonSelection.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index `%s`", jsonSelection.ValidatorIndex)
+		}
+		slot, err := strconv.ParseUint(jsonSelection.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse slot `%s`", jsonSelection.Slot)
+		}
+		subcommitteeIndex, err := strconv.ParseUint(jsonSelection.SubcommitteeIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse subcommittee index `%s`", jsonSelection.SubcommitteeIndex)
+		}
+		selectionProof, err := hexutil.Decode(jsonSelection.SelectionProof)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode selection proof `%s`", jsonSelection.SelectionProof)
+		}
+		aggregatedSelections[i] = iface.SyncCommitteeSelection{
+			SelectionProof:    selectionProof,
+			Slot:              types.Slot(slot),
+			SubcommitteeIndex: types.CommitteeIndex(subcommitteeIndex),
+			ValidatorIndex:    types.ValidatorIndex(validatorIndex),
+		}
+	}
+
+	return aggregatedSelections, nil
+}
@@ -0,0 +1,183 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/mock"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+)
+
+const (
+	beaconCommitteeSelectionsEndpoint = "/eth/v1/validator/beacon_committee_selections"
+	syncCommitteeSelectionsEndpoint   = "/eth/v1/validator/sync_committee_selections"
+)
+
+func TestGetAggregatedSelections(t *testing.T) {
+	partialProof := []byte{0x01, 0x02}
+	aggregatedProof := []byte{0x03, 0x04}
+
+	testCases := []struct {
+		name                 string
+		responseJson         *apimiddleware.AggregatedBeaconCommitteeSelectionsResponseJson
+		endpointError        error
+		expectedErrorMessage string
+	}{
+		{
+			name: "valid",
+			responseJson: &apimiddleware.AggregatedBeaconCommitteeSelectionsResponseJson{
+				Data: []*apimiddleware.BeaconCommitteeSelectionJson{
+					{ValidatorIndex: "1", Slot: "2", SelectionProof: hexutil.Encode(aggregatedProof)},
+				},
+			},
+		},
+		{
+			name:                 "endpoint error",
+			responseJson:         &apimiddleware.AggregatedBeaconCommitteeSelectionsResponseJson{},
+			endpointError:        errors.New("foo error"),
+			expectedErrorMessage: "failed to send POST data to `/eth/v1/validator/beacon_committee_selections` REST endpoint: foo error",
+		},
+		{
+			name:                 "no selections",
+			responseJson:         &apimiddleware.AggregatedBeaconCommitteeSelectionsResponseJson{},
+			expectedErrorMessage: "no aggregated beacon committee selections returned",
+		},
+		{
+			name: "bad selection proof",
+			responseJson: &apimiddleware.AggregatedBeaconCommitteeSelectionsResponseJson{
+				Data: []*apimiddleware.BeaconCommitteeSelectionJson{
+					{ValidatorIndex: "1", Slot: "2", SelectionProof: "foo"},
+				},
+			},
+			expectedErrorMessage: "failed to decode selection proof `foo`",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			marshalledSelections, err := json.Marshal([]*apimiddleware.BeaconCommitteeSelectionJson{
+				{ValidatorIndex: "1", Slot: "2", SelectionProof: hexutil.Encode(partialProof)},
+			})
+			require.NoError(t, err)
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().PostRestJson(
+				ctx,
+				beaconCommitteeSelectionsEndpoint,
+				nil,
+				bytes.NewBuffer(marshalledSelections),
+				&apimiddleware.AggregatedBeaconCommitteeSelectionsResponseJson{},
+			).SetArg(
+				4,
+				*testCase.responseJson,
+			).Return(
+				nil,
+				testCase.endpointError,
+			).Times(1)
+
+			validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+			selections, err := validatorClient.GetAggregatedSelections(ctx, []iface.BeaconCommitteeSelection{
+				{SelectionProof: partialProof, Slot: 2, ValidatorIndex: 1},
+			})
+			if testCase.expectedErrorMessage != "" {
+				assert.ErrorContains(t, testCase.expectedErrorMessage, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.DeepEqual(t, []iface.BeaconCommitteeSelection{
+				{SelectionProof: aggregatedProof, Slot: 2, ValidatorIndex: 1},
+			}, selections)
+		})
+	}
+}
+
+func TestGetAggregatedSyncSelections(t *testing.T) {
+	partialProof := []byte{0x01, 0x02}
+	aggregatedProof := []byte{0x03, 0x04}
+
+	testCases := []struct {
+		name                 string
+		responseJson         *apimiddleware.AggregatedSyncCommitteeSelectionsResponseJson
+		endpointError        error
+		expectedErrorMessage string
+	}{
+		{
+			name: "valid",
+			responseJson: &apimiddleware.AggregatedSyncCommitteeSelectionsResponseJson{
+				Data: []*apimiddleware.SyncCommitteeSelectionJson{
+					{ValidatorIndex: "1", Slot: "2", SubcommitteeIndex: "3", SelectionProof: hexutil.Encode(aggregatedProof)},
+				},
+			},
+		},
+		{
+			name:                 "endpoint error",
+			responseJson:         &apimiddleware.AggregatedSyncCommitteeSelectionsResponseJson{},
+			endpointError:        errors.New("foo error"),
+			expectedErrorMessage: "failed to send POST data to `/eth/v1/validator/sync_committee_selections` REST endpoint: foo error",
+		},
+		{
+			name: "bad subcommittee index",
+			responseJson: &apimiddleware.AggregatedSyncCommitteeSelectionsResponseJson{
+				Data: []*apimiddleware.SyncCommitteeSelectionJson{
+					{ValidatorIndex: "1", Slot: "2", SubcommitteeIndex: "foo", SelectionProof: hexutil.Encode(aggregatedProof)},
+				},
+			},
+			expectedErrorMessage: "failed to parse subcommittee index `foo`",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			marshalledSelections, err := json.Marshal([]*apimiddleware.SyncCommitteeSelectionJson{
+				{ValidatorIndex: "1", Slot: "2", SubcommitteeIndex: "3", SelectionProof: hexutil.Encode(partialProof)},
+			})
+			require.NoError(t, err)
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().PostRestJson(
+				ctx,
+				syncCommitteeSelectionsEndpoint,
+				nil,
+				bytes.NewBuffer(marshalledSelections),
+				&apimiddleware.AggregatedSyncCommitteeSelectionsResponseJson{},
+			).SetArg(
+				4,
+				*testCase.responseJson,
+			).Return(
+				nil,
+				testCase.endpointError,
+			).Times(1)
+
+			validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+			selections, err := validatorClient.GetAggregatedSyncSelections(ctx, []iface.SyncCommitteeSelection{
+				{SelectionProof: partialProof, Slot: 2, SubcommitteeIndex: 3, ValidatorIndex: 1},
+			})
+			if testCase.expectedErrorMessage != "" {
+				assert.ErrorContains(t, testCase.expectedErrorMessage, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.DeepEqual(t, []iface.SyncCommitteeSelection{
+				{SelectionProof: aggregatedProof, Slot: 2, SubcommitteeIndex: 3, ValidatorIndex: 1},
+			}, selections)
+		})
+	}
+}
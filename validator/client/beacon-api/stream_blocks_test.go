@@ -0,0 +1,152 @@
+package beacon_api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/mock"
+	test_helpers "github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/test-helpers"
+)
+
+const streamBlocksTestEndpoint = "/eth/v2/beacon/blocks/head"
+
+func TestStreamBlocks_Valid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	signature := []byte{1}
+
+	phase0JsonBlock := test_helpers.GenerateJsonPhase0BeaconBlock()
+	phase0BlockBytes, err := json.Marshal(apimiddleware.SignedBeaconBlockContainerJson{
+		Message:   phase0JsonBlock,
+		Signature: hexutil.Encode(signature),
+	})
+	require.NoError(t, err)
+
+	capellaJsonBlock := test_helpers.GenerateJsonCapellaBeaconBlock()
+	capellaJsonBlock.Slot = "2"
+	capellaBlockBytes, err := json.Marshal(apimiddleware.SignedBeaconBlockCapellaContainerJson{
+		Message:   capellaJsonBlock,
+		Signature: hexutil.Encode(signature),
+	})
+	require.NoError(t, err)
+
+	// The head is the phase0 block for the first two queries, then the capella block
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	gomock.InOrder(
+		jsonRestHandler.EXPECT().GetRestJsonResponse(
+			ctx,
+			streamBlocksTestEndpoint,
+			&abstractSignedBlockResponseJson{},
+		).Return(
+			nil,
+			nil,
+		).SetArg(
+			2,
+			abstractSignedBlockResponseJson{Version: "phase0", Data: phase0BlockBytes},
+		).Times(2),
+		jsonRestHandler.EXPECT().GetRestJsonResponse(
+			ctx,
+			streamBlocksTestEndpoint,
+			&abstractSignedBlockResponseJson{},
+		).Return(
+			nil,
+			nil,
+		).SetArg(
+			2,
+			abstractSignedBlockResponseJson{Version: "capella", Data: capellaBlockBytes},
+		).Times(1),
+	)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+	stream := validatorClient.streamBlocks(ctx, &ethpb.StreamBlocksRequest{VerifiedOnly: true}, time.Millisecond)
+
+	expectedPhase0Block := test_helpers.GenerateProtoPhase0BeaconBlock()
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ethpb.StreamBlocksResponse{
+		Block: &ethpb.StreamBlocksResponse_Phase0Block{
+			Phase0Block: &ethpb.SignedBeaconBlock{Block: expectedPhase0Block, Signature: signature},
+		},
+	}, resp)
+
+	expectedCapellaBlock := test_helpers.GenerateProtoCapellaBeaconBlock()
+	expectedCapellaBlock.Slot = 2
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ethpb.StreamBlocksResponse{
+		Block: &ethpb.StreamBlocksResponse_CapellaBlock{
+			CapellaBlock: &ethpb.SignedBeaconBlockCapella{Block: expectedCapellaBlock, Signature: signature},
+		},
+	}, resp)
+}
+
+func TestStreamBlocks_Invalid(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		version              string
+		data                 interface{}
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "unsupported version",
+			version:              "foo",
+			data:                 apimiddleware.SignedBeaconBlockContainerJson{},
+			expectedErrorMessage: "unsupported consensus version `foo`",
+		},
+		{
+			name:                 "nil message",
+			version:              "altair",
+			data:                 apimiddleware.SignedBeaconBlockAltairContainerJson{},
+			expectedErrorMessage: "signed altair block message is nil",
+		},
+		{
+			name:    "bad signature",
+			version: "bellatrix",
+			data: apimiddleware.SignedBeaconBlockBellatrixContainerJson{
+				Message:   test_helpers.GenerateJsonBellatrixBeaconBlock(),
+				Signature: "foo",
+			},
+			expectedErrorMessage: "failed to decode bellatrix block signature `foo`",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			dataBytes, err := json.Marshal(testCase.data)
+			require.NoError(t, err)
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().GetRestJsonResponse(
+				ctx,
+				streamBlocksTestEndpoint,
+				&abstractSignedBlockResponseJson{},
+			).Return(
+				nil,
+				nil,
+			).SetArg(
+				2,
+				abstractSignedBlockResponseJson{Version: testCase.version, Data: dataBytes},
+			).Times(1)
+
+			validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+			stream := validatorClient.streamBlocks(ctx, &ethpb.StreamBlocksRequest{}, time.Millisecond)
+			_, err = stream.Recv()
+			assert.ErrorContains(t, testCase.expectedErrorMessage, err)
+		})
+	}
+}
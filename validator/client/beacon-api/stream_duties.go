@@ -0,0 +1,73 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"google.golang.org/grpc"
+)
+
+func (c *beaconApiValidatorClient) streamDuties(ctx context.Context, in *ethpb.DutiesRequest) (ethpb.BeaconNodeValidator_StreamDutiesClient, error) {
+	return &streamDutiesClient{
+		ctx:                      ctx,
+		beaconApiValidatorClient: c,
+		dutiesRequest:            in,
+	}, nil
+}
+
+// streamDutiesClient emulates the gRPC duties stream by polling the duties endpoints once per epoch.
+type streamDutiesClient struct {
+	grpc.ClientStream
+	ctx                      context.Context
+	beaconApiValidatorClient *beaconApiValidatorClient
+	dutiesRequest            *ethpb.DutiesRequest
+	genesisTime              uint64
+	nextEpoch                types.Epoch
+	started                  bool
+}
+
+// Recv returns the duties of the requested epoch on the first call. Every following call blocks until
+// the start of the next epoch and returns the duties of that epoch.
+func (c *streamDutiesClient) Recv() (*ethpb.DutiesResponse, error) {
+	if !c.started {
+		c.started = true
+		c.nextEpoch = c.dutiesRequest.Epoch + 1
+		return c.beaconApiValidatorClient.getDuties(c.ctx, c.dutiesRequest)
+	}
+
+	if c.genesisTime == 0 {
+		genesis, _, err := c.beaconApiValidatorClient.genesisProvider.GetGenesis(c.ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get genesis")
+		}
+
+		genesisTime, err := strconv.ParseUint(genesis.GenesisTime, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse genesis time `%s`", genesis.GenesisTime)
+		}
+		c.genesisTime = genesisTime
+	}
+
+	epochStartSlot, err := slots.EpochStart(c.nextEpoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get start slot of epoch `%d`", c.nextEpoch)
+	}
+
+	select {
+	case <-time.After(time.Until(slots.StartTime(c.genesisTime, epochStartSlot))):
+		epoch := c.nextEpoch
+		c.nextEpoch++
+
+		return c.beaconApiValidatorClient.getDuties(c.ctx, &ethpb.DutiesRequest{
+			Epoch:      epoch,
+			PublicKeys: c.dutiesRequest.PublicKeys,
+		})
+	case <-c.ctx.Done():
+		return nil, errors.New("context canceled")
+	}
+}
@@ -0,0 +1,126 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/mock"
+)
+
+func TestStreamDuties_Valid(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 100
+	params.OverrideBeaconConfig(cfg)
+
+	const epoch = types.Epoch(1)
+	pubkey := []byte{1}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+	stateValidatorsProvider.EXPECT().GetStateValidators(
+		ctx,
+		[]string{hexutil.Encode(pubkey)},
+		nil,
+		nil,
+	).Return(
+		&apimiddleware.StateValidatorsResponseJson{
+			Data: []*apimiddleware.ValidatorContainerJson{
+				{
+					Index:     "1",
+					Status:    "active_ongoing",
+					Validator: &apimiddleware.ValidatorJson{PublicKey: hexutil.Encode(pubkey), ActivationEpoch: "0"},
+				},
+			},
+		},
+		nil,
+	).Times(2)
+
+	dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+	dutiesProvider.EXPECT().GetAttesterDuties(ctx, gomock.Any(), []types.ValidatorIndex{1}).Return(
+		[]*apimiddleware.AttesterDutyJson{},
+		nil,
+	).Times(4)
+
+	// Only the requested epoch and the following one should be queried for proposer duties
+	dutiesProvider.EXPECT().GetProposerDuties(ctx, epoch).Return(
+		[]*apimiddleware.ProposerDutyJson{{ValidatorIndex: "1", Slot: "33"}},
+		nil,
+	).Times(1)
+	dutiesProvider.EXPECT().GetProposerDuties(ctx, epoch+1).Return(
+		[]*apimiddleware.ProposerDutyJson{{ValidatorIndex: "1", Slot: "65"}},
+		nil,
+	).Times(1)
+
+	// The genesis is far enough in the past for the next epoch to have already started
+	genesisProvider := mock.NewMockgenesisProvider(ctrl)
+	genesisProvider.EXPECT().GetGenesis(ctx).Return(
+		&apimiddleware.GenesisResponse_GenesisJson{
+			GenesisTime: strconv.FormatInt(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot)*100, 10),
+		},
+		nil,
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{
+		stateValidatorsProvider: stateValidatorsProvider,
+		dutiesProvider:          dutiesProvider,
+		genesisProvider:         genesisProvider,
+	}
+
+	stream, err := validatorClient.StreamDuties(ctx, &ethpb.DutiesRequest{Epoch: epoch, PublicKeys: [][]byte{pubkey}})
+	require.NoError(t, err)
+
+	duties, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(duties.CurrentEpochDuties))
+	assert.DeepEqual(t, []types.Slot{33}, duties.CurrentEpochDuties[0].ProposerSlots)
+
+	duties, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(duties.CurrentEpochDuties))
+	assert.DeepEqual(t, []types.Slot{65}, duties.CurrentEpochDuties[0].ProposerSlots)
+}
+
+func TestStreamDuties_ContextCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The genesis is in the future, so the next epoch never starts before the context is canceled
+	genesisProvider := mock.NewMockgenesisProvider(ctrl)
+	genesisProvider.EXPECT().GetGenesis(ctx).Return(
+		&apimiddleware.GenesisResponse_GenesisJson{
+			GenesisTime: strconv.FormatInt(time.Now().Unix()+3600, 10),
+		},
+		nil,
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{genesisProvider: genesisProvider}
+	stream := &streamDutiesClient{
+		ctx:                      ctx,
+		beaconApiValidatorClient: validatorClient,
+		dutiesRequest:            &ethpb.DutiesRequest{},
+		started:                  true,
+	}
+
+	cancel()
+	_, err := stream.Recv()
+	assert.ErrorContains(t, "context canceled", err)
+}
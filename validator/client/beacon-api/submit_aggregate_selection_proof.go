@@ -0,0 +1,99 @@
+package beacon_api
+
+import (
+	"context"
+	neturl "net/url"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+func (c *beaconApiValidatorClient) submitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	validatorIndexResponse, err := c.validatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: in.PublicKey})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get validator index")
+	}
+
+	committees, err := c.dutiesProvider.GetCommittees(ctx, slots.ToEpoch(in.Slot))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get committees")
+	}
+
+	var committeeLength uint64
+	committeeFound := false
+	for _, committee := range committees {
+		key, validators, err := convertCommitteeJsonToProto(committee)
+		if err != nil {
+			return nil, err
+		}
+
+		if key.slot == in.Slot && key.committeeIndex == in.CommitteeIndex {
+			committeeLength = uint64(len(validators))
+			committeeFound = true
+			break
+		}
+	}
+
+	if !committeeFound {
+		return nil, errors.Errorf("failed to find committee for slot `%d` and committee index `%d`", in.Slot, in.CommitteeIndex)
+	}
+
+	isAggregator, err := helpers.IsAggregator(committeeLength, in.SlotSignature)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get aggregator status")
+	}
+	if !isAggregator {
+		return nil, errors.New("validator is not an aggregator")
+	}
+
+	attestationData, err := c.getAttestationData(ctx, in.Slot, in.CommitteeIndex)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get attestation data for slot `%d` and committee index `%d`", in.Slot, in.CommitteeIndex)
+	}
+
+	attestationDataRoot, err := attestationData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate attestation data root")
+	}
+
+	aggregateAttestation, err := c.getAggregateAttestation(ctx, in.Slot, attestationDataRoot[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ethpb.AggregateSelectionResponse{
+		AggregateAndProof: &ethpb.AggregateAttestationAndProof{
+			AggregatorIndex: validatorIndexResponse.Index,
+			Aggregate:       aggregateAttestation,
+			SelectionProof:  in.SlotSignature,
+		},
+	}, nil
+}
+
+func (c *beaconApiValidatorClient) getAggregateAttestation(ctx context.Context, slot types.Slot, attestationDataRoot []byte) (*ethpb.Attestation, error) {
+	params := neturl.Values{}
+	params.Add("slot", uint64ToString(slot))
+	params.Add("attestation_data_root", hexutil.Encode(attestationDataRoot))
+	endpoint := buildURL("/eth/v1/validator/aggregate_attestation", params)
+
+	aggregateAttestationResponse := apimiddleware.AggregateAttestationResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, endpoint, &aggregateAttestationResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to query GET REST endpoint")
+	}
+
+	if aggregateAttestationResponse.Data == nil {
+		return nil, errors.New("aggregate attestation data is nil")
+	}
+
+	attestations, err := convertAttestationsToProto([]*apimiddleware.AttestationJson{aggregateAttestationResponse.Data})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert aggregate attestation json to proto")
+	}
+
+	return attestations[0], nil
+}
@@ -0,0 +1,187 @@
+package beacon_api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/mock"
+	test_helpers "github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api/test-helpers"
+)
+
+func TestSubmitAggregateSelectionProof_Valid(t *testing.T) {
+	const slot = types.Slot(123)
+	const committeeIndex = types.CommitteeIndex(1)
+	const validatorIndex = types.ValidatorIndex(55)
+	pubkey := []byte{1}
+	slotSignature := []byte{2}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+	stateValidatorsProvider.EXPECT().GetStateValidators(
+		ctx,
+		[]string{hexutil.Encode(pubkey)},
+		nil,
+		nil,
+	).Return(
+		&apimiddleware.StateValidatorsResponseJson{
+			Data: []*apimiddleware.ValidatorContainerJson{{Index: "55"}},
+		},
+		nil,
+	).Times(1)
+
+	// With such a small committee, every validator is an aggregator
+	dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+	dutiesProvider.EXPECT().GetCommittees(ctx, slots.ToEpoch(slot)).Return(
+		[]*apimiddleware.CommitteeJson{
+			{Index: "0", Slot: "123", Validators: []string{"1"}},
+			{Index: "1", Slot: "123", Validators: []string{"55", "56"}},
+		},
+		nil,
+	).Times(1)
+
+	attestationDataResponse := generateValidAttestation(uint64(slot), uint64(committeeIndex))
+	attestationData, err := convertAttestationDataToProto(attestationDataResponse.Data)
+	require.NoError(t, err)
+	attestationDataRoot, err := attestationData.HashTreeRoot()
+	require.NoError(t, err)
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("/eth/v1/validator/attestation_data?committee_index=%d&slot=%d", committeeIndex, slot),
+		&apimiddleware.ProduceAttestationDataResponseJson{},
+	).Return(
+		nil,
+		nil,
+	).SetArg(
+		2,
+		attestationDataResponse,
+	).Times(1)
+
+	aggregateSignature := test_helpers.FillByteSlice(96, 3)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("/eth/v1/validator/aggregate_attestation?attestation_data_root=%s&slot=%d", hexutil.Encode(attestationDataRoot[:]), slot),
+		&apimiddleware.AggregateAttestationResponseJson{},
+	).Return(
+		nil,
+		nil,
+	).SetArg(
+		2,
+		apimiddleware.AggregateAttestationResponseJson{
+			Data: &apimiddleware.AttestationJson{
+				AggregationBits: "0x07",
+				Data:            attestationDataResponse.Data,
+				Signature:       hexutil.Encode(aggregateSignature),
+			},
+		},
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{
+		jsonRestHandler:         jsonRestHandler,
+		stateValidatorsProvider: stateValidatorsProvider,
+		dutiesProvider:          dutiesProvider,
+	}
+
+	resp, err := validatorClient.SubmitAggregateSelectionProof(ctx, &ethpb.AggregateSelectionRequest{
+		Slot:           slot,
+		CommitteeIndex: committeeIndex,
+		PublicKey:      pubkey,
+		SlotSignature:  slotSignature,
+	})
+	require.NoError(t, err)
+
+	expectedResponse := &ethpb.AggregateSelectionResponse{
+		AggregateAndProof: &ethpb.AggregateAttestationAndProof{
+			AggregatorIndex: validatorIndex,
+			Aggregate: &ethpb.Attestation{
+				AggregationBits: []byte{7},
+				Data:            attestationData,
+				Signature:       aggregateSignature,
+			},
+			SelectionProof: slotSignature,
+		},
+	}
+	assert.DeepEqual(t, expectedResponse, resp)
+}
+
+func TestSubmitAggregateSelectionProof_Invalid(t *testing.T) {
+	const slot = types.Slot(123)
+	const committeeIndex = types.CommitteeIndex(1)
+
+	largeCommittee := make([]string, 1000)
+	for index := range largeCommittee {
+		largeCommittee[index] = "1"
+	}
+
+	testCases := []struct {
+		name                 string
+		committees           []*apimiddleware.CommitteeJson
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "missing committee",
+			committees:           []*apimiddleware.CommitteeJson{{Index: "0", Slot: "123"}},
+			expectedErrorMessage: "failed to find committee for slot `123` and committee index `1`",
+		},
+		{
+			name:                 "not an aggregator",
+			committees:           []*apimiddleware.CommitteeJson{{Index: "1", Slot: "123", Validators: largeCommittee}},
+			expectedErrorMessage: "validator is not an aggregator",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+			stateValidatorsProvider.EXPECT().GetStateValidators(
+				ctx,
+				gomock.Any(),
+				nil,
+				nil,
+			).Return(
+				&apimiddleware.StateValidatorsResponseJson{
+					Data: []*apimiddleware.ValidatorContainerJson{{Index: "55"}},
+				},
+				nil,
+			).Times(1)
+
+			dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+			dutiesProvider.EXPECT().GetCommittees(ctx, slots.ToEpoch(slot)).Return(
+				testCase.committees,
+				nil,
+			).Times(1)
+
+			validatorClient := &beaconApiValidatorClient{
+				stateValidatorsProvider: stateValidatorsProvider,
+				dutiesProvider:          dutiesProvider,
+			}
+
+			_, err := validatorClient.SubmitAggregateSelectionProof(ctx, &ethpb.AggregateSelectionRequest{
+				Slot:           slot,
+				CommitteeIndex: committeeIndex,
+				PublicKey:      []byte{1},
+				SlotSignature:  []byte{1},
+			})
+			assert.ErrorContains(t, testCase.expectedErrorMessage, err)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
 )
 
 func (c *beaconApiValidatorClient) submitSyncMessage(ctx context.Context, syncMessage *ethpb.SyncCommitteeMessage) error {
@@ -126,3 +127,34 @@ func convertSyncContributionJsonToProto(contribution *apimiddleware.SyncCommitte
 		Signature:         signature,
 	}, nil
 }
+
+func (c *beaconApiValidatorClient) getSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	validatorIndexResponse, err := c.validatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: in.PublicKey})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get validator index")
+	}
+
+	// At sync committee period boundary, validator should sample the next epoch sync committee.
+	epoch := slots.ToEpoch(in.Slot + 1)
+	syncDuties, err := c.dutiesProvider.GetSyncDuties(ctx, epoch, []types.ValidatorIndex{validatorIndexResponse.Index})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sync duties for epoch `%d`", epoch)
+	}
+
+	var indices []types.CommitteeIndex
+	for _, syncDuty := range syncDuties {
+		if syncDuty.ValidatorIndex != uint64ToString(validatorIndexResponse.Index) {
+			continue
+		}
+
+		for _, stringSyncCommitteeIndex := range syncDuty.ValidatorSyncCommitteeIndices {
+			syncCommitteeIndex, err := strconv.ParseUint(stringSyncCommitteeIndex, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse sync committee index `%s`", stringSyncCommitteeIndex)
+			}
+			indices = append(indices, types.CommitteeIndex(syncCommitteeIndex))
+		}
+	}
+
+	return &ethpb.SyncSubcommitteeIndexResponse{Indices: indices}, nil
+}
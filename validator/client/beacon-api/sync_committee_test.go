@@ -253,3 +253,109 @@ func TestGetSyncCommitteeContribution(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSyncSubcommitteeIndex(t *testing.T) {
+	const pubkey = "0x8000091c2ae64ee414a54c1cc1fc67dec663408bc636cb86756e0200e41a75c8f86603f104f02c856983d2783116be13"
+	const validatorIndex = "55293"
+	const slot = types.Slot(123)
+
+	expectedIndices := []types.CommitteeIndex{123, 456}
+	expectedResponse := &ethpb.SyncSubcommitteeIndexResponse{Indices: expectedIndices}
+
+	testCases := []struct {
+		name           string
+		duties         []*apimiddleware.SyncCommitteeDuty
+		validatorsErr  error
+		dutiesErr      error
+		expectedErrMsg string
+		expectedResp   *ethpb.SyncSubcommitteeIndexResponse
+	}{
+		{
+			name: "success",
+			duties: []*apimiddleware.SyncCommitteeDuty{
+				{
+					Pubkey:                        pubkey,
+					ValidatorIndex:                validatorIndex,
+					ValidatorSyncCommitteeIndices: []string{"123", "456"},
+				},
+			},
+			expectedResp: expectedResponse,
+		},
+		{
+			name:         "not in sync committee",
+			duties:       []*apimiddleware.SyncCommitteeDuty{},
+			expectedResp: &ethpb.SyncSubcommitteeIndexResponse{},
+		},
+		{
+			name:           "validator index error",
+			validatorsErr:  errors.New("foo error"),
+			expectedErrMsg: "failed to get validator index",
+		},
+		{
+			name:           "sync duties error",
+			dutiesErr:      errors.New("bar error"),
+			expectedErrMsg: "failed to get sync duties for epoch `3`",
+		},
+		{
+			name: "invalid sync committee index",
+			duties: []*apimiddleware.SyncCommitteeDuty{
+				{
+					Pubkey:                        pubkey,
+					ValidatorIndex:                validatorIndex,
+					ValidatorSyncCommitteeIndices: []string{"foo"},
+				},
+			},
+			expectedErrMsg: "failed to parse sync committee index `foo`",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+			stateValidatorsProvider.EXPECT().GetStateValidators(
+				ctx,
+				[]string{pubkey},
+				nil,
+				nil,
+			).Return(
+				&apimiddleware.StateValidatorsResponseJson{
+					Data: []*apimiddleware.ValidatorContainerJson{{Index: validatorIndex}},
+				},
+				test.validatorsErr,
+			).Times(1)
+
+			dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+			dutiesProvider.EXPECT().GetSyncDuties(
+				ctx,
+				types.Epoch(3),
+				[]types.ValidatorIndex{55293},
+			).Return(
+				test.duties,
+				test.dutiesErr,
+			).AnyTimes()
+
+			pubkeyBytes, err := hexutil.Decode(pubkey)
+			require.NoError(t, err)
+
+			validatorClient := &beaconApiValidatorClient{
+				stateValidatorsProvider: stateValidatorsProvider,
+				dutiesProvider:          dutiesProvider,
+			}
+			actualResponse, err := validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
+				PublicKey: pubkeyBytes,
+				Slot:      slot,
+			})
+			if test.expectedErrMsg == "" {
+				require.NoError(t, err)
+				assert.DeepEqual(t, test.expectedResp, actualResponse)
+			} else {
+				require.ErrorContains(t, test.expectedErrMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package beacon_node_pool
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var _ grpc.ClientConnInterface = (*Pool)(nil)
+
+var errNoConn = errors.New("beacon node has no gRPC connection")
+
+// Invoke performs a unary gRPC call on the active beacon node. It lets the beacon chain, node and slasher
+// clients of the validator follow the failovers of the pool, like its validator client calls.
+func (p *Pool) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	conn := p.activeNode().conn
+	if conn == nil {
+		return errNoConn
+	}
+	err := conn.Invoke(ctx, method, args, reply, opts...)
+	if err != nil {
+		p.requestProbe()
+	}
+	return err
+}
+
+// NewStream opens a gRPC stream on the active beacon node.
+func (p *Pool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	conn := p.activeNode().conn
+	if conn == nil {
+		return nil, errNoConn
+	}
+	stream, err := conn.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		p.requestProbe()
+	}
+	return stream, err
+}
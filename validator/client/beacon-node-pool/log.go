@@ -0,0 +1,5 @@
+package beacon_node_pool
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "beacon-node-pool")
@@ -0,0 +1,63 @@
+package beacon_node_pool
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// beaconNodeHealthGaugeVec used to track the health of every beacon node of the pool.
+	beaconNodeHealthGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_health",
+			Help:      "beacon node health: 0 UNREACHABLE, 1 SYNCING, 2 OPTIMISTIC, 3 HEALTHY",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodeHeadSlotGaugeVec used to track the head slot of every beacon node of the pool.
+	beaconNodeHeadSlotGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_head_slot",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodeActiveGaugeVec used to track which beacon node of the pool duties are routed to.
+	beaconNodeActiveGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_active",
+			Help:      "1 for the beacon node duties are routed to, 0 for the others",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodeFailoverCounterVec used to count failovers from a beacon node to another.
+	beaconNodeFailoverCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_failovers",
+			Help:      "number of times duties were moved from a beacon node to another",
+		},
+		[]string{
+			"from",
+			"to",
+		},
+	)
+	// beaconNodeBroadcastFailCounterVec used to count failed broadcasts to a beacon node.
+	beaconNodeBroadcastFailCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_failed_broadcasts",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+)
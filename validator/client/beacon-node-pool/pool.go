@@ -0,0 +1,273 @@
+package beacon_node_pool
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// headSlotTolerance is how many slots a beacon node must be ahead of the active node, for duties to be moved
+// to it. It keeps nodes that receive the same block a few milliseconds apart from causing failovers.
+const headSlotTolerance = 2
+
+// Health of a beacon node, from the least to the most fit to serve duties.
+type Health int
+
+const (
+	// Unreachable beacon nodes could not be probed.
+	Unreachable Health = iota
+	// Syncing beacon nodes are catching up with the chain.
+	Syncing
+	// Optimistic beacon nodes follow a head whose execution payload is not verified yet.
+	Optimistic
+	// Healthy beacon nodes are synced and verified their head.
+	Healthy
+)
+
+func (h Health) String() string {
+	switch h {
+	case Unreachable:
+		return "unreachable"
+	case Syncing:
+		return "syncing"
+	case Optimistic:
+		return "optimistic"
+	case Healthy:
+		return "healthy"
+	default:
+		return "unknown"
+	}
+}
+
+// Node is a beacon node of the pool, along with the outcome of its last probe.
+type Node struct {
+	endpoint     string
+	client       iface.ValidatorClient
+	healthClient iface.NodeHealthClient
+	conn         grpc.ClientConnInterface
+	health       Health
+	headSlot     types.Slot
+}
+
+// NewNode returns a beacon node reached through the given clients and gRPC connection. Until it is probed,
+// the node is deemed healthy.
+func NewNode(endpoint string, client iface.ValidatorClient, healthClient iface.NodeHealthClient, conn grpc.ClientConnInterface) *Node {
+	return &Node{
+		endpoint:     endpoint,
+		client:       client,
+		healthClient: healthClient,
+		conn:         conn,
+		health:       Healthy,
+	}
+}
+
+// fitterThan tells whether duties should be moved from the other node to this one.
+func (n *Node) fitterThan(other *Node) bool {
+	if n.health != other.health {
+		return n.health > other.health
+	}
+	return n.headSlot > other.headSlot+headSlotTolerance
+}
+
+// Pool is a validator client spanning several beacon nodes. It routes every duty to the healthiest node, and
+// broadcasts blocks, attestations and other signed messages to all nodes, so that the validator keeps performing
+// when a node goes down or falls behind. The pool is also a gRPC connection to the active node, for the gRPC
+// clients of the validator that are not part of the validator client.
+//
+// Streams are opened on the node that is the healthiest at the time, and stay on that node until they break.
+type Pool struct {
+	nodes      []*Node
+	active     int
+	lock       sync.RWMutex
+	probeNow   chan struct{}
+	broadcasts sync.WaitGroup
+}
+
+// NewPool returns a pool of the given beacon nodes. The first node serves duties until the nodes are probed.
+func NewPool(nodes []*Node) *Pool {
+	p := &Pool{
+		nodes:    nodes,
+		probeNow: make(chan struct{}, 1),
+	}
+	for i, n := range nodes {
+		beaconNodeHealthGaugeVec.WithLabelValues(n.endpoint).Set(float64(n.health))
+		beaconNodeActiveGaugeVec.WithLabelValues(n.endpoint).Set(boolToFloat(i == p.active))
+	}
+	return p
+}
+
+// Run probes the beacon nodes of the pool until the context is canceled, three times per slot and whenever
+// a call to a node fails.
+func (p *Pool) Run(ctx context.Context) {
+	probeInterval := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 3
+	ticker := time.NewTicker(probeInterval)
+	defer ticker.Stop()
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, probeInterval)
+		p.probe(probeCtx)
+		cancel()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-p.probeNow:
+		}
+	}
+}
+
+// probe queries the sync status of every beacon node of the pool, and fails over to another node
+// if the active one is no longer the fittest.
+func (p *Pool) probe(ctx context.Context) {
+	type probeResult struct {
+		health   Health
+		headSlot types.Slot
+	}
+	results := make([]probeResult, len(p.nodes))
+	var wg sync.WaitGroup
+	for i, n := range p.nodes {
+		wg.Add(1)
+		go func(i int, n *Node) {
+			defer wg.Done()
+			syncInfo, err := n.healthClient.SyncStatus(ctx)
+			switch {
+			case err != nil:
+				log.WithError(err).WithField("endpoint", n.endpoint).Debug("Could not probe beacon node")
+				results[i] = probeResult{health: Unreachable}
+			case syncInfo.IsSyncing:
+				results[i] = probeResult{health: Syncing, headSlot: syncInfo.HeadSlot}
+			case syncInfo.IsOptimistic:
+				results[i] = probeResult{health: Optimistic, headSlot: syncInfo.HeadSlot}
+			default:
+				results[i] = probeResult{health: Healthy, headSlot: syncInfo.HeadSlot}
+			}
+		}(i, n)
+	}
+	wg.Wait()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, n := range p.nodes {
+		n.health = results[i].health
+		n.headSlot = results[i].headSlot
+		beaconNodeHealthGaugeVec.WithLabelValues(n.endpoint).Set(float64(n.health))
+		beaconNodeHeadSlotGaugeVec.WithLabelValues(n.endpoint).Set(float64(n.headSlot))
+	}
+
+	fittest := p.active
+	for i, n := range p.nodes {
+		if n.fitterThan(p.nodes[fittest]) {
+			fittest = i
+		}
+	}
+	if fittest == p.active {
+		return
+	}
+	from, to := p.nodes[p.active], p.nodes[fittest]
+	log.WithFields(logrus.Fields{
+		"from":         from.endpoint,
+		"fromHealth":   from.health,
+		"fromHeadSlot": from.headSlot,
+		"to":           to.endpoint,
+		"toHealth":     to.health,
+		"toHeadSlot":   to.headSlot,
+	}).Warn("Failing over to another beacon node")
+	beaconNodeFailoverCounterVec.WithLabelValues(from.endpoint, to.endpoint).Inc()
+	beaconNodeActiveGaugeVec.WithLabelValues(from.endpoint).Set(0)
+	beaconNodeActiveGaugeVec.WithLabelValues(to.endpoint).Set(1)
+	p.active = fittest
+}
+
+// requestProbe makes the pool probe its beacon nodes without waiting for the next scheduled probe.
+func (p *Pool) requestProbe() {
+	select {
+	case p.probeNow <- struct{}{}:
+	default:
+	}
+}
+
+func (p *Pool) activeNode() *Node {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.nodes[p.active]
+}
+
+// rankedNodes returns the active beacon node, followed by the other nodes from the fittest to the least fit.
+func (p *Pool) rankedNodes() []*Node {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	others := make([]*Node, 0, len(p.nodes)-1)
+	for i, n := range p.nodes {
+		if i != p.active {
+			others = append(others, n)
+		}
+	}
+	sort.SliceStable(others, func(i, j int) bool {
+		if others[i].health != others[j].health {
+			return others[i].health > others[j].health
+		}
+		return others[i].headSlot > others[j].headSlot
+	})
+	return append([]*Node{p.nodes[p.active]}, others...)
+}
+
+// route performs a call on the active beacon node.
+func route[T any](p *Pool, call func(iface.ValidatorClient) (T, error)) (T, error) {
+	resp, err := call(p.activeNode().client)
+	if err != nil {
+		p.requestProbe()
+	}
+	return resp, err
+}
+
+// broadcast performs a call on every beacon node of the pool. It returns as soon as a node succeeds, and fails with
+// the error of the active node if no node succeeds.
+func broadcast[T any](p *Pool, name string, call func(iface.ValidatorClient) (T, error)) (T, error) {
+	type broadcastResult struct {
+		resp T
+		err  error
+		rank int
+	}
+	nodes := p.rankedNodes()
+	results := make(chan broadcastResult, len(nodes))
+	for i, n := range nodes {
+		p.broadcasts.Add(1)
+		go func(rank int, n *Node) {
+			defer p.broadcasts.Done()
+			resp, err := call(n.client)
+			if err != nil {
+				log.WithError(err).WithFields(logrus.Fields{
+					"endpoint": n.endpoint,
+					"call":     name,
+				}).Debug("Could not broadcast to beacon node")
+				beaconNodeBroadcastFailCounterVec.WithLabelValues(n.endpoint).Inc()
+				p.requestProbe()
+			}
+			results <- broadcastResult{resp: resp, err: err, rank: rank}
+		}(i, n)
+	}
+
+	errs := make([]error, len(nodes))
+	for range nodes {
+		result := <-results
+		if result.err == nil {
+			return result.resp, nil
+		}
+		errs[result.rank] = result.err
+	}
+	var resp T
+	return resp, errs[0]
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,171 @@
+package beacon_node_pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/empty"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/mock"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"google.golang.org/grpc"
+)
+
+type fakeHealthClient struct {
+	syncInfo *ethpbv1.SyncInfo
+	err      error
+}
+
+func (c *fakeHealthClient) SyncStatus(_ context.Context) (*ethpbv1.SyncInfo, error) {
+	return c.syncInfo, c.err
+}
+
+func setupPool(t *testing.T, n int) (*Pool, []*mock.MockValidatorClient, []*fakeHealthClient) {
+	ctrl := gomock.NewController(t)
+	clients := make([]*mock.MockValidatorClient, n)
+	healthClients := make([]*fakeHealthClient, n)
+	nodes := make([]*Node, n)
+	for i := range nodes {
+		clients[i] = mock.NewMockValidatorClient(ctrl)
+		healthClients[i] = &fakeHealthClient{syncInfo: &ethpbv1.SyncInfo{HeadSlot: 100}}
+		nodes[i] = NewNode(string(rune('a'+i)), clients[i], healthClients[i], nil)
+	}
+	return NewPool(nodes), clients, healthClients
+}
+
+func TestPool_RoutesToHealthiestNode(t *testing.T) {
+	ctx := context.Background()
+	p, clients, healthClients := setupPool(t, 3)
+
+	// Until probed, duties go to the first node.
+	clients[0].EXPECT().GetDuties(ctx, gomock.Any()).Return(&ethpb.DutiesResponse{}, nil)
+	_, err := p.GetDuties(ctx, &ethpb.DutiesRequest{})
+	require.NoError(t, err)
+
+	healthClients[0].err = errors.New("connection refused")
+	healthClients[1].syncInfo = &ethpbv1.SyncInfo{HeadSlot: 200, IsSyncing: true}
+	p.probe(ctx)
+	assert.Equal(t, Unreachable, p.nodes[0].health)
+	assert.Equal(t, Syncing, p.nodes[1].health)
+	assert.Equal(t, Healthy, p.nodes[2].health)
+	assert.Equal(t, 2, p.active)
+
+	clients[2].EXPECT().GetDuties(ctx, gomock.Any()).Return(&ethpb.DutiesResponse{}, nil)
+	_, err = p.GetDuties(ctx, &ethpb.DutiesRequest{})
+	require.NoError(t, err)
+
+	// An optimistic node is preferred over a syncing one.
+	healthClients[2].syncInfo = &ethpbv1.SyncInfo{HeadSlot: 100, IsOptimistic: true}
+	p.probe(ctx)
+	assert.Equal(t, 2, p.active)
+	healthClients[1].syncInfo = &ethpbv1.SyncInfo{HeadSlot: 200}
+	p.probe(ctx)
+	assert.Equal(t, 1, p.active)
+}
+
+func TestPool_FailsOverWhenNodeFallsBehind(t *testing.T) {
+	ctx := context.Background()
+	p, _, healthClients := setupPool(t, 2)
+	p.probe(ctx)
+	require.Equal(t, 0, p.active)
+
+	// Nodes within the tolerance do not cause failovers.
+	healthClients[1].syncInfo = &ethpbv1.SyncInfo{HeadSlot: 100 + headSlotTolerance}
+	p.probe(ctx)
+	assert.Equal(t, 0, p.active)
+
+	healthClients[1].syncInfo = &ethpbv1.SyncInfo{HeadSlot: 101 + headSlotTolerance}
+	p.probe(ctx)
+	assert.Equal(t, 1, p.active)
+	assert.Equal(t, types.Slot(101+headSlotTolerance), p.nodes[1].headSlot)
+
+	// The active node is kept once the other node catches up.
+	healthClients[0].syncInfo = &ethpbv1.SyncInfo{HeadSlot: 101 + headSlotTolerance}
+	p.probe(ctx)
+	assert.Equal(t, 1, p.active)
+}
+
+func TestPool_RequestsProbeOnError(t *testing.T) {
+	ctx := context.Background()
+	p, clients, _ := setupPool(t, 2)
+
+	clients[0].EXPECT().GetAttestationData(ctx, gomock.Any()).Return(nil, errors.New("bad"))
+	_, err := p.GetAttestationData(ctx, &ethpb.AttestationDataRequest{})
+	require.ErrorContains(t, "bad", err)
+	assert.Equal(t, 1, len(p.probeNow))
+}
+
+func TestPool_BroadcastsToAllNodes(t *testing.T) {
+	ctx := context.Background()
+	p, clients, _ := setupPool(t, 3)
+	att := &ethpb.Attestation{}
+
+	clients[0].EXPECT().ProposeAttestation(ctx, att).Return(nil, errors.New("bad"))
+	clients[1].EXPECT().ProposeAttestation(ctx, att).Return(&ethpb.AttestResponse{AttestationDataRoot: []byte{1}}, nil)
+	clients[2].EXPECT().ProposeAttestation(ctx, att).Return(&ethpb.AttestResponse{AttestationDataRoot: []byte{1}}, nil)
+	resp, err := p.ProposeAttestation(ctx, att)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{1}, resp.AttestationDataRoot)
+	p.broadcasts.Wait()
+}
+
+func TestPool_BroadcastFailsOnAllNodes(t *testing.T) {
+	ctx := context.Background()
+	p, clients, _ := setupPool(t, 2)
+	blk := &ethpb.GenericSignedBeaconBlock{}
+
+	clients[0].EXPECT().ProposeBeaconBlock(ctx, blk).Return(nil, errors.New("first"))
+	clients[1].EXPECT().ProposeBeaconBlock(ctx, blk).Return(nil, errors.New("second"))
+	_, err := p.ProposeBeaconBlock(ctx, blk)
+	require.ErrorContains(t, "first", err)
+	p.broadcasts.Wait()
+}
+
+type fakeConn struct {
+	methods []string
+	err     error
+}
+
+func (c *fakeConn) Invoke(_ context.Context, method string, _ interface{}, _ interface{}, _ ...grpc.CallOption) error {
+	c.methods = append(c.methods, method)
+	return c.err
+}
+
+func (c *fakeConn) NewStream(_ context.Context, _ *grpc.StreamDesc, method string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+	c.methods = append(c.methods, method)
+	return nil, c.err
+}
+
+func TestPool_RoutesGRPCCallsToActiveNode(t *testing.T) {
+	ctx := context.Background()
+	p, _, healthClients := setupPool(t, 2)
+	conns := []*fakeConn{{}, {}}
+	for i, n := range p.nodes {
+		n.conn = conns[i]
+	}
+
+	node := ethpb.NewNodeClient(p)
+	_, err := node.GetGenesis(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"/ethereum.eth.v1alpha1.Node/GetGenesis"}, conns[0].methods)
+
+	healthClients[0].err = errors.New("connection refused")
+	p.probe(ctx)
+	_, err = ethpb.NewBeaconChainClient(p).GetChainHead(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"/ethereum.eth.v1alpha1.BeaconChain/GetChainHead"}, conns[1].methods)
+
+	conns[1].err = errors.New("bad")
+	_, err = node.GetGenesis(ctx, &empty.Empty{})
+	require.ErrorContains(t, "bad", err)
+	assert.Equal(t, 1, len(p.probeNow))
+
+	p.nodes[1].conn = nil
+	_, err = node.GetGenesis(ctx, &empty.Empty{})
+	require.ErrorIs(t, err, errNoConn)
+}
@@ -0,0 +1,187 @@
+package beacon_node_pool
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+)
+
+var _ iface.ValidatorClient = (*Pool)(nil)
+
+func (p *Pool) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.DutiesResponse, error) {
+		return c.GetDuties(ctx, in)
+	})
+}
+
+func (p *Pool) StreamDuties(ctx context.Context, in *ethpb.DutiesRequest) (ethpb.BeaconNodeValidator_StreamDutiesClient, error) {
+	return route(p, func(c iface.ValidatorClient) (ethpb.BeaconNodeValidator_StreamDutiesClient, error) {
+		return c.StreamDuties(ctx, in)
+	})
+}
+
+func (p *Pool) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.DomainResponse, error) {
+		return c.DomainData(ctx, in)
+	})
+}
+
+func (p *Pool) WaitForChainStart(ctx context.Context, in *empty.Empty) (*ethpb.ChainStartResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.ChainStartResponse, error) {
+		return c.WaitForChainStart(ctx, in)
+	})
+}
+
+func (p *Pool) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	return route(p, func(c iface.ValidatorClient) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+		return c.WaitForActivation(ctx, in)
+	})
+}
+
+func (p *Pool) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.ValidatorIndexResponse, error) {
+		return c.ValidatorIndex(ctx, in)
+	})
+}
+
+func (p *Pool) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest) (*ethpb.ValidatorStatusResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.ValidatorStatusResponse, error) {
+		return c.ValidatorStatus(ctx, in)
+	})
+}
+
+func (p *Pool) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.MultipleValidatorStatusResponse, error) {
+		return c.MultipleValidatorStatus(ctx, in)
+	})
+}
+
+func (p *Pool) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.GenericBeaconBlock, error) {
+		return c.GetBeaconBlock(ctx, in)
+	})
+}
+
+func (p *Pool) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	return broadcast(p, "ProposeBeaconBlock", func(c iface.ValidatorClient) (*ethpb.ProposeResponse, error) {
+		return c.ProposeBeaconBlock(ctx, in)
+	})
+}
+
+func (p *Pool) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*empty.Empty, error) {
+	return broadcast(p, "PrepareBeaconProposer", func(c iface.ValidatorClient) (*empty.Empty, error) {
+		return c.PrepareBeaconProposer(ctx, in)
+	})
+}
+
+func (p *Pool) GetFeeRecipientByPubKey(ctx context.Context, in *ethpb.FeeRecipientByPubKeyRequest) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+		return c.GetFeeRecipientByPubKey(ctx, in)
+	})
+}
+
+func (p *Pool) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.AttestationData, error) {
+		return c.GetAttestationData(ctx, in)
+	})
+}
+
+func (p *Pool) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	return broadcast(p, "ProposeAttestation", func(c iface.ValidatorClient) (*ethpb.AttestResponse, error) {
+		return c.ProposeAttestation(ctx, in)
+	})
+}
+
+func (p *Pool) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.AggregateSelectionResponse, error) {
+		return c.SubmitAggregateSelectionProof(ctx, in)
+	})
+}
+
+func (p *Pool) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return broadcast(p, "SubmitSignedAggregateSelectionProof", func(c iface.ValidatorClient) (*ethpb.SignedAggregateSubmitResponse, error) {
+		return c.SubmitSignedAggregateSelectionProof(ctx, in)
+	})
+}
+
+func (p *Pool) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	return broadcast(p, "ProposeExit", func(c iface.ValidatorClient) (*ethpb.ProposeExitResponse, error) {
+		return c.ProposeExit(ctx, in)
+	})
+}
+
+func (p *Pool) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, validatorIndices []types.ValidatorIndex) (*empty.Empty, error) {
+	return broadcast(p, "SubscribeCommitteeSubnets", func(c iface.ValidatorClient) (*empty.Empty, error) {
+		return c.SubscribeCommitteeSubnets(ctx, in, validatorIndices)
+	})
+}
+
+func (p *Pool) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.DoppelGangerResponse, error) {
+		return c.CheckDoppelGanger(ctx, in)
+	})
+}
+
+func (p *Pool) GetLiveness(ctx context.Context, in *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpbv2.GetLivenessResponse, error) {
+		return c.GetLiveness(ctx, in)
+	})
+}
+
+func (p *Pool) GetSyncMessageBlockRoot(ctx context.Context, in *empty.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.SyncMessageBlockRootResponse, error) {
+		return c.GetSyncMessageBlockRoot(ctx, in)
+	})
+}
+
+func (p *Pool) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*empty.Empty, error) {
+	return broadcast(p, "SubmitSyncMessage", func(c iface.ValidatorClient) (*empty.Empty, error) {
+		return c.SubmitSyncMessage(ctx, in)
+	})
+}
+
+func (p *Pool) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+		return c.GetSyncSubcommitteeIndex(ctx, in)
+	})
+}
+
+func (p *Pool) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	return route(p, func(c iface.ValidatorClient) (*ethpb.SyncCommitteeContribution, error) {
+		return c.GetSyncCommitteeContribution(ctx, in)
+	})
+}
+
+func (p *Pool) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error) {
+	return broadcast(p, "SubmitSignedContributionAndProof", func(c iface.ValidatorClient) (*empty.Empty, error) {
+		return c.SubmitSignedContributionAndProof(ctx, in)
+	})
+}
+
+func (p *Pool) StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	return route(p, func(c iface.ValidatorClient) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+		return c.StreamBlocksAltair(ctx, in)
+	})
+}
+
+func (p *Pool) SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error) {
+	return broadcast(p, "SubmitValidatorRegistrations", func(c iface.ValidatorClient) (*empty.Empty, error) {
+		return c.SubmitValidatorRegistrations(ctx, in)
+	})
+}
+
+func (p *Pool) GetAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return route(p, func(c iface.ValidatorClient) ([]iface.BeaconCommitteeSelection, error) {
+		return c.GetAggregatedSelections(ctx, selections)
+	})
+}
+
+func (p *Pool) GetAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return route(p, func(c iface.ValidatorClient) ([]iface.SyncCommitteeSelection, error) {
+		return c.GetAggregatedSyncSelections(ctx, selections)
+	})
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// clock is the source of time of the validator client, which decides when slots start and
+// when duties are due. The validator client runs on the system clock, except during a replay.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	WithDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc)
+	NewSlotTicker(genesisTime time.Time, secondsPerSlot uint64) slots.Ticker
+}
+
+// systemClock is the clock of the validator client outside of replays.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return prysmTime.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) WithDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, deadline)
+}
+
+func (systemClock) NewSlotTicker(genesisTime time.Time, secondsPerSlot uint64) slots.Ticker {
+	return slots.NewSlotTicker(genesisTime, secondsPerSlot)
+}
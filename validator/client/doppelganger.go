@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// doppelGangerKey is the doppelganger protection state of a validator key. The duties of the key
+// are only performed once it has not been observed live on the network for the configured number
+// of epochs, and are stopped for good if it is.
+type doppelGangerKey struct {
+	// startEpoch is the first epoch in which liveness of the key cannot stem from this validator client.
+	startEpoch      types.Epoch
+	remainingEpochs uint64
+	detected        bool
+}
+
+// CheckDoppelGanger puts the current validating keys under doppelganger protection, which holds
+// back their duties until they are not observed live on the network for the configured number
+// of epochs.
+func (v *validator) CheckDoppelGanger(ctx context.Context) error {
+	if !features.Get().EnableDoppelGanger {
+		return nil
+	}
+	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"keys":   len(pubkeys),
+		"epochs": v.doppelGangerEpochs,
+	}).Info("Running doppelganger check, duties are performed once the keys are not observed live on the network")
+	currentEpoch := slots.ToEpoch(v.currentSlot())
+	for _, pubKey := range pubkeys {
+		if err := v.trackDoppelGanger(ctx, pubKey, currentEpoch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackDoppelGanger puts a validator key under doppelganger protection from the given epoch on.
+// Liveness in epochs the key has signed messages in, according to the slashing protection
+// history, is that of this validator client and does not count as a doppelganger.
+func (v *validator) trackDoppelGanger(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, epoch types.Epoch) error {
+	v.doppelGangerLock.Lock()
+	defer v.doppelGangerLock.Unlock()
+	if _, ok := v.doppelGangerKeys[pubKey]; ok {
+		return nil
+	}
+	startEpoch := epoch
+	attRecs, err := v.db.AttestationHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not get attestation history")
+	}
+	if r := retrieveLatestRecord(attRecs); r != nil && r.Target >= startEpoch {
+		startEpoch = r.Target + 1
+	}
+	proposalSlot, exists, err := v.db.HighestSignedProposal(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not get highest signed proposal")
+	}
+	if exists && slots.ToEpoch(proposalSlot) >= startEpoch {
+		startEpoch = slots.ToEpoch(proposalSlot) + 1
+	}
+	v.doppelGangerKeys[pubKey] = &doppelGangerKey{
+		startEpoch:      startEpoch,
+		remainingEpochs: v.doppelGangerEpochs,
+	}
+	return nil
+}
+
+// doppelGangerSafe returns whether the duties of a validator key may be performed. Keys which are
+// not under doppelganger protection yet, such as keys added at runtime, are put under protection.
+func (v *validator) doppelGangerSafe(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot types.Slot) (bool, error) {
+	if !features.Get().EnableDoppelGanger {
+		return true, nil
+	}
+	v.doppelGangerLock.RLock()
+	k, ok := v.doppelGangerKeys[pubKey]
+	safe := ok && !k.detected && k.remainingEpochs == 0
+	v.doppelGangerLock.RUnlock()
+	if !ok {
+		log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:]))).Info("Running doppelganger check for new key")
+		if err := v.trackDoppelGanger(ctx, pubKey, slots.ToEpoch(slot)); err != nil {
+			return false, err
+		}
+		return v.doppelGangerEpochs == 0, nil
+	}
+	return safe, nil
+}
+
+// CheckDoppelGangerLiveness checks whether the keys under doppelganger protection were observed
+// live on the network in the previous or the current epoch, and is meant to be called in the last
+// slot of every epoch. The duties of keys observed live are stopped, while keys not observed live
+// for the configured number of epochs start performing their duties.
+func (v *validator) CheckDoppelGangerLiveness(ctx context.Context, slot types.Slot) error {
+	if !features.Get().EnableDoppelGanger {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.CheckDoppelGangerLiveness")
+	defer span.End()
+
+	v.doppelGangerLock.Lock()
+	defer v.doppelGangerLock.Unlock()
+
+	currentEpoch := slots.ToEpoch(slot)
+	pending := make(map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey)
+	for pubKey, k := range v.doppelGangerKeys {
+		if !k.detected && k.remainingEpochs > 0 && k.startEpoch <= currentEpoch {
+			pending[pubKey] = k
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Liveness is based on the participation flags introduced in Altair.
+	if currentEpoch < params.BeaconConfig().AltairForkEpoch {
+		log.Info("Skipping doppelganger check for Phase 0")
+		for _, k := range pending {
+			k.remainingEpochs = 0
+		}
+		return nil
+	}
+
+	// Keys which are not in the beacon state yet have no validator index, and stay pending until they are.
+	indices := make(map[[fieldparams.BLSPubkeyLength]byte]types.ValidatorIndex, len(pending))
+	if v.duties != nil {
+		for _, duty := range v.duties.CurrentEpochDuties {
+			if duty.Status == ethpb.ValidatorStatus_UNKNOWN_STATUS {
+				continue
+			}
+			pubKey := bytesutil.ToBytes48(duty.PublicKey)
+			if _, ok := pending[pubKey]; ok {
+				indices[pubKey] = duty.ValidatorIndex
+			}
+		}
+	}
+
+	epochs := []types.Epoch{currentEpoch}
+	if currentEpoch > 0 {
+		epochs = append(epochs, currentEpoch-1)
+	}
+	live := make(map[types.ValidatorIndex]types.Epoch)
+	for _, epoch := range epochs {
+		req := &ethpbv2.GetLivenessRequest{Epoch: epoch}
+		for pubKey, index := range indices {
+			if pending[pubKey].startEpoch <= epoch {
+				req.Index = append(req.Index, index)
+			}
+		}
+		if len(req.Index) == 0 {
+			continue
+		}
+		resp, err := v.validatorClient.GetLiveness(ctx, req)
+		if err != nil {
+			return errors.Wrapf(err, "could not get liveness of validators in epoch %d", epoch)
+		}
+		for _, liveness := range resp.Data {
+			if liveness.IsLive {
+				live[liveness.Index] = epoch
+			}
+		}
+	}
+
+	for pubKey, k := range pending {
+		index, ok := indices[pubKey]
+		if !ok {
+			continue
+		}
+		log := log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])))
+		if epoch, ok := live[index]; ok {
+			k.detected = true
+			log.WithFields(logrus.Fields{
+				"validatorIndex": index,
+				"epoch":          epoch,
+			}).Error("Doppelganger detected, duties are stopped for this key. Make sure no other validator client " +
+				"uses this key before restarting")
+			continue
+		}
+		// The previous epoch is complete, so that it counts as an epoch without liveness.
+		if currentEpoch > 0 && k.startEpoch <= currentEpoch-1 {
+			k.remainingEpochs--
+			if k.remainingEpochs == 0 {
+				log.Info("No doppelganger detected, starting duties for this key")
+			}
+		}
+	}
+	return nil
+}
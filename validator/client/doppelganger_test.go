@@ -0,0 +1,261 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	mock2 "github.com/prysmaticlabs/prysm/v3/testing/mock"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	dbTest "github.com/prysmaticlabs/prysm/v3/validator/db/testing"
+)
+
+func enableDoppelGanger(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableDoppelGanger: true})
+	t.Cleanup(resetCfg)
+}
+
+func TestCheckDoppelGanger_StartEpochs(t *testing.T) {
+	enableDoppelGanger(t)
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	// The validator client starts in epoch 6.
+	genesisTime := uint64(time.Now().Unix()) - uint64(slotsPerEpoch.Mul(6))*params.BeaconConfig().SecondsPerSlot
+	tests := []struct {
+		name        string
+		attestation *ethpb.IndexedAttestation
+		proposal    types.Slot
+		hasProposal bool
+		startEpoch  types.Epoch
+	}{
+		{
+			name:       "no slashing protection history",
+			startEpoch: 6,
+		},
+		{
+			name:        "history before the current epoch",
+			attestation: createAttestation(3, 4),
+			proposal:    slotsPerEpoch.Mul(2),
+			hasProposal: true,
+			startEpoch:  6,
+		},
+		{
+			name:        "attestation in the current epoch",
+			attestation: createAttestation(5, 6),
+			startEpoch:  7,
+		},
+		{
+			name:        "proposal after the current epoch",
+			proposal:    slotsPerEpoch.Mul(8),
+			hasProposal: true,
+			startEpoch:  9,
+		},
+		{
+			name:        "latest of attestation and proposal",
+			attestation: createAttestation(7, 8),
+			proposal:    slotsPerEpoch.Mul(7),
+			hasProposal: true,
+			startEpoch:  9,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km := genMockKeymanager(1)
+			keys, err := km.FetchValidatingPublicKeys(ctx)
+			require.NoError(t, err)
+			db := dbTest.SetupDB(t, keys)
+			if tt.attestation != nil {
+				require.NoError(t, db.SaveAttestationForPubKey(ctx, keys[0], [32]byte{1}, tt.attestation))
+			}
+			if tt.hasProposal {
+				require.NoError(t, db.SaveProposalHistoryForSlot(ctx, keys[0], tt.proposal, []byte{1}))
+			}
+			v := &validator{
+				db:                 db,
+				keyManager:         km,
+				genesisTime:        genesisTime,
+				doppelGangerKeys:   make(map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey),
+				doppelGangerEpochs: 2,
+			}
+			require.NoError(t, v.CheckDoppelGanger(ctx))
+			require.Equal(t, 1, len(v.doppelGangerKeys))
+			assert.DeepEqual(t, &doppelGangerKey{startEpoch: tt.startEpoch, remainingEpochs: 2}, v.doppelGangerKeys[keys[0]])
+
+			safe, err := v.doppelGangerSafe(ctx, keys[0], slotsPerEpoch.Mul(6))
+			require.NoError(t, err)
+			assert.Equal(t, false, safe)
+		})
+	}
+}
+
+func TestDoppelGangerSafe_NewKey(t *testing.T) {
+	ctx := context.Background()
+	km := genMockKeymanager(2)
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	db := dbTest.SetupDB(t, keys)
+	// The second key was imported with slashing protection history of a later epoch.
+	require.NoError(t, db.SaveAttestationForPubKey(ctx, keys[1], [32]byte{1}, createAttestation(3, 4)))
+	v := &validator{
+		db:                 db,
+		doppelGangerKeys:   make(map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey),
+		doppelGangerEpochs: 2,
+	}
+
+	// Without doppelganger protection, duties are always performed.
+	safe, err := v.doppelGangerSafe(ctx, keys[0], 64)
+	require.NoError(t, err)
+	assert.Equal(t, true, safe)
+	assert.Equal(t, 0, len(v.doppelGangerKeys))
+
+	// Keys added at runtime are put under protection from the epoch of their first duty.
+	enableDoppelGanger(t)
+	safe, err = v.doppelGangerSafe(ctx, keys[0], 64)
+	require.NoError(t, err)
+	assert.Equal(t, false, safe)
+	assert.DeepEqual(t, &doppelGangerKey{startEpoch: 2, remainingEpochs: 2}, v.doppelGangerKeys[keys[0]])
+	safe, err = v.doppelGangerSafe(ctx, keys[1], 64)
+	require.NoError(t, err)
+	assert.Equal(t, false, safe)
+	assert.DeepEqual(t, &doppelGangerKey{startEpoch: 5, remainingEpochs: 2}, v.doppelGangerKeys[keys[1]])
+
+	// Tracked keys keep their protection state.
+	v.doppelGangerKeys[keys[0]].remainingEpochs = 0
+	safe, err = v.doppelGangerSafe(ctx, keys[0], 128)
+	require.NoError(t, err)
+	assert.Equal(t, true, safe)
+	assert.DeepEqual(t, &doppelGangerKey{startEpoch: 2, remainingEpochs: 0}, v.doppelGangerKeys[keys[0]])
+
+	// Without epochs to wait for, duties of new keys are performed right away.
+	v.doppelGangerEpochs = 0
+	v.doppelGangerKeys = make(map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey)
+	safe, err = v.doppelGangerSafe(ctx, keys[0], 64)
+	require.NoError(t, err)
+	assert.Equal(t, true, safe)
+}
+
+func TestCheckDoppelGangerLiveness(t *testing.T) {
+	enableDoppelGanger(t)
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	km := genMockKeymanager(2)
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	client := mock2.NewMockValidatorClient(ctrl)
+	v := &validator{
+		db:              dbTest.SetupDB(t, keys),
+		validatorClient: client,
+		doppelGangerKeys: map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey{
+			keys[0]: {startEpoch: 10, remainingEpochs: 2},
+			keys[1]: {startEpoch: 10, remainingEpochs: 2},
+		},
+		duties: &ethpb.DutiesResponse{
+			CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+				{PublicKey: keys[0][:], ValidatorIndex: 1, Status: ethpb.ValidatorStatus_ACTIVE},
+				{PublicKey: keys[1][:], ValidatorIndex: 2, Status: ethpb.ValidatorStatus_ACTIVE},
+			},
+		},
+	}
+	// The validator of index 2 is observed live in epoch 10.
+	client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+			assert.Equal(t, true, req.Epoch >= 10)
+			resp := &ethpbv2.GetLivenessResponse{}
+			for _, index := range req.Index {
+				resp.Data = append(resp.Data, &ethpbv2.GetLivenessResponse_Liveness{
+					Index:  index,
+					IsLive: index == 2 && req.Epoch == 10,
+				})
+			}
+			return resp, nil
+		},
+	).AnyTimes()
+	epochEnd := func(epoch types.Epoch) types.Slot {
+		return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch+1)) - 1
+	}
+
+	// Liveness of the start epoch is only known once the epoch is over.
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEnd(10)))
+	assert.DeepEqual(t, &doppelGangerKey{startEpoch: 10, remainingEpochs: 2}, v.doppelGangerKeys[keys[0]])
+	assert.DeepEqual(t, &doppelGangerKey{startEpoch: 10, remainingEpochs: 2, detected: true}, v.doppelGangerKeys[keys[1]])
+
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEnd(11)))
+	assert.DeepEqual(t, &doppelGangerKey{startEpoch: 10, remainingEpochs: 1}, v.doppelGangerKeys[keys[0]])
+	rolesAt, err := v.RolesAt(ctx, 400)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(rolesAt))
+
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEnd(12)))
+	assert.DeepEqual(t, &doppelGangerKey{startEpoch: 10, remainingEpochs: 0}, v.doppelGangerKeys[keys[0]])
+	assert.Equal(t, true, v.doppelGangerKeys[keys[1]].detected)
+
+	// Only the key without doppelganger performs its duties.
+	v.duties.Duties = v.duties.CurrentEpochDuties
+	rolesAt, err = v.RolesAt(ctx, 400)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(rolesAt))
+	_, ok := rolesAt[keys[0]]
+	assert.Equal(t, true, ok)
+}
+
+func TestCheckDoppelGangerLiveness_UnknownStatus(t *testing.T) {
+	enableDoppelGanger(t)
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	client := mock2.NewMockValidatorClient(ctrl)
+	v := &validator{
+		validatorClient: client,
+		doppelGangerKeys: map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey{
+			pubKey: {startEpoch: 10, remainingEpochs: 2},
+		},
+		// Keys which are not in the beacon state yet are returned with validator index 0.
+		duties: &ethpb.DutiesResponse{
+			CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+				{PublicKey: pubKey[:], Status: ethpb.ValidatorStatus_UNKNOWN_STATUS},
+			},
+		},
+	}
+	// Liveness of validator 0 is not queried for the key.
+	client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).Times(0)
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(12) - 1
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, slot))
+	assert.DeepEqual(t, &doppelGangerKey{startEpoch: 10, remainingEpochs: 2}, v.doppelGangerKeys[pubKey])
+}
+
+func TestCheckDoppelGangerLiveness_Phase0(t *testing.T) {
+	enableDoppelGanger(t)
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 100
+	params.OverrideBeaconConfig(cfg)
+
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	v := &validator{
+		doppelGangerKeys: map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey{
+			pubKey: {startEpoch: 10, remainingEpochs: 2},
+		},
+	}
+	require.NoError(t, v.CheckDoppelGangerLiveness(context.Background(), params.BeaconConfig().SlotsPerEpoch.Mul(11)-1))
+	assert.Equal(t, uint64(0), v.doppelGangerKeys[pubKey].remainingEpochs)
+}
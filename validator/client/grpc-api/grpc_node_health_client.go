@@ -0,0 +1,31 @@
+package grpc_api
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	ethpbservice "github.com/prysmaticlabs/prysm/v3/proto/eth/service"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+	"google.golang.org/grpc"
+)
+
+type grpcNodeHealthClient struct {
+	beaconNodeClient ethpbservice.BeaconNodeClient
+}
+
+func (c *grpcNodeHealthClient) SyncStatus(ctx context.Context) (*ethpbv1.SyncInfo, error) {
+	resp, err := c.beaconNodeClient.GetSyncStatus(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Data == nil {
+		return nil, errors.New("sync status is nil")
+	}
+	return resp.Data, nil
+}
+
+func NewGrpcNodeHealthClient(cc grpc.ClientConnInterface) iface.NodeHealthClient {
+	return &grpcNodeHealthClient{beaconNodeClient: ethpbservice.NewBeaconNodeClient(cc)}
+}
@@ -6,13 +6,19 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbservice "github.com/prysmaticlabs/prysm/v3/proto/eth/service"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	iface "github.com/prysmaticlabs/prysm/v3/validator/client/iface"
 	"google.golang.org/grpc"
 )
 
+// Aggregated selections are only served by distributed validator middleware, which sits in front of the REST API.
+var errAggregatedSelectionsNotSupported = errors.New("aggregated selections are not supported over gRPC, use --enable-beacon-rest-api")
+
 type grpcValidatorClient struct {
 	beaconNodeValidatorClient ethpb.BeaconNodeValidatorClient
+	beaconValidatorClient     ethpbservice.BeaconValidatorClient
 }
 
 func (c *grpcValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
@@ -23,6 +29,10 @@ func (c *grpcValidatorClient) CheckDoppelGanger(ctx context.Context, in *ethpb.D
 	return c.beaconNodeValidatorClient.CheckDoppelGanger(ctx, in)
 }
 
+func (c *grpcValidatorClient) GetLiveness(ctx context.Context, in *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	return c.beaconValidatorClient.GetLiveness(ctx, in)
+}
+
 func (c *grpcValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
 	return c.beaconNodeValidatorClient.DomainData(ctx, in)
 }
@@ -99,6 +109,14 @@ func (c *grpcValidatorClient) SubmitValidatorRegistrations(ctx context.Context,
 	return c.beaconNodeValidatorClient.SubmitValidatorRegistrations(ctx, in)
 }
 
+func (c *grpcValidatorClient) GetAggregatedSelections(context.Context, []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return nil, errAggregatedSelectionsNotSupported
+}
+
+func (c *grpcValidatorClient) GetAggregatedSyncSelections(context.Context, []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return nil, errAggregatedSelectionsNotSupported
+}
+
 func (c *grpcValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, _ []types.ValidatorIndex) (*empty.Empty, error) {
 	return c.beaconNodeValidatorClient.SubscribeCommitteeSubnets(ctx, in)
 }
@@ -129,5 +147,8 @@ func (c *grpcValidatorClient) WaitForChainStart(ctx context.Context, in *empty.E
 }
 
 func NewGrpcValidatorClient(cc grpc.ClientConnInterface) iface.ValidatorClient {
-	return &grpcValidatorClient{ethpb.NewBeaconNodeValidatorClient(cc)}
+	return &grpcValidatorClient{
+		beaconNodeValidatorClient: ethpb.NewBeaconNodeValidatorClient(cc),
+		beaconValidatorClient:     ethpbservice.NewBeaconValidatorClient(cc),
+	}
 }
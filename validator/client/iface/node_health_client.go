@@ -0,0 +1,13 @@
+package iface
+
+import (
+	"context"
+
+	ethpbv1 "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
+)
+
+// NodeHealthClient probes the sync status of a beacon node, so that the validator client can tell
+// whether the node is fit to serve duties.
+type NodeHealthClient interface {
+	SyncStatus(ctx context.Context) (*ethpbv1.SyncInfo, error)
+}
@@ -5,9 +5,29 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 )
 
+// BeaconCommitteeSelection is the selection proof of a validator for its beacon committee at a slot.
+// In a distributed validator cluster, the selection proof signed by a validator client is only a partial
+// signature, which the distributed validator middleware aggregates into the actual selection proof.
+type BeaconCommitteeSelection struct {
+	SelectionProof []byte
+	Slot           types.Slot
+	ValidatorIndex types.ValidatorIndex
+}
+
+// SyncCommitteeSelection is the selection proof of a validator for a sync subcommittee at a slot.
+// In a distributed validator cluster, the selection proof signed by a validator client is only a partial
+// signature, which the distributed validator middleware aggregates into the actual selection proof.
+type SyncCommitteeSelection struct {
+	SelectionProof    []byte
+	Slot              types.Slot
+	SubcommitteeIndex types.CommitteeIndex
+	ValidatorIndex    types.ValidatorIndex
+}
+
 type ValidatorClient interface {
 	GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error)
 	StreamDuties(ctx context.Context, in *ethpb.DutiesRequest) (ethpb.BeaconNodeValidator_StreamDutiesClient, error)
@@ -28,6 +48,7 @@ type ValidatorClient interface {
 	ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error)
 	SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, validatorIndices []types.ValidatorIndex) (*empty.Empty, error)
 	CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error)
+	GetLiveness(ctx context.Context, in *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error)
 	GetSyncMessageBlockRoot(ctx context.Context, in *empty.Empty) (*ethpb.SyncMessageBlockRootResponse, error)
 	SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*empty.Empty, error)
 	GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error)
@@ -35,4 +56,6 @@ type ValidatorClient interface {
 	SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error)
 	StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error)
 	SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error)
+	GetAggregatedSelections(ctx context.Context, selections []BeaconCommitteeSelection) ([]BeaconCommitteeSelection, error)
+	GetAggregatedSyncSelections(ctx context.Context, selections []SyncCommitteeSelection) ([]SyncCommitteeSelection, error)
 }
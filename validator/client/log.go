@@ -42,7 +42,7 @@ func (v *validator) LogAttestationsSubmitted() {
 
 // LogSyncCommitteeMessagesSubmitted logs info about submitted sync committee messages.
 func (v *validator) LogSyncCommitteeMessagesSubmitted() {
-	log.WithField("messages", v.syncCommitteeStats.totalMessagesSubmitted).Debug("Submitted sync committee messages successfully to beacon node")
+	log.WithField("messages", atomic.LoadUint64(&v.syncCommitteeStats.totalMessagesSubmitted)).Debug("Submitted sync committee messages successfully to beacon node")
 	// Reset the amount.
 	atomic.StoreUint64(&v.syncCommitteeStats.totalMessagesSubmitted, 0)
 }
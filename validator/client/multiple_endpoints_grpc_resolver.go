@@ -12,6 +12,8 @@ import (
 // It can be used with any grpc load balancer (pick_first, round_robin). Default is pick_first.
 // Round robin can be used by adding the following option:
 // grpc.WithDefaultServiceConfig("{\"loadBalancingConfig\":[{\"round_robin\":{}}]}")
+// Validator duties do not go through this connection when several endpoints are given: they are routed by the
+// beacon node pool, which dials every endpoint separately.
 type multipleEndpointsGrpcResolverBuilder struct{}
 
 // Build creates and starts multiple endpoints resolver.
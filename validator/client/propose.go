@@ -235,6 +235,34 @@ func ProposeExit(
 	return nil
 }
 
+// CreateSignedVoluntaryExit creates a voluntary exit for the validator with the given public key at the given epoch
+// and signs it, without submitting it to the beacon node.
+func CreateSignedVoluntaryExit(
+	ctx context.Context,
+	validatorClient iface.ValidatorClient,
+	signer iface.SigningFunc,
+	pubKey []byte,
+	epoch types.Epoch,
+) (*ethpb.SignedVoluntaryExit, error) {
+	ctx, span := trace.StartSpan(ctx, "validator.CreateSignedVoluntaryExit")
+	defer span.End()
+
+	indexResponse, err := validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey})
+	if err != nil {
+		return nil, errors.Wrap(err, "gRPC call to get validator index failed")
+	}
+	slot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	exit := &ethpb.VoluntaryExit{Epoch: epoch, ValidatorIndex: indexResponse.Index}
+	sig, err := signVoluntaryExit(ctx, validatorClient, signer, pubKey, exit, slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign voluntary exit")
+	}
+	return &ethpb.SignedVoluntaryExit{Exit: exit, Signature: sig}, nil
+}
+
 // Sign randao reveal with randao domain and private key.
 func (v *validator) signRandaoReveal(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, epoch types.Epoch, slot types.Slot) ([]byte, error) {
 	domain, err := v.domainData(ctx, epoch, params.BeaconConfig().DomainRandao[:])
@@ -336,9 +364,17 @@ func signVoluntaryExit(
 	return sig.Marshal(), nil
 }
 
-// Gets the graffiti from cli or file for the validator public key.
+// Gets the graffiti from proposer settings, cli or file for the validator public key.
 func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
-	// When specified, default graffiti from the command line takes the first priority.
+	// When specified, graffiti from the proposer settings of the validator takes the first priority.
+	if v.ProposerSettings() != nil && v.ProposerSettings().ProposeConfig != nil {
+		option, ok := v.ProposerSettings().ProposeConfig[pubKey]
+		if ok && option != nil && option.Graffiti != "" {
+			return []byte(option.Graffiti), nil
+		}
+	}
+
+	// When specified, default graffiti from the command line takes the second priority.
 	if len(v.graffiti) != 0 {
 		return v.graffiti, nil
 	}
@@ -347,7 +383,7 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		return nil, errors.New("graffitiStruct can't be nil")
 	}
 
-	// When specified, individual validator specified graffiti takes the second priority.
+	// When specified, individual validator specified graffiti takes the third priority.
 	idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
 	if err != nil {
 		return []byte{}, err
@@ -357,7 +393,7 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		return []byte(g), nil
 	}
 
-	// When specified, a graffiti from the ordered list in the file take third priority.
+	// When specified, a graffiti from the ordered list in the file take fourth priority.
 	if v.graffitiOrderedIndex < uint64(len(v.graffitiStruct.Ordered)) {
 		graffiti := v.graffitiStruct.Ordered[v.graffitiOrderedIndex]
 		v.graffitiOrderedIndex = v.graffitiOrderedIndex + 1
@@ -368,7 +404,7 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		return []byte(graffiti), nil
 	}
 
-	// When specified, a graffiti from the random list in the file take fourth priority.
+	// When specified, a graffiti from the random list in the file take fifth priority.
 	if len(v.graffitiStruct.Random) != 0 {
 		r := rand.NewGenerator()
 		r.Seed(time.Now().Unix())
@@ -376,10 +412,15 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		return []byte(v.graffitiStruct.Random[i]), nil
 	}
 
-	// Finally, default graffiti if specified in the file will be used.
+	// Then, default graffiti if specified in the file will be used.
 	if v.graffitiStruct.Default != "" {
 		return []byte(v.graffitiStruct.Default), nil
 	}
 
+	// Finally, default graffiti of the proposer settings will be used.
+	if v.ProposerSettings() != nil && v.ProposerSettings().DefaultConfig != nil && v.ProposerSettings().DefaultConfig.Graffiti != "" {
+		return []byte(v.ProposerSettings().DefaultConfig.Graffiti), nil
+	}
+
 	return []byte{}, nil
 }
@@ -13,6 +13,7 @@ import (
 	lruwrpr "github.com/prysmaticlabs/prysm/v3/cache/lru"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v3/config/validator/service"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	blocktest "github.com/prysmaticlabs/prysm/v3/consensus-types/blocks/testing"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
@@ -819,6 +820,42 @@ func TestProposeExit_BroadcastsBlock(t *testing.T) {
 	))
 }
 
+func TestCreateSignedVoluntaryExit(t *testing.T) {
+	_, m, validatorKey, finish := setup(t)
+	defer finish()
+
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), gomock.Any()).
+		Return(&ethpb.ValidatorIndexResponse{Index: 1}, nil)
+
+	m.validatorClient.EXPECT().
+		DomainData(gomock.Any(), &ethpb.DomainRequest{Epoch: 5, Domain: params.BeaconConfig().DomainVoluntaryExit[:]}).
+		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+	var signReq *validatorpb.SignRequest
+	signer := func(_ context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+		signReq = req
+		return validatorKey.Sign(req.SigningRoot), nil
+	}
+	signedExit, err := CreateSignedVoluntaryExit(
+		context.Background(),
+		m.validatorClient,
+		signer,
+		validatorKey.PublicKey().Marshal(),
+		5,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().SlotsPerEpoch.Mul(5), signReq.SigningSlot)
+	assert.Equal(t, types.Epoch(5), signedExit.Exit.Epoch)
+	assert.Equal(t, types.ValidatorIndex(1), signedExit.Exit.ValidatorIndex)
+
+	root, err := signing.ComputeSigningRoot(signedExit.Exit, make([]byte, 32))
+	require.NoError(t, err)
+	sig, err := bls.SignatureFromBytes(signedExit.Signature)
+	require.NoError(t, err)
+	assert.Equal(t, true, sig.Verify(validatorKey.PublicKey(), root[:]))
+}
+
 func TestSignBlock(t *testing.T) {
 	validator, m, _, finish := setup(t)
 	defer finish()
@@ -957,15 +994,43 @@ func TestGetGraffiti_Ok(t *testing.T) {
 			},
 			want: []byte{'b'},
 		},
+		{name: "use validator proposer settings graffiti",
+			v: &validator{
+				graffiti: []byte{'b'},
+				graffitiStruct: &graffiti.Graffiti{
+					Default: "c",
+				},
+				proposerSettings: &validatorserviceconfig.ProposerSettings{
+					ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+						pubKey: {Graffiti: "h"},
+					},
+					DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "i"},
+				},
+			},
+			want: []byte{'h'},
+		},
 		{name: "use default file graffiti",
 			v: &validator{
 				validatorClient: m.validatorClient,
 				graffitiStruct: &graffiti.Graffiti{
 					Default: "c",
 				},
+				proposerSettings: &validatorserviceconfig.ProposerSettings{
+					DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "i"},
+				},
 			},
 			want: []byte{'c'},
 		},
+		{name: "use default proposer settings graffiti",
+			v: &validator{
+				validatorClient: m.validatorClient,
+				graffitiStruct:  &graffiti.Graffiti{},
+				proposerSettings: &validatorserviceconfig.ProposerSettings{
+					DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "i"},
+				},
+			},
+			want: []byte{'i'},
+		},
 		{name: "use random file graffiti",
 			v: &validator{
 				validatorClient: m.validatorClient,
@@ -1001,7 +1066,7 @@ func TestGetGraffiti_Ok(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if !strings.Contains(tt.name, "use default cli graffiti") {
+			if !strings.Contains(tt.name, "use default cli graffiti") && !strings.Contains(tt.name, "use validator proposer settings graffiti") {
 				m.validatorClient.EXPECT().
 					ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
 					Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
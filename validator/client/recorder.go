@@ -0,0 +1,398 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// recvSuffix is appended to the method name of a server side stream to name the
+// messages received from that stream.
+const recvSuffix = ".Recv"
+
+// RecordedCall is a single request made by the validator client to the beacon node,
+// along with the response or error it received and when, relative to the slot clock,
+// the request was made. A recording is a file with one JSON encoded RecordedCall per line.
+type RecordedCall struct {
+	Method string     `json:"method"`
+	Slot   types.Slot `json:"slot"`
+	// Offset is the time elapsed since the start of the slot when the request was made.
+	Offset time.Duration `json:"offset"`
+	// Latency is the time it took for the beacon node to respond.
+	Latency          time.Duration          `json:"latency"`
+	Request          json.RawMessage        `json:"request,omitempty"`
+	ValidatorIndices []types.ValidatorIndex `json:"validator_indices,omitempty"`
+	Response         json.RawMessage        `json:"response,omitempty"`
+	Error            string                 `json:"error,omitempty"`
+	Code             codes.Code             `json:"code,omitempty"`
+	ConnectionIssue  bool                   `json:"connection_issue,omitempty"`
+}
+
+// ReadRecording reads the calls of a recording written by a recording validator client.
+func ReadRecording(r io.Reader) ([]*RecordedCall, error) {
+	dec := json.NewDecoder(r)
+	var calls []*RecordedCall
+	for {
+		call := &RecordedCall{}
+		if err := dec.Decode(call); err == io.EOF {
+			return calls, nil
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "could not decode recorded call %d", len(calls))
+		}
+		calls = append(calls, call)
+	}
+}
+
+// recordingValidatorClient captures every request made through the wrapped validator
+// client, along with the responses of the beacon node, to a recording.
+type recordingValidatorClient struct {
+	client      iface.ValidatorClient
+	lock        sync.Mutex
+	enc         *json.Encoder
+	genesisTime time.Time
+}
+
+// NewRecordingValidatorClient wraps the given validator client so that every call made
+// through it is written to w. The slot timing of the calls is known once the client has
+// received the genesis time from WaitForChainStart, calls made before are recorded at slot 0.
+func NewRecordingValidatorClient(client iface.ValidatorClient, w io.Writer) iface.ValidatorClient {
+	return &recordingValidatorClient{
+		client: client,
+		enc:    json.NewEncoder(w),
+	}
+}
+
+// marshalRecorded encodes a request or response of the validator client, which is a
+// protobuf message for all but the few calls that only exist in the REST API.
+func marshalRecorded(v interface{}) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.Marshal(m)
+	}
+	return json.Marshal(v)
+}
+
+// unmarshalRecorded decodes a request or response encoded by marshalRecorded into v.
+func unmarshalRecorded(b []byte, v interface{}) error {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.Unmarshal(b, m)
+	}
+	return json.Unmarshal(b, v)
+}
+
+func (r *recordingValidatorClient) record(method string, start time.Time, in, resp interface{}, err error) {
+	r.write(r.newCall(method, start, in, resp, err))
+}
+
+func (r *recordingValidatorClient) newCall(method string, start time.Time, in, resp interface{}, err error) *RecordedCall {
+	call := &RecordedCall{
+		Method:  method,
+		Latency: prysmTime.Now().Sub(start),
+	}
+	r.lock.Lock()
+	genesisTime := r.genesisTime
+	r.lock.Unlock()
+	if !genesisTime.IsZero() && !start.Before(genesisTime) {
+		slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+		sinceGenesis := start.Sub(genesisTime)
+		call.Slot = types.Slot(sinceGenesis / slotDuration)
+		call.Offset = sinceGenesis % slotDuration
+	}
+	if in != nil {
+		b, mErr := marshalRecorded(in)
+		if mErr != nil {
+			log.WithError(mErr).WithField("method", method).Error("Could not marshal recorded request")
+		}
+		call.Request = b
+	}
+	if err != nil {
+		st := status.Convert(err)
+		call.Error = st.Message()
+		call.Code = st.Code()
+		call.ConnectionIssue = errors.Is(err, iface.ErrConnectionIssue)
+	} else if resp != nil {
+		b, mErr := marshalRecorded(resp)
+		if mErr != nil {
+			log.WithError(mErr).WithField("method", method).Error("Could not marshal recorded response")
+		}
+		call.Response = b
+	}
+	return call
+}
+
+func (r *recordingValidatorClient) write(call *RecordedCall) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := r.enc.Encode(call); err != nil {
+		log.WithError(err).WithField("method", call.Method).Error("Could not record validator client call")
+	}
+}
+
+func (r *recordingValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetDuties(ctx, in)
+	r.record("GetDuties", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) StreamDuties(ctx context.Context, in *ethpb.DutiesRequest) (ethpb.BeaconNodeValidator_StreamDutiesClient, error) {
+	start := prysmTime.Now()
+	stream, err := r.client.StreamDuties(ctx, in)
+	r.record("StreamDuties", start, in, nil, err)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingDutiesStream{BeaconNodeValidator_StreamDutiesClient: stream, r: r}, nil
+}
+
+func (r *recordingValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.DomainData(ctx, in)
+	r.record("DomainData", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) WaitForChainStart(ctx context.Context, in *empty.Empty) (*ethpb.ChainStartResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.WaitForChainStart(ctx, in)
+	if err == nil && resp != nil {
+		r.lock.Lock()
+		r.genesisTime = time.Unix(int64(resp.GenesisTime), 0)
+		r.lock.Unlock()
+	}
+	r.record("WaitForChainStart", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	start := prysmTime.Now()
+	stream, err := r.client.WaitForActivation(ctx, in)
+	r.record("WaitForActivation", start, in, nil, err)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingActivationStream{BeaconNodeValidator_WaitForActivationClient: stream, r: r}, nil
+}
+
+func (r *recordingValidatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.ValidatorIndex(ctx, in)
+	r.record("ValidatorIndex", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest) (*ethpb.ValidatorStatusResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.ValidatorStatus(ctx, in)
+	r.record("ValidatorStatus", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.MultipleValidatorStatus(ctx, in)
+	r.record("MultipleValidatorStatus", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetBeaconBlock(ctx, in)
+	r.record("GetBeaconBlock", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.ProposeBeaconBlock(ctx, in)
+	r.record("ProposeBeaconBlock", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*empty.Empty, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.PrepareBeaconProposer(ctx, in)
+	r.record("PrepareBeaconProposer", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) GetFeeRecipientByPubKey(ctx context.Context, in *ethpb.FeeRecipientByPubKeyRequest) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetFeeRecipientByPubKey(ctx, in)
+	r.record("GetFeeRecipientByPubKey", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetAttestationData(ctx, in)
+	r.record("GetAttestationData", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.ProposeAttestation(ctx, in)
+	r.record("ProposeAttestation", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.SubmitAggregateSelectionProof(ctx, in)
+	r.record("SubmitAggregateSelectionProof", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.SubmitSignedAggregateSelectionProof(ctx, in)
+	r.record("SubmitSignedAggregateSelectionProof", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.ProposeExit(ctx, in)
+	r.record("ProposeExit", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, validatorIndices []types.ValidatorIndex) (*empty.Empty, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.SubscribeCommitteeSubnets(ctx, in, validatorIndices)
+	call := r.newCall("SubscribeCommitteeSubnets", start, in, resp, err)
+	call.ValidatorIndices = validatorIndices
+	r.write(call)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) GetAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetAggregatedSelections(ctx, selections)
+	r.record("GetAggregatedSelections", start, selections, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) GetAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetAggregatedSyncSelections(ctx, selections)
+	r.record("GetAggregatedSyncSelections", start, selections, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.CheckDoppelGanger(ctx, in)
+	r.record("CheckDoppelGanger", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) GetLiveness(ctx context.Context, in *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetLiveness(ctx, in)
+	r.record("GetLiveness", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) GetSyncMessageBlockRoot(ctx context.Context, in *empty.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetSyncMessageBlockRoot(ctx, in)
+	r.record("GetSyncMessageBlockRoot", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*empty.Empty, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.SubmitSyncMessage(ctx, in)
+	r.record("SubmitSyncMessage", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetSyncSubcommitteeIndex(ctx, in)
+	r.record("GetSyncSubcommitteeIndex", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.GetSyncCommitteeContribution(ctx, in)
+	r.record("GetSyncCommitteeContribution", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.SubmitSignedContributionAndProof(ctx, in)
+	r.record("SubmitSignedContributionAndProof", start, in, resp, err)
+	return resp, err
+}
+
+func (r *recordingValidatorClient) StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	start := prysmTime.Now()
+	stream, err := r.client.StreamBlocksAltair(ctx, in)
+	r.record("StreamBlocksAltair", start, in, nil, err)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingBlocksStream{BeaconNodeValidator_StreamBlocksAltairClient: stream, r: r}, nil
+}
+
+func (r *recordingValidatorClient) SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error) {
+	start := prysmTime.Now()
+	resp, err := r.client.SubmitValidatorRegistrations(ctx, in)
+	r.record("SubmitValidatorRegistrations", start, in, resp, err)
+	return resp, err
+}
+
+type recordingDutiesStream struct {
+	ethpb.BeaconNodeValidator_StreamDutiesClient
+	r *recordingValidatorClient
+}
+
+func (s *recordingDutiesStream) Recv() (*ethpb.DutiesResponse, error) {
+	start := prysmTime.Now()
+	resp, err := s.BeaconNodeValidator_StreamDutiesClient.Recv()
+	s.r.record("StreamDuties"+recvSuffix, start, nil, resp, err)
+	return resp, err
+}
+
+type recordingActivationStream struct {
+	ethpb.BeaconNodeValidator_WaitForActivationClient
+	r *recordingValidatorClient
+}
+
+func (s *recordingActivationStream) Recv() (*ethpb.ValidatorActivationResponse, error) {
+	start := prysmTime.Now()
+	resp, err := s.BeaconNodeValidator_WaitForActivationClient.Recv()
+	s.r.record("WaitForActivation"+recvSuffix, start, nil, resp, err)
+	return resp, err
+}
+
+type recordingBlocksStream struct {
+	ethpb.BeaconNodeValidator_StreamBlocksAltairClient
+	r *recordingValidatorClient
+}
+
+func (s *recordingBlocksStream) Recv() (*ethpb.StreamBlocksResponse, error) {
+	start := prysmTime.Now()
+	resp, err := s.BeaconNodeValidator_StreamBlocksAltairClient.Recv()
+	s.r.record("StreamBlocksAltair"+recvSuffix, start, nil, resp, err)
+	return resp, err
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/mock"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestRecordingValidatorClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockValidatorClient(ctrl)
+
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	genesis := time.Now().Add(-10*secondsPerSlot - 3*time.Second)
+	chainStart := &ethpb.ChainStartResponse{
+		Started:               true,
+		GenesisTime:           uint64(genesis.Unix()),
+		GenesisValidatorsRoot: make([]byte, 32),
+	}
+	dutiesReq := &ethpb.DutiesRequest{Epoch: 0, PublicKeys: [][]byte{{'a'}}}
+	attReq := &ethpb.AttestationDataRequest{Slot: 10, CommitteeIndex: 2}
+	data := &ethpb.AttestationData{Slot: 10, CommitteeIndex: 2, BeaconBlockRoot: []byte{'b'}}
+	client.EXPECT().WaitForChainStart(gomock.Any(), gomock.Any()).Return(chainStart, nil)
+	client.EXPECT().GetDuties(gomock.Any(), dutiesReq).Return(nil, status.Error(codes.Unavailable, "beacon node is down"))
+	client.EXPECT().GetAttestationData(gomock.Any(), attReq).Return(data, nil)
+	client.EXPECT().SubscribeCommitteeSubnets(gomock.Any(), gomock.Any(), []types.ValidatorIndex{1, 2}).Return(&emptypb.Empty{}, nil)
+
+	var buf bytes.Buffer
+	recorder := NewRecordingValidatorClient(client, &buf)
+	ctx := context.Background()
+	_, err := recorder.WaitForChainStart(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	_, err = recorder.GetDuties(ctx, dutiesReq)
+	require.ErrorContains(t, "beacon node is down", err)
+	_, err = recorder.GetAttestationData(ctx, attReq)
+	require.NoError(t, err)
+	_, err = recorder.SubscribeCommitteeSubnets(ctx, &ethpb.CommitteeSubnetsSubscribeRequest{}, []types.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+
+	calls, err := ReadRecording(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(calls))
+	for _, call := range calls {
+		assert.Equal(t, types.Slot(10), call.Slot)
+		assert.Equal(t, true, call.Offset >= 2*time.Second && call.Offset < 5*time.Second, "unexpected offset %v", call.Offset)
+	}
+
+	assert.Equal(t, "WaitForChainStart", calls[0].Method)
+	recordedChainStart := &ethpb.ChainStartResponse{}
+	require.NoError(t, protojson.Unmarshal(calls[0].Response, recordedChainStart))
+	assert.DeepSSZEqual(t, chainStart, recordedChainStart)
+
+	assert.Equal(t, "GetDuties", calls[1].Method)
+	assert.Equal(t, codes.Unavailable, calls[1].Code)
+	assert.Equal(t, "beacon node is down", calls[1].Error)
+	assert.Equal(t, 0, len(calls[1].Response))
+	recordedDutiesReq := &ethpb.DutiesRequest{}
+	require.NoError(t, protojson.Unmarshal(calls[1].Request, recordedDutiesReq))
+	assert.DeepSSZEqual(t, dutiesReq, recordedDutiesReq)
+
+	assert.Equal(t, "GetAttestationData", calls[2].Method)
+	recordedData := &ethpb.AttestationData{}
+	require.NoError(t, protojson.Unmarshal(calls[2].Response, recordedData))
+	assert.DeepSSZEqual(t, data, recordedData)
+
+	assert.Equal(t, "SubscribeCommitteeSubnets", calls[3].Method)
+	assert.DeepEqual(t, []types.ValidatorIndex{1, 2}, calls[3].ValidatorIndices)
+}
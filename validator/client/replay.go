@@ -0,0 +1,778 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/async/event"
+	lruwrpr "github.com/prysmaticlabs/prysm/v3/cache/lru"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/prysmaticlabs/prysm/v3/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+	vdb "github.com/prysmaticlabs/prysm/v3/validator/db"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrRecordingExhausted is returned by a replay validator client when the recording has no
+// more responses for the requested method.
+var ErrRecordingExhausted = errors.New("no recorded response left")
+
+// ReplayValidatorClient is a validator client which serves the responses of a recorded beacon
+// node session. The recording is replayed on a simulated clock, which starts at the genesis time
+// of the recorded chain start plus the timing of the first recorded call, and which only moves
+// forward when the validator client is idle. Every call is answered, after the recorded latency,
+// with the oldest unused response recorded for the same method in the current slot, preferring
+// one whose recorded request equals the actual request, or else with the oldest unused response
+// of that method.
+type ReplayValidatorClient struct {
+	lock        sync.Mutex
+	genesisTime time.Time
+	clock       *replayClock
+	pending     map[string][]*RecordedCall
+	remaining   int
+	done        chan struct{}
+	calls       []*RecordedCall
+}
+
+// NewReplayValidatorClient creates a validator client replaying the given recording, which
+// must contain the WaitForChainStart response of the recorded session. Its simulated clock
+// only moves forward while the recording is replayed with Replay.
+func NewReplayValidatorClient(recording []*RecordedCall) (*ReplayValidatorClient, error) {
+	if len(recording) == 0 {
+		return nil, errors.New("recording is empty")
+	}
+	var chainStart *RecordedCall
+	for _, call := range recording {
+		if call.Method == "WaitForChainStart" && call.Error == "" {
+			chainStart = call
+			break
+		}
+	}
+	if chainStart == nil {
+		return nil, errors.New("recording does not contain the chain start of the beacon node")
+	}
+	resp := &ethpb.ChainStartResponse{}
+	if err := unmarshalRecorded(chainStart.Response, resp); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal recorded chain start")
+	}
+	r := &ReplayValidatorClient{
+		genesisTime: time.Unix(int64(resp.GenesisTime), 0), // lint:ignore uintcast -- Genesis time will not exceed int64 in your lifetime.
+		pending:     make(map[string][]*RecordedCall),
+		remaining:   len(recording),
+		done:        make(chan struct{}),
+	}
+	r.clock = newReplayClock(r.recordedTime(recording[0]))
+	for _, call := range recording {
+		r.pending[call.Method] = append(r.pending[call.Method], call)
+	}
+	return r, nil
+}
+
+// GenesisTime is the genesis time of the recorded chain start.
+func (r *ReplayValidatorClient) GenesisTime() time.Time {
+	return r.genesisTime
+}
+
+// Now is the current time of the simulated clock of the replay.
+func (r *ReplayValidatorClient) Now() time.Time {
+	return r.clock.Now()
+}
+
+// Done is closed once every recorded response has been replayed.
+func (r *ReplayValidatorClient) Done() <-chan struct{} {
+	return r.done
+}
+
+// Calls returns the calls made to the replay validator client so far, in the same format as
+// a recording, with the slot timing of the simulated slot clock.
+func (r *ReplayValidatorClient) Calls() []*RecordedCall {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	calls := make([]*RecordedCall, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// now returns the current slot of the simulated clock and the time elapsed since its start.
+func (r *ReplayValidatorClient) now() (types.Slot, time.Duration) {
+	sinceGenesis := r.clock.Now().Sub(r.genesisTime)
+	if sinceGenesis < 0 {
+		return 0, 0
+	}
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	return types.Slot(sinceGenesis / slotDuration), sinceGenesis % slotDuration
+}
+
+// recordedTime is the time of the simulated clock at which the given call was recorded.
+func (r *ReplayValidatorClient) recordedTime(call *RecordedCall) time.Time {
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	return r.genesisTime.Add(time.Duration(call.Slot)*slotDuration + call.Offset)
+}
+
+// nextRecorded returns the earliest recorded time after the given time of the calls which were
+// not replayed yet.
+func (r *ReplayValidatorClient) nextRecorded(after time.Time) (time.Time, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var next time.Time
+	found := false
+	for _, pending := range r.pending {
+		for _, call := range pending {
+			at := r.recordedTime(call)
+			if at.After(after) && (!found || at.Before(next)) {
+				next, found = at, true
+			}
+		}
+	}
+	return next, found
+}
+
+// run drives the simulated clock until the context is canceled. Whenever the validator client
+// is idle, the clock moves to the time of its next timer, but not past the recorded time of a
+// call which was not replayed yet, unless the validator client stalls for replayStallPeriod.
+func (r *ReplayValidatorClient) run(ctx context.Context) {
+	var stalled time.Duration
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.clock.activity:
+			stalled = 0
+			continue
+		case <-time.After(replayIdlePeriod):
+		}
+		next, ok := r.clock.next()
+		if !ok {
+			continue
+		}
+		if at, ok := r.nextRecorded(r.clock.Now()); ok && at.Before(next) && stalled < replayStallPeriod {
+			stalled += replayIdlePeriod
+			continue
+		}
+		stalled = 0
+		r.clock.advance(next)
+	}
+}
+
+// next records the given request and takes the recorded call answering it.
+func (r *ReplayValidatorClient) next(method string, in interface{}) (*RecordedCall, error) {
+	r.clock.touch()
+	slot, offset := r.now()
+	call := &RecordedCall{Method: method, Slot: slot, Offset: offset}
+	if in != nil {
+		b, err := marshalRecorded(in)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal request")
+		}
+		call.Request = b
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls = append(r.calls, call)
+	pending := r.pending[method]
+	if len(pending) == 0 {
+		return nil, errors.Wrapf(ErrRecordingExhausted, "method %s at slot %d", method, slot)
+	}
+	i := 0
+	for j, c := range pending {
+		if c.Slot != slot {
+			continue
+		}
+		if requestEqual(c, in) {
+			i = j
+			break
+		}
+		if pending[i].Slot != slot {
+			i = j
+		}
+	}
+	found := pending[i]
+	r.pending[method] = append(pending[:i:i], pending[i+1:]...)
+	r.remaining--
+	if r.remaining == 0 {
+		close(r.done)
+	}
+	return found, nil
+}
+
+func requestEqual(call *RecordedCall, in interface{}) bool {
+	if in == nil || len(call.Request) == 0 {
+		return in == nil && len(call.Request) == 0
+	}
+	m, ok := in.(proto.Message)
+	if !ok {
+		b, err := json.Marshal(in)
+		return err == nil && bytes.Equal(b, call.Request)
+	}
+	recorded := m.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(call.Request, recorded); err != nil {
+		return false
+	}
+	return proto.Equal(recorded, m)
+}
+
+// reply waits for the recorded latency of the call on the simulated clock and returns its
+// recorded outcome, unmarshaling the recorded response into resp.
+func (r *ReplayValidatorClient) reply(ctx context.Context, call *RecordedCall, resp interface{}) error {
+	if call.Latency > 0 {
+		select {
+		case <-r.clock.After(call.Latency):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if call.Error != "" {
+		return recordedError(call)
+	}
+	if resp == nil || len(call.Response) == 0 {
+		return nil
+	}
+	return unmarshalRecorded(call.Response, resp)
+}
+
+func recordedError(call *RecordedCall) error {
+	var err error
+	switch {
+	case call.Error == io.EOF.Error():
+		return io.EOF
+	case call.Code != codes.OK && call.Code != codes.Unknown:
+		err = status.Error(call.Code, call.Error)
+	default:
+		err = errors.New(call.Error)
+	}
+	if call.ConnectionIssue {
+		return errors.Wrap(iface.ErrConnectionIssue, err.Error())
+	}
+	return err
+}
+
+func (r *ReplayValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	call, err := r.next("GetDuties", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.DutiesResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) StreamDuties(ctx context.Context, in *ethpb.DutiesRequest) (ethpb.BeaconNodeValidator_StreamDutiesClient, error) {
+	stream, err := r.stream(ctx, "StreamDuties", in)
+	if err != nil {
+		return nil, err
+	}
+	return &replayDutiesStream{stream}, nil
+}
+
+func (r *ReplayValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	call, err := r.next("DomainData", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.DomainResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// WaitForChainStart replies with the recorded chain start, with the genesis time of the
+// simulated clock.
+func (r *ReplayValidatorClient) WaitForChainStart(ctx context.Context, in *empty.Empty) (*ethpb.ChainStartResponse, error) {
+	call, err := r.next("WaitForChainStart", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.ChainStartResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	resp.GenesisTime = uint64(r.genesisTime.Unix())
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	stream, err := r.stream(ctx, "WaitForActivation", in)
+	if err != nil {
+		return nil, err
+	}
+	return &replayActivationStream{stream}, nil
+}
+
+func (r *ReplayValidatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	call, err := r.next("ValidatorIndex", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.ValidatorIndexResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest) (*ethpb.ValidatorStatusResponse, error) {
+	call, err := r.next("ValidatorStatus", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.ValidatorStatusResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	call, err := r.next("MultipleValidatorStatus", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.MultipleValidatorStatusResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	call, err := r.next("GetBeaconBlock", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.GenericBeaconBlock{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	call, err := r.next("ProposeBeaconBlock", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.ProposeResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*empty.Empty, error) {
+	call, err := r.next("PrepareBeaconProposer", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &empty.Empty{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) GetFeeRecipientByPubKey(ctx context.Context, in *ethpb.FeeRecipientByPubKeyRequest) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+	call, err := r.next("GetFeeRecipientByPubKey", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.FeeRecipientByPubKeyResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	call, err := r.next("GetAttestationData", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.AttestationData{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	call, err := r.next("ProposeAttestation", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.AttestResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	call, err := r.next("SubmitAggregateSelectionProof", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.AggregateSelectionResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	call, err := r.next("SubmitSignedAggregateSelectionProof", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.SignedAggregateSubmitResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	call, err := r.next("ProposeExit", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.ProposeExitResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, _ []types.ValidatorIndex) (*empty.Empty, error) {
+	call, err := r.next("SubscribeCommitteeSubnets", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &empty.Empty{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) GetAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	call, err := r.next("GetAggregatedSelections", selections)
+	if err != nil {
+		return nil, err
+	}
+	var resp []iface.BeaconCommitteeSelection
+	if err := r.reply(ctx, call, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) GetAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	call, err := r.next("GetAggregatedSyncSelections", selections)
+	if err != nil {
+		return nil, err
+	}
+	var resp []iface.SyncCommitteeSelection
+	if err := r.reply(ctx, call, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	call, err := r.next("CheckDoppelGanger", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.DoppelGangerResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) GetLiveness(ctx context.Context, in *ethpbv2.GetLivenessRequest) (*ethpbv2.GetLivenessResponse, error) {
+	call, err := r.next("GetLiveness", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpbv2.GetLivenessResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) GetSyncMessageBlockRoot(ctx context.Context, in *empty.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	call, err := r.next("GetSyncMessageBlockRoot", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.SyncMessageBlockRootResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*empty.Empty, error) {
+	call, err := r.next("SubmitSyncMessage", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &empty.Empty{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	call, err := r.next("GetSyncSubcommitteeIndex", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.SyncSubcommitteeIndexResponse{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	call, err := r.next("GetSyncCommitteeContribution", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.SyncCommitteeContribution{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error) {
+	call, err := r.next("SubmitSignedContributionAndProof", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &empty.Empty{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *ReplayValidatorClient) StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	stream, err := r.stream(ctx, "StreamBlocksAltair", in)
+	if err != nil {
+		return nil, err
+	}
+	return &replayBlocksStream{stream}, nil
+}
+
+func (r *ReplayValidatorClient) SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error) {
+	call, err := r.next("SubmitValidatorRegistrations", in)
+	if err != nil {
+		return nil, err
+	}
+	resp := &empty.Empty{}
+	if err := r.reply(ctx, call, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// stream opens a replayed server side stream. A stream whose opening was not recorded
+// is opened anyway, as if the beacon node never sent anything on it.
+func (r *ReplayValidatorClient) stream(ctx context.Context, method string, in proto.Message) (*replayStream, error) {
+	call, err := r.next(method, in)
+	switch {
+	case errors.Is(err, ErrRecordingExhausted):
+	case err != nil:
+		return nil, err
+	default:
+		if err := r.reply(ctx, call, nil); err != nil {
+			return nil, err
+		}
+	}
+	return &replayStream{ctx: ctx, r: r, method: method + recvSuffix}, nil
+}
+
+// replayStream replays the messages received on a server side stream. Each message is
+// received at the time of the simulated clock it was received in the recording.
+type replayStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	r      *ReplayValidatorClient
+	method string
+}
+
+func (s *replayStream) recv(resp proto.Message) error {
+	call, err := s.r.next(s.method, nil)
+	if errors.Is(err, ErrRecordingExhausted) {
+		<-s.ctx.Done()
+		return s.ctx.Err()
+	}
+	if err != nil {
+		return err
+	}
+	receivedAt := s.r.recordedTime(call).Add(call.Latency)
+	select {
+	case <-s.r.clock.After(receivedAt.Sub(s.r.clock.Now())):
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+	if call.Error != "" {
+		return recordedError(call)
+	}
+	return protojson.Unmarshal(call.Response, resp)
+}
+
+func (s *replayStream) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *replayStream) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+func (s *replayStream) CloseSend() error {
+	return nil
+}
+
+func (s *replayStream) Context() context.Context {
+	return s.ctx
+}
+
+type replayDutiesStream struct {
+	*replayStream
+}
+
+func (s *replayDutiesStream) Recv() (*ethpb.DutiesResponse, error) {
+	resp := &ethpb.DutiesResponse{}
+	if err := s.recv(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type replayActivationStream struct {
+	*replayStream
+}
+
+func (s *replayActivationStream) Recv() (*ethpb.ValidatorActivationResponse, error) {
+	resp := &ethpb.ValidatorActivationResponse{}
+	if err := s.recv(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type replayBlocksStream struct {
+	*replayStream
+}
+
+func (s *replayBlocksStream) Recv() (*ethpb.StreamBlocksResponse, error) {
+	resp := &ethpb.StreamBlocksResponse{}
+	if err := s.recv(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// replayBeaconChainClient answers the beacon chain requests made by the validator outside
+// of the validator client, which are not part of a recording, from the simulated clock.
+type replayBeaconChainClient struct {
+	ethpb.BeaconChainClient
+	r *ReplayValidatorClient
+}
+
+func (c *replayBeaconChainClient) GetChainHead(_ context.Context, _ *empty.Empty, _ ...grpc.CallOption) (*ethpb.ChainHead, error) {
+	slot, _ := c.r.now()
+	return &ethpb.ChainHead{HeadSlot: slot, HeadEpoch: slots.ToEpoch(slot)}, nil
+}
+
+func (*replayBeaconChainClient) ListValidators(_ context.Context, _ *ethpb.ListValidatorsRequest, _ ...grpc.CallOption) (*ethpb.Validators, error) {
+	return &ethpb.Validators{}, nil
+}
+
+// replayNodeClient reports a synced beacon node.
+type replayNodeClient struct {
+	ethpb.NodeClient
+}
+
+func (*replayNodeClient) GetSyncStatus(_ context.Context, _ *empty.Empty, _ ...grpc.CallOption) (*ethpb.SyncStatus, error) {
+	return &ethpb.SyncStatus{Syncing: false}, nil
+}
+
+// Replay runs the validator client against the given recording of a beacon node session,
+// signing with the given keymanager and protecting against slashings with the given database,
+// until the context is canceled. The validator client runs on the simulated clock of the replay.
+// The returned replay validator client is used to follow the progress of the replay and to
+// inspect the requests of the validator client.
+func Replay(ctx context.Context, recording []*RecordedCall, km keymanager.IKeymanager, db vdb.Database) (*ReplayValidatorClient, <-chan struct{}, error) {
+	r, err := NewReplayValidatorClient(recording)
+	if err != nil {
+		return nil, nil, err
+	}
+	v, err := newReplayValidator(r, km, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	stopped := make(chan struct{})
+	go r.run(ctx)
+	go func() {
+		defer close(stopped)
+		run(ctx, v)
+	}()
+	return r, stopped, nil
+}
+
+func newReplayValidator(r *ReplayValidatorClient, km keymanager.IKeymanager, db vdb.Database) (*validator, error) {
+	cache, err := newDomainDataCache()
+	if err != nil {
+		return nil, err
+	}
+	v := &validator{
+		db:                             db,
+		keyManager:                     km,
+		validatorClient:                r,
+		clock:                          r.clock,
+		beaconClient:                   &replayBeaconChainClient{r: r},
+		node:                           &replayNodeClient{},
+		startBalances:                  make(map[[fieldparams.BLSPubkeyLength]byte]uint64),
+		prevBalance:                    make(map[[fieldparams.BLSPubkeyLength]byte]uint64),
+		pubkeyToValidatorIndex:         make(map[[fieldparams.BLSPubkeyLength]byte]types.ValidatorIndex),
+		doppelGangerKeys:               make(map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey),
+		signedValidatorRegistrations:   make(map[[fieldparams.BLSPubkeyLength]byte]*ethpb.SignedValidatorRegistrationV1),
+		attLogs:                        make(map[[32]byte]*attSubmitted),
+		domainDataCache:                cache,
+		aggregatedSlotCommitteeIDCache: lruwrpr.New(int(params.BeaconConfig().MaxCommitteesPerSlot)),
+		voteStats:                      voteStats{startEpoch: types.Epoch(^uint64(0))},
+		eipImportBlacklistedPublicKeys: make(map[[fieldparams.BLSPubkeyLength]byte]bool),
+		blockFeed:                      new(event.Feed),
+		walletInitializedChannel:       make(chan *wallet.Wallet, 1),
+	}
+	return v, nil
+}
@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+var (
+	// replayIdlePeriod is how long the validator client must be idle before the simulated clock
+	// of a replay moves forward.
+	replayIdlePeriod = 10 * time.Millisecond
+	// replayStallPeriod is how long the simulated clock of a replay waits for a recorded call
+	// before moving past its recorded time.
+	replayStallPeriod = time.Second
+)
+
+// replayClock is the simulated clock of a replay. It only moves forward once the validator
+// client is idle, straight to the time of the next timer, so that a replay is as fast as the
+// validator client and does not depend on the speed of the machine running it.
+type replayClock struct {
+	lock     sync.Mutex
+	now      time.Time
+	timers   []*replayTimer
+	activity chan struct{}
+}
+
+type replayTimer struct {
+	at   time.Time
+	fire func(now time.Time)
+}
+
+func newReplayClock(now time.Time) *replayClock {
+	return &replayClock{now: now, activity: make(chan struct{}, 1)}
+}
+
+// Now is the current time of the simulated clock.
+func (c *replayClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// After sends the time on the returned channel once the simulated clock moved forward by d.
+func (c *replayClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.schedule(c.Now().Add(d), func(now time.Time) {
+		ch <- now
+	})
+	return ch
+}
+
+// WithDeadline returns a copy of the context which is canceled once the simulated clock
+// reaches the deadline.
+func (c *replayClock) WithDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	t := c.schedule(deadline, func(time.Time) {
+		cancel()
+	})
+	return ctx, func() {
+		c.stop(t)
+		cancel()
+	}
+}
+
+// NewSlotTicker returns a ticker emitting each slot when the simulated clock reaches its start.
+func (c *replayClock) NewSlotTicker(genesisTime time.Time, secondsPerSlot uint64) slots.Ticker {
+	t := &replaySlotTicker{
+		clock:        c,
+		c:            make(chan types.Slot, 1),
+		genesisTime:  genesisTime,
+		slotDuration: time.Duration(secondsPerSlot) * time.Second,
+	}
+	next := types.Slot(0)
+	if sinceGenesis := c.Now().Sub(genesisTime); sinceGenesis >= 0 {
+		next = types.Slot(sinceGenesis/t.slotDuration) + 1
+	}
+	t.tick(next)
+	return t
+}
+
+// touch signals activity of the validator client, which postpones the next move of the clock.
+func (c *replayClock) touch() {
+	select {
+	case c.activity <- struct{}{}:
+	default:
+	}
+}
+
+// schedule calls fire once the simulated clock reaches the given time.
+func (c *replayClock) schedule(at time.Time, fire func(now time.Time)) *replayTimer {
+	t := &replayTimer{at: at, fire: fire}
+	c.lock.Lock()
+	if !at.After(c.now) {
+		now := c.now
+		c.lock.Unlock()
+		fire(now)
+		return t
+	}
+	c.timers = append(c.timers, t)
+	c.lock.Unlock()
+	c.touch()
+	return t
+}
+
+func (c *replayClock) stop(t *replayTimer) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i, timer := range c.timers {
+		if timer == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+// next returns the time of the next timer of the simulated clock.
+func (c *replayClock) next() (time.Time, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.timers) == 0 {
+		return time.Time{}, false
+	}
+	next := c.timers[0].at
+	for _, t := range c.timers[1:] {
+		if t.at.Before(next) {
+			next = t.at
+		}
+	}
+	return next, true
+}
+
+// advance moves the simulated clock forward to the given time and fires the timers which are due.
+func (c *replayClock) advance(to time.Time) {
+	c.lock.Lock()
+	if to.After(c.now) {
+		c.now = to
+	}
+	var due []*replayTimer
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			due = append(due, t)
+		}
+	}
+	c.timers = pending
+	now := c.now
+	c.lock.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].at.Before(due[j].at)
+	})
+	for _, t := range due {
+		t.fire(now)
+	}
+}
+
+// replaySlotTicker is a slot ticker driven by the simulated clock of a replay.
+type replaySlotTicker struct {
+	clock        *replayClock
+	c            chan types.Slot
+	genesisTime  time.Time
+	slotDuration time.Duration
+	lock         sync.Mutex
+	timer        *replayTimer
+	done         bool
+}
+
+func (t *replaySlotTicker) C() <-chan types.Slot {
+	return t.c
+}
+
+func (t *replaySlotTicker) Done() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.done = true
+	if t.timer != nil {
+		t.clock.stop(t.timer)
+	}
+}
+
+// tick schedules the given slot, which starts after the current time of the clock.
+func (t *replaySlotTicker) tick(slot types.Slot) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.done {
+		return
+	}
+	t.timer = t.clock.schedule(t.genesisTime.Add(time.Duration(slot)*t.slotDuration), func(time.Time) {
+		select {
+		case t.c <- slot:
+		default:
+		}
+		t.tick(slot + 1)
+	})
+}
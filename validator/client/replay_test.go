@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+	dbTest "github.com/prysmaticlabs/prysm/v3/validator/db/testing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func recordedCall(t *testing.T, method string, slot types.Slot, in, resp proto.Message) *RecordedCall {
+	call := &RecordedCall{Method: method, Slot: slot}
+	var err error
+	if in != nil {
+		call.Request, err = protojson.Marshal(in)
+		require.NoError(t, err)
+	}
+	if resp != nil {
+		call.Response, err = protojson.Marshal(resp)
+		require.NoError(t, err)
+	}
+	return call
+}
+
+func TestReplayValidatorClient(t *testing.T) {
+	chainStart := &ethpb.ChainStartResponse{Started: true, GenesisTime: 1606824023, GenesisValidatorsRoot: make([]byte, 32)}
+	first := &ethpb.AttestationData{Slot: 5, CommitteeIndex: 1}
+	second := &ethpb.AttestationData{Slot: 5, CommitteeIndex: 2}
+	failedDuties := recordedCall(t, "GetDuties", 5, &ethpb.DutiesRequest{}, nil)
+	failedDuties.Error = "beacon node is down"
+	failedDuties.Code = codes.Unavailable
+	recording := []*RecordedCall{
+		recordedCall(t, "WaitForChainStart", 5, &emptypb.Empty{}, chainStart),
+		recordedCall(t, "GetAttestationData", 5, &ethpb.AttestationDataRequest{Slot: 5, CommitteeIndex: 1}, first),
+		recordedCall(t, "GetAttestationData", 5, &ethpb.AttestationDataRequest{Slot: 5, CommitteeIndex: 2}, second),
+		failedDuties,
+	}
+	r, err := NewReplayValidatorClient(recording)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	resp, err := r.WaitForChainStart(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, chainStart.GenesisTime, resp.GenesisTime)
+	assert.Equal(t, uint64(r.GenesisTime().Unix()), resp.GenesisTime)
+	assert.Equal(t, r.GenesisTime().Add(5*time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second), r.Now())
+	assert.DeepEqual(t, chainStart.GenesisValidatorsRoot, resp.GenesisValidatorsRoot)
+
+	// Recorded responses to the same request are preferred.
+	data, err := r.GetAttestationData(ctx, &ethpb.AttestationDataRequest{Slot: 5, CommitteeIndex: 2})
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, second, data)
+	data, err = r.GetAttestationData(ctx, &ethpb.AttestationDataRequest{Slot: 5, CommitteeIndex: 3})
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, first, data)
+	_, err = r.GetAttestationData(ctx, &ethpb.AttestationDataRequest{Slot: 5, CommitteeIndex: 1})
+	assert.Equal(t, true, errors.Is(err, ErrRecordingExhausted))
+
+	_, err = r.GetDuties(ctx, &ethpb.DutiesRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorContains(t, "beacon node is down", err)
+
+	select {
+	case <-r.Done():
+	default:
+		t.Error("Replay should be done once every recorded response has been replayed")
+	}
+	calls := r.Calls()
+	require.Equal(t, 5, len(calls))
+	assert.Equal(t, "GetDuties", calls[4].Method)
+	assert.Equal(t, types.Slot(5), calls[4].Slot)
+}
+
+func TestReplayValidatorClient_ConnectionIssue(t *testing.T) {
+	failed := recordedCall(t, "WaitForChainStart", 0, &emptypb.Empty{}, nil)
+	failed.Error = "could not connect"
+	failed.Code = codes.Unavailable
+	failed.ConnectionIssue = true
+	recording := []*RecordedCall{
+		failed,
+		recordedCall(t, "WaitForChainStart", 0, &emptypb.Empty{}, &ethpb.ChainStartResponse{Started: true}),
+	}
+	r, err := NewReplayValidatorClient(recording)
+	require.NoError(t, err)
+	_, err = r.WaitForChainStart(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, true, errors.Is(err, iface.ErrConnectionIssue))
+	_, err = r.WaitForChainStart(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+
+	_, err = NewReplayValidatorClient(recording[:1])
+	assert.ErrorContains(t, "does not contain the chain start", err)
+}
+
+func TestReplay_SubmitsAttestation(t *testing.T) {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], key.PublicKey().Marshal())
+	km := &mockKeymanager{keysMap: map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{pubKey: key}}
+
+	root := make([]byte, 32)
+	data := &ethpb.AttestationData{
+		Slot:            2,
+		BeaconBlockRoot: root,
+		Source:          &ethpb.Checkpoint{Root: root},
+		Target:          &ethpb.Checkpoint{Root: root},
+	}
+	activated := recordedCall(t, "WaitForActivation.Recv", 1, nil, &ethpb.ValidatorActivationResponse{
+		Statuses: []*ethpb.ValidatorActivationResponse_Status{{
+			PublicKey: pubKey[:],
+			Status:    &ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_ACTIVE},
+		}},
+	})
+	activated.Latency = 100 * time.Millisecond
+	duties := []*ethpb.DutiesResponse_Duty{{
+		PublicKey:    pubKey[:],
+		Committee:    []types.ValidatorIndex{0},
+		AttesterSlot: 2,
+		Status:       ethpb.ValidatorStatus_ACTIVE,
+	}}
+	domain := &ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}
+	// The attestation data is requested a third into the slot.
+	dataRequested := recordedCall(t, "GetAttestationData", 2, nil, data)
+	dataRequested.Offset = slots.DivideSlotBy(3) + 10*time.Millisecond
+	attested := recordedCall(t, "ProposeAttestation", 2, nil, &ethpb.AttestResponse{AttestationDataRoot: root})
+	attested.Offset = dataRequested.Offset + 20*time.Millisecond
+	recording := []*RecordedCall{
+		recordedCall(t, "WaitForChainStart", 1, &emptypb.Empty{}, &ethpb.ChainStartResponse{Started: true, GenesisValidatorsRoot: root}),
+		recordedCall(t, "WaitForActivation", 1, nil, nil),
+		activated,
+		recordedCall(t, "GetDuties", 1, nil, &ethpb.DutiesResponse{Duties: duties, CurrentEpochDuties: duties}),
+		recordedCall(t, "SubscribeCommitteeSubnets", 1, nil, &emptypb.Empty{}),
+		recordedCall(t, "DomainData", 1, nil, domain),
+		recordedCall(t, "MultipleValidatorStatus", 2, nil, &ethpb.MultipleValidatorStatusResponse{
+			PublicKeys: [][]byte{pubKey[:]},
+			Statuses:   []*ethpb.ValidatorStatusResponse{{Status: ethpb.ValidatorStatus_ACTIVE}},
+		}),
+		recordedCall(t, "DomainData", 2, nil, domain),
+		recordedCall(t, "DomainData", 2, nil, domain),
+		dataRequested,
+		attested,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	r, stopped, err := Replay(ctx, recording, km, dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}))
+	require.NoError(t, err)
+
+	var attestation *ethpb.Attestation
+	for attestation == nil && ctx.Err() == nil {
+		time.Sleep(100 * time.Millisecond)
+		for _, call := range r.Calls() {
+			if call.Method == "ProposeAttestation" {
+				attestation = &ethpb.Attestation{}
+				require.NoError(t, protojson.Unmarshal(call.Request, attestation))
+				assert.Equal(t, types.Slot(2), call.Slot)
+				assert.Equal(t, slots.DivideSlotBy(3), call.Offset)
+			}
+		}
+	}
+	cancel()
+	<-stopped
+	require.NotNil(t, attestation, "Validator did not attest during the replay")
+	assert.DeepSSZEqual(t, data, attestation.Data)
+}
+
+func TestReplayClock(t *testing.T) {
+	start := time.Unix(1000, 0)
+	c := newReplayClock(start)
+
+	ticker := c.NewSlotTicker(time.Unix(994, 0), 12)
+	defer ticker.Done()
+	after := c.After(4 * time.Second)
+	ctx, cancel := c.WithDeadline(context.Background(), start.Add(2*time.Second))
+	defer cancel()
+
+	next, ok := c.next()
+	require.Equal(t, true, ok)
+	assert.Equal(t, start.Add(2*time.Second), next)
+	c.advance(next)
+	assert.Equal(t, context.Canceled, ctx.Err())
+
+	next, ok = c.next()
+	require.Equal(t, true, ok)
+	c.advance(next)
+	assert.Equal(t, start.Add(4*time.Second), <-after)
+
+	next, ok = c.next()
+	require.Equal(t, true, ok)
+	assert.Equal(t, time.Unix(1006, 0), next)
+	c.advance(next)
+	assert.Equal(t, types.Slot(1), <-ticker.C())
+	next, ok = c.next()
+	require.Equal(t, true, ok)
+	assert.Equal(t, time.Unix(1018, 0), next)
+
+	// Timers which are already due fire at once.
+	select {
+	case <-c.After(0):
+	default:
+		t.Error("Timer due at the current time of the clock should have fired")
+	}
+}
@@ -114,7 +114,7 @@ func run(ctx context.Context, v iface.Validator) {
 			}
 
 			deadline := v.SlotDeadline(slot)
-			slotCtx, cancel := context.WithDeadline(ctx, deadline)
+			slotCtx, cancel := v.WithDeadline(ctx, deadline)
 			log := log.WithField("slot", slot)
 			log.WithField("deadline", deadline).Debug("Set deadline for proposals and attestations")
 
@@ -151,6 +151,13 @@ func run(ctx context.Context, v iface.Validator) {
 				continue
 			}
 			performRoles(slotCtx, allRoles, v, slot, &wg, span)
+
+			// Check the liveness of the keys under doppelganger protection at the end of the epoch.
+			if slots.IsEpochEnd(slot) {
+				if err := v.CheckDoppelGangerLiveness(slotCtx, slot); err != nil {
+					log.WithError(err).Error("Could not check doppelganger liveness")
+				}
+			}
 		}
 	}
 }
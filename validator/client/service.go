@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"os"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/v3/api/grpc"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/v3/cache/lru"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v3/config/validator/service"
@@ -55,6 +57,7 @@ type ValidatorService struct {
 	logValidatorBalances  bool
 	interopKeysConfig     *local.InteropKeymanagerConfig
 	conn                  validatorHelpers.NodeConnection
+	nodeConns             []validatorHelpers.NodeConnection
 	grpcRetryDelay        time.Duration
 	grpcRetries           uint
 	maxCallRecvMsgSize    int
@@ -72,6 +75,10 @@ type ValidatorService struct {
 	graffiti              []byte
 	Web3SignerConfig      *remoteweb3signer.SetupConfig
 	proposerSettings      *validatorserviceconfig.ProposerSettings
+	recordingPath         string
+	recording             *os.File
+	distributed           bool
+	doppelGangerEpochs    uint64
 }
 
 // Config for the validator service.
@@ -97,6 +104,9 @@ type Config struct {
 	ProposerSettings           *validatorserviceconfig.ProposerSettings
 	BeaconApiEndpoint          string
 	BeaconApiTimeout           time.Duration
+	RecordingPath              string
+	Distributed                bool
+	DoppelGangerEpochs         uint64
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -125,6 +135,9 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		graffitiStruct:        cfg.GraffitiStruct,
 		Web3SignerConfig:      cfg.Web3SignerConfig,
 		proposerSettings:      cfg.ProposerSettings,
+		recordingPath:         cfg.RecordingPath,
+		distributed:           cfg.Distributed,
+		doppelGangerEpochs:    cfg.DoppelGangerEpochs,
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -146,23 +159,38 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 	if s.withCert != "" {
 		log.Info("Established secure gRPC connection")
 	}
+	beaconApiEndpoints := strings.Split(cfg.BeaconApiEndpoint, ",")
 	s.conn = validatorHelpers.NewNodeConnection(
 		grpcConn,
-		cfg.BeaconApiEndpoint,
+		beaconApiEndpoints[0],
 		cfg.BeaconApiTimeout,
 	)
 
+	// When several beacon nodes are configured, each node gets a connection of its own, so that duties
+	// can be routed to the healthiest node.
+	if features.Get().EnableBeaconRESTApi {
+		if len(beaconApiEndpoints) > 1 {
+			for _, endpoint := range beaconApiEndpoints {
+				s.nodeConns = append(s.nodeConns, validatorHelpers.NewNodeConnection(grpcConn, endpoint, cfg.BeaconApiTimeout))
+			}
+		}
+	} else if endpoints := strings.Split(s.endpoint, ","); len(endpoints) > 1 {
+		for _, endpoint := range endpoints {
+			nodeGrpcConn, err := grpc.DialContext(ctx, endpoint, dialOpts...)
+			if err != nil {
+				return s, err
+			}
+			s.nodeConns = append(s.nodeConns, validatorHelpers.NewNodeConnection(nodeGrpcConn, "", cfg.BeaconApiTimeout))
+		}
+	}
+
 	return s, nil
 }
 
 // Start the validator service. Launches the main go routine for the validator
 // client.
 func (v *ValidatorService) Start() {
-	cache, err := ristretto.NewCache(&ristretto.Config{
-		NumCounters: 1920, // number of keys to track.
-		MaxCost:     192,  // maximum cost of cache, 1 item = 1 cost.
-		BufferItems: 64,   // number of keys per Get buffer.
-	})
+	cache, err := newDomainDataCache()
 	if err != nil {
 		panic(err)
 	}
@@ -185,15 +213,38 @@ func (v *ValidatorService) Start() {
 		return
 	}
 
+	var validatorClient iface.ValidatorClient
+	var grpcConn grpc.ClientConnInterface = v.conn.GetGrpcClientConn()
+	if len(v.nodeConns) > 1 {
+		pool := validatorClientFactory.NewBeaconNodePoolValidatorClient(v.ctx, v.nodeConns)
+		validatorClient, grpcConn = pool, pool
+		log.WithField("beaconNodes", len(v.nodeConns)).Info("Routing duties to the healthiest beacon node")
+	} else {
+		validatorClient = validatorClientFactory.NewValidatorClient(v.conn)
+	}
+	if v.recordingPath != "" {
+		f, err := os.OpenFile(v.recordingPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, params.BeaconIoConfig().ReadWritePermissions)
+		if err != nil {
+			log.WithError(err).Error("Could not create beacon node session recording")
+			return
+		}
+		v.recording = f
+		validatorClient = NewRecordingValidatorClient(validatorClient, f)
+		log.WithField("path", v.recordingPath).Info("Recording beacon node session")
+	}
+
 	valStruct := &validator{
 		db:                             v.db,
-		validatorClient:                validatorClientFactory.NewValidatorClient(v.conn),
-		beaconClient:                   ethpb.NewBeaconChainClient(v.conn.GetGrpcClientConn()),
-		slashingProtectionClient:       ethpb.NewSlasherClient(v.conn.GetGrpcClientConn()),
-		node:                           ethpb.NewNodeClient(v.conn.GetGrpcClientConn()),
+		validatorClient:                validatorClient,
+		beaconClient:                   ethpb.NewBeaconChainClient(grpcConn),
+		slashingProtectionClient:       ethpb.NewSlasherClient(grpcConn),
+		node:                           ethpb.NewNodeClient(grpcConn),
 		graffiti:                       v.graffiti,
 		logValidatorBalances:           v.logValidatorBalances,
 		emitAccountMetrics:             v.emitAccountMetrics,
+		distributed:                    v.distributed,
+		doppelGangerKeys:               make(map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey),
+		doppelGangerEpochs:             v.doppelGangerEpochs,
 		startBalances:                  make(map[[fieldparams.BLSPubkeyLength]byte]uint64),
 		prevBalance:                    make(map[[fieldparams.BLSPubkeyLength]byte]uint64),
 		pubkeyToValidatorIndex:         make(map[[fieldparams.BLSPubkeyLength]byte]types.ValidatorIndex),
@@ -229,14 +280,35 @@ func (v *ValidatorService) Start() {
 	go run(v.ctx, v.validator)
 }
 
+func newDomainDataCache() (*ristretto.Cache, error) {
+	return ristretto.NewCache(&ristretto.Config{
+		NumCounters: 1920, // number of keys to track.
+		MaxCost:     192,  // maximum cost of cache, 1 item = 1 cost.
+		BufferItems: 64,   // number of keys per Get buffer.
+	})
+}
+
 // Stop the validator service.
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
-	if v.conn != nil {
-		return v.conn.GetGrpcClientConn().Close()
+	if v.recording != nil {
+		if err := v.recording.Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon node session recording")
+		}
 	}
-	return nil
+	if v.conn == nil {
+		return nil
+	}
+	for _, nodeConn := range v.nodeConns {
+		if nodeConn.GetGrpcClientConn() == v.conn.GetGrpcClientConn() {
+			continue
+		}
+		if err := nodeConn.GetGrpcClientConn().Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon node connection")
+		}
+	}
+	return v.conn.GetGrpcClientConn().Close()
 }
 
 // Status of the validator service.
@@ -265,6 +337,29 @@ func (v *ValidatorService) SetProposerSettings(settings *validatorserviceconfig.
 	v.validator.SetProposerSettings(settings)
 }
 
+// Graffiti returns the graffiti configured for the given public key, following the priority used when proposing.
+// Graffiti assigned by validator index or picked from the ordered and random lists of the graffiti file is only
+// resolved at proposal time, so it is not taken into account.
+func (v *ValidatorService) Graffiti(pubKey [fieldparams.BLSPubkeyLength]byte) []byte {
+	settings := v.ProposerSettings()
+	if settings != nil && settings.ProposeConfig != nil {
+		option, ok := settings.ProposeConfig[pubKey]
+		if ok && option != nil && option.Graffiti != "" {
+			return []byte(option.Graffiti)
+		}
+	}
+	if len(v.graffiti) != 0 {
+		return v.graffiti
+	}
+	if v.graffitiStruct != nil && v.graffitiStruct.Default != "" {
+		return []byte(v.graffitiStruct.Default)
+	}
+	if settings != nil && settings.DefaultConfig != nil {
+		return []byte(settings.DefaultConfig.Graffiti)
+	}
+	return []byte{}
+}
+
 // ConstructDialOptions constructs a list of grpc dial options
 func ConstructDialOptions(
 	maxCallRecvMsgSize int,
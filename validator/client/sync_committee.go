@@ -7,6 +7,7 @@ import (
 	"time"
 
 	emptypb "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
@@ -17,6 +18,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -116,7 +118,7 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 		return
 	}
 
-	selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, indexRes)
+	selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, indexRes, duty.ValidatorIndex)
 	if err != nil {
 		log.WithError(err).Error("Could not get selection proofs")
 		return
@@ -186,24 +188,160 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 	}
 }
 
-// Signs and returns selection proofs per validator for slot and pub key.
-func (v *validator) selectionProofs(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, indexRes *ethpb.SyncSubcommitteeIndexResponse) ([][]byte, error) {
+type syncSelectionKey struct {
+	slot         types.Slot
+	index        types.ValidatorIndex
+	subcommittee uint64
+}
+
+// Signs and returns selection proofs per validator for slot and pub key. In distributed mode, the
+// signatures are partial and are exchanged for the selection proofs aggregated over the cluster.
+func (v *validator) selectionProofs(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, indexRes *ethpb.SyncSubcommitteeIndexResponse, validatorIndex types.ValidatorIndex) ([][]byte, error) {
 	selectionProofs := make([][]byte, len(indexRes.Indices))
 	cfg := params.BeaconConfig()
 	size := cfg.SyncCommitteeSize
 	subCount := cfg.SyncCommitteeSubnetCount
+	var missing []iface.SyncCommitteeSelection
 	for i, index := range indexRes.Indices {
 		subSize := size / subCount
 		subnet := uint64(index) / subSize
+		if v.distributed {
+			if proof, ok := v.syncSelection(slot, validatorIndex, subnet); ok {
+				selectionProofs[i] = proof
+				continue
+			}
+		}
 		selectionProof, err := v.signSyncSelectionData(ctx, pubKey, subnet, slot)
 		if err != nil {
 			return nil, err
 		}
 		selectionProofs[i] = selectionProof
+		if v.distributed {
+			missing = append(missing, iface.SyncCommitteeSelection{
+				SelectionProof:    selectionProof,
+				Slot:              slot,
+				SubcommitteeIndex: types.CommitteeIndex(subnet),
+				ValidatorIndex:    validatorIndex,
+			})
+		}
+	}
+	if len(missing) == 0 {
+		return selectionProofs, nil
+	}
+
+	if err := v.aggregatedSyncSelectionProofs(ctx, slot, missing); err != nil {
+		return nil, errors.Wrap(err, "could not get aggregated sync selection proofs")
+	}
+	for i, index := range indexRes.Indices {
+		subnet := uint64(index) / (size / subCount)
+		proof, ok := v.syncSelection(slot, validatorIndex, subnet)
+		if !ok {
+			return nil, errors.Errorf("no aggregated sync selection proof for subcommittee %d", subnet)
+		}
+		selectionProofs[i] = proof
 	}
 	return selectionProofs, nil
 }
 
+// prepareSyncSelections exchanges the partial sync committee selection proofs of the keys in the sync
+// committee at the given slot for the ones aggregated over the distributed validator cluster, ahead of
+// the sync committee aggregator check. The exchange is bounded to a sixth of the slot, so that a slow
+// cluster does not hold back the duties of the slot. Keys which cannot get their aggregated proofs do
+// not aggregate, which is logged but not an error.
+func (v *validator) prepareSyncSelections(ctx context.Context, slot types.Slot, duties *ethpb.DutiesResponse) {
+	ctx, span := trace.StartSpan(ctx, "validator.prepareSyncSelections")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, slots.DivideSlotBy(6 /* a sixth of the slot duration */))
+	defer cancel()
+
+	subSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	var selections []iface.SyncCommitteeSelection
+	for i, duty := range duties.Duties {
+		if duty == nil {
+			continue
+		}
+		// As in RolesAt, the sync committee of the following epoch is checked at the last slot of the epoch.
+		inSyncCommittee := duty.IsSyncCommittee
+		if slots.IsEpochEnd(slot) {
+			inSyncCommittee = i < len(duties.NextEpochDuties) && duties.NextEpochDuties[i].IsSyncCommittee
+		}
+		if !inSyncCommittee {
+			continue
+		}
+		pubKey := bytesutil.ToBytes48(duty.PublicKey)
+		res, err := v.validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
+			PublicKey: pubKey[:],
+			Slot:      slot,
+		})
+		if err != nil {
+			log.WithError(err).WithField("slot", slot).Warn("Could not get sync subcommittee index")
+			continue
+		}
+		seen := make(map[uint64]bool)
+		for _, index := range res.Indices {
+			subnet := uint64(index) / subSize
+			if seen[subnet] {
+				continue
+			}
+			seen[subnet] = true
+			if _, ok := v.syncSelection(slot, duty.ValidatorIndex, subnet); ok {
+				continue
+			}
+			selectionProof, err := v.signSyncSelectionData(ctx, pubKey, subnet, slot)
+			if err != nil {
+				log.WithError(err).WithField("slot", slot).Warn("Could not sign sync selection proof")
+				continue
+			}
+			selections = append(selections, iface.SyncCommitteeSelection{
+				SelectionProof:    selectionProof,
+				Slot:              slot,
+				SubcommitteeIndex: types.CommitteeIndex(subnet),
+				ValidatorIndex:    duty.ValidatorIndex,
+			})
+		}
+	}
+	if len(selections) == 0 {
+		return
+	}
+	if err := v.aggregatedSyncSelectionProofs(ctx, slot, selections); err != nil {
+		log.WithError(err).WithField("slot", slot).Warn("Could not get aggregated sync selection proofs")
+	}
+}
+
+// aggregatedSyncSelectionProofs exchanges the given partial sync committee selection proofs for the
+// ones aggregated over the distributed validator cluster, and caches them until the following slot,
+// as the proofs of a slot may be prepared during the previous one.
+func (v *validator) aggregatedSyncSelectionProofs(ctx context.Context, slot types.Slot, selections []iface.SyncCommitteeSelection) error {
+	aggregated, err := v.validatorClient.GetAggregatedSyncSelections(ctx, selections)
+	if err != nil {
+		return err
+	}
+
+	v.syncSelectionLock.Lock()
+	defer v.syncSelectionLock.Unlock()
+	if v.syncSelections == nil {
+		v.syncSelections = make(map[syncSelectionKey]iface.SyncCommitteeSelection)
+	}
+	for k := range v.syncSelections {
+		if k.slot+1 < slot {
+			delete(v.syncSelections, k)
+		}
+	}
+	for _, s := range aggregated {
+		v.syncSelections[syncSelectionKey{slot: s.Slot, index: s.ValidatorIndex, subcommittee: uint64(s.SubcommitteeIndex)}] = s
+	}
+	return nil
+}
+
+// syncSelection returns the aggregated sync committee selection proof of the validator for the given
+// slot and subcommittee.
+func (v *validator) syncSelection(slot types.Slot, index types.ValidatorIndex, subcommittee uint64) ([]byte, bool) {
+	v.syncSelectionLock.Lock()
+	defer v.syncSelectionLock.Unlock()
+	s, ok := v.syncSelections[syncSelectionKey{slot: slot, index: index, subcommittee: subcommittee}]
+	return s.SelectionProof, ok
+}
+
 // Signs input slot with domain sync committee selection proof. This is used to create the signature for sync committee selection.
 func (v *validator) signSyncSelectionData(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, index uint64, slot types.Slot) (signature []byte, err error) {
 	domain, err := v.domainData(ctx, slots.ToEpoch(slot), params.BeaconConfig().DomainSyncCommitteeSelectionProof[:])
@@ -15,6 +15,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -467,3 +468,46 @@ func TestSubmitSignedContributionAndProof_Ok(t *testing.T) {
 
 	validator.SubmitSignedContributionAndProof(context.Background(), 1, pubKey)
 }
+
+func TestSelectionProofs_Distributed(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	validator.distributed = true
+
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	validatorIndex := types.ValidatorIndex(7)
+	aggregatedProof := bytesutil.PadTo([]byte{0x01}, 96)
+
+	m.validatorClient.EXPECT().
+		DomainData(gomock.Any(), // ctx
+			gomock.Any()). // epoch
+		Return(&ethpb.DomainResponse{
+			SignatureDomain: make([]byte, 32),
+		}, nil).AnyTimes()
+
+	m.validatorClient.EXPECT().GetAggregatedSyncSelections(
+		gomock.Any(), // ctx
+		gomock.Any(), // selections
+	).DoAndReturn(func(_ context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+		require.Equal(t, 1, len(selections))
+		assert.Equal(t, types.CommitteeIndex(0), selections[0].SubcommitteeIndex)
+		assert.Equal(t, validatorIndex, selections[0].ValidatorIndex)
+		return []iface.SyncCommitteeSelection{{
+			SelectionProof:    aggregatedProof,
+			Slot:              1,
+			SubcommitteeIndex: 0,
+			ValidatorIndex:    validatorIndex,
+		}}, nil
+	}).Times(1)
+
+	indexRes := &ethpb.SyncSubcommitteeIndexResponse{Indices: []types.CommitteeIndex{1}}
+	proofs, err := validator.selectionProofs(context.Background(), 1, pubKey, indexRes, validatorIndex)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][]byte{aggregatedProof}, proofs)
+
+	// The aggregated proofs are cached for the rest of the slot.
+	proofs, err = validator.selectionProofs(context.Background(), 1, pubKey, indexRes, validatorIndex)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][]byte{aggregatedProof}, proofs)
+}
@@ -126,6 +126,11 @@ func (fv *FakeValidator) SlotDeadline(_ types.Slot) time.Time {
 	return prysmTime.Now()
 }
 
+// WithDeadline for mocking.
+func (fv *FakeValidator) WithDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, deadline)
+}
+
 // NextSlot for mocking.
 func (fv *FakeValidator) NextSlot() <-chan types.Slot {
 	fv.NextSlotCalled = true
@@ -229,6 +234,11 @@ func (_ *FakeValidator) CheckDoppelGanger(_ context.Context) error {
 	return nil
 }
 
+// CheckDoppelGangerLiveness for mocking
+func (_ *FakeValidator) CheckDoppelGangerLiveness(_ context.Context, _ types.Slot) error {
+	return nil
+}
+
 // ReceiveBlocks for mocking
 func (fv *FakeValidator) ReceiveBlocks(_ context.Context, connectionErrorChannel chan<- error) {
 	fv.ReceiveBlocksCalled++
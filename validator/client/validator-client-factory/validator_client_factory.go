@@ -1,20 +1,51 @@
 package validator_client_factory
 
 import (
+	"context"
+
 	"github.com/prysmaticlabs/prysm/v3/config/features"
 	beaconApi "github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api"
+	beaconNodePool "github.com/prysmaticlabs/prysm/v3/validator/client/beacon-node-pool"
 	grpcApi "github.com/prysmaticlabs/prysm/v3/validator/client/grpc-api"
 	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
 	validatorHelpers "github.com/prysmaticlabs/prysm/v3/validator/helpers"
 )
 
 func NewValidatorClient(validatorConn validatorHelpers.NodeConnection) iface.ValidatorClient {
-	grpcClient := grpcApi.NewGrpcValidatorClient(validatorConn.GetGrpcClientConn())
 	featureFlags := features.Get()
 
 	if featureFlags.EnableBeaconRESTApi {
-		return beaconApi.NewBeaconApiValidatorClientWithFallback(validatorConn.GetBeaconApiUrl(), validatorConn.GetBeaconApiTimeout(), grpcClient)
+		return beaconApi.NewBeaconApiValidatorClient(validatorConn.GetBeaconApiUrl(), validatorConn.GetBeaconApiTimeout())
+	} else {
+		return grpcApi.NewGrpcValidatorClient(validatorConn.GetGrpcClientConn())
+	}
+}
+
+func NewNodeHealthClient(validatorConn validatorHelpers.NodeConnection) iface.NodeHealthClient {
+	featureFlags := features.Get()
+
+	if featureFlags.EnableBeaconRESTApi {
+		return beaconApi.NewBeaconApiNodeHealthClient(validatorConn.GetBeaconApiUrl(), validatorConn.GetBeaconApiTimeout())
 	} else {
-		return grpcClient
+		return grpcApi.NewGrpcNodeHealthClient(validatorConn.GetGrpcClientConn())
 	}
 }
+
+// NewBeaconNodePoolValidatorClient returns a validator client spanning the beacon nodes of the given connections,
+// one per node. The nodes are probed until the context is canceled.
+func NewBeaconNodePoolValidatorClient(ctx context.Context, validatorConns []validatorHelpers.NodeConnection) *beaconNodePool.Pool {
+	featureFlags := features.Get()
+
+	nodes := make([]*beaconNodePool.Node, len(validatorConns))
+	for i, validatorConn := range validatorConns {
+		endpoint := validatorConn.GetBeaconApiUrl()
+		if !featureFlags.EnableBeaconRESTApi {
+			endpoint = validatorConn.GetGrpcClientConn().Target()
+		}
+		nodes[i] = beaconNodePool.NewNode(endpoint, NewValidatorClient(validatorConn), NewNodeHealthClient(validatorConn), validatorConn.GetGrpcClientConn())
+	}
+
+	pool := beaconNodePool.NewPool(nodes)
+	go pool.Run(ctx)
+	return pool
+}
@@ -22,7 +22,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
-	"github.com/prysmaticlabs/prysm/v3/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v3/config/validator/service"
@@ -67,12 +66,20 @@ type validator struct {
 	logValidatorBalances               bool
 	useWeb                             bool
 	emitAccountMetrics                 bool
+	distributed                        bool
 	domainDataLock                     sync.Mutex
 	attLogsLock                        sync.Mutex
 	aggregatedSlotCommitteeIDCacheLock sync.Mutex
 	highestValidSlotLock               sync.Mutex
 	prevBalanceLock                    sync.RWMutex
 	slashableKeysLock                  sync.RWMutex
+	attSelectionLock                   sync.Mutex
+	syncSelectionLock                  sync.Mutex
+	attSelections                      map[attSelectionKey]iface.BeaconCommitteeSelection
+	syncSelections                     map[syncSelectionKey]iface.SyncCommitteeSelection
+	doppelGangerLock                   sync.RWMutex
+	doppelGangerKeys                   map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey
+	doppelGangerEpochs                 uint64
 	eipImportBlacklistedPublicKeys     map[[fieldparams.BLSPubkeyLength]byte]bool
 	walletInitializedFeed              *event.Feed
 	attLogs                            map[[32]byte]*attSubmitted
@@ -96,6 +103,7 @@ type validator struct {
 	beaconClient                       ethpb.BeaconChainClient
 	keyManager                         keymanager.IKeymanager
 	ticker                             slots.Ticker
+	clock                              clock
 	validatorClient                    iface.ValidatorClient
 	graffiti                           []byte
 	voteStats                          voteStats
@@ -116,6 +124,23 @@ func (v *validator) Done() {
 	v.ticker.Done()
 }
 
+// timeSource returns the clock of the validator, which is the system clock unless it is replayed.
+func (v *validator) timeSource() clock {
+	if v.clock == nil {
+		return systemClock{}
+	}
+	return v.clock
+}
+
+// currentSlot returns the slot at the current time of the clock of the validator.
+func (v *validator) currentSlot() types.Slot {
+	now := uint64(v.timeSource().Now().Unix())
+	if now < v.genesisTime {
+		return 0
+	}
+	return types.Slot((now - v.genesisTime) / params.BeaconConfig().SecondsPerSlot)
+}
+
 // WaitForKeymanagerInitialization checks if the validator needs to wait for
 func (v *validator) WaitForKeymanagerInitialization(ctx context.Context) error {
 	genesisRoot, err := v.db.GenesisValidatorsRoot(ctx)
@@ -138,9 +163,11 @@ func (v *validator) WaitForKeymanagerInitialization(ctx context.Context) error {
 				return errors.Wrap(err, "could not generate interop keys for key manager")
 			}
 			v.keyManager = keyManager
-		} else if v.wallet == nil {
-			return errors.New("wallet not set")
-		} else {
+		} else if v.keyManager == nil {
+			// The keymanager may be provided up front, such as when replaying a recorded session.
+			if v.wallet == nil {
+				return errors.New("wallet not set")
+			}
 			if v.Web3SignerConfig != nil {
 				v.Web3SignerConfig.GenesisValidatorsRoot = genesisRoot
 			}
@@ -277,7 +304,7 @@ func (v *validator) WaitForChainStart(ctx context.Context) error {
 
 	// Once the ChainStart log is received, we update the genesis time of the validator client
 	// and begin a slot ticker used to track the current slot the beacon node is in.
-	v.ticker = slots.NewSlotTicker(time.Unix(int64(v.genesisTime), 0), params.BeaconConfig().SecondsPerSlot)
+	v.ticker = v.timeSource().NewSlotTicker(time.Unix(int64(v.genesisTime), 0), params.BeaconConfig().SecondsPerSlot)
 	log.WithField("genesisTime", time.Unix(int64(v.genesisTime), 0)).Info("Beacon chain started")
 	return nil
 }
@@ -298,7 +325,7 @@ func (v *validator) WaitForSync(ctx context.Context) error {
 	for {
 		select {
 		// Poll every half slot.
-		case <-time.After(slots.DivideSlotBy(2 /* twice per slot */)):
+		case <-v.timeSource().After(slots.DivideSlotBy(2 /* twice per slot */)):
 			s, err := v.node.GetSyncStatus(ctx, &emptypb.Empty{})
 			if err != nil {
 				return errors.Wrap(iface.ErrConnectionIssue, errors.Wrap(err, "could not get sync status").Error())
@@ -458,75 +485,10 @@ func (v *validator) SlotDeadline(slot types.Slot) time.Time {
 	return time.Unix(int64(v.genesisTime), 0 /*ns*/).Add(secs * time.Second)
 }
 
-// CheckDoppelGanger checks if the current actively provided keys have
-// any duplicates active in the network.
-func (v *validator) CheckDoppelGanger(ctx context.Context) error {
-	if !features.Get().EnableDoppelGanger {
-		return nil
-	}
-	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
-	if err != nil {
-		return err
-	}
-	log.WithField("keys", len(pubkeys)).Info("Running doppelganger check")
-	// Exit early if no validating pub keys are found.
-	if len(pubkeys) == 0 {
-		return nil
-	}
-	req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-	for _, pkey := range pubkeys {
-		copiedKey := pkey
-		attRec, err := v.db.AttestationHistoryForPubKey(ctx, copiedKey)
-		if err != nil {
-			return err
-		}
-		if len(attRec) == 0 {
-			// If no history exists we simply send in a zero
-			// value for the request epoch and root.
-			req.ValidatorRequests = append(req.ValidatorRequests,
-				&ethpb.DoppelGangerRequest_ValidatorRequest{
-					PublicKey:  copiedKey[:],
-					Epoch:      0,
-					SignedRoot: make([]byte, fieldparams.RootLength),
-				})
-			continue
-		}
-		r := retrieveLatestRecord(attRec)
-		if copiedKey != r.PubKey {
-			return errors.New("attestation record mismatched public key")
-		}
-		req.ValidatorRequests = append(req.ValidatorRequests,
-			&ethpb.DoppelGangerRequest_ValidatorRequest{
-				PublicKey:  r.PubKey[:],
-				Epoch:      r.Target,
-				SignedRoot: r.SigningRoot[:],
-			})
-	}
-	resp, err := v.validatorClient.CheckDoppelGanger(ctx, req)
-	if err != nil {
-		return err
-	}
-	// If nothing is returned by the beacon node, we return an
-	// error as it is unsafe for us to proceed.
-	if resp == nil || resp.Responses == nil || len(resp.Responses) == 0 {
-		return errors.New("beacon node returned 0 responses for doppelganger check")
-	}
-	return buildDuplicateError(resp.Responses)
-}
-
-func buildDuplicateError(response []*ethpb.DoppelGangerResponse_ValidatorResponse) error {
-	duplicates := make([][]byte, 0)
-	for _, valRes := range response {
-		if valRes.DuplicateExists {
-			var copiedKey [fieldparams.BLSPubkeyLength]byte
-			copy(copiedKey[:], valRes.PublicKey)
-			duplicates = append(duplicates, copiedKey[:])
-		}
-	}
-	if len(duplicates) == 0 {
-		return nil
-	}
-	return errors.Errorf("Duplicate instances exists in the network for validator keys: %#x", duplicates)
+// WithDeadline returns a copy of the context which is canceled once the clock of the validator
+// reaches the deadline.
+func (v *validator) WithDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	return v.timeSource().WithDeadline(ctx, deadline)
 }
 
 // Ensures that the latest attestation history is retrieved.
@@ -565,7 +527,7 @@ func (v *validator) UpdateDuties(ctx context.Context, slot types.Slot) error {
 	if err != nil {
 		return err
 	}
-	ctx, cancel := context.WithDeadline(ctx, v.SlotDeadline(ss))
+	ctx, cancel := v.WithDeadline(ctx, v.SlotDeadline(ss))
 	defer cancel()
 	ctx, span := trace.StartSpan(ctx, "validator.UpdateAssignments")
 	defer span.End()
@@ -606,6 +568,14 @@ func (v *validator) UpdateDuties(ctx context.Context, slot types.Slot) error {
 	v.duties = resp
 	v.logDuties(slot, v.duties.CurrentEpochDuties)
 
+	// In distributed mode the selection proofs of the whole cluster have to be combined before anyone
+	// knows whether it aggregates, which must be done before subscribing to the subnets below.
+	if v.distributed {
+		if err := v.aggregatedSelectionProofs(ctx, slot, resp); err != nil {
+			log.WithError(err).Error("Could not get aggregated selection proofs")
+		}
+	}
+
 	// Non-blocking call for beacon node to start subscriptions for aggregators.
 	// Make sure to copy metadata into a new context
 	md, exists := metadata.FromOutgoingContext(ctx)
@@ -643,7 +613,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 				continue
 			}
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, pk)
+			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, pk, validatorIndex)
 			if err != nil {
 				return errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -669,7 +639,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 				continue
 			}
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, bytesutil.ToBytes48(duty.PublicKey), validatorIndex)
 			if err != nil {
 				return errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -700,6 +670,16 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 // validator is known to not have a roles at the slot. Returns UNKNOWN if the
 // validator assignments are unknown. Otherwise returns a valid ValidatorRole map.
 func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldparams.BLSPubkeyLength]byte][]iface.ValidatorRole, error) {
+	// In distributed mode, the aggregated sync committee selection proofs are prepared during the previous
+	// slot, and only the ones which are still missing are exchanged now.
+	if v.distributed {
+		v.prepareSyncSelections(ctx, slot, v.duties)
+		// The duties of the next slot are only known before the last slot of the epoch.
+		if !slots.IsEpochEnd(slot) {
+			go v.prepareSyncSelections(ctx, slot+1, v.duties)
+		}
+	}
+
 	rolesAt := make(map[[fieldparams.BLSPubkeyLength]byte][]iface.ValidatorRole)
 	for validator, duty := range v.duties.Duties {
 		var roles []iface.ValidatorRole
@@ -707,6 +687,15 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldpar
 		if duty == nil {
 			continue
 		}
+		var pubKey [fieldparams.BLSPubkeyLength]byte
+		copy(pubKey[:], duty.PublicKey)
+		safe, err := v.doppelGangerSafe(ctx, pubKey, slot)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not check doppelganger protection")
+		}
+		if !safe {
+			continue
+		}
 		if len(duty.ProposerSlots) > 0 {
 			for _, proposerSlot := range duty.ProposerSlots {
 				if proposerSlot != 0 && proposerSlot == slot {
@@ -718,7 +707,7 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldpar
 		if duty.AttesterSlot == slot {
 			roles = append(roles, iface.RoleAttester)
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey), duty.ValidatorIndex)
 			if err != nil {
 				return nil, errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -744,7 +733,7 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldpar
 			}
 		}
 		if inSyncCommittee {
-			aggregator, err := v.isSyncCommitteeAggregator(ctx, slot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isSyncCommitteeAggregator(ctx, slot, bytesutil.ToBytes48(duty.PublicKey), duty.ValidatorIndex)
 			if err != nil {
 				return nil, errors.Wrap(err, "could not check if a validator is a sync committee aggregator")
 			}
@@ -757,8 +746,6 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldpar
 			roles = append(roles, iface.RoleUnknown)
 		}
 
-		var pubKey [fieldparams.BLSPubkeyLength]byte
-		copy(pubKey[:], duty.PublicKey)
 		rolesAt[pubKey] = roles
 	}
 	return rolesAt, nil
@@ -774,15 +761,31 @@ func (v *validator) Keymanager() (keymanager.IKeymanager, error) {
 
 // isAggregator checks if a validator is an aggregator of a given slot and committee,
 // it uses a modulo calculated by validator count in committee and samples randomness around it.
-func (v *validator) isAggregator(ctx context.Context, committee []types.ValidatorIndex, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (bool, error) {
+// In distributed mode, the aggregated selection proof fetched along with the duties is used instead
+// of the validator's own signature.
+func (v *validator) isAggregator(ctx context.Context, committee []types.ValidatorIndex, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, validatorIndex types.ValidatorIndex) (bool, error) {
 	modulo := uint64(1)
 	if len(committee)/int(params.BeaconConfig().TargetAggregatorsPerCommittee) > 1 {
 		modulo = uint64(len(committee)) / params.BeaconConfig().TargetAggregatorsPerCommittee
 	}
 
-	slotSig, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
-	if err != nil {
-		return false, err
+	var slotSig []byte
+	if v.distributed {
+		var ok bool
+		slotSig, ok = v.attSelection(slot, validatorIndex)
+		if !ok {
+			log.WithFields(logrus.Fields{
+				"slot":           slot,
+				"validatorIndex": validatorIndex,
+			}).Warn("No aggregated selection proof, not aggregating")
+			return false, nil
+		}
+	} else {
+		var err error
+		slotSig, err = v.signSlotWithSelectionProof(ctx, pubKey, slot)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	b := hash.Hash(slotSig)
@@ -792,13 +795,15 @@ func (v *validator) isAggregator(ctx context.Context, committee []types.Validato
 
 // isSyncCommitteeAggregator checks if a validator in an aggregator of a subcommittee for sync committee.
 // it uses a modulo calculated by validator count in committee and samples randomness around it.
+// In distributed mode, the aggregated selection proofs prepared ahead of the slot are used instead of
+// the validator's own signatures.
 //
 // Spec code:
 // def is_sync_committee_aggregator(signature: BLSSignature) -> bool:
 //
 //	modulo = max(1, SYNC_COMMITTEE_SIZE // SYNC_COMMITTEE_SUBNET_COUNT // TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE)
 //	return bytes_to_uint64(hash(signature)[0:8]) % modulo == 0
-func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (bool, error) {
+func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, validatorIndex types.ValidatorIndex) (bool, error) {
 	res, err := v.validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
 		PublicKey: pubKey[:],
 		Slot:      slot,
@@ -807,13 +812,27 @@ func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot types.Sl
 		return false, err
 	}
 
-	for _, index := range res.Indices {
-		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
-		subnet := uint64(index) / subCommitteeSize
-		sig, err := v.signSyncSelectionData(ctx, pubKey, subnet, slot)
+	var selectionProofs [][]byte
+	if v.distributed {
+		subSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+		for _, index := range res.Indices {
+			proof, ok := v.syncSelection(slot, validatorIndex, uint64(index)/subSize)
+			if !ok {
+				log.WithFields(logrus.Fields{
+					"slot":           slot,
+					"validatorIndex": validatorIndex,
+				}).Warn("No aggregated sync selection proof, not aggregating")
+				return false, nil
+			}
+			selectionProofs = append(selectionProofs, proof)
+		}
+	} else {
+		selectionProofs, err = v.selectionProofs(ctx, slot, pubKey, res, validatorIndex)
 		if err != nil {
 			return false, err
 		}
+	}
+	for _, sig := range selectionProofs {
 		isAggregator, err := altair.IsSyncCommitteeAggregator(sig)
 		if err != nil {
 			return false, err
@@ -951,7 +970,7 @@ func (v *validator) logDuties(slot types.Slot, duties []*ethpb.DutiesResponse_Du
 	}
 	for i := types.Slot(0); i < params.BeaconConfig().SlotsPerEpoch; i++ {
 		startTime := slots.StartTime(v.genesisTime, slotOffset+i)
-		durationTillDuty := (time.Until(startTime) + time.Second).Truncate(time.Second) // Round up to next second.
+		durationTillDuty := (startTime.Sub(v.timeSource().Now()) + time.Second).Truncate(time.Second) // Round up to next second.
 
 		if len(attesterKeys[i]) > 0 {
 			attestationLog := log.WithFields(logrus.Fields{
@@ -990,8 +1009,8 @@ func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKey
 		return errors.New("keymanager is nil when calling PrepareBeaconProposer")
 	}
 
-	deadline := v.SlotDeadline(slots.RoundUpToNearestEpoch(slots.CurrentSlot(v.genesisTime)))
-	ctx, cancel := context.WithDeadline(ctx, deadline)
+	deadline := v.SlotDeadline(slots.RoundUpToNearestEpoch(v.currentSlot()))
+	ctx, cancel := v.WithDeadline(ctx, deadline)
 	defer cancel()
 
 	pubkeys, err := km.FetchValidatingPublicKeys(ctx)
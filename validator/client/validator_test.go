@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"errors"
-	"fmt"
 	"io"
 	"math"
 	"strings"
@@ -16,7 +15,6 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
-	"github.com/prysmaticlabs/prysm/v3/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v3/config/validator/service"
@@ -686,6 +684,54 @@ func TestRolesAt_OK(t *testing.T) {
 	assert.Equal(t, iface.RoleSyncCommittee, roleMap[bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())][0])
 }
 
+func TestRolesAt_DistributedSyncSelectionsUnavailable(t *testing.T) {
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+	v.distributed = true
+
+	// The last slot of the epoch does not prepare the selections of the next slot in the background.
+	slot := params.BeaconConfig().SlotsPerEpoch - 1
+	pubKey := validatorKey.PublicKey().Marshal()
+	v.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				CommitteeIndex: 1,
+				AttesterSlot:   slot,
+				ProposerSlots:  []types.Slot{slot},
+				PublicKey:      pubKey,
+				ValidatorIndex: 7,
+			},
+		},
+		NextEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:       pubKey,
+				ValidatorIndex:  7,
+				IsSyncCommittee: true,
+			},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/).AnyTimes()
+	m.validatorClient.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(), // ctx
+		&ethpb.SyncSubcommitteeIndexRequest{
+			PublicKey: pubKey,
+			Slot:      slot,
+		},
+	).Return(&ethpb.SyncSubcommitteeIndexResponse{Indices: []types.CommitteeIndex{1}}, nil /*err*/).Times(2)
+	m.validatorClient.EXPECT().GetAggregatedSyncSelections(
+		gomock.Any(), // ctx
+		gomock.Any(), // selections
+	).Return(nil, errors.New("middleware unavailable"))
+
+	roleMap, err := v.RolesAt(context.Background(), slot)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []iface.ValidatorRole{iface.RoleProposer, iface.RoleAttester, iface.RoleSyncCommittee}, roleMap[bytesutil.ToBytes48(pubKey)])
+}
+
 func TestRolesAt_DoesNotAssignProposer_Slot0(t *testing.T) {
 	v, m, validatorKey, finish := setup(t)
 	defer finish()
@@ -1009,214 +1055,6 @@ func TestService_ReceiveBlocks_SetHighest(t *testing.T) {
 	require.Equal(t, slot, v.highestValidSlot)
 }
 
-type doppelGangerRequestMatcher struct {
-	req *ethpb.DoppelGangerRequest
-}
-
-var _ gomock.Matcher = (*doppelGangerRequestMatcher)(nil)
-
-func (m *doppelGangerRequestMatcher) Matches(x interface{}) bool {
-	r, ok := x.(*ethpb.DoppelGangerRequest)
-	if !ok {
-		panic("Invalid match type")
-	}
-	return gomock.InAnyOrder(m.req.ValidatorRequests).Matches(r.ValidatorRequests)
-}
-
-func (m *doppelGangerRequestMatcher) String() string {
-	return fmt.Sprintf("%#v", m.req.ValidatorRequests)
-}
-
-func TestValidator_CheckDoppelGanger(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	flgs := features.Get()
-	flgs.EnableDoppelGanger = true
-	reset := features.InitWithReset(flgs)
-	defer reset()
-	tests := []struct {
-		name            string
-		validatorSetter func(t *testing.T) *validator
-		err             string
-	}{
-		{
-			name: "no doppelganger",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockValidatorClient(ctrl)
-				km := genMockKeymanager(10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				for _, k := range keys {
-					pkey := k
-					att := createAttestation(10, 12)
-					rt, err := att.Data.HashTreeRoot()
-					assert.NoError(t, err)
-					assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-					resp.ValidatorRequests = append(resp.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(nil, nil /*err*/)
-
-				return v
-			},
-		},
-		{
-			name: "multiple doppelganger exists",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockValidatorClient(ctrl)
-				km := genMockKeymanager(10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				for i, k := range keys {
-					pkey := k
-					att := createAttestation(10, 12)
-					rt, err := att.Data.HashTreeRoot()
-					assert.NoError(t, err)
-					assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-					if i%3 == 0 {
-						resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
-					}
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "Duplicate instances exists in the network for validator keys",
-		},
-		{
-			name: "single doppelganger exists",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockValidatorClient(ctrl)
-				km := genMockKeymanager(10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				for i, k := range keys {
-					pkey := k
-					att := createAttestation(10, 12)
-					rt, err := att.Data.HashTreeRoot()
-					assert.NoError(t, err)
-					assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-					if i%9 == 0 {
-						resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
-					}
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "Duplicate instances exists in the network for validator keys",
-		},
-		{
-			name: "multiple attestations saved",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockValidatorClient(ctrl)
-				km := genMockKeymanager(10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				attLimit := 5
-				for i, k := range keys {
-					pkey := k
-					for j := 0; j < attLimit; j++ {
-						att := createAttestation(10+types.Epoch(j), 12+types.Epoch(j))
-						rt, err := att.Data.HashTreeRoot()
-						assert.NoError(t, err)
-						assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-						if j == attLimit-1 {
-							req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-						}
-					}
-					if i%3 == 0 {
-						resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
-					}
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "Duplicate instances exists in the network for validator keys",
-		},
-		{
-			name: "no history exists",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockValidatorClient(ctrl)
-				// Use only 1 key for deterministic order.
-				km := genMockKeymanager(1)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				for _, k := range keys {
-					resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: k[:], DuplicateExists: false})
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: k[:], SignedRoot: make([]byte, 32), Epoch: 0})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(), // ctx
-					req,          // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			v := tt.validatorSetter(t)
-			if err := v.CheckDoppelGanger(context.Background()); tt.err != "" {
-				assert.ErrorContains(t, tt.err, err)
-			}
-		})
-	}
-}
-
 func TestValidatorAttestationsAreOrdered(t *testing.T) {
 	km := genMockKeymanager(10)
 	keys, err := km.FetchValidatingPublicKeys(context.Background())
@@ -1283,7 +1121,7 @@ func TestIsSyncCommitteeAggregator_OK(t *testing.T) {
 		},
 	).Return(&ethpb.SyncSubcommitteeIndexResponse{}, nil /*err*/)
 
-	aggregator, err := v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey))
+	aggregator, err := v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey), 0)
 	require.NoError(t, err)
 	require.Equal(t, false, aggregator)
 
@@ -1304,7 +1142,7 @@ func TestIsSyncCommitteeAggregator_OK(t *testing.T) {
 		},
 	).Return(&ethpb.SyncSubcommitteeIndexResponse{Indices: []types.CommitteeIndex{0}}, nil /*err*/)
 
-	aggregator, err = v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey))
+	aggregator, err = v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey), 0)
 	require.NoError(t, err)
 	require.Equal(t, true, aggregator)
 }
@@ -99,11 +99,24 @@ var (
 )
 
 // AttestationHistoryForPubKey retrieves a list of attestation records for data
-// we have stored in the database for the given validator public key.
+// we have stored in the database for the given validator public key. In minimal
+// slashing protection mode, the list only consists of the attestation watermarks.
 func (s *Store) AttestationHistoryForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*AttestationRecord, error) {
 	records := make([]*AttestationRecord, 0)
 	ctx, span := trace.StartSpan(ctx, "Validator.AttestationHistoryForPubKey")
 	defer span.End()
+	if s.minimalSlashingProtection {
+		p, err := s.viewMinimalProtection(pubKey)
+		if err != nil || !p.attested {
+			return records, err
+		}
+		return append(records, &AttestationRecord{
+			PubKey:      pubKey,
+			Source:      p.highestSourceEpoch,
+			Target:      p.highestTargetEpoch,
+			SigningRoot: p.targetSigningRoot,
+		}), nil
+	}
 	err := s.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(pubKeysBucket)
 		pkBucket := bucket.Bucket(pubKey[:])
@@ -140,6 +153,8 @@ func (s *Store) AttestationHistoryForPubKey(ctx context.Context, pubKey [fieldpa
 
 // CheckSlashableAttestation verifies an incoming attestation is
 // not a double vote for a validator public key nor a surround vote.
+// In minimal slashing protection mode, only double votes at the highest
+// signed target epoch can be detected.
 func (s *Store) CheckSlashableAttestation(
 	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, signingRoot [32]byte, att *ethpb.IndexedAttestation,
 ) (SlashingKind, error) {
@@ -150,6 +165,14 @@ func (s *Store) CheckSlashableAttestation(
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
+		if s.minimalSlashingProtection {
+			p, err := minimalProtectionForPubKey(tx, pubKey[:])
+			if err != nil {
+				return err
+			}
+			slashKind, err = checkMinimalSlashableAttestation(p, signingRoot, att.Data.Target.Epoch)
+			return err
+		}
 		bucket := tx.Bucket(pubKeysBucket)
 		pkBucket := bucket.Bucket(pubKey[:])
 		if pkBucket == nil {
@@ -425,6 +448,9 @@ func (s *Store) flushAttestationRecords(ctx context.Context, records []*Attestat
 func (s *Store) saveAttestationRecords(ctx context.Context, atts []*AttestationRecord) error {
 	ctx, span := trace.StartSpan(ctx, "Validator.saveAttestationRecords")
 	defer span.End()
+	if s.minimalSlashingProtection {
+		return s.saveMinimalAttestationRecords(atts)
+	}
 	return s.update(func(tx *bolt.Tx) error {
 		// Initialize buckets for the lowest target and source epochs.
 		lowestSourceBucket, err := tx.CreateBucketIfNotExists(lowestSignedSourceBucket)
@@ -521,6 +547,9 @@ func (s *Store) saveAttestationRecords(ctx context.Context, atts []*AttestationR
 func (s *Store) AttestedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "Validator.AttestedPublicKeys")
 	defer span.End()
+	if s.minimalSlashingProtection {
+		return s.minimalProtectedPublicKeys(false /* proposals */)
+	}
 	var err error
 	attestedPublicKeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
 	err = s.view(func(tx *bolt.Tx) error {
@@ -536,11 +565,19 @@ func (s *Store) AttestedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubke
 }
 
 // SigningRootAtTargetEpoch checks for an existing signing root at a specified
-// target epoch for a given validator public key.
+// target epoch for a given validator public key. In minimal slashing protection
+// mode, only the signing root at the highest signed target epoch is known.
 func (s *Store) SigningRootAtTargetEpoch(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, target types.Epoch) ([32]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "Validator.SigningRootAtTargetEpoch")
 	defer span.End()
 	var signingRoot [32]byte
+	if s.minimalSlashingProtection {
+		p, err := s.viewMinimalProtection(pubKey)
+		if err != nil || !p.attested || p.highestTargetEpoch != target {
+			return signingRoot, err
+		}
+		return p.targetSigningRoot, nil
+	}
 	err := s.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(pubKeysBucket)
 		pkBucket := bucket.Bucket(pubKey[:])
@@ -559,10 +596,18 @@ func (s *Store) SigningRootAtTargetEpoch(ctx context.Context, pubKey [fieldparam
 }
 
 // LowestSignedSourceEpoch returns the lowest signed source epoch for a validator public key.
-// If no data exists, returning 0 is a sensible default.
+// If no data exists, returning 0 is a sensible default. In minimal slashing protection mode,
+// the highest signed source epoch is returned, below which nothing may be signed.
 func (s *Store) LowestSignedSourceEpoch(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (types.Epoch, bool, error) {
 	ctx, span := trace.StartSpan(ctx, "Validator.LowestSignedSourceEpoch")
 	defer span.End()
+	if s.minimalSlashingProtection {
+		p, err := s.viewMinimalProtection(publicKey)
+		if err != nil {
+			return 0, false, err
+		}
+		return p.highestSourceEpoch, p.attested, nil
+	}
 
 	var err error
 	var lowestSignedSourceEpoch types.Epoch
@@ -582,10 +627,18 @@ func (s *Store) LowestSignedSourceEpoch(ctx context.Context, publicKey [fieldpar
 }
 
 // LowestSignedTargetEpoch returns the lowest signed target epoch for a validator public key.
-// If no data exists, returning 0 is a sensible default.
+// If no data exists, returning 0 is a sensible default. In minimal slashing protection mode,
+// the highest signed target epoch is returned, at or below which nothing else may be signed.
 func (s *Store) LowestSignedTargetEpoch(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (types.Epoch, bool, error) {
 	ctx, span := trace.StartSpan(ctx, "Validator.LowestSignedTargetEpoch")
 	defer span.End()
+	if s.minimalSlashingProtection {
+		p, err := s.viewMinimalProtection(publicKey)
+		if err != nil {
+			return 0, false, err
+		}
+		return p.highestTargetEpoch, p.attested, nil
+	}
 
 	var err error
 	var lowestSignedTargetEpoch types.Epoch
@@ -51,6 +51,7 @@ var blockedBuckets = [][]byte{
 	attestationSigningRootsBucket,
 	attestationSourceEpochsBucket,
 	attestationTargetEpochsBucket,
+	minimalSlashingProtectionBucket,
 }
 
 // Config represents store's config object.
@@ -67,6 +68,7 @@ type Store struct {
 	batchedAttestationsChan            chan *AttestationRecordSaveRequest
 	batchAttestationsFlushedFeed       *event.Feed
 	batchedAttestationsFlushInProgress abool.AtomicBool
+	minimalSlashingProtection          bool
 }
 
 // Close closes the underlying boltdb database.
@@ -136,6 +138,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		batchedAttestations:          NewQueuedAttestationRecords(),
 		batchedAttestationsChan:      make(chan *AttestationRecordSaveRequest, attestationBatchCapacity),
 		batchAttestationsFlushedFeed: new(event.Feed),
+		minimalSlashingProtection:    features.Get().EnableMinimalSlashingProtection,
 	}
 
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
@@ -152,6 +155,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			pubKeysBucket,
 			migrationsBucket,
 			graffitiBucket,
+			minimalSlashingProtectionBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -164,7 +168,19 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		}
 	}
 
-	if features.Get().EnableSlashingProtectionPruning {
+	// Convert the slashing protection history whenever minimal slashing protection is toggled.
+	if kv.minimalSlashingProtection {
+		if err := kv.migrateOptimalAttesterProtectionUp(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not migrate attesting history to optimized format")
+		}
+		if err := kv.migrateToMinimalSlashingProtection(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not convert slashing protection history to minimal slashing protection")
+		}
+	} else if err := kv.migrateFromMinimalSlashingProtection(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not convert minimal slashing protection to slashing protection history")
+	}
+
+	if features.Get().EnableSlashingProtectionPruning && !kv.minimalSlashingProtection {
 		// Prune attesting records older than the current weak subjectivity period.
 		if err := kv.PruneAttestations(ctx); err != nil {
 			return nil, errors.Wrap(err, "could not prune old attestations from DB")
@@ -0,0 +1,385 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/monitoring/progress"
+	"github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1/slashings"
+	bolt "go.etcd.io/bbolt"
+)
+
+var migrationMinimalSlashingProtectionKey = []byte("minimal_slashing_protection_0")
+
+// Size of an encoded minimal protection record: a flag, the highest source and target epochs and
+// the signing root at the highest target, then a flag, the highest proposal slot and its signing root.
+const minimalProtectionSize = 1 + 8 + 8 + 32 + 1 + 8 + 32
+
+// minimalProtection is the slashing protection kept for a validator public key in minimal slashing
+// protection mode. As in the EIP-3076 minimal interchange format, it only consists of watermarks:
+// the validator may not sign an attestation with a source below the highest signed source, nor with a
+// target at or below the highest signed target, nor a block at or below the highest signed slot,
+// unless it is the very same message signed at the highest target or slot.
+type minimalProtection struct {
+	attested            bool
+	highestSourceEpoch  types.Epoch
+	highestTargetEpoch  types.Epoch
+	targetSigningRoot   [32]byte
+	proposed            bool
+	highestProposalSlot types.Slot
+	proposalSigningRoot [32]byte
+}
+
+func (p *minimalProtection) marshal() []byte {
+	enc := make([]byte, minimalProtectionSize)
+	if p.attested {
+		enc[0] = 1
+	}
+	binary.BigEndian.PutUint64(enc[1:9], uint64(p.highestSourceEpoch))
+	binary.BigEndian.PutUint64(enc[9:17], uint64(p.highestTargetEpoch))
+	copy(enc[17:49], p.targetSigningRoot[:])
+	if p.proposed {
+		enc[49] = 1
+	}
+	binary.BigEndian.PutUint64(enc[50:58], uint64(p.highestProposalSlot))
+	copy(enc[58:90], p.proposalSigningRoot[:])
+	return enc
+}
+
+func unmarshalMinimalProtection(enc []byte) (*minimalProtection, error) {
+	if len(enc) != minimalProtectionSize {
+		return nil, fmt.Errorf("wrong minimal slashing protection record size, expected %d, received %d", minimalProtectionSize, len(enc))
+	}
+	p := &minimalProtection{
+		attested:            enc[0] == 1,
+		highestSourceEpoch:  types.Epoch(binary.BigEndian.Uint64(enc[1:9])),
+		highestTargetEpoch:  types.Epoch(binary.BigEndian.Uint64(enc[9:17])),
+		proposed:            enc[49] == 1,
+		highestProposalSlot: types.Slot(binary.BigEndian.Uint64(enc[50:58])),
+	}
+	copy(p.targetSigningRoot[:], enc[17:49])
+	copy(p.proposalSigningRoot[:], enc[58:90])
+	return p, nil
+}
+
+// updateAttestation raises the attestation watermarks to the given attestation.
+func (p *minimalProtection) updateAttestation(source, target types.Epoch, signingRoot [32]byte) {
+	if !p.attested {
+		p.attested = true
+		p.highestSourceEpoch = source
+		p.highestTargetEpoch = target
+		p.targetSigningRoot = signingRoot
+		return
+	}
+	if source > p.highestSourceEpoch {
+		p.highestSourceEpoch = source
+	}
+	if target > p.highestTargetEpoch {
+		p.highestTargetEpoch = target
+		p.targetSigningRoot = signingRoot
+	}
+}
+
+// updateProposal raises the proposal watermark to the given proposal.
+func (p *minimalProtection) updateProposal(slot types.Slot, signingRoot []byte) {
+	if p.proposed && slot <= p.highestProposalSlot {
+		return
+	}
+	p.proposed = true
+	p.highestProposalSlot = slot
+	p.proposalSigningRoot = bytesutil.ToBytes32(signingRoot)
+}
+
+// minimalProtectionForPubKey returns the minimal slashing protection record of the public key,
+// which is empty if the key has never signed.
+func minimalProtectionForPubKey(tx *bolt.Tx, pubKey []byte) (*minimalProtection, error) {
+	enc := tx.Bucket(minimalSlashingProtectionBucket).Get(pubKey)
+	if enc == nil {
+		return &minimalProtection{}, nil
+	}
+	return unmarshalMinimalProtection(enc)
+}
+
+func saveMinimalProtection(tx *bolt.Tx, pubKey []byte, p *minimalProtection) error {
+	return tx.Bucket(minimalSlashingProtectionBucket).Put(pubKey, p.marshal())
+}
+
+// viewMinimalProtection reads the minimal slashing protection record of a public key.
+func (s *Store) viewMinimalProtection(pubKey [fieldparams.BLSPubkeyLength]byte) (*minimalProtection, error) {
+	var p *minimalProtection
+	err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		p, err = minimalProtectionForPubKey(tx, pubKey[:])
+		return err
+	})
+	return p, err
+}
+
+// minimalProtectedPublicKeys returns the public keys which have attested, or proposed, in minimal
+// slashing protection mode.
+func (s *Store) minimalProtectedPublicKeys(proposals bool) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	err := s.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(minimalSlashingProtectionBucket).ForEach(func(k, v []byte) error {
+			p, err := unmarshalMinimalProtection(v)
+			if err != nil {
+				return err
+			}
+			if (proposals && p.proposed) || (!proposals && p.attested) {
+				pubKeys = append(pubKeys, bytesutil.ToBytes48(k))
+			}
+			return nil
+		})
+	})
+	return pubKeys, err
+}
+
+// checkMinimalSlashableAttestation detects double votes against the highest signed target. Surround
+// votes cannot be told apart with watermarks alone, attestations below the watermarks are refused by
+// the validator client based on the lowest signable source and target epochs.
+func checkMinimalSlashableAttestation(p *minimalProtection, signingRoot [32]byte, target types.Epoch) (SlashingKind, error) {
+	if p.attested && target == p.highestTargetEpoch && slashings.SigningRootsDiffer(p.targetSigningRoot, signingRoot) {
+		return DoubleVote, fmt.Errorf(doubleVoteMessage, target, p.targetSigningRoot)
+	}
+	return NotSlashable, nil
+}
+
+// saveMinimalAttestationRecords raises the attestation watermarks of the given records.
+func (s *Store) saveMinimalAttestationRecords(atts []*AttestationRecord) error {
+	return s.update(func(tx *bolt.Tx) error {
+		for _, att := range atts {
+			p, err := minimalProtectionForPubKey(tx, att.PubKey[:])
+			if err != nil {
+				return err
+			}
+			p.updateAttestation(att.Source, att.Target, att.SigningRoot)
+			if err := saveMinimalProtection(tx, att.PubKey[:], p); err != nil {
+				return errors.Wrapf(err, "could not save minimal slashing protection for public key %#x", att.PubKey)
+			}
+		}
+		return nil
+	})
+}
+
+// migrateToMinimalSlashingProtection condenses the complete signing history of every public key into
+// its minimal slashing protection watermarks. The watermarks of the highest signed messages, as well as
+// the lowest signed epochs and slot set by slashing protection imports, are all kept, so that nothing
+// the complete history refuses to sign can be signed in minimal mode. The condensed per-key history is
+// then deleted, which lets the database reuse its pages.
+func (s *Store) migrateToMinimalSlashingProtection(_ context.Context) error {
+	publicKeyBytes := make([][]byte, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(migrationsBucket).Get(migrationMinimalSlashingProtectionKey); bytes.Equal(b, migrationCompleted) {
+			return nil // Migration already completed.
+		}
+		seen := make(map[string]bool)
+		for _, bucket := range [][]byte{
+			pubKeysBucket,
+			historicProposalsBucket,
+			lowestSignedSourceBucket,
+			lowestSignedTargetBucket,
+			lowestSignedProposalsBucket,
+			highestSignedProposalsBucket,
+		} {
+			if err := tx.Bucket(bucket).ForEach(func(k, _ []byte) error {
+				if seen[string(k)] {
+					return nil
+				}
+				seen[string(k)] = true
+				nk := make([]byte, len(k))
+				copy(nk, k)
+				publicKeyBytes = append(publicKeyBytes, nk)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	batchedKeys := batchPublicKeys(publicKeyBytes, publicKeyMigrationBatchSize)
+	bar := progress.InitializeProgressBar(
+		len(batchedKeys), "Converting validator slashing protection history to minimal watermarks",
+	)
+	for _, batch := range batchedKeys {
+		err = s.db.Update(func(tx *bolt.Tx) error {
+			for _, pubKey := range batch {
+				p, err := minimalProtectionForPubKey(tx, pubKey)
+				if err != nil {
+					return err
+				}
+				condenseAttestingHistory(tx, pubKey, p)
+				condenseProposalHistory(tx, pubKey, p)
+				if p.attested || p.proposed {
+					if err := saveMinimalProtection(tx, pubKey, p); err != nil {
+						return err
+					}
+				}
+				if err := deleteCondensedHistory(tx, pubKey); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := bar.Add(1); err != nil {
+			return err
+		}
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(migrationsBucket).Put(migrationMinimalSlashingProtectionKey, migrationCompleted)
+	})
+}
+
+func condenseAttestingHistory(tx *bolt.Tx, pubKey []byte, p *minimalProtection) {
+	if pkBucket := tx.Bucket(pubKeysBucket).Bucket(pubKey); pkBucket != nil {
+		signingRootsBucket := pkBucket.Bucket(attestationSigningRootsBucket)
+		if sourceEpochsBucket := pkBucket.Bucket(attestationSourceEpochsBucket); sourceEpochsBucket != nil {
+			// Errors are only returned by the callback, which never fails.
+			_ = sourceEpochsBucket.ForEach(func(sourceBytes, targetEpochsList []byte) error {
+				source := bytesutil.BytesToEpochBigEndian(sourceBytes)
+				for i := 0; i+8 <= len(targetEpochsList); i += 8 {
+					var signingRoot [32]byte
+					if signingRootsBucket != nil {
+						copy(signingRoot[:], signingRootsBucket.Get(targetEpochsList[i:i+8]))
+					}
+					p.updateAttestation(source, bytesutil.BytesToEpochBigEndian(targetEpochsList[i:i+8]), signingRoot)
+				}
+				return nil
+			})
+		}
+	}
+	lowestSource := tx.Bucket(lowestSignedSourceBucket).Get(pubKey)
+	lowestTarget := tx.Bucket(lowestSignedTargetBucket).Get(pubKey)
+	if len(lowestSource) >= 8 && len(lowestTarget) >= 8 {
+		// The signing root is unknown, so that no attestation can be signed at that target anymore.
+		p.updateAttestation(bytesutil.BytesToEpochBigEndian(lowestSource), bytesutil.BytesToEpochBigEndian(lowestTarget), [32]byte{})
+	}
+}
+
+func condenseProposalHistory(tx *bolt.Tx, pubKey []byte, p *minimalProtection) {
+	if valBucket := tx.Bucket(historicProposalsBucket).Bucket(pubKey); valBucket != nil {
+		if slot, signingRoot := valBucket.Cursor().Last(); slot != nil {
+			p.updateProposal(bytesutil.BytesToSlotBigEndian(slot), signingRoot)
+		}
+	}
+	for _, bucket := range [][]byte{lowestSignedProposalsBucket, highestSignedProposalsBucket} {
+		if slot := tx.Bucket(bucket).Get(pubKey); len(slot) >= 8 {
+			p.updateProposal(bytesutil.BytesToSlotBigEndian(slot), nil)
+		}
+	}
+}
+
+// deleteCondensedHistory deletes the attesting and proposal history of a public key, once condensed into
+// its minimal slashing protection watermarks.
+func deleteCondensedHistory(tx *bolt.Tx, pubKey []byte) error {
+	for _, bucket := range [][]byte{pubKeysBucket, historicProposalsBucket} {
+		b := tx.Bucket(bucket)
+		if b.Bucket(pubKey) == nil {
+			continue
+		}
+		if err := b.DeleteBucket(pubKey); err != nil {
+			return errors.Wrapf(err, "could not delete history of public key %#x", pubKey)
+		}
+	}
+	return nil
+}
+
+// migrateFromMinimalSlashingProtection writes the minimal slashing protection watermarks back into
+// the complete signing history, raising the lowest signed epochs and slot to the watermarks since
+// the history of what was signed below them in minimal mode is unknown.
+func (s *Store) migrateFromMinimalSlashingProtection(ctx context.Context) error {
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	watermarks := make([]*minimalProtection, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(minimalSlashingProtectionBucket).ForEach(func(k, v []byte) error {
+			p, err := unmarshalMinimalProtection(v)
+			if err != nil {
+				return err
+			}
+			pubKeys = append(pubKeys, bytesutil.ToBytes48(k))
+			watermarks = append(watermarks, p)
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if len(watermarks) == 0 {
+		return s.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(migrationsBucket).Delete(migrationMinimalSlashingProtectionKey)
+		})
+	}
+
+	records := make([]*AttestationRecord, 0, len(watermarks))
+	for i, p := range watermarks {
+		if p.attested {
+			records = append(records, &AttestationRecord{
+				PubKey:      pubKeys[i],
+				Source:      p.highestSourceEpoch,
+				Target:      p.highestTargetEpoch,
+				SigningRoot: p.targetSigningRoot,
+			})
+		}
+	}
+	if err := s.saveAttestationRecords(ctx, records); err != nil {
+		return errors.Wrap(err, "could not save minimal slashing protection attestations")
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for i, p := range watermarks {
+			pubKey := pubKeys[i][:]
+			if p.attested {
+				if err := raiseEpoch(tx.Bucket(lowestSignedSourceBucket), pubKey, p.highestSourceEpoch); err != nil {
+					return err
+				}
+				if err := raiseEpoch(tx.Bucket(lowestSignedTargetBucket), pubKey, p.highestTargetEpoch); err != nil {
+					return err
+				}
+			}
+			if p.proposed {
+				valBucket, err := tx.Bucket(historicProposalsBucket).CreateBucketIfNotExists(pubKey)
+				if err != nil {
+					return errors.Wrapf(err, "could not create bucket for public key %#x", pubKey)
+				}
+				slotBytes := bytesutil.SlotToBytesBigEndian(p.highestProposalSlot)
+				if err := valBucket.Put(slotBytes, p.proposalSigningRoot[:]); err != nil {
+					return err
+				}
+				if err := raiseEpoch(tx.Bucket(lowestSignedProposalsBucket), pubKey, types.Epoch(p.highestProposalSlot)); err != nil {
+					return err
+				}
+				if err := raiseEpoch(tx.Bucket(highestSignedProposalsBucket), pubKey, types.Epoch(p.highestProposalSlot)); err != nil {
+					return err
+				}
+			}
+		}
+		if err := tx.DeleteBucket(minimalSlashingProtectionBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucket(minimalSlashingProtectionBucket); err != nil {
+			return err
+		}
+		return tx.Bucket(migrationsBucket).Delete(migrationMinimalSlashingProtectionKey)
+	})
+}
+
+// raiseEpoch sets the big endian encoded value of a key to the given epoch, or slot, unless it is already higher.
+func raiseEpoch(bkt *bolt.Bucket, key []byte, epoch types.Epoch) error {
+	if existing := bkt.Get(key); len(existing) >= 8 && bytesutil.BytesToEpochBigEndian(existing) >= epoch {
+		return nil
+	}
+	return bkt.Put(key, bytesutil.EpochToBytesBigEndian(epoch))
+}
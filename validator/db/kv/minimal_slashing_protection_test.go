@@ -0,0 +1,199 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func openMinimalDB(t *testing.T, dirPath string, minimal bool) *Store {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableMinimalSlashingProtection: minimal,
+	})
+	defer resetCfg()
+	db, err := NewKVStore(context.Background(), dirPath, &Config{})
+	require.NoError(t, err, "Failed to instantiate DB")
+	return db
+}
+
+func TestMinimalProtection_MarshalUnmarshal(t *testing.T) {
+	p := &minimalProtection{
+		attested:            true,
+		highestSourceEpoch:  3,
+		highestTargetEpoch:  4,
+		targetSigningRoot:   [32]byte{1},
+		proposed:            true,
+		highestProposalSlot: 5,
+		proposalSigningRoot: [32]byte{2},
+	}
+	decoded, err := unmarshalMinimalProtection(p.marshal())
+	require.NoError(t, err)
+	assert.DeepEqual(t, p, decoded)
+
+	_, err = unmarshalMinimalProtection([]byte{1, 2, 3})
+	assert.ErrorContains(t, "wrong minimal slashing protection record size", err)
+}
+
+func TestStore_MinimalSlashingProtection_Attestations(t *testing.T) {
+	ctx := context.Background()
+	validatorDB := openMinimalDB(t, t.TempDir(), true)
+	defer func() {
+		require.NoError(t, validatorDB.Close())
+	}()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+
+	_, exists, err := validatorDB.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, false, exists)
+
+	require.NoError(t, validatorDB.SaveAttestationsForPubKey(
+		ctx,
+		pubKey,
+		[][32]byte{{1}, {2}, {3}},
+		[]*ethpb.IndexedAttestation{createAttestation(2, 3), createAttestation(4, 6), createAttestation(5, 5)},
+	))
+
+	source, exists, err := validatorDB.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, types.Epoch(5), source)
+	target, _, err := validatorDB.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(6), target)
+
+	signingRoot, err := validatorDB.SigningRootAtTargetEpoch(ctx, pubKey, 6)
+	require.NoError(t, err)
+	assert.Equal(t, [32]byte{2}, signingRoot)
+	signingRoot, err = validatorDB.SigningRootAtTargetEpoch(ctx, pubKey, 3)
+	require.NoError(t, err)
+	assert.Equal(t, [32]byte{}, signingRoot)
+
+	slashingKind, err := validatorDB.CheckSlashableAttestation(ctx, pubKey, [32]byte{4}, createAttestation(4, 6))
+	assert.ErrorContains(t, "double vote found", err)
+	assert.Equal(t, DoubleVote, slashingKind)
+	slashingKind, err = validatorDB.CheckSlashableAttestation(ctx, pubKey, [32]byte{2}, createAttestation(4, 6))
+	require.NoError(t, err)
+	assert.Equal(t, NotSlashable, slashingKind)
+
+	history, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*AttestationRecord{{PubKey: pubKey, Source: 5, Target: 6, SigningRoot: [32]byte{2}}}, history)
+
+	attested, err := validatorDB.AttestedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, attested)
+	proposed, err := validatorDB.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(proposed))
+}
+
+func TestStore_MinimalSlashingProtection_Proposals(t *testing.T) {
+	ctx := context.Background()
+	validatorDB := openMinimalDB(t, t.TempDir(), true)
+	defer func() {
+		require.NoError(t, validatorDB.Close())
+	}()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 10, []byte{1}))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 8, []byte{2}))
+
+	lowest, exists, err := validatorDB.LowestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, types.Slot(10), lowest)
+	highest, _, err := validatorDB.HighestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(10), highest)
+
+	signingRoot, exists, err := validatorDB.ProposalHistoryForSlot(ctx, pubKey, 10)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, [32]byte{1}, signingRoot)
+	_, exists, err = validatorDB.ProposalHistoryForSlot(ctx, pubKey, 8)
+	require.NoError(t, err)
+	assert.Equal(t, false, exists)
+
+	proposals, err := validatorDB.ProposalHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(proposals))
+	assert.Equal(t, types.Slot(10), proposals[0].Slot)
+
+	proposed, err := validatorDB.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, proposed)
+}
+
+func TestStore_MinimalSlashingProtection_Conversions(t *testing.T) {
+	ctx := context.Background()
+	dirPath := t.TempDir()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+
+	// Fill a complete slashing protection history.
+	validatorDB := openMinimalDB(t, dirPath, false)
+	require.NoError(t, validatorDB.SaveAttestationsForPubKey(
+		ctx,
+		pubKey,
+		[][32]byte{{1}, {2}, {3}},
+		[]*ethpb.IndexedAttestation{createAttestation(1, 2), createAttestation(3, 7), createAttestation(4, 5)},
+	))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 3, []byte{4}))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 9, []byte{5}))
+	require.NoError(t, validatorDB.Close())
+
+	// Opening in minimal mode condenses the history into watermarks.
+	validatorDB = openMinimalDB(t, dirPath, true)
+	history, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*AttestationRecord{{PubKey: pubKey, Source: 4, Target: 7, SigningRoot: [32]byte{2}}}, history)
+	proposals, err := validatorDB.ProposalHistoryForPubKey(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(proposals))
+	assert.Equal(t, types.Slot(9), proposals[0].Slot)
+	assert.DeepEqual(t, []byte{5}, proposals[0].SigningRoot[:1])
+	// The condensed history is deleted.
+	require.NoError(t, validatorDB.db.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, (*bolt.Bucket)(nil), tx.Bucket(pubKeysBucket).Bucket(pubKey[:]))
+		assert.Equal(t, (*bolt.Bucket)(nil), tx.Bucket(historicProposalsBucket).Bucket(pubKey[:]))
+		return nil
+	}))
+
+	require.NoError(t, validatorDB.SaveAttestationsForPubKey(
+		ctx, pubKey, [][32]byte{{6}}, []*ethpb.IndexedAttestation{createAttestation(6, 8)},
+	))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 12, []byte{7}))
+	require.NoError(t, validatorDB.Close())
+
+	// Opening in complete mode writes the watermarks back into the history.
+	validatorDB = openMinimalDB(t, dirPath, false)
+	defer func() {
+		require.NoError(t, validatorDB.Close())
+	}()
+	slashingKind, err := validatorDB.CheckSlashableAttestation(ctx, pubKey, [32]byte{9}, createAttestation(6, 8))
+	assert.ErrorContains(t, "double vote found", err)
+	assert.Equal(t, DoubleVote, slashingKind)
+	source, _, err := validatorDB.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(6), source)
+	target, _, err := validatorDB.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(8), target)
+
+	signingRoot, exists, err := validatorDB.ProposalHistoryForSlot(ctx, pubKey, 12)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, [32]byte{7}, signingRoot)
+	lowest, _, err := validatorDB.LowestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(12), lowest)
+	highest, _, err := validatorDB.HighestSignedProposal(ctx, pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(12), highest)
+}
@@ -29,6 +29,9 @@ type Proposal struct {
 func (s *Store) ProposedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "Validator.ProposedPublicKeys")
 	defer span.End()
+	if s.minimalSlashingProtection {
+		return s.minimalProtectedPublicKeys(true /* proposals */)
+	}
 	var err error
 	proposedPublicKeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
 	err = s.view(func(tx *bolt.Tx) error {
@@ -45,10 +48,18 @@ func (s *Store) ProposedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubke
 
 // ProposalHistoryForSlot accepts a validator public key and returns the corresponding signing root as well
 // as a boolean that tells us if we have a proposal history stored at the slot. It is possible we have proposed
-// a slot but stored a nil signing root, so the boolean helps give full information.
+// a slot but stored a nil signing root, so the boolean helps give full information. In minimal slashing
+// protection mode, only the proposal at the highest signed slot is known.
 func (s *Store) ProposalHistoryForSlot(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte, slot types.Slot) ([32]byte, bool, error) {
 	ctx, span := trace.StartSpan(ctx, "Validator.ProposalHistoryForSlot")
 	defer span.End()
+	if s.minimalSlashingProtection {
+		p, err := s.viewMinimalProtection(publicKey)
+		if err != nil || !p.proposed || p.highestProposalSlot != slot {
+			return [32]byte{}, false, err
+		}
+		return p.proposalSigningRoot, true, nil
+	}
 
 	var err error
 	var proposalExists bool
@@ -71,11 +82,21 @@ func (s *Store) ProposalHistoryForSlot(ctx context.Context, publicKey [fieldpara
 }
 
 // ProposalHistoryForPubKey returns the entire proposal history for a given public key.
+// In minimal slashing protection mode, it only consists of the proposal at the highest signed slot.
 func (s *Store) ProposalHistoryForPubKey(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) ([]*Proposal, error) {
 	ctx, span := trace.StartSpan(ctx, "Validator.ProposalHistoryForPubKey")
 	defer span.End()
 
 	proposals := make([]*Proposal, 0)
+	if s.minimalSlashingProtection {
+		p, err := s.viewMinimalProtection(publicKey)
+		if err != nil || !p.proposed {
+			return proposals, err
+		}
+		sr := make([]byte, fieldparams.RootLength)
+		copy(sr, p.proposalSigningRoot[:])
+		return append(proposals, &Proposal{Slot: p.highestProposalSlot, SigningRoot: sr}), nil
+	}
 	err := s.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(historicProposalsBucket)
 		valBucket := bucket.Bucket(publicKey[:])
@@ -98,12 +119,21 @@ func (s *Store) ProposalHistoryForPubKey(ctx context.Context, publicKey [fieldpa
 
 // SaveProposalHistoryForSlot saves the proposal history for the requested validator public key.
 // We also check if the incoming proposal slot is lower than the lowest signed proposal slot
-// for the validator and override its value on disk.
+// for the validator and override its value on disk. In minimal slashing protection mode, only
+// the highest signed proposal slot is kept.
 func (s *Store) SaveProposalHistoryForSlot(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot types.Slot, signingRoot []byte) error {
 	ctx, span := trace.StartSpan(ctx, "Validator.SaveProposalHistoryForEpoch")
 	defer span.End()
 
 	err := s.update(func(tx *bolt.Tx) error {
+		if s.minimalSlashingProtection {
+			p, err := minimalProtectionForPubKey(tx, pubKey[:])
+			if err != nil {
+				return err
+			}
+			p.updateProposal(slot, signingRoot)
+			return saveMinimalProtection(tx, pubKey[:], p)
+		}
 		bucket := tx.Bucket(historicProposalsBucket)
 		valBucket, err := bucket.CreateBucketIfNotExists(pubKey[:])
 		if err != nil {
@@ -145,10 +175,14 @@ func (s *Store) SaveProposalHistoryForSlot(ctx context.Context, pubKey [fieldpar
 }
 
 // LowestSignedProposal returns the lowest signed proposal slot for a validator public key.
-// If no data exists, a boolean of value false is returned.
+// If no data exists, a boolean of value false is returned. In minimal slashing protection mode,
+// the highest signed proposal slot is returned, at or below which nothing else may be signed.
 func (s *Store) LowestSignedProposal(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (types.Slot, bool, error) {
 	ctx, span := trace.StartSpan(ctx, "Validator.LowestSignedProposal")
 	defer span.End()
+	if s.minimalSlashingProtection {
+		return s.HighestSignedProposal(ctx, publicKey)
+	}
 
 	var err error
 	var lowestSignedProposalSlot types.Slot
@@ -172,6 +206,13 @@ func (s *Store) LowestSignedProposal(ctx context.Context, publicKey [fieldparams
 func (s *Store) HighestSignedProposal(ctx context.Context, publicKey [fieldparams.BLSPubkeyLength]byte) (types.Slot, bool, error) {
 	ctx, span := trace.StartSpan(ctx, "Validator.HighestSignedProposal")
 	defer span.End()
+	if s.minimalSlashingProtection {
+		p, err := s.viewMinimalProtection(publicKey)
+		if err != nil {
+			return 0, false, err
+		}
+		return p.highestProposalSlot, p.proposed, nil
+	}
 
 	var err error
 	var highestSignedProposalSlot types.Slot
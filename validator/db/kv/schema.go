@@ -28,6 +28,9 @@ var (
 	attestationSourceEpochsBucket = []byte("att-source-epochs-bucket")
 	attestationTargetEpochsBucket = []byte("att-target-epochs-bucket")
 
+	// Minimal slashing protection watermarks bucket.
+	minimalSlashingProtectionBucket = []byte("minimal-slashing-protection-bucket")
+
 	// Migrations
 	migrationsBucket = []byte("migrations")
 
@@ -208,3 +208,22 @@ func TestDerivedKeymanager_Sign_NoPublicKeyInCache(t *testing.T) {
 	_, err := dr.Sign(context.Background(), req)
 	assert.ErrorContains(t, "no signing key found", err)
 }
+
+func TestWithdrawalKeysFromMnemonic(t *testing.T) {
+	derivedSeed, err := seedFromMnemonic(constant.TestMnemonic, "", "")
+	require.NoError(t, err)
+	keys, err := WithdrawalKeysFromMnemonic(constant.TestMnemonic, "", "", 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(keys))
+	for i, key := range keys {
+		wanted, err := util.PrivateKeyFromSeedAndPath(derivedSeed, fmt.Sprintf(WithdrawalKeyDerivationPathTemplate, 2+i))
+		require.NoError(t, err)
+		assert.DeepEqual(t, wanted.Marshal(), key.Marshal())
+		validatingKey, err := util.PrivateKeyFromSeedAndPath(derivedSeed, fmt.Sprintf(ValidatingKeyDerivationPathTemplate, 2+i))
+		require.NoError(t, err)
+		assert.DeepNotEqual(t, validatingKey.Marshal(), key.Marshal())
+	}
+
+	_, err = WithdrawalKeysFromMnemonic("invalid mnemonic", "", "", 0, 1)
+	require.ErrorContains(t, "could not derive seed from mnemonic", err)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pkg/errors"
 	fastssz "github.com/prysmaticlabs/fastssz"
@@ -74,6 +75,7 @@ type ValidatorClient struct {
 	wallet            *wallet.Wallet
 	walletInitialized *event.Feed
 	stop              chan struct{} // Channel to wait for termination notifications.
+	router            *mux.Router
 }
 
 // NewValidatorClient creates a new instance of the Prysm validator client.
@@ -108,6 +110,7 @@ func NewValidatorClient(cliCtx *cli.Context) (*ValidatorClient, error) {
 		services:          registry,
 		walletInitialized: new(event.Feed),
 		stop:              make(chan struct{}),
+		router:            mux.NewRouter(),
 	}
 
 	if err := features.ConfigureValidator(cliCtx); err != nil {
@@ -412,6 +415,11 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	distributed := c.cliCtx.Bool(flags.DistributedValidatorFlag.Name)
+	if distributed && !features.Get().EnableBeaconRESTApi {
+		return fmt.Errorf("--%s requires --%s", flags.DistributedValidatorFlag.Name, features.EnableBeaconRESTApi.Name)
+	}
+
 	v, err := client.NewValidatorService(c.cliCtx.Context, &client.Config{
 		Endpoint:                   endpoint,
 		DataDir:                    dataDir,
@@ -433,6 +441,9 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		ProposerSettings:           bpc,
 		BeaconApiTimeout:           time.Second * 30,
 		BeaconApiEndpoint:          c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+		RecordingPath:              c.cliCtx.String(flags.RecordBeaconNodeSessionFlag.Name),
+		Distributed:                distributed,
+		DoppelGangerEpochs:         c.cliCtx.Uint64(flags.DoppelGangerEpochsFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -545,6 +556,7 @@ func proposerSettings(cliCtx *cli.Context) (*validatorServiceConfig.ProposerSett
 	vpSettings.DefaultConfig = &validatorServiceConfig.ProposerOption{
 		FeeRecipient:  common.HexToAddress(fileConfig.DefaultConfig.FeeRecipient),
 		BuilderConfig: fileConfig.DefaultConfig.BuilderConfig,
+		Graffiti:      g.ParseHexGraffiti(fileConfig.DefaultConfig.Graffiti),
 	}
 	if vpSettings.DefaultConfig.BuilderConfig == nil {
 		builderConfig, err := BuilderSettingsFromFlags(cliCtx)
@@ -589,6 +601,7 @@ func proposerSettings(cliCtx *cli.Context) (*validatorServiceConfig.ProposerSett
 			vpSettings.ProposeConfig[bytesutil.ToBytes48(decodedKey)] = &validatorServiceConfig.ProposerOption{
 				FeeRecipient:  common.HexToAddress(option.FeeRecipient),
 				BuilderConfig: option.BuilderConfig,
+				Graffiti:      g.ParseHexGraffiti(option.Graffiti),
 			}
 
 		}
@@ -670,6 +683,7 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
 		NodeGatewayEndpoint:      nodeGatewayEndpoint,
 		WalletDir:                walletDir,
 		Wallet:                   c.wallet,
+		Router:                   c.router,
 		ValidatorGatewayHost:     validatorGatewayHost,
 		ValidatorGatewayPort:     validatorGatewayPort,
 		ValidatorMonitoringHost:  validatorMonitoringHost,
@@ -757,6 +771,7 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 		Mux:           gwmux,
 	}
 	opts := []gateway.Option{
+		gateway.WithRouter(c.router),
 		gateway.WithRemoteAddr(rpcAddr),
 		gateway.WithGatewayAddr(gatewayAddress),
 		gateway.WithMaxCallRecvMsgSize(maxCallSize),
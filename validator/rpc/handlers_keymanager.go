@@ -0,0 +1,204 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/gorilla/mux"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	validatorServiceConfig "github.com/prysmaticlabs/prysm/v3/config/validator/service"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/network/httputil"
+	eth "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/prysmaticlabs/prysm/v3/validator/client"
+)
+
+// SetVoluntaryExit creates and signs a voluntary exit for the validator public key in the path, using the epoch
+// given as query parameter or the current epoch. The signed exit is returned but not submitted to the beacon node.
+func (s *Server) SetVoluntaryExit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	pubkey, ok := pubkeyFromPath(w, r)
+	if !ok {
+		return
+	}
+	km, err := s.validatorService.Keymanager()
+	if err != nil {
+		httputil.HandleError(w, "Could not get keymanager: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not fetch validating public keys: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	found := false
+	for _, k := range keys {
+		if bytes.Equal(k[:], pubkey) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		httputil.HandleError(w, fmt.Sprintf("Public key %s is not managed by the validator client", hexutil.Encode(pubkey)), http.StatusNotFound)
+		return
+	}
+
+	var epoch types.Epoch
+	if rawEpoch := r.URL.Query().Get("epoch"); rawEpoch != "" {
+		e, err := strconv.ParseUint(rawEpoch, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, "Invalid epoch: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		epoch = types.Epoch(e)
+	} else {
+		genesisResponse, err := s.beaconNodeClient.GetGenesis(ctx, &empty.Empty{})
+		if err != nil {
+			httputil.HandleError(w, "Could not get genesis time: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		epoch = slots.ToEpoch(slots.CurrentSlot(uint64(genesisResponse.GenesisTime.AsTime().Unix())))
+	}
+
+	signedExit, err := client.CreateSignedVoluntaryExit(ctx, s.beaconNodeValidatorClient, km.Sign, pubkey, epoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not create voluntary exit: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &SetVoluntaryExitResponse{
+		Data: &SignedVoluntaryExitJson{
+			Message: &VoluntaryExitJson{
+				Epoch:          strconv.FormatUint(uint64(signedExit.Exit.Epoch), 10),
+				ValidatorIndex: strconv.FormatUint(uint64(signedExit.Exit.ValidatorIndex), 10),
+			},
+			Signature: hexutil.Encode(signedExit.Signature),
+		},
+	})
+}
+
+// GetGraffiti returns the graffiti used in blocks proposed by the validator public key in the path.
+func (s *Server) GetGraffiti(w http.ResponseWriter, r *http.Request) {
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	pubkey, ok := pubkeyFromPath(w, r)
+	if !ok {
+		return
+	}
+	graffiti := s.validatorService.Graffiti(bytesutil.ToBytes48(pubkey))
+	httputil.WriteJson(w, &GetGraffitiResponse{
+		Data: &GraffitiJson{
+			Pubkey:   hexutil.Encode(pubkey),
+			Graffiti: string(graffiti),
+		},
+	})
+}
+
+// SetGraffiti stores the graffiti of the validator public key in the path in its proposer settings.
+func (s *Server) SetGraffiti(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	pubkey, ok := pubkeyFromPath(w, r)
+	if !ok {
+		return
+	}
+	var req SetGraffitiRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Graffiti) > 32 {
+		httputil.HandleError(w, "Graffiti exceeds 32 bytes", http.StatusBadRequest)
+		return
+	}
+
+	settings := s.validatorService.ProposerSettings()
+	if settings == nil {
+		// Get the default fee recipient defined with an invalid public key from beacon node.
+		defaultOption := validatorServiceConfig.DefaultProposerOption()
+		resp, err := s.beaconNodeValidatorClient.GetFeeRecipientByPubKey(ctx, &eth.FeeRecipientByPubKeyRequest{
+			PublicKey: []byte(nonExistantPublicKey),
+		})
+		if err == nil && resp != nil && len(resp.FeeRecipient) != 0 {
+			defaultOption.FeeRecipient = common.BytesToAddress(resp.FeeRecipient)
+		}
+		settings = &validatorServiceConfig.ProposerSettings{DefaultConfig: &defaultOption}
+	}
+	if settings.ProposeConfig == nil {
+		settings.ProposeConfig = make(map[[fieldparams.BLSPubkeyLength]byte]*validatorServiceConfig.ProposerOption)
+	}
+	option, found := settings.ProposeConfig[bytesutil.ToBytes48(pubkey)]
+	if !found || option == nil {
+		// Copy the default config, so the fee recipient and builder settings of the key are left unchanged.
+		o := validatorServiceConfig.DefaultProposerOption()
+		if settings.DefaultConfig != nil {
+			o = *settings.DefaultConfig
+			if o.BuilderConfig != nil {
+				bo := *o.BuilderConfig
+				o.BuilderConfig = &bo
+			}
+		}
+		option = &o
+		settings.ProposeConfig[bytesutil.ToBytes48(pubkey)] = option
+	}
+	option.Graffiti = req.Graffiti
+	s.validatorService.SetProposerSettings(settings)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// DeleteGraffiti removes the graffiti of the validator public key in the path from its proposer settings.
+func (s *Server) DeleteGraffiti(w http.ResponseWriter, r *http.Request) {
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	pubkey, ok := pubkeyFromPath(w, r)
+	if !ok {
+		return
+	}
+	settings := s.validatorService.ProposerSettings()
+	if settings != nil && settings.ProposeConfig != nil {
+		option, found := settings.ProposeConfig[bytesutil.ToBytes48(pubkey)]
+		if found && option != nil && option.Graffiti != "" {
+			option.Graffiti = ""
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	httputil.HandleError(w, fmt.Sprintf("No graffiti found for pubkey: %q", hexutil.Encode(pubkey)), http.StatusNotFound)
+}
+
+// pubkeyFromPath decodes the validator public key from the request path, writing an error response if it is invalid.
+func pubkeyFromPath(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	rawPubkey := mux.Vars(r)["pubkey"]
+	pubkey, err := hexutil.Decode(rawPubkey)
+	if err != nil {
+		httputil.HandleError(w, "Invalid public key: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	if len(pubkey) != fieldparams.BLSPubkeyLength {
+		httputil.HandleError(
+			w,
+			fmt.Sprintf("Provided public key in path is not byte length %d and not a valid bls public key", fieldparams.BLSPubkeyLength),
+			http.StatusBadRequest,
+		)
+		return nil, false
+	}
+	return pubkey, true
+}
@@ -0,0 +1,294 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v3/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	eth "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	mock2 "github.com/prysmaticlabs/prysm/v3/testing/mock"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/accounts"
+	"github.com/prysmaticlabs/prysm/v3/validator/accounts/iface"
+	mock "github.com/prysmaticlabs/prysm/v3/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/v3/validator/client"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager/derived"
+	mocks "github.com/prysmaticlabs/prysm/v3/validator/testing"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestServer_SetVoluntaryExit(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defaultWalletPath = setupWalletDir(t)
+	opts := []accounts.Option{
+		accounts.WithWalletDir(defaultWalletPath),
+		accounts.WithKeymanagerType(keymanager.Derived),
+		accounts.WithWalletPassword(strongPass),
+		accounts.WithSkipMnemonicConfirm(true),
+	}
+	acc, err := accounts.NewCLIManager(opts...)
+	require.NoError(t, err)
+	w, err := acc.WalletCreate(ctx)
+	require.NoError(t, err)
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	dr, ok := km.(*derived.Keymanager)
+	require.Equal(t, true, ok)
+	require.NoError(t, dr.RecoverAccountsFromMnemonic(ctx, mocks.TestMnemonic, "", "", 1))
+	keys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Wallet:    w,
+		Validator: &mock.MockValidator{Km: km},
+	})
+	require.NoError(t, err)
+
+	validatorClient := mock2.NewMockValidatorClient(ctrl)
+	nodeClient := mock2.NewMockNodeClient(ctrl)
+	s := &Server{
+		validatorService:          vs,
+		beaconNodeValidatorClient: validatorClient,
+		beaconNodeClient:          nodeClient,
+	}
+	pubkey := hexutil.Encode(keys[0][:])
+	domain := bytesutil.PadTo([]byte{1}, 32)
+
+	t.Run("epoch from query", func(t *testing.T) {
+		validatorClient.EXPECT().
+			ValidatorIndex(gomock.Any(), &eth.ValidatorIndexRequest{PublicKey: keys[0][:]}).
+			Return(&eth.ValidatorIndexResponse{Index: 2}, nil)
+		validatorClient.EXPECT().
+			DomainData(gomock.Any(), &eth.DomainRequest{Epoch: 10, Domain: params.BeaconConfig().DomainVoluntaryExit[:]}).
+			Return(&eth.DomainResponse{SignatureDomain: domain}, nil)
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/"+pubkey+"/voluntary_exit?epoch=10", nil)
+		request = mux.SetURLVars(request, map[string]string{"pubkey": pubkey})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SetVoluntaryExit(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SetVoluntaryExitResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "10", resp.Data.Message.Epoch)
+		assert.Equal(t, "2", resp.Data.Message.ValidatorIndex)
+
+		exit := &eth.VoluntaryExit{Epoch: 10, ValidatorIndex: 2}
+		root, err := signing.ComputeSigningRoot(exit, domain)
+		require.NoError(t, err)
+		sigBytes, err := hexutil.Decode(resp.Data.Signature)
+		require.NoError(t, err)
+		sig, err := bls.SignatureFromBytes(sigBytes)
+		require.NoError(t, err)
+		pk, err := bls.PublicKeyFromBytes(keys[0][:])
+		require.NoError(t, err)
+		assert.Equal(t, true, sig.Verify(pk, root[:]))
+	})
+	t.Run("current epoch", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		genesisTime := time.Now().Add(-time.Duration(3*params.BeaconConfig().SecondsPerSlot*uint64(params.BeaconConfig().SlotsPerEpoch)) * time.Second)
+		nodeClient.EXPECT().
+			GetGenesis(gomock.Any(), gomock.Any()).
+			Return(&eth.Genesis{GenesisTime: timestamppb.New(genesisTime)}, nil)
+		validatorClient.EXPECT().
+			ValidatorIndex(gomock.Any(), gomock.Any()).
+			Return(&eth.ValidatorIndexResponse{Index: 2}, nil)
+		validatorClient.EXPECT().
+			DomainData(gomock.Any(), &eth.DomainRequest{Epoch: 3, Domain: params.BeaconConfig().DomainVoluntaryExit[:]}).
+			Return(&eth.DomainResponse{SignatureDomain: domain}, nil)
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/"+pubkey+"/voluntary_exit", nil)
+		request = mux.SetURLVars(request, map[string]string{"pubkey": pubkey})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SetVoluntaryExit(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SetVoluntaryExitResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "3", resp.Data.Message.Epoch)
+	})
+	t.Run("unknown public key", func(t *testing.T) {
+		unknown := hexutil.Encode(make([]byte, 48))
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/"+unknown+"/voluntary_exit", nil)
+		request = mux.SetURLVars(request, map[string]string{"pubkey": unknown})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SetVoluntaryExit(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		assert.StringContains(t, "is not managed by the validator client", writer.Body.String())
+	})
+	t.Run("invalid public key", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/0x1234/voluntary_exit", nil)
+		request = mux.SetURLVars(request, map[string]string{"pubkey": "0x1234"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SetVoluntaryExit(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "not a valid bls public key", writer.Body.String())
+	})
+	t.Run("invalid epoch", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/"+pubkey+"/voluntary_exit?epoch=foo", nil)
+		request = mux.SetURLVars(request, map[string]string{"pubkey": pubkey})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SetVoluntaryExit(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Invalid epoch", writer.Body.String())
+	})
+}
+
+func TestServer_Graffiti(t *testing.T) {
+	ctx := context.Background()
+	pubkey1 := "0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"
+	pubkey2 := "0xbedefeaa94e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2cdddddddddddddddddddddddd"
+	rawPubkey1, err := hexutil.Decode(pubkey1)
+	require.NoError(t, err)
+	rawPubkey2, err := hexutil.Decode(pubkey2)
+	require.NoError(t, err)
+	feeRecipient := common.HexToAddress("0x055Fb65722E7b2455012BFEBf6177F1D2e97387")
+
+	m := &mock.MockValidator{}
+	m.SetProposerSettings(&validatorserviceconfig.ProposerSettings{
+		ProposeConfig: map[[48]byte]*validatorserviceconfig.ProposerOption{
+			bytesutil.ToBytes48(rawPubkey1): {FeeRecipient: feeRecipient},
+		},
+		DefaultConfig: &validatorserviceconfig.ProposerOption{
+			FeeRecipient:  feeRecipient,
+			BuilderConfig: &validatorserviceconfig.BuilderConfig{Enabled: true, GasLimit: 1000},
+		},
+	})
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator:    m,
+		GraffitiFlag: "cli graffiti",
+	})
+	require.NoError(t, err)
+	s := &Server{validatorService: vs}
+
+	getGraffiti := func(t *testing.T, pubkey string) string {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/validator/"+pubkey+"/graffiti", nil)
+		request = mux.SetURLVars(request, map[string]string{"pubkey": pubkey})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetGraffiti(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetGraffitiResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, pubkey, resp.Data.Pubkey)
+		return resp.Data.Graffiti
+	}
+	setGraffiti := func(t *testing.T, pubkey string, graffiti string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(&SetGraffitiRequest{Graffiti: graffiti})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/"+pubkey+"/graffiti", bytes.NewReader(body))
+		request = mux.SetURLVars(request, map[string]string{"pubkey": pubkey})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.SetGraffiti(writer, request)
+		return writer
+	}
+	deleteGraffiti := func(t *testing.T, pubkey string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodDelete, "http://example.com/eth/v1/validator/"+pubkey+"/graffiti", nil)
+		request = mux.SetURLVars(request, map[string]string{"pubkey": pubkey})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.DeleteGraffiti(writer, request)
+		return writer
+	}
+
+	assert.Equal(t, "cli graffiti", getGraffiti(t, pubkey1))
+
+	t.Run("update existing proposer option", func(t *testing.T) {
+		assert.Equal(t, http.StatusAccepted, setGraffiti(t, pubkey1, "graffiti 1").Code)
+		assert.Equal(t, "graffiti 1", getGraffiti(t, pubkey1))
+		option := vs.ProposerSettings().ProposeConfig[bytesutil.ToBytes48(rawPubkey1)]
+		assert.Equal(t, feeRecipient, option.FeeRecipient)
+	})
+	t.Run("insert new proposer option", func(t *testing.T) {
+		assert.Equal(t, http.StatusAccepted, setGraffiti(t, pubkey2, "graffiti 2").Code)
+		assert.Equal(t, "graffiti 2", getGraffiti(t, pubkey2))
+		option := vs.ProposerSettings().ProposeConfig[bytesutil.ToBytes48(rawPubkey2)]
+		assert.Equal(t, feeRecipient, option.FeeRecipient)
+		require.NotNil(t, option.BuilderConfig)
+		assert.Equal(t, validatorserviceconfig.Uint64(1000), option.BuilderConfig.GasLimit)
+		// The builder config of the default config must not be shared with the new option.
+		option.BuilderConfig.GasLimit = 2000
+		assert.Equal(t, validatorserviceconfig.Uint64(1000), vs.ProposerSettings().DefaultConfig.BuilderConfig.GasLimit)
+	})
+	t.Run("graffiti too long", func(t *testing.T) {
+		writer := setGraffiti(t, pubkey1, strings.Repeat("a", 33))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Graffiti exceeds 32 bytes", writer.Body.String())
+	})
+	t.Run("delete graffiti", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, deleteGraffiti(t, pubkey1).Code)
+		assert.Equal(t, "cli graffiti", getGraffiti(t, pubkey1))
+		writer := deleteGraffiti(t, pubkey1)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		assert.StringContains(t, "No graffiti found for pubkey", writer.Body.String())
+	})
+	t.Run("invalid public key", func(t *testing.T) {
+		writer := setGraffiti(t, "0x1234", "graffiti")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestServer_SetGraffiti_NilProposerSettings(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	pubkey := "0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"
+	rawPubkey, err := hexutil.Decode(pubkey)
+	require.NoError(t, err)
+	feeRecipient := common.HexToAddress("0x055Fb65722E7b2455012BFEBf6177F1D2e97387")
+
+	beaconClient := mock2.NewMockValidatorClient(ctrl)
+	beaconClient.EXPECT().GetFeeRecipientByPubKey(gomock.Any(), gomock.Any()).Return(&eth.FeeRecipientByPubKeyResponse{
+		FeeRecipient: feeRecipient.Bytes(),
+	}, nil)
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator: &mock.MockValidator{},
+	})
+	require.NoError(t, err)
+	s := &Server{
+		validatorService:          vs,
+		beaconNodeValidatorClient: beaconClient,
+	}
+
+	body, err := json.Marshal(&SetGraffitiRequest{Graffiti: "graffiti"})
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/"+pubkey+"/graffiti", bytes.NewReader(body))
+	request = mux.SetURLVars(request, map[string]string{"pubkey": pubkey})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.SetGraffiti(writer, request)
+	require.Equal(t, http.StatusAccepted, writer.Code)
+
+	settings := vs.ProposerSettings()
+	require.NotNil(t, settings)
+	assert.Equal(t, feeRecipient, settings.DefaultConfig.FeeRecipient)
+	option := settings.ProposeConfig[bytesutil.ToBytes48(rawPubkey)]
+	assert.Equal(t, "graffiti", option.Graffiti)
+	assert.Equal(t, feeRecipient, option.FeeRecipient)
+}
@@ -3,9 +3,11 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/prysmaticlabs/prysm/v3/network/httputil"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -33,6 +35,28 @@ func (s *Server) JWTInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// JwtHttpInterceptor is an HTTP middleware to authorize incoming requests which are served
+// directly, without going through the gRPC server.
+func (s *Server) JwtHttpInterceptor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			httputil.HandleError(w, "Authorization token could not be found", http.StatusUnauthorized)
+			return
+		}
+		if !strings.Contains(authHeader, "Bearer ") {
+			httputil.HandleError(w, "Invalid auth header, needs Bearer {token}", http.StatusUnauthorized)
+			return
+		}
+		token := strings.Split(authHeader, "Bearer ")[1]
+		if _, err := jwt.Parse(token, s.validateJWT); err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Could not parse JWT token: %v", err), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // Authorize the token received is valid.
 func (s *Server) authorize(ctx context.Context) error {
 	md, ok := metadata.FromIncomingContext(ctx)
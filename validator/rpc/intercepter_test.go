@@ -2,9 +2,12 @@ package rpc
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -67,3 +70,43 @@ func TestServer_JWTInterceptor_InvalidSigningType(t *testing.T) {
 	_, err := ss.validateJWT(token)
 	require.ErrorContains(t, "unexpected JWT signing method", err)
 }
+
+func TestServer_JwtHttpInterceptor(t *testing.T) {
+	s := Server{
+		jwtSecret: []byte("testKey"),
+	}
+	handled := false
+	handler := s.JwtHttpInterceptor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+	badToken, err := createTokenString([]byte("badTestKey"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		header  string
+		code    int
+		handled bool
+	}{
+		{name: "valid token", header: "Bearer " + token, code: http.StatusOK, handled: true},
+		{name: "missing header", header: "", code: http.StatusUnauthorized},
+		{name: "missing bearer", header: token, code: http.StatusUnauthorized},
+		{name: "bad token", header: "Bearer " + badToken, code: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = false
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/validator/0x00/graffiti", nil)
+			if tt.header != "" {
+				request.Header.Set("Authorization", tt.header)
+			}
+			writer := httptest.NewRecorder()
+			handler.ServeHTTP(writer, request)
+			assert.Equal(t, tt.code, writer.Code)
+			assert.Equal(t, tt.handled, handled)
+		})
+	}
+}
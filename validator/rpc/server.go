@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"path/filepath"
 	"time"
 
+	"github.com/gorilla/mux"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpcopentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
@@ -52,6 +54,7 @@ type Config struct {
 	WalletInitializedFeed    *event.Feed
 	NodeGatewayEndpoint      string
 	Wallet                   *wallet.Wallet
+	Router                   *mux.Router
 }
 
 // Server defining a gRPC server for the remote signer API.
@@ -93,12 +96,13 @@ type Server struct {
 	validatorGatewayPort      int
 	beaconApiEndpoint         string
 	beaconApiTimeout          time.Duration
+	router                    *mux.Router
 }
 
 // NewServer instantiates a new gRPC server.
 func NewServer(ctx context.Context, cfg *Config) *Server {
 	ctx, cancel := context.WithCancel(ctx)
-	return &Server{
+	server := &Server{
 		ctx:                      ctx,
 		cancel:                   cancel,
 		logsStreamer:             logs.NewStreamServer(),
@@ -126,7 +130,23 @@ func NewServer(ctx context.Context, cfg *Config) *Server {
 		validatorMonitoringPort:  cfg.ValidatorMonitoringPort,
 		validatorGatewayHost:     cfg.ValidatorGatewayHost,
 		validatorGatewayPort:     cfg.ValidatorGatewayPort,
+		router:                   cfg.Router,
 	}
+	if server.router != nil {
+		server.initializeKeymanagerRoutes()
+	}
+	return server
+}
+
+// initializeKeymanagerRoutes registers the keymanager API endpoints which are not served through
+// the gRPC gateway on the HTTP router, protected by the same JWT authentication as the gRPC server.
+func (s *Server) initializeKeymanagerRoutes() {
+	sr := s.router.PathPrefix("/eth/v1/validator/{pubkey}").Subrouter()
+	sr.Use(s.JwtHttpInterceptor)
+	sr.HandleFunc("/voluntary_exit", s.SetVoluntaryExit).Methods(http.MethodPost)
+	sr.HandleFunc("/graffiti", s.GetGraffiti).Methods(http.MethodGet)
+	sr.HandleFunc("/graffiti", s.SetGraffiti).Methods(http.MethodPost)
+	sr.HandleFunc("/graffiti", s.DeleteGraffiti).Methods(http.MethodDelete)
 }
 
 // Start the gRPC server.
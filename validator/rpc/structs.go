@@ -0,0 +1,34 @@
+package rpc
+
+// SetVoluntaryExitResponse is the response of the keymanager API voluntary exit endpoint.
+type SetVoluntaryExitResponse struct {
+	Data *SignedVoluntaryExitJson `json:"data"`
+}
+
+// SignedVoluntaryExitJson is the JSON representation of a signed voluntary exit.
+type SignedVoluntaryExitJson struct {
+	Message   *VoluntaryExitJson `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+// VoluntaryExitJson is the JSON representation of a voluntary exit.
+type VoluntaryExitJson struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
+// GetGraffitiResponse is the response of the keymanager API graffiti GET endpoint.
+type GetGraffitiResponse struct {
+	Data *GraffitiJson `json:"data"`
+}
+
+// GraffitiJson holds the graffiti of a validator public key.
+type GraffitiJson struct {
+	Pubkey   string `json:"pubkey"`
+	Graffiti string `json:"graffiti"`
+}
+
+// SetGraffitiRequest is the request body of the keymanager API graffiti POST endpoint.
+type SetGraffitiRequest struct {
+	Graffiti string `json:"graffiti"`
+}
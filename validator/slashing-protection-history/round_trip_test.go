@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
@@ -373,3 +374,51 @@ func TestStore_ImportInterchangeData_BadFormat_PreventsDBWrites(t *testing.T) {
 		)
 	}
 }
+
+func TestImportExport_RoundTrip_MinimalSlashingProtection(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableMinimalSlashingProtection: true,
+	})
+	defer resetCfg()
+	ctx := context.Background()
+	numValidators := 10
+	publicKeys, err := slashtest.CreateRandomPubKeys(numValidators)
+	require.NoError(t, err)
+	validatorDB := dbtest.SetupDB(t, publicKeys)
+
+	attestingHistory, proposalHistory := slashtest.MockAttestingAndProposalHistories(publicKeys)
+	wanted, err := slashtest.MockSlashingProtectionJSON(publicKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	blob, err := json.Marshal(wanted)
+	require.NoError(t, err)
+	require.NoError(t, history.ImportStandardProtectionJSON(ctx, validatorDB, bytes.NewBuffer(blob)))
+
+	// Only the highest signed attestation and block of each key are exported.
+	eipStandard, err := history.ExportStandardProtectionJSON(ctx, validatorDB)
+	require.NoError(t, err)
+	require.Equal(t, wanted.Metadata, eipStandard.Metadata)
+	dataByPubKey := make(map[string]*format.ProtectionData)
+	for _, item := range wanted.Data {
+		dataByPubKey[item.Pubkey] = item
+	}
+	for _, item := range eipStandard.Data {
+		want, ok := dataByPubKey[item.Pubkey]
+		require.Equal(t, true, ok)
+		if len(want.SignedAttestations) == 0 {
+			require.Equal(t, 0, len(item.SignedAttestations))
+		} else {
+			require.Equal(t, 1, len(item.SignedAttestations))
+			require.DeepEqual(t, want.SignedAttestations[len(want.SignedAttestations)-1], item.SignedAttestations[0])
+		}
+		require.Equal(t, 1, len(item.SignedBlocks))
+		require.DeepEqual(t, want.SignedBlocks[len(want.SignedBlocks)-1], item.SignedBlocks[0])
+	}
+
+	// Importing the minimal export again is not slashable.
+	blob, err = json.Marshal(eipStandard)
+	require.NoError(t, err)
+	require.NoError(t, history.ImportStandardProtectionJSON(ctx, validatorDB, bytes.NewBuffer(blob)))
+	slashableKeys, err := validatorDB.EIPImportBlacklistedPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slashableKeys))
+}